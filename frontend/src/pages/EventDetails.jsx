@@ -1,7 +1,7 @@
 import React, { useState, useEffect, useCallback } from 'react';
 import { useParams, Link, useNavigate } from 'react-router-dom';
 import { useAuth } from '../hooks/useAuth';
-import { eventService, bookingService, formatError } from '../services/api';
+import { eventService, bookingService, searchService, formatError } from '../services/api';
 import {
     MapPin, Calendar, Users, DollarSign, Clock,
     Ticket, AlertCircle, CheckCircle, ArrowLeft
@@ -32,6 +32,8 @@ const EventDetails = () => {
         };
 
         fetchEventDetails();
+        // Views feed the trending ranking; a failure here is not the user's concern.
+        searchService.recordView(eventId).catch(() => {});
     }, [eventId]);
 
     const checkAvailability = useCallback(async (requestedQuantity = quantity) => {
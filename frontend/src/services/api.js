@@ -186,7 +186,8 @@ export const searchService = {
   search: (params = {}) => searchAPI.get('/search', { params }),
   getSuggestions: (params) => searchAPI.get('/search/suggestions', { params }),
   getFilters: () => searchAPI.get('/search/filters'),
-  getTrending: (params = {}) => searchAPI.get('/search', { params }), // Use regular search for trending
+  getTrending: (params = {}) => searchAPI.get('/search/trending', { params }),
+  recordView: (eventId) => searchAPI.post(`/search/events/${eventId}/view`),
 
   // Health (via gateway)
   health: () => searchAPI.get('/healthz')
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		step := TOTPStep(time.Unix(tt.unix, 0))
+		got, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode at %d error = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPCodeSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "lower case", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq"},
+		{name: "not base32", secret: "not-base32!", wantErr: true},
+		{name: "padded", secret: rfc6238Secret + "====", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TOTPCode(tt.secret, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TOTPCode error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != "287082" {
+				t.Errorf("TOTPCode = %s, want 287082", got)
+			}
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := TOTPStep(now)
+	code := func(step int64) string {
+		code, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode error = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: code(current), wantStep: current, wantOK: true},
+		{name: "previous step", code: code(current - 1), wantStep: current - 1, wantOK: true},
+		{name: "next step", code: code(current + 1), wantStep: current + 1, wantOK: true},
+		{name: "two steps old", code: code(current - 2)},
+		{name: "two steps ahead", code: code(current + 2)},
+		{name: "spaces are ignored", code: " 050 471 ", wantStep: current, wantOK: true},
+		{name: "too short", code: "05047"},
+		{name: "too long", code: "0504710"},
+		{name: "empty", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfc6238Secret, tt.code, now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP(%q) = %d, %v, want %d, %v", tt.code, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := ValidateTOTP("not-base32!", "050471", now); ok {
+		t.Error("ValidateTOTP accepted a code for an invalid secret")
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret error = %v", err)
+	}
+	// 20 random bytes are 32 base32 characters without padding.
+	if len(secret) != 32 {
+		t.Errorf("secret %q has %d characters, want 32", secret, len(secret))
+	}
+	if _, err := TOTPCode(secret, 0); err != nil {
+		t.Errorf("generated secret does not decode: %v", err)
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("BookMyEvent", "admin@example.com", rfc6238Secret)
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("TOTPURI returned an invalid URL %q: %v", uri, err)
+	}
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" || parsed.Path != "/BookMyEvent:admin@example.com" {
+		t.Errorf("TOTPURI = %q, want otpauth://totp/BookMyEvent:admin@example.com", uri)
+	}
+
+	query := parsed.Query()
+	for key, want := range map[string]string{
+		"secret":    rfc6238Secret,
+		"issuer":    "BookMyEvent",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	} {
+		if got := query.Get(key); got != want {
+			t.Errorf("TOTPURI %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes error = %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("got %d codes, want 10", len(codes))
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("recovery code %q is not formatted xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q was generated twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-fghij")
+	for _, code := range []string{"ABCDE-FGHIJ", "abcdefghij", " abcde fghij ", "Abcde-Fghij"} {
+		if got := HashRecoveryCode(code); got != want {
+			t.Errorf("HashRecoveryCode(%q) differs from HashRecoveryCode(%q)", code, "abcde-fghij")
+		}
+	}
+	if HashRecoveryCode("abcde-fghik") == want {
+		t.Error("different recovery codes hashed the same")
+	}
+}
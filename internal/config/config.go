@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,32 +34,80 @@ func LoadUserServiceConfig() *UserServiceConfig {
 }
 
 type EventServiceConfig struct {
-	Port               string
-	DatabaseURL        string
-	DatabaseReplicaURL string
-	JWTSecret          string
-	JWTAccessDuration  time.Duration
-	JWTRefreshDuration time.Duration
-	InternalAPIKey     string
-	UserServiceURL     string
-	SearchServiceURL   string
-	LogLevel           string
-	Environment        string
+	Port                string
+	DatabaseURL         string
+	DatabaseReplicaURL  string
+	JWTSecret           string
+	JWTAccessDuration   time.Duration
+	JWTRefreshDuration  time.Duration
+	InternalAPIKey      string
+	UserServiceURL      string
+	SearchServiceURL    string
+	BookingServiceURL   string
+	SearchSyncInterval  time.Duration
+	SearchSyncBatchSize int
+	SearchSyncRetention time.Duration
+	VenueSetupBuffer    time.Duration
+	VenueTeardownBuffer time.Duration
+	DeletedRetention    time.Duration
+	PurgeInterval       time.Duration
+	MediaStorage        string
+	MediaLocalDir       string
+	MediaPublicBaseURL  string
+	MediaMaxUploadBytes int
+	S3Endpoint          string
+	S3Region            string
+	S3Bucket            string
+	S3AccessKeyID       string
+	S3SecretAccessKey   string
+	S3UsePathStyle      bool
+	Geocoder            string
+	GeocoderURL         string
+	GeocoderUserAgent   string
+	DefaultCurrency     string
+	TwoFactorIssuer     string
+	TwoFactorRoles      []string
+	LogLevel            string
+	Environment         string
 }
 
 func LoadEventServiceConfig() *EventServiceConfig {
 	return &EventServiceConfig{
-		Port:               getEnv("EVENT_SERVICE_PORT", "8002"),
-		DatabaseURL:        getEnvRequired("EVENT_SERVICE_DB_URL"),
-		DatabaseReplicaURL: getEnv("EVENT_SERVICE_DB_REPLICA_URL", ""),
-		JWTSecret:          getEnvRequired("JWT_SECRET"),
-		JWTAccessDuration:  getDuration("JWT_ACCESS_TOKEN_DURATION", 15*time.Minute),
-		JWTRefreshDuration: getDuration("JWT_REFRESH_TOKEN_DURATION", 7*24*time.Hour),
-		InternalAPIKey:     getEnvRequired("INTERNAL_API_KEY"),
-		UserServiceURL:     getEnvRequired("USER_SERVICE_URL"),
-		SearchServiceURL:   getEnv("SEARCH_SERVICE_URL", ""),
-		LogLevel:           getEnv("LOG_LEVEL", "info"),
-		Environment:        getEnv("ENVIRONMENT", "development"),
+		Port:                getEnv("EVENT_SERVICE_PORT", "8002"),
+		DatabaseURL:         getEnvRequired("EVENT_SERVICE_DB_URL"),
+		DatabaseReplicaURL:  getEnv("EVENT_SERVICE_DB_REPLICA_URL", ""),
+		JWTSecret:           getEnvRequired("JWT_SECRET"),
+		JWTAccessDuration:   getDuration("JWT_ACCESS_TOKEN_DURATION", 15*time.Minute),
+		JWTRefreshDuration:  getDuration("JWT_REFRESH_TOKEN_DURATION", 7*24*time.Hour),
+		InternalAPIKey:      getEnvRequired("INTERNAL_API_KEY"),
+		UserServiceURL:      getEnvRequired("USER_SERVICE_URL"),
+		SearchServiceURL:    getEnv("SEARCH_SERVICE_URL", ""),
+		BookingServiceURL:   getEnv("BOOKING_SERVICE_URL", ""),
+		SearchSyncInterval:  getDuration("SEARCH_SYNC_INTERVAL", 2*time.Second),
+		SearchSyncBatchSize: getInt("SEARCH_SYNC_BATCH_SIZE", 100),
+		SearchSyncRetention: getDuration("SEARCH_SYNC_RETENTION", 24*time.Hour),
+		VenueSetupBuffer:    getDuration("EVENT_VENUE_SETUP_BUFFER", 0),
+		VenueTeardownBuffer: getDuration("EVENT_VENUE_TEARDOWN_BUFFER", 0),
+		DeletedRetention:    getDuration("EVENT_DELETED_RETENTION", 30*24*time.Hour),
+		PurgeInterval:       getDuration("EVENT_PURGE_INTERVAL", time.Hour),
+		MediaStorage:        getEnv("MEDIA_STORAGE", "local"),
+		MediaLocalDir:       getEnv("MEDIA_LOCAL_DIR", "./data/media"),
+		MediaPublicBaseURL:  getEnv("MEDIA_PUBLIC_BASE_URL", ""),
+		MediaMaxUploadBytes: getInt("MEDIA_MAX_UPLOAD_BYTES", 10<<20),
+		S3Endpoint:          getEnv("MEDIA_S3_ENDPOINT", ""),
+		S3Region:            getEnv("MEDIA_S3_REGION", "us-east-1"),
+		S3Bucket:            getEnv("MEDIA_S3_BUCKET", ""),
+		S3AccessKeyID:       getEnv("MEDIA_S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:   getEnv("MEDIA_S3_SECRET_ACCESS_KEY", ""),
+		S3UsePathStyle:      getBool("MEDIA_S3_USE_PATH_STYLE", false),
+		Geocoder:            getEnv("GEOCODER", "stub"),
+		GeocoderURL:         getEnv("GEOCODER_URL", "https://nominatim.openstreetmap.org"),
+		GeocoderUserAgent:   getEnv("GEOCODER_USER_AGENT", ""),
+		DefaultCurrency:     getEnv("DEFAULT_CURRENCY", "INR"),
+		TwoFactorIssuer:     getEnv("ADMIN_2FA_ISSUER", "BookMyEvent"),
+		TwoFactorRoles:      getList("ADMIN_2FA_REQUIRED_ROLES", nil),
+		LogLevel:            getEnv("LOG_LEVEL", "info"),
+		Environment:         getEnv("ENVIRONMENT", "development"),
 	}
 }
 
@@ -179,34 +228,106 @@ func getFloat(key string, defaultValue float64) float64 {
 	return floatValue
 }
 
+func getList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
 type SearchServiceConfig struct {
 	Port             string
 	ElasticsearchURL string
 	RedisURL         string
 	// RedisReplicaURL     string
-	EventServiceURL  string
-	InternalAPIKey   string
-	IndexName        string
-	CacheExpiry      time.Duration
-	MaxSearchResults int
-	SearchTimeout    time.Duration
-	LogLevel         string
-	Environment      string
+	EventServiceURL string
+	// UserServiceURL and BookingServiceURL feed personalized
+	// recommendations; without them the feed leaves out saved preferences or
+	// booking history. Saved searches are stored by the user service, so
+	// they are unavailable without UserServiceURL.
+	UserServiceURL    string
+	BookingServiceURL string
+	InternalAPIKey    string
+	JWTSecret         string
+	IndexName         string
+	// IndexRetainVersions is how many replaced index versions are kept for
+	// rollback.
+	IndexRetainVersions int
+	CacheExpiry         time.Duration
+	MaxSearchResults    int
+	SearchTimeout       time.Duration
+	// TrendingHalfLife is how long it takes a popularity signal to count
+	// half as much towards an event's trending score.
+	TrendingHalfLife      time.Duration
+	TrendingFlushInterval time.Duration
+	// ChangesSyncInterval is how often the index catches up on event-service's
+	// change feed. ChangesSyncOverlap is how far before the stored watermark
+	// each pass starts, to pick up transactions that committed late.
+	ChangesSyncInterval time.Duration
+	ChangesSyncOverlap  time.Duration
+	// SearchBackend is where searches are served from: "elasticsearch", or
+	// "memory" to run without Elasticsearch. SearchFallback is the backend
+	// reads switch to while Elasticsearch is unhealthy: "memory" or "none".
+	SearchBackend  string
+	SearchFallback string
+	// FallbackSyncInterval is how often an in-memory index catches up on
+	// event-service's change feed. HealthCheckInterval is how often
+	// Elasticsearch is checked to decide whether to degrade.
+	FallbackSyncInterval time.Duration
+	HealthCheckInterval  time.Duration
+	// AlertDeliveryInterval is how often matches for saved searches are
+	// gathered into alerts.
+	AlertDeliveryInterval time.Duration
+	// TrustedProxies are the addresses or CIDR ranges of the gateway in
+	// front of the service. Client address headers are only believed on
+	// requests that come from them.
+	TrustedProxies []string
+	LogLevel       string
+	Environment    string
 }
 
 func LoadSearchServiceConfig() *SearchServiceConfig {
+	// Elasticsearch is only needed when it is the backend.
+	searchBackend := getEnv("SEARCH_BACKEND", "elasticsearch")
+	elasticsearchURL := getEnv("ELASTICSEARCH_URL", "")
+	if searchBackend == "elasticsearch" {
+		elasticsearchURL = getEnvRequired("ELASTICSEARCH_URL")
+	}
+
 	return &SearchServiceConfig{
 		Port:             getEnv("SEARCH_SERVICE_PORT", "8003"),
-		ElasticsearchURL: getEnvRequired("ELASTICSEARCH_URL"),
+		ElasticsearchURL: elasticsearchURL,
 		RedisURL:         getEnvRequired("REDIS_URL"),
 		// RedisReplicaURL:  getEnv("REDIS_REPLICA_URL", ""),
-		EventServiceURL:  getEnvRequired("EVENT_SERVICE_URL"),
-		InternalAPIKey:   getEnvRequired("INTERNAL_API_KEY"),
-		IndexName:        getEnv("ELASTICSEARCH_INDEX_NAME", "events"),
-		CacheExpiry:      getDuration("SEARCH_CACHE_EXPIRY", 5*time.Minute),
-		MaxSearchResults: getInt("SEARCH_MAX_RESULTS", 1000),
-		SearchTimeout:    getDuration("SEARCH_TIMEOUT", 10*time.Second),
-		LogLevel:         getEnv("LOG_LEVEL", "info"),
-		Environment:      getEnv("ENVIRONMENT", "development"),
+		EventServiceURL:       getEnvRequired("EVENT_SERVICE_URL"),
+		UserServiceURL:        getEnv("USER_SERVICE_URL", ""),
+		BookingServiceURL:     getEnv("BOOKING_SERVICE_URL", ""),
+		InternalAPIKey:        getEnvRequired("INTERNAL_API_KEY"),
+		JWTSecret:             getEnvRequired("JWT_SECRET"),
+		IndexName:             getEnv("ELASTICSEARCH_INDEX_NAME", "events"),
+		IndexRetainVersions:   getInt("SEARCH_INDEX_RETAIN_VERSIONS", 2),
+		CacheExpiry:           getDuration("SEARCH_CACHE_EXPIRY", 5*time.Minute),
+		MaxSearchResults:      getInt("SEARCH_MAX_RESULTS", 1000),
+		SearchTimeout:         getDuration("SEARCH_TIMEOUT", 10*time.Second),
+		TrendingHalfLife:      getDuration("TRENDING_HALF_LIFE", 24*time.Hour),
+		TrendingFlushInterval: getDuration("TRENDING_FLUSH_INTERVAL", 30*time.Second),
+		ChangesSyncInterval:   getDuration("SEARCH_CHANGES_SYNC_INTERVAL", 5*time.Minute),
+		ChangesSyncOverlap:    getDuration("SEARCH_CHANGES_SYNC_OVERLAP", time.Minute),
+		SearchBackend:         searchBackend,
+		SearchFallback:        getEnv("SEARCH_FALLBACK", "memory"),
+		FallbackSyncInterval:  getDuration("SEARCH_FALLBACK_SYNC_INTERVAL", 30*time.Second),
+		HealthCheckInterval:   getDuration("SEARCH_HEALTH_CHECK_INTERVAL", 10*time.Second),
+		AlertDeliveryInterval: getDuration("SEARCH_ALERT_DELIVERY_INTERVAL", time.Minute),
+		TrustedProxies:        getList("SEARCH_TRUSTED_PROXIES", nil),
+		LogLevel:              getEnv("LOG_LEVEL", "info"),
+		Environment:           getEnv("ENVIRONMENT", "development"),
 	}
 }
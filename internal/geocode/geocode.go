@@ -0,0 +1,63 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Geocoder resolves a postal address to coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, address Address) (Point, error)
+}
+
+type Address struct {
+	Street     string
+	City       string
+	State      string
+	Country    string
+	PostalCode string
+}
+
+// String joins the non-empty parts of the address, most specific first.
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.Street, a.City, a.State, a.PostalCode, a.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+var ErrNotFound = errors.New("address not found")
+
+// Validate checks that the point is a real position on the globe.
+func (p Point) Validate() error {
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+const earthRadiusKm = 6371.0088
+
+// DistanceKm is the great-circle distance to another point.
+func (p Point) DistanceKm(q Point) float64 {
+	lat1, lat2 := p.Lat*math.Pi/180, q.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (q.Lon - p.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
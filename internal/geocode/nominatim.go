@@ -0,0 +1,86 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NominatimGeocoder queries an OpenStreetMap Nominatim server. The public
+// instance requires an identifying User-Agent and at most one request per
+// second, which venue creation stays well under.
+type NominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+func NewNominatimGeocoder(baseURL, userAgent string) (*NominatimGeocoder, error) {
+	parsed, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid geocoder URL %q", baseURL)
+	}
+	if userAgent == "" {
+		return nil, fmt.Errorf("geocoder user agent is required")
+	}
+	return &NominatimGeocoder{
+		baseURL:   parsed.String(),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (g *NominatimGeocoder) Geocode(ctx context.Context, address Address) (Point, error) {
+	query := url.Values{}
+	query.Set("format", "jsonv2")
+	query.Set("limit", "1")
+	query.Set("q", address.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+query.Encode(), nil)
+	if err != nil {
+		return Point{}, fmt.Errorf("failed to create geocode request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Point{}, fmt.Errorf("failed to call geocoder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Point{}, fmt.Errorf("geocoder returned status %d", resp.StatusCode)
+	}
+
+	// Nominatim returns coordinates as strings.
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Point{}, fmt.Errorf("failed to decode geocoder response: %w", err)
+	}
+	if len(results) == 0 {
+		return Point{}, ErrNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude in geocoder response: %w", err)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude in geocoder response: %w", err)
+	}
+	point := Point{Lat: lat, Lon: lon}
+	if err := point.Validate(); err != nil {
+		return Point{}, err
+	}
+	return point, nil
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+func TestLoggingMiddlewareFlush(t *testing.T) {
+	handler := LoggingMiddleware(logger.New("error"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first page\n"))
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush error = %v", err)
+		}
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/export", nil))
+
+	if !recorder.Flushed {
+		t.Error("Flush did not reach the underlying writer")
+	}
+	if got := recorder.Body.String(); got != "first page\n" {
+		t.Errorf("body = %q, want %q", got, "first page\n")
+	}
+}
+
+func TestLoggingMiddlewareUnwrap(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	handler := LoggingMiddleware(logger.New("error"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			t.Fatal("wrapped writer has no Unwrap method")
+		}
+		if unwrapper.Unwrap() != recorder {
+			t.Error("Unwrap did not return the underlying writer")
+		}
+	}))
+
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+}
@@ -0,0 +1,91 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is what events were priced in before they carried a
+// currency of their own.
+const DefaultCurrency = "INR"
+
+// Currency is an ISO 4217 currency. Amounts are kept as integers in its
+// minor unit (cents, paise, ...); Exponent is how many decimal places the
+// major unit has, so JPY is 0 and BHD is 3.
+type Currency struct {
+	Code     string
+	Exponent int
+}
+
+var currencies = map[string]int{
+	"AED": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2,
+	"DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "IDR": 2, "INR": 2, "JOD": 3,
+	"JPY": 0, "KRW": 0, "KWD": 3, "MXN": 2, "MYR": 2, "NOK": 2, "NZD": 2,
+	"OMR": 3, "PHP": 2, "PLN": 2, "QAR": 2, "SAR": 2, "SEK": 2, "SGD": 2,
+	"THB": 2, "TRY": 2, "USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// Lookup returns the currency for a code, ignoring case and surrounding
+// whitespace.
+func Lookup(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	exponent, ok := currencies[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("unsupported currency %q", code)
+	}
+	return Currency{Code: code, Exponent: exponent}, nil
+}
+
+// MustLookup is Lookup for codes that were validated before they were
+// stored. Unknown codes fall back to two decimal places.
+func MustLookup(code string) Currency {
+	currency, err := Lookup(code)
+	if err != nil {
+		return Currency{Code: strings.ToUpper(strings.TrimSpace(code)), Exponent: 2}
+	}
+	return currency
+}
+
+func (c Currency) scale() float64 {
+	return math.Pow10(c.Exponent)
+}
+
+// ToMinor converts a major-unit amount such as 12.5 to minor units (1250).
+// Amounts with more decimal places than the currency allows are rejected
+// rather than rounded.
+func (c Currency) ToMinor(amount float64) (int64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid amount")
+	}
+	scaled := amount * c.scale()
+	minor := math.Round(scaled)
+	if math.Abs(scaled-minor) > 1e-6 {
+		return 0, fmt.Errorf("%s amounts have at most %d decimal places", c.Code, c.Exponent)
+	}
+	if math.Abs(minor) >= 1<<53 {
+		return 0, fmt.Errorf("amount is too large")
+	}
+	return int64(minor), nil
+}
+
+// ToMajor converts minor units back to a major-unit amount for JSON
+// responses.
+func (c Currency) ToMajor(minor int64) float64 {
+	return float64(minor) / c.scale()
+}
+
+// Format renders minor units exactly, e.g. "USD 12.50" or "JPY 1200".
+func (c Currency) Format(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	if c.Exponent == 0 {
+		return c.Code + " " + sign + strconv.FormatInt(minor, 10)
+	}
+	unit := int64(c.scale())
+	return fmt.Sprintf("%s %s%d.%0*d", c.Code, sign, minor/unit, c.Exponent, minor%unit)
+}
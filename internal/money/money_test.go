@@ -0,0 +1,124 @@
+package money
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    Currency
+		wantErr bool
+	}{
+		{code: "USD", want: Currency{Code: "USD", Exponent: 2}},
+		{code: " jpy ", want: Currency{Code: "JPY", Exponent: 0}},
+		{code: "bhd", want: Currency{Code: "BHD", Exponent: 3}},
+		{code: "XYZ", wantErr: true},
+		{code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := Lookup(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Lookup(%q) = %+v, want %+v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustLookupFallsBackToTwoDecimals(t *testing.T) {
+	got := MustLookup(" xyz")
+	want := Currency{Code: "XYZ", Exponent: 2}
+	if got != want {
+		t.Errorf("MustLookup = %+v, want %+v", got, want)
+	}
+}
+
+func TestToMinor(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		amount   float64
+		want     int64
+		wantErr  bool
+	}{
+		{name: "whole amount", currency: "USD", amount: 12, want: 1200},
+		{name: "one decimal", currency: "USD", amount: 12.5, want: 1250},
+		{name: "inexact binary fraction", currency: "USD", amount: 0.29, want: 29},
+		{name: "price ending in 99", currency: "INR", amount: 19.99, want: 1999},
+		{name: "sum with float noise", currency: "EUR", amount: 0.1 + 0.2, want: 30},
+		{name: "negative", currency: "USD", amount: -3.75, want: -375},
+		{name: "zero exponent", currency: "JPY", amount: 1200, want: 1200},
+		{name: "three decimals", currency: "BHD", amount: 1.234, want: 1234},
+		{name: "too many decimals", currency: "USD", amount: 12.345, wantErr: true},
+		{name: "decimals on a zero exponent", currency: "JPY", amount: 12.5, wantErr: true},
+		{name: "four decimals on three", currency: "KWD", amount: 0.0005, wantErr: true},
+		{name: "NaN", currency: "USD", amount: math.NaN(), wantErr: true},
+		{name: "infinity", currency: "USD", amount: math.Inf(1), wantErr: true},
+		{name: "beyond float precision", currency: "USD", amount: 1e14, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MustLookup(tt.currency).ToMinor(tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToMinor(%v) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ToMinor(%v) = %d, want %d", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToMajorRoundTrips(t *testing.T) {
+	tests := []struct {
+		currency string
+		minor    int64
+		want     float64
+	}{
+		{currency: "USD", minor: 1250, want: 12.5},
+		{currency: "JPY", minor: 1200, want: 1200},
+		{currency: "BHD", minor: 1234, want: 1.234},
+		{currency: "USD", minor: -99, want: -0.99},
+	}
+
+	for _, tt := range tests {
+		c := MustLookup(tt.currency)
+		if got := c.ToMajor(tt.minor); got != tt.want {
+			t.Errorf("%s ToMajor(%d) = %v, want %v", tt.currency, tt.minor, got, tt.want)
+		}
+		if back, err := c.ToMinor(c.ToMajor(tt.minor)); err != nil || back != tt.minor {
+			t.Errorf("%s ToMinor(ToMajor(%d)) = %d, %v", tt.currency, tt.minor, back, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		currency string
+		minor    int64
+		want     string
+	}{
+		{currency: "USD", minor: 1250, want: "USD 12.50"},
+		{currency: "USD", minor: 5, want: "USD 0.05"},
+		{currency: "USD", minor: 0, want: "USD 0.00"},
+		{currency: "USD", minor: -1250, want: "USD -12.50"},
+		{currency: "USD", minor: -5, want: "USD -0.05"},
+		{currency: "JPY", minor: 1200, want: "JPY 1200"},
+		{currency: "JPY", minor: -1200, want: "JPY -1200"},
+		{currency: "BHD", minor: 1005, want: "BHD 1.005"},
+		{currency: "KWD", minor: 40, want: "KWD 0.040"},
+	}
+
+	for _, tt := range tests {
+		if got := MustLookup(tt.currency).Format(tt.minor); got != tt.want {
+			t.Errorf("%s Format(%d) = %q, want %q", tt.currency, tt.minor, got, tt.want)
+		}
+	}
+}
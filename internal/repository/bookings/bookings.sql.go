@@ -8,17 +8,91 @@ package bookings
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const checkInBooking = `-- name: CheckInBooking :one
+UPDATE bookings
+SET checked_in_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE booking_id = $1
+    AND status = 'confirmed'
+    AND checked_in_at IS NULL
+RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency
+`
+
+func (q *Queries) CheckInBooking(ctx context.Context, db DBTX, bookingID uuid.UUID) (Booking, error) {
+	row := db.QueryRowContext(ctx, checkInBooking, bookingID)
+	var i Booking
+	err := row.Scan(
+		&i.BookingID,
+		&i.UserID,
+		&i.EventID,
+		&i.BookingReference,
+		&i.Quantity,
+		&i.TotalAmountMinor,
+		&i.Status,
+		&i.PaymentStatus,
+		&i.IdempotencyKey,
+		&i.BookedAt,
+		&i.ExpiresAt,
+		&i.ConfirmedAt,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const countEventAttendees = `-- name: CountEventAttendees :one
+SELECT
+    COUNT(*) as total_bookings,
+    COALESCE(SUM(quantity), 0)::bigint as total_tickets,
+    COUNT(checked_in_at) as checked_in_bookings
+FROM bookings
+WHERE event_id = $1 AND status = 'confirmed'
+`
+
+type CountEventAttendeesRow struct {
+	TotalBookings     int64 `json:"total_bookings"`
+	TotalTickets      int64 `json:"total_tickets"`
+	CheckedInBookings int64 `json:"checked_in_bookings"`
+}
+
+func (q *Queries) CountEventAttendees(ctx context.Context, db DBTX, eventID uuid.UUID) (CountEventAttendeesRow, error) {
+	row := db.QueryRowContext(ctx, countEventAttendees, eventID)
+	var i CountEventAttendeesRow
+	err := row.Scan(
+		&i.TotalBookings,
+		&i.TotalTickets,
+		&i.CheckedInBookings,
+	)
+	return i, err
+}
+
+const countEventBookings = `-- name: CountEventBookings :one
+SELECT COUNT(*) FROM bookings
+WHERE event_id = $1
+`
+
+func (q *Queries) CountEventBookings(ctx context.Context, db DBTX, eventID uuid.UUID) (int64, error) {
+	row := db.QueryRowContext(ctx, countEventBookings, eventID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createBooking = `-- name: CreateBooking :one
 INSERT INTO bookings (
-    user_id, event_id, booking_reference, quantity, total_amount, 
-    status, payment_status, idempotency_key, expires_at
+    user_id, event_id, booking_reference, quantity, total_amount_minor, 
+    status, payment_status, idempotency_key, expires_at, currency
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency
 `
 
 type CreateBookingParams struct {
@@ -26,11 +100,12 @@ type CreateBookingParams struct {
 	EventID          uuid.UUID      `json:"event_id"`
 	BookingReference string         `json:"booking_reference"`
 	Quantity         int32          `json:"quantity"`
-	TotalAmount      string         `json:"total_amount"`
+	TotalAmountMinor int64          `json:"total_amount_minor"`
 	Status           string         `json:"status"`
 	PaymentStatus    string         `json:"payment_status"`
 	IdempotencyKey   sql.NullString `json:"idempotency_key"`
 	ExpiresAt        sql.NullTime   `json:"expires_at"`
+	Currency         string         `json:"currency"`
 }
 
 func (q *Queries) CreateBooking(ctx context.Context, db DBTX, arg CreateBookingParams) (Booking, error) {
@@ -39,11 +114,12 @@ func (q *Queries) CreateBooking(ctx context.Context, db DBTX, arg CreateBookingP
 		arg.EventID,
 		arg.BookingReference,
 		arg.Quantity,
-		arg.TotalAmount,
+		arg.TotalAmountMinor,
 		arg.Status,
 		arg.PaymentStatus,
 		arg.IdempotencyKey,
 		arg.ExpiresAt,
+		arg.Currency,
 	)
 	var i Booking
 	err := row.Scan(
@@ -52,7 +128,7 @@ func (q *Queries) CreateBooking(ctx context.Context, db DBTX, arg CreateBookingP
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -62,6 +138,8 @@ func (q *Queries) CreateBooking(ctx context.Context, db DBTX, arg CreateBookingP
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -76,7 +154,7 @@ func (q *Queries) DeleteBooking(ctx context.Context, db DBTX, bookingID uuid.UUI
 }
 
 const getBookingByID = `-- name: GetBookingByID :one
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings WHERE booking_id = $1
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings WHERE booking_id = $1
 `
 
 func (q *Queries) GetBookingByID(ctx context.Context, db DBTX, bookingID uuid.UUID) (Booking, error) {
@@ -88,7 +166,7 @@ func (q *Queries) GetBookingByID(ctx context.Context, db DBTX, bookingID uuid.UU
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -98,12 +176,14 @@ func (q *Queries) GetBookingByID(ctx context.Context, db DBTX, bookingID uuid.UU
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getBookingByIdempotencyKey = `-- name: GetBookingByIdempotencyKey :one
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings WHERE idempotency_key = $1
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings WHERE idempotency_key = $1
 `
 
 func (q *Queries) GetBookingByIdempotencyKey(ctx context.Context, db DBTX, idempotencyKey sql.NullString) (Booking, error) {
@@ -115,7 +195,7 @@ func (q *Queries) GetBookingByIdempotencyKey(ctx context.Context, db DBTX, idemp
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -125,12 +205,14 @@ func (q *Queries) GetBookingByIdempotencyKey(ctx context.Context, db DBTX, idemp
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getBookingByReference = `-- name: GetBookingByReference :one
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings WHERE booking_reference = $1
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings WHERE booking_reference = $1
 `
 
 func (q *Queries) GetBookingByReference(ctx context.Context, db DBTX, bookingReference string) (Booking, error) {
@@ -142,7 +224,7 @@ func (q *Queries) GetBookingByReference(ctx context.Context, db DBTX, bookingRef
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -152,15 +234,17 @@ func (q *Queries) GetBookingByReference(ctx context.Context, db DBTX, bookingRef
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getBookingWithPayment = `-- name: GetBookingWithPayment :one
 SELECT 
-    b.booking_id, b.user_id, b.event_id, b.booking_reference, b.quantity, b.total_amount, b.status, b.payment_status, b.idempotency_key, b.booked_at, b.expires_at, b.confirmed_at, b.cancelled_at, b.created_at, b.updated_at,
+    b.booking_id, b.user_id, b.event_id, b.booking_reference, b.quantity, b.total_amount_minor, b.status, b.payment_status, b.idempotency_key, b.booked_at, b.expires_at, b.confirmed_at, b.cancelled_at, b.created_at, b.updated_at, b.checked_in_at, b.currency,
     p.payment_id,
-    p.amount as payment_amount,
+    p.amount_minor as payment_amount_minor,
     p.gateway_transaction_id,
     p.payment_method,
     p.ticket_url,
@@ -177,7 +261,7 @@ type GetBookingWithPaymentRow struct {
 	EventID              uuid.UUID      `json:"event_id"`
 	BookingReference     string         `json:"booking_reference"`
 	Quantity             int32          `json:"quantity"`
-	TotalAmount          string         `json:"total_amount"`
+	TotalAmountMinor     int64          `json:"total_amount_minor"`
 	Status               string         `json:"status"`
 	PaymentStatus        string         `json:"payment_status"`
 	IdempotencyKey       sql.NullString `json:"idempotency_key"`
@@ -187,8 +271,10 @@ type GetBookingWithPaymentRow struct {
 	CancelledAt          sql.NullTime   `json:"cancelled_at"`
 	CreatedAt            sql.NullTime   `json:"created_at"`
 	UpdatedAt            sql.NullTime   `json:"updated_at"`
+	CheckedInAt          sql.NullTime   `json:"checked_in_at"`
+	Currency             string         `json:"currency"`
 	PaymentID            uuid.NullUUID  `json:"payment_id"`
-	PaymentAmount        sql.NullString `json:"payment_amount"`
+	PaymentAmountMinor   sql.NullInt64  `json:"payment_amount_minor"`
 	GatewayTransactionID sql.NullString `json:"gateway_transaction_id"`
 	PaymentMethod        sql.NullString `json:"payment_method"`
 	TicketUrl            sql.NullString `json:"ticket_url"`
@@ -205,7 +291,7 @@ func (q *Queries) GetBookingWithPayment(ctx context.Context, db DBTX, bookingID
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -215,8 +301,10 @@ func (q *Queries) GetBookingWithPayment(ctx context.Context, db DBTX, bookingID
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 		&i.PaymentID,
-		&i.PaymentAmount,
+		&i.PaymentAmountMinor,
 		&i.GatewayTransactionID,
 		&i.PaymentMethod,
 		&i.TicketUrl,
@@ -227,7 +315,7 @@ func (q *Queries) GetBookingWithPayment(ctx context.Context, db DBTX, bookingID
 }
 
 const getBookingsForCleanup = `-- name: GetBookingsForCleanup :many
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings 
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings 
 WHERE status = 'pending' AND expires_at < CURRENT_TIMESTAMP
 `
 
@@ -246,7 +334,7 @@ func (q *Queries) GetBookingsForCleanup(ctx context.Context, db DBTX) ([]Booking
 			&i.EventID,
 			&i.BookingReference,
 			&i.Quantity,
-			&i.TotalAmount,
+			&i.TotalAmountMinor,
 			&i.Status,
 			&i.PaymentStatus,
 			&i.IdempotencyKey,
@@ -256,6 +344,8 @@ func (q *Queries) GetBookingsForCleanup(ctx context.Context, db DBTX) ([]Booking
 			&i.CancelledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CheckedInAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -270,8 +360,46 @@ func (q *Queries) GetBookingsForCleanup(ctx context.Context, db DBTX) ([]Booking
 	return items, nil
 }
 
+const getEventBookingStats = `-- name: GetEventBookingStats :one
+SELECT
+    COUNT(*) as total_bookings,
+    COUNT(*) FILTER (WHERE status = 'confirmed') as confirmed_bookings,
+    COUNT(*) FILTER (WHERE status = 'cancelled') as cancelled_bookings,
+    COUNT(*) FILTER (WHERE status = 'expired') as expired_bookings,
+    COUNT(*) FILTER (WHERE status = 'pending') as pending_bookings,
+    COALESCE(SUM(quantity) FILTER (WHERE status = 'confirmed'), 0)::bigint as tickets_sold,
+    COALESCE(SUM(quantity) FILTER (WHERE status = 'cancelled'), 0)::bigint as tickets_cancelled
+FROM bookings
+WHERE event_id = $1
+`
+
+type GetEventBookingStatsRow struct {
+	TotalBookings     int64 `json:"total_bookings"`
+	ConfirmedBookings int64 `json:"confirmed_bookings"`
+	CancelledBookings int64 `json:"cancelled_bookings"`
+	ExpiredBookings   int64 `json:"expired_bookings"`
+	PendingBookings   int64 `json:"pending_bookings"`
+	TicketsSold       int64 `json:"tickets_sold"`
+	TicketsCancelled  int64 `json:"tickets_cancelled"`
+}
+
+func (q *Queries) GetEventBookingStats(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventBookingStatsRow, error) {
+	row := db.QueryRowContext(ctx, getEventBookingStats, eventID)
+	var i GetEventBookingStatsRow
+	err := row.Scan(
+		&i.TotalBookings,
+		&i.ConfirmedBookings,
+		&i.CancelledBookings,
+		&i.ExpiredBookings,
+		&i.PendingBookings,
+		&i.TicketsSold,
+		&i.TicketsCancelled,
+	)
+	return i, err
+}
+
 const getEventBookings = `-- name: GetEventBookings :many
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings 
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings 
 WHERE event_id = $1 AND status = $2
 ORDER BY created_at DESC 
 LIMIT $3 OFFSET $4
@@ -304,7 +432,7 @@ func (q *Queries) GetEventBookings(ctx context.Context, db DBTX, arg GetEventBoo
 			&i.EventID,
 			&i.BookingReference,
 			&i.Quantity,
-			&i.TotalAmount,
+			&i.TotalAmountMinor,
 			&i.Status,
 			&i.PaymentStatus,
 			&i.IdempotencyKey,
@@ -314,6 +442,8 @@ func (q *Queries) GetEventBookings(ctx context.Context, db DBTX, arg GetEventBoo
 			&i.CancelledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CheckedInAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -329,7 +459,7 @@ func (q *Queries) GetEventBookings(ctx context.Context, db DBTX, arg GetEventBoo
 }
 
 const getExpiredBookings = `-- name: GetExpiredBookings :many
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings 
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings 
 WHERE status = 'pending' AND expires_at < CURRENT_TIMESTAMP
 LIMIT $1
 `
@@ -349,7 +479,7 @@ func (q *Queries) GetExpiredBookings(ctx context.Context, db DBTX, limit int32)
 			&i.EventID,
 			&i.BookingReference,
 			&i.Quantity,
-			&i.TotalAmount,
+			&i.TotalAmountMinor,
 			&i.Status,
 			&i.PaymentStatus,
 			&i.IdempotencyKey,
@@ -359,6 +489,8 @@ func (q *Queries) GetExpiredBookings(ctx context.Context, db DBTX, limit int32)
 			&i.CancelledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CheckedInAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -374,7 +506,7 @@ func (q *Queries) GetExpiredBookings(ctx context.Context, db DBTX, limit int32)
 }
 
 const getPendingBookings = `-- name: GetPendingBookings :many
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings 
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings 
 WHERE status = 'pending'
 LIMIT $1
 `
@@ -394,7 +526,7 @@ func (q *Queries) GetPendingBookings(ctx context.Context, db DBTX, limit int32)
 			&i.EventID,
 			&i.BookingReference,
 			&i.Quantity,
-			&i.TotalAmount,
+			&i.TotalAmountMinor,
 			&i.Status,
 			&i.PaymentStatus,
 			&i.IdempotencyKey,
@@ -404,6 +536,8 @@ func (q *Queries) GetPendingBookings(ctx context.Context, db DBTX, limit int32)
 			&i.CancelledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CheckedInAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -419,7 +553,7 @@ func (q *Queries) GetPendingBookings(ctx context.Context, db DBTX, limit int32)
 }
 
 const getUserBookings = `-- name: GetUserBookings :many
-SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at FROM bookings 
+SELECT booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency FROM bookings 
 WHERE user_id = $1 
 ORDER BY created_at DESC 
 LIMIT $2 OFFSET $3
@@ -446,7 +580,7 @@ func (q *Queries) GetUserBookings(ctx context.Context, db DBTX, arg GetUserBooki
 			&i.EventID,
 			&i.BookingReference,
 			&i.Quantity,
-			&i.TotalAmount,
+			&i.TotalAmountMinor,
 			&i.Status,
 			&i.PaymentStatus,
 			&i.IdempotencyKey,
@@ -456,6 +590,8 @@ func (q *Queries) GetUserBookings(ctx context.Context, db DBTX, arg GetUserBooki
 			&i.CancelledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CheckedInAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -481,11 +617,123 @@ func (q *Queries) GetUserBookingsCount(ctx context.Context, db DBTX, userID uuid
 	return count, err
 }
 
+const listEventAttendees = `-- name: ListEventAttendees :many
+SELECT
+    b.booking_id,
+    b.user_id,
+    b.booking_reference,
+    b.quantity,
+    b.confirmed_at,
+    b.checked_in_at,
+    COALESCE(string_agg(CONCAT_WS('-', s.seat_section, s.seat_row, s.seat_number), ',' ORDER BY s.seat_section, s.seat_row, s.seat_number) FILTER (WHERE s.booking_seat_id IS NOT NULL), '')::text as seats
+FROM bookings b
+LEFT JOIN booking_seats s ON s.booking_id = b.booking_id
+WHERE b.event_id = $1
+    AND b.status = 'confirmed'
+    AND b.booking_id > $2
+GROUP BY b.booking_id
+ORDER BY b.booking_id
+LIMIT $3
+`
+
+type ListEventAttendeesParams struct {
+	EventID        uuid.UUID `json:"event_id"`
+	AfterBookingID uuid.UUID `json:"after_booking_id"`
+	PageSize       int32     `json:"page_size"`
+}
+
+type ListEventAttendeesRow struct {
+	BookingID        uuid.UUID    `json:"booking_id"`
+	UserID           uuid.UUID    `json:"user_id"`
+	BookingReference string       `json:"booking_reference"`
+	Quantity         int32        `json:"quantity"`
+	ConfirmedAt      sql.NullTime `json:"confirmed_at"`
+	CheckedInAt      sql.NullTime `json:"checked_in_at"`
+	Seats            string       `json:"seats"`
+}
+
+func (q *Queries) ListEventAttendees(ctx context.Context, db DBTX, arg ListEventAttendeesParams) ([]ListEventAttendeesRow, error) {
+	rows, err := db.QueryContext(ctx, listEventAttendees, arg.EventID, arg.AfterBookingID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEventAttendeesRow{}
+	for rows.Next() {
+		var i ListEventAttendeesRow
+		if err := rows.Scan(
+			&i.BookingID,
+			&i.UserID,
+			&i.BookingReference,
+			&i.Quantity,
+			&i.ConfirmedAt,
+			&i.CheckedInAt,
+			&i.Seats,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserBookedEvents = `-- name: ListUserBookedEvents :many
+SELECT
+    event_id,
+    SUM(quantity)::bigint as tickets,
+    MAX(booked_at)::timestamp as last_booked_at
+FROM bookings
+WHERE user_id = $1 AND status = 'confirmed'
+GROUP BY event_id
+ORDER BY last_booked_at DESC
+LIMIT $2
+`
+
+type ListUserBookedEventsParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Limit  int32     `json:"limit"`
+}
+
+type ListUserBookedEventsRow struct {
+	EventID      uuid.UUID `json:"event_id"`
+	Tickets      int64     `json:"tickets"`
+	LastBookedAt time.Time `json:"last_booked_at"`
+}
+
+func (q *Queries) ListUserBookedEvents(ctx context.Context, db DBTX, arg ListUserBookedEventsParams) ([]ListUserBookedEventsRow, error) {
+	rows, err := db.QueryContext(ctx, listUserBookedEvents, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserBookedEventsRow{}
+	for rows.Next() {
+		var i ListUserBookedEventsRow
+		if err := rows.Scan(&i.EventID, &i.Tickets, &i.LastBookedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateBookingPaymentStatus = `-- name: UpdateBookingPaymentStatus :one
 UPDATE bookings 
 SET payment_status = $2, updated_at = CURRENT_TIMESTAMP
 WHERE booking_id = $1 
-RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at
+RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency
 `
 
 type UpdateBookingPaymentStatusParams struct {
@@ -502,7 +750,7 @@ func (q *Queries) UpdateBookingPaymentStatus(ctx context.Context, db DBTX, arg U
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -512,6 +760,8 @@ func (q *Queries) UpdateBookingPaymentStatus(ctx context.Context, db DBTX, arg U
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -522,7 +772,7 @@ SET status = $1::VARCHAR, updated_at = CURRENT_TIMESTAMP,
     confirmed_at = CASE WHEN $1::VARCHAR = 'confirmed' THEN CURRENT_TIMESTAMP ELSE confirmed_at END,
     cancelled_at = CASE WHEN $1::VARCHAR = 'cancelled' THEN CURRENT_TIMESTAMP ELSE cancelled_at END
 WHERE booking_id = $2 
-RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at
+RETURNING booking_id, user_id, event_id, booking_reference, quantity, total_amount_minor, status, payment_status, idempotency_key, booked_at, expires_at, confirmed_at, cancelled_at, created_at, updated_at, checked_in_at, currency
 `
 
 type UpdateBookingStatusParams struct {
@@ -539,7 +789,7 @@ func (q *Queries) UpdateBookingStatus(ctx context.Context, db DBTX, arg UpdateBo
 		&i.EventID,
 		&i.BookingReference,
 		&i.Quantity,
-		&i.TotalAmount,
+		&i.TotalAmountMinor,
 		&i.Status,
 		&i.PaymentStatus,
 		&i.IdempotencyKey,
@@ -549,6 +799,8 @@ func (q *Queries) UpdateBookingStatus(ctx context.Context, db DBTX, arg UpdateBo
 		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CheckedInAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -16,7 +16,7 @@ type Booking struct {
 	EventID          uuid.UUID      `json:"event_id"`
 	BookingReference string         `json:"booking_reference"`
 	Quantity         int32          `json:"quantity"`
-	TotalAmount      string         `json:"total_amount"`
+	TotalAmountMinor int64          `json:"total_amount_minor"`
 	Status           string         `json:"status"`
 	PaymentStatus    string         `json:"payment_status"`
 	IdempotencyKey   sql.NullString `json:"idempotency_key"`
@@ -26,6 +26,8 @@ type Booking struct {
 	CancelledAt      sql.NullTime   `json:"cancelled_at"`
 	CreatedAt        sql.NullTime   `json:"created_at"`
 	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	CheckedInAt      sql.NullTime   `json:"checked_in_at"`
+	Currency         string         `json:"currency"`
 }
 
 type BookingSeat struct {
@@ -44,8 +46,8 @@ type Payment struct {
 	BookingID            uuid.UUID      `json:"booking_id"`
 	UserID               uuid.UUID      `json:"user_id"`
 	EventID              uuid.UUID      `json:"event_id"`
-	Amount               string         `json:"amount"`
-	Currency             sql.NullString `json:"currency"`
+	AmountMinor          int64          `json:"amount_minor"`
+	Currency             string         `json:"currency"`
 	PaymentMethod        sql.NullString `json:"payment_method"`
 	PaymentGateway       sql.NullString `json:"payment_gateway"`
 	GatewayTransactionID sql.NullString `json:"gateway_transaction_id"`
@@ -57,6 +59,8 @@ type Payment struct {
 	ErrorMessage         sql.NullString `json:"error_message"`
 	CreatedAt            sql.NullTime   `json:"created_at"`
 	UpdatedAt            sql.NullTime   `json:"updated_at"`
+	RefundAmountMinor    int64          `json:"refund_amount_minor"`
+	RefundedAt           sql.NullTime   `json:"refunded_at"`
 }
 
 type Waitlist struct {
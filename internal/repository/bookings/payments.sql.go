@@ -8,25 +8,26 @@ package bookings
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createPayment = `-- name: CreatePayment :one
 INSERT INTO payments (
-    booking_id, user_id, event_id, amount, currency, 
+    booking_id, user_id, event_id, amount_minor, currency, 
     payment_method, payment_gateway, gateway_transaction_id, status
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at
+) RETURNING payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at
 `
 
 type CreatePaymentParams struct {
 	BookingID            uuid.UUID      `json:"booking_id"`
 	UserID               uuid.UUID      `json:"user_id"`
 	EventID              uuid.UUID      `json:"event_id"`
-	Amount               string         `json:"amount"`
-	Currency             sql.NullString `json:"currency"`
+	AmountMinor          int64          `json:"amount_minor"`
+	Currency             string         `json:"currency"`
 	PaymentMethod        sql.NullString `json:"payment_method"`
 	PaymentGateway       sql.NullString `json:"payment_gateway"`
 	GatewayTransactionID sql.NullString `json:"gateway_transaction_id"`
@@ -38,7 +39,7 @@ func (q *Queries) CreatePayment(ctx context.Context, db DBTX, arg CreatePaymentP
 		arg.BookingID,
 		arg.UserID,
 		arg.EventID,
-		arg.Amount,
+		arg.AmountMinor,
 		arg.Currency,
 		arg.PaymentMethod,
 		arg.PaymentGateway,
@@ -51,7 +52,7 @@ func (q *Queries) CreatePayment(ctx context.Context, db DBTX, arg CreatePaymentP
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -64,12 +65,14 @@ func (q *Queries) CreatePayment(ctx context.Context, db DBTX, arg CreatePaymentP
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
 
 const getEventPayments = `-- name: GetEventPayments :many
-SELECT payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at FROM payments 
+SELECT payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at FROM payments 
 WHERE event_id = $1 AND status = 'completed'
 ORDER BY completed_at DESC 
 LIMIT $2 OFFSET $3
@@ -95,7 +98,7 @@ func (q *Queries) GetEventPayments(ctx context.Context, db DBTX, arg GetEventPay
 			&i.BookingID,
 			&i.UserID,
 			&i.EventID,
-			&i.Amount,
+			&i.AmountMinor,
 			&i.Currency,
 			&i.PaymentMethod,
 			&i.PaymentGateway,
@@ -108,6 +111,130 @@ func (q *Queries) GetEventPayments(ctx context.Context, db DBTX, arg GetEventPay
 			&i.ErrorMessage,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RefundAmountMinor,
+			&i.RefundedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEventRevenueSummary = `-- name: GetEventRevenueSummary :one
+SELECT
+    COUNT(*) as total_payments,
+    COALESCE(MAX(currency), '')::text as currency,
+    COALESCE(SUM(amount_minor), 0)::bigint as gross_revenue_minor,
+    COALESCE(SUM(refund_amount_minor), 0)::bigint as refunded_amount_minor,
+    COALESCE(SUM(amount_minor - refund_amount_minor), 0)::bigint as net_revenue_minor,
+    COALESCE(ROUND(AVG(amount_minor)), 0)::bigint as average_order_value_minor
+FROM payments
+WHERE event_id = $1 AND status = 'completed'
+`
+
+type GetEventRevenueSummaryRow struct {
+	TotalPayments          int64  `json:"total_payments"`
+	Currency               string `json:"currency"`
+	GrossRevenueMinor      int64  `json:"gross_revenue_minor"`
+	RefundedAmountMinor    int64  `json:"refunded_amount_minor"`
+	NetRevenueMinor        int64  `json:"net_revenue_minor"`
+	AverageOrderValueMinor int64  `json:"average_order_value_minor"`
+}
+
+func (q *Queries) GetEventRevenueSummary(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventRevenueSummaryRow, error) {
+	row := db.QueryRowContext(ctx, getEventRevenueSummary, eventID)
+	var i GetEventRevenueSummaryRow
+	err := row.Scan(
+		&i.TotalPayments,
+		&i.Currency,
+		&i.GrossRevenueMinor,
+		&i.RefundedAmountMinor,
+		&i.NetRevenueMinor,
+		&i.AverageOrderValueMinor,
+	)
+	return i, err
+}
+
+const getEventSalesTimeSeries = `-- name: GetEventSalesTimeSeries :many
+WITH sales AS (
+    SELECT
+        date_trunc($2::text, p.completed_at) as bucket,
+        COUNT(*) as orders,
+        SUM(b.quantity) as tickets_sold,
+        SUM(p.amount_minor) as gross_revenue_minor
+    FROM payments p
+    JOIN bookings b ON p.booking_id = b.booking_id
+    WHERE p.event_id = $1
+        AND p.status = 'completed'
+        AND p.completed_at >= $3
+        AND p.completed_at < $4
+    GROUP BY 1
+),
+refunds AS (
+    -- Refunds count when they happen, not when the sale did.
+    SELECT
+        date_trunc($2::text, p.refunded_at) as bucket,
+        SUM(p.refund_amount_minor) as refunded_amount_minor
+    FROM payments p
+    WHERE p.event_id = $1
+        AND p.status = 'completed'
+        AND p.refunded_at >= $3
+        AND p.refunded_at < $4
+    GROUP BY 1
+)
+SELECT
+    COALESCE(s.bucket, r.bucket)::timestamp as bucket,
+    COALESCE(s.orders, 0)::bigint as orders,
+    COALESCE(s.tickets_sold, 0)::bigint as tickets_sold,
+    COALESCE(s.gross_revenue_minor, 0)::bigint as gross_revenue_minor,
+    COALESCE(r.refunded_amount_minor, 0)::bigint as refunded_amount_minor
+FROM sales s
+FULL OUTER JOIN refunds r ON s.bucket = r.bucket
+ORDER BY bucket
+`
+
+type GetEventSalesTimeSeriesParams struct {
+	EventID       uuid.UUID    `json:"event_id"`
+	Column2       string       `json:"column_2"`
+	CompletedAt   sql.NullTime `json:"completed_at"`
+	CompletedAt_2 sql.NullTime `json:"completed_at_2"`
+}
+
+type GetEventSalesTimeSeriesRow struct {
+	Bucket              time.Time `json:"bucket"`
+	Orders              int64     `json:"orders"`
+	TicketsSold         int64     `json:"tickets_sold"`
+	GrossRevenueMinor   int64     `json:"gross_revenue_minor"`
+	RefundedAmountMinor int64     `json:"refunded_amount_minor"`
+}
+
+func (q *Queries) GetEventSalesTimeSeries(ctx context.Context, db DBTX, arg GetEventSalesTimeSeriesParams) ([]GetEventSalesTimeSeriesRow, error) {
+	rows, err := db.QueryContext(ctx, getEventSalesTimeSeries,
+		arg.EventID,
+		arg.Column2,
+		arg.CompletedAt,
+		arg.CompletedAt_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEventSalesTimeSeriesRow{}
+	for rows.Next() {
+		var i GetEventSalesTimeSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.Orders,
+			&i.TicketsSold,
+			&i.GrossRevenueMinor,
+			&i.RefundedAmountMinor,
 		); err != nil {
 			return nil, err
 		}
@@ -123,7 +250,7 @@ func (q *Queries) GetEventPayments(ctx context.Context, db DBTX, arg GetEventPay
 }
 
 const getPaymentByBookingID = `-- name: GetPaymentByBookingID :one
-SELECT payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at FROM payments WHERE booking_id = $1
+SELECT payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at FROM payments WHERE booking_id = $1
 `
 
 func (q *Queries) GetPaymentByBookingID(ctx context.Context, db DBTX, bookingID uuid.UUID) (Payment, error) {
@@ -134,7 +261,7 @@ func (q *Queries) GetPaymentByBookingID(ctx context.Context, db DBTX, bookingID
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -147,12 +274,14 @@ func (q *Queries) GetPaymentByBookingID(ctx context.Context, db DBTX, bookingID
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
 
 const getPaymentByGatewayTransactionID = `-- name: GetPaymentByGatewayTransactionID :one
-SELECT payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at FROM payments WHERE gateway_transaction_id = $1
+SELECT payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at FROM payments WHERE gateway_transaction_id = $1
 `
 
 func (q *Queries) GetPaymentByGatewayTransactionID(ctx context.Context, db DBTX, gatewayTransactionID sql.NullString) (Payment, error) {
@@ -163,7 +292,7 @@ func (q *Queries) GetPaymentByGatewayTransactionID(ctx context.Context, db DBTX,
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -176,12 +305,14 @@ func (q *Queries) GetPaymentByGatewayTransactionID(ctx context.Context, db DBTX,
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
 
 const getPaymentByID = `-- name: GetPaymentByID :one
-SELECT payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at FROM payments WHERE payment_id = $1
+SELECT payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at FROM payments WHERE payment_id = $1
 `
 
 func (q *Queries) GetPaymentByID(ctx context.Context, db DBTX, paymentID uuid.UUID) (Payment, error) {
@@ -192,7 +323,7 @@ func (q *Queries) GetPaymentByID(ctx context.Context, db DBTX, paymentID uuid.UU
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -205,6 +336,8 @@ func (q *Queries) GetPaymentByID(ctx context.Context, db DBTX, paymentID uuid.UU
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
@@ -213,14 +346,14 @@ const getPaymentsForAnalytics = `-- name: GetPaymentsForAnalytics :many
 SELECT 
     event_id,
     COUNT(*) as total_payments,
-    SUM(amount) as total_revenue,
-    AVG(amount) as average_amount
+    SUM(amount_minor) as total_revenue_minor,
+    AVG(amount_minor) as average_amount_minor
 FROM payments 
 WHERE status = 'completed' 
     AND completed_at >= $1 
     AND completed_at <= $2
 GROUP BY event_id
-ORDER BY total_revenue DESC
+ORDER BY total_revenue_minor DESC
 `
 
 type GetPaymentsForAnalyticsParams struct {
@@ -229,10 +362,10 @@ type GetPaymentsForAnalyticsParams struct {
 }
 
 type GetPaymentsForAnalyticsRow struct {
-	EventID       uuid.UUID `json:"event_id"`
-	TotalPayments int64     `json:"total_payments"`
-	TotalRevenue  int64     `json:"total_revenue"`
-	AverageAmount float64   `json:"average_amount"`
+	EventID            uuid.UUID `json:"event_id"`
+	TotalPayments      int64     `json:"total_payments"`
+	TotalRevenueMinor  int64     `json:"total_revenue_minor"`
+	AverageAmountMinor float64   `json:"average_amount_minor"`
 }
 
 func (q *Queries) GetPaymentsForAnalytics(ctx context.Context, db DBTX, arg GetPaymentsForAnalyticsParams) ([]GetPaymentsForAnalyticsRow, error) {
@@ -247,8 +380,8 @@ func (q *Queries) GetPaymentsForAnalytics(ctx context.Context, db DBTX, arg GetP
 		if err := rows.Scan(
 			&i.EventID,
 			&i.TotalPayments,
-			&i.TotalRevenue,
-			&i.AverageAmount,
+			&i.TotalRevenueMinor,
+			&i.AverageAmountMinor,
 		); err != nil {
 			return nil, err
 		}
@@ -265,7 +398,7 @@ func (q *Queries) GetPaymentsForAnalytics(ctx context.Context, db DBTX, arg GetP
 
 const getUserPaymentHistory = `-- name: GetUserPaymentHistory :many
 SELECT 
-    p.payment_id, p.booking_id, p.user_id, p.event_id, p.amount, p.currency, p.payment_method, p.payment_gateway, p.gateway_transaction_id, p.status, p.ticket_url, p.initiated_at, p.completed_at, p.failed_at, p.error_message, p.created_at, p.updated_at,
+    p.payment_id, p.booking_id, p.user_id, p.event_id, p.amount_minor, p.currency, p.payment_method, p.payment_gateway, p.gateway_transaction_id, p.status, p.ticket_url, p.initiated_at, p.completed_at, p.failed_at, p.error_message, p.created_at, p.updated_at, p.refund_amount_minor, p.refunded_at,
     b.booking_reference,
     b.quantity,
     b.event_id
@@ -287,8 +420,8 @@ type GetUserPaymentHistoryRow struct {
 	BookingID            uuid.UUID      `json:"booking_id"`
 	UserID               uuid.UUID      `json:"user_id"`
 	EventID              uuid.UUID      `json:"event_id"`
-	Amount               string         `json:"amount"`
-	Currency             sql.NullString `json:"currency"`
+	AmountMinor          int64          `json:"amount_minor"`
+	Currency             string         `json:"currency"`
 	PaymentMethod        sql.NullString `json:"payment_method"`
 	PaymentGateway       sql.NullString `json:"payment_gateway"`
 	GatewayTransactionID sql.NullString `json:"gateway_transaction_id"`
@@ -300,6 +433,8 @@ type GetUserPaymentHistoryRow struct {
 	ErrorMessage         sql.NullString `json:"error_message"`
 	CreatedAt            sql.NullTime   `json:"created_at"`
 	UpdatedAt            sql.NullTime   `json:"updated_at"`
+	RefundAmountMinor    int64          `json:"refund_amount_minor"`
+	RefundedAt           sql.NullTime   `json:"refunded_at"`
 	BookingReference     string         `json:"booking_reference"`
 	Quantity             int32          `json:"quantity"`
 	EventID_2            uuid.UUID      `json:"event_id_2"`
@@ -319,7 +454,7 @@ func (q *Queries) GetUserPaymentHistory(ctx context.Context, db DBTX, arg GetUse
 			&i.BookingID,
 			&i.UserID,
 			&i.EventID,
-			&i.Amount,
+			&i.AmountMinor,
 			&i.Currency,
 			&i.PaymentMethod,
 			&i.PaymentGateway,
@@ -332,6 +467,8 @@ func (q *Queries) GetUserPaymentHistory(ctx context.Context, db DBTX, arg GetUse
 			&i.ErrorMessage,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RefundAmountMinor,
+			&i.RefundedAt,
 			&i.BookingReference,
 			&i.Quantity,
 			&i.EventID_2,
@@ -350,7 +487,7 @@ func (q *Queries) GetUserPaymentHistory(ctx context.Context, db DBTX, arg GetUse
 }
 
 const getUserPayments = `-- name: GetUserPayments :many
-SELECT payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at FROM payments 
+SELECT payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at FROM payments 
 WHERE user_id = $1 AND status = 'completed'
 ORDER BY completed_at DESC 
 LIMIT $2 OFFSET $3
@@ -376,7 +513,7 @@ func (q *Queries) GetUserPayments(ctx context.Context, db DBTX, arg GetUserPayme
 			&i.BookingID,
 			&i.UserID,
 			&i.EventID,
-			&i.Amount,
+			&i.AmountMinor,
 			&i.Currency,
 			&i.PaymentMethod,
 			&i.PaymentGateway,
@@ -389,6 +526,8 @@ func (q *Queries) GetUserPayments(ctx context.Context, db DBTX, arg GetUserPayme
 			&i.ErrorMessage,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RefundAmountMinor,
+			&i.RefundedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -403,6 +542,47 @@ func (q *Queries) GetUserPayments(ctx context.Context, db DBTX, arg GetUserPayme
 	return items, nil
 }
 
+const recordPaymentRefund = `-- name: RecordPaymentRefund :one
+UPDATE payments
+SET refund_amount_minor = $2,
+    refunded_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE booking_id = $1 AND status = 'completed'
+RETURNING payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at
+`
+
+type RecordPaymentRefundParams struct {
+	BookingID         uuid.UUID `json:"booking_id"`
+	RefundAmountMinor int64     `json:"refund_amount_minor"`
+}
+
+func (q *Queries) RecordPaymentRefund(ctx context.Context, db DBTX, arg RecordPaymentRefundParams) (Payment, error) {
+	row := db.QueryRowContext(ctx, recordPaymentRefund, arg.BookingID, arg.RefundAmountMinor)
+	var i Payment
+	err := row.Scan(
+		&i.PaymentID,
+		&i.BookingID,
+		&i.UserID,
+		&i.EventID,
+		&i.AmountMinor,
+		&i.Currency,
+		&i.PaymentMethod,
+		&i.PaymentGateway,
+		&i.GatewayTransactionID,
+		&i.Status,
+		&i.TicketUrl,
+		&i.InitiatedAt,
+		&i.CompletedAt,
+		&i.FailedAt,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
+	)
+	return i, err
+}
+
 const updatePaymentStatus = `-- name: UpdatePaymentStatus :one
 UPDATE payments 
 SET status = $2, updated_at = CURRENT_TIMESTAMP,
@@ -410,7 +590,7 @@ SET status = $2, updated_at = CURRENT_TIMESTAMP,
     failed_at = CASE WHEN $2 = 'failed' THEN CURRENT_TIMESTAMP ELSE failed_at END,
     error_message = $3
 WHERE payment_id = $1 
-RETURNING payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at
+RETURNING payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at
 `
 
 type UpdatePaymentStatusParams struct {
@@ -427,7 +607,7 @@ func (q *Queries) UpdatePaymentStatus(ctx context.Context, db DBTX, arg UpdatePa
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -440,6 +620,8 @@ func (q *Queries) UpdatePaymentStatus(ctx context.Context, db DBTX, arg UpdatePa
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
@@ -448,7 +630,7 @@ const updatePaymentTicketURL = `-- name: UpdatePaymentTicketURL :one
 UPDATE payments 
 SET ticket_url = $2, updated_at = CURRENT_TIMESTAMP
 WHERE payment_id = $1 
-RETURNING payment_id, booking_id, user_id, event_id, amount, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at
+RETURNING payment_id, booking_id, user_id, event_id, amount_minor, currency, payment_method, payment_gateway, gateway_transaction_id, status, ticket_url, initiated_at, completed_at, failed_at, error_message, created_at, updated_at, refund_amount_minor, refunded_at
 `
 
 type UpdatePaymentTicketURLParams struct {
@@ -464,7 +646,7 @@ func (q *Queries) UpdatePaymentTicketURL(ctx context.Context, db DBTX, arg Updat
 		&i.BookingID,
 		&i.UserID,
 		&i.EventID,
-		&i.Amount,
+		&i.AmountMinor,
 		&i.Currency,
 		&i.PaymentMethod,
 		&i.PaymentGateway,
@@ -477,6 +659,8 @@ func (q *Queries) UpdatePaymentTicketURL(ctx context.Context, db DBTX, arg Updat
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundAmountMinor,
+		&i.RefundedAt,
 	)
 	return i, err
 }
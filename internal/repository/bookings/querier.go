@@ -12,6 +12,9 @@ import (
 )
 
 type Querier interface {
+	CheckInBooking(ctx context.Context, db DBTX, bookingID uuid.UUID) (Booking, error)
+	CountEventAttendees(ctx context.Context, db DBTX, eventID uuid.UUID) (CountEventAttendeesRow, error)
+	CountEventBookings(ctx context.Context, db DBTX, eventID uuid.UUID) (int64, error)
 	CreateBooking(ctx context.Context, db DBTX, arg CreateBookingParams) (Booking, error)
 	CreateBookingSeat(ctx context.Context, db DBTX, arg CreateBookingSeatParams) (BookingSeat, error)
 	CreatePayment(ctx context.Context, db DBTX, arg CreatePaymentParams) (Payment, error)
@@ -25,9 +28,13 @@ type Querier interface {
 	GetBookingSeatsCount(ctx context.Context, db DBTX, bookingID uuid.UUID) (int64, error)
 	GetBookingWithPayment(ctx context.Context, db DBTX, bookingID uuid.UUID) (GetBookingWithPaymentRow, error)
 	GetBookingsForCleanup(ctx context.Context, db DBTX) ([]Booking, error)
+	GetEventBookingStats(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventBookingStatsRow, error)
 	GetEventBookings(ctx context.Context, db DBTX, arg GetEventBookingsParams) ([]Booking, error)
 	GetEventPayments(ctx context.Context, db DBTX, arg GetEventPaymentsParams) ([]Payment, error)
+	GetEventRevenueSummary(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventRevenueSummaryRow, error)
+	GetEventSalesTimeSeries(ctx context.Context, db DBTX, arg GetEventSalesTimeSeriesParams) ([]GetEventSalesTimeSeriesRow, error)
 	GetEventWaitlist(ctx context.Context, db DBTX, arg GetEventWaitlistParams) ([]Waitlist, error)
+	GetEventWaitlistConversion(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventWaitlistConversionRow, error)
 	GetExpiredBookings(ctx context.Context, db DBTX, limit int32) ([]Booking, error)
 	GetExpiredWaitlistOffers(ctx context.Context, db DBTX) ([]Waitlist, error)
 	GetNextWaitlistEntries(ctx context.Context, db DBTX, arg GetNextWaitlistEntriesParams) ([]Waitlist, error)
@@ -49,6 +56,9 @@ type Querier interface {
 	GetWaitlistStats(ctx context.Context, db DBTX, eventID uuid.UUID) (GetWaitlistStatsRow, error)
 	JoinWaitlist(ctx context.Context, db DBTX, arg JoinWaitlistParams) (Waitlist, error)
 	ReassignWaitlistPosition(ctx context.Context, db DBTX, arg ReassignWaitlistPositionParams) error
+	ListEventAttendees(ctx context.Context, db DBTX, arg ListEventAttendeesParams) ([]ListEventAttendeesRow, error)
+	ListUserBookedEvents(ctx context.Context, db DBTX, arg ListUserBookedEventsParams) ([]ListUserBookedEventsRow, error)
+	RecordPaymentRefund(ctx context.Context, db DBTX, arg RecordPaymentRefundParams) (Payment, error)
 	RemoveFromWaitlist(ctx context.Context, db DBTX, arg RemoveFromWaitlistParams) error
 	ReorderWaitlistAfterRemoval(ctx context.Context, db DBTX, arg ReorderWaitlistAfterRemovalParams) error
 	SetWaitlistOffered(ctx context.Context, db DBTX, arg SetWaitlistOfferedParams) (Waitlist, error)
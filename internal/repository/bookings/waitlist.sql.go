@@ -60,6 +60,38 @@ func (q *Queries) GetEventWaitlist(ctx context.Context, db DBTX, arg GetEventWai
 	return items, nil
 }
 
+const getEventWaitlistConversion = `-- name: GetEventWaitlistConversion :one
+SELECT
+    COUNT(*) as total_joined,
+    COUNT(*) FILTER (WHERE status = 'waiting') as waiting,
+    COUNT(*) FILTER (WHERE offered_at IS NOT NULL) as total_offered,
+    COUNT(*) FILTER (WHERE status = 'converted') as converted,
+    COUNT(*) FILTER (WHERE status = 'expired') as expired
+FROM waitlist
+WHERE event_id = $1
+`
+
+type GetEventWaitlistConversionRow struct {
+	TotalJoined  int64 `json:"total_joined"`
+	Waiting      int64 `json:"waiting"`
+	TotalOffered int64 `json:"total_offered"`
+	Converted    int64 `json:"converted"`
+	Expired      int64 `json:"expired"`
+}
+
+func (q *Queries) GetEventWaitlistConversion(ctx context.Context, db DBTX, eventID uuid.UUID) (GetEventWaitlistConversionRow, error) {
+	row := db.QueryRowContext(ctx, getEventWaitlistConversion, eventID)
+	var i GetEventWaitlistConversionRow
+	err := row.Scan(
+		&i.TotalJoined,
+		&i.Waiting,
+		&i.TotalOffered,
+		&i.Converted,
+		&i.Expired,
+	)
+	return i, err
+}
+
 const getExpiredWaitlistOffers = `-- name: GetExpiredWaitlistOffers :many
 SELECT waitlist_id, event_id, user_id, position, quantity_requested, status, joined_at, offered_at, expires_at, converted_at, created_at, updated_at FROM waitlist
 WHERE status = 'offered'
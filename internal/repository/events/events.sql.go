@@ -11,53 +11,115 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const checkEventOwnership = `-- name: CheckEventOwnership :one
-SELECT event_id, created_by, status, version
-FROM events
-WHERE event_id = $1 AND created_by = $2
+SELECT e.event_id, e.created_by, e.organization_id, e.status, e.version,
+       COALESCE(m.role, 'owner')::text as role
+FROM events e
+LEFT JOIN organization_members m
+    ON m.organization_id = e.organization_id AND m.admin_id = $2
+WHERE e.event_id = $1
+  AND e.deleted_at IS NULL
+  AND (m.admin_id IS NOT NULL OR (e.organization_id IS NULL AND e.created_by = $2))
 `
 
 type CheckEventOwnershipParams struct {
-	EventID   uuid.UUID `json:"event_id"`
-	CreatedBy uuid.UUID `json:"created_by"`
+	EventID uuid.UUID `json:"event_id"`
+	AdminID uuid.UUID `json:"admin_id"`
 }
 
 type CheckEventOwnershipRow struct {
-	EventID   uuid.UUID      `json:"event_id"`
-	CreatedBy uuid.UUID      `json:"created_by"`
-	Status    sql.NullString `json:"status"`
-	Version   int32          `json:"version"`
+	EventID        uuid.UUID      `json:"event_id"`
+	CreatedBy      uuid.UUID      `json:"created_by"`
+	OrganizationID uuid.NullUUID  `json:"organization_id"`
+	Status         sql.NullString `json:"status"`
+	Version        int32          `json:"version"`
+	Role           string         `json:"role"`
 }
 
 // CheckEventOwnership
 //
-//	SELECT event_id, created_by, status, version
-//	FROM events
-//	WHERE event_id = $1 AND created_by = $2
+//	SELECT e.event_id, e.created_by, e.organization_id, e.status, e.version,
+//	       COALESCE(m.role, 'owner')::text as role
+//	FROM events e
+//	LEFT JOIN organization_members m
+//	    ON m.organization_id = e.organization_id AND m.admin_id = $2
+//	WHERE e.event_id = $1
+//	  AND e.deleted_at IS NULL
+//	  AND (m.admin_id IS NOT NULL OR (e.organization_id IS NULL AND e.created_by = $2))
 func (q *Queries) CheckEventOwnership(ctx context.Context, arg CheckEventOwnershipParams) (CheckEventOwnershipRow, error) {
-	row := q.db.QueryRowContext(ctx, checkEventOwnership, arg.EventID, arg.CreatedBy)
+	row := q.db.QueryRowContext(ctx, checkEventOwnership, arg.EventID, arg.AdminID)
 	var i CheckEventOwnershipRow
 	err := row.Scan(
 		&i.EventID,
 		&i.CreatedBy,
+		&i.OrganizationID,
 		&i.Status,
 		&i.Version,
+		&i.Role,
 	)
 	return i, err
 }
 
+const countDuplicateEvents = `-- name: CountDuplicateEvents :one
+SELECT COUNT(*) FROM events
+WHERE LOWER(name) = LOWER($1::text)
+  AND venue_id = $2
+  AND start_datetime = $3
+  AND status != 'cancelled'
+`
+
+type CountDuplicateEventsParams struct {
+	Name          string    `json:"name"`
+	VenueID       uuid.UUID `json:"venue_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+}
+
+// CountDuplicateEvents
+//
+//	SELECT COUNT(*) FROM events
+//	WHERE LOWER(name) = LOWER($1::text)
+//	  AND venue_id = $2
+//	  AND start_datetime = $3
+//	  AND status != 'cancelled'
+func (q *Queries) CountDuplicateEvents(ctx context.Context, arg CountDuplicateEventsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDuplicateEvents, arg.Name, arg.VenueID, arg.StartDatetime)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEventsByOrganization = `-- name: CountEventsByOrganization :one
+SELECT COUNT(*) FROM events WHERE organization_id = $1 AND deleted_at IS NULL
+`
+
+// CountEventsByOrganization
+//
+//	SELECT COUNT(*) FROM events WHERE organization_id = $1 AND deleted_at IS NULL
+func (q *Queries) CountEventsByOrganization(ctx context.Context, organizationID uuid.NullUUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEventsByOrganization, organizationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPublishedEvents = `-- name: CountPublishedEvents :one
 SELECT COUNT(*)
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
 WHERE e.status = 'published'
+  AND e.deleted_at IS NULL
   AND e.start_datetime > CURRENT_TIMESTAMP
-  AND ($1::text = '' OR e.event_type = $1)
+  AND ($1::text = '' OR e.event_type = $1 OR e.category_id IN (
+        SELECT c.category_id FROM categories c
+        JOIN categories p ON p.category_id = c.parent_id
+        WHERE p.slug = $1
+    ))
   AND ($2::text = '' OR v.city ILIKE '%' || $2 || '%')
-  AND ($3::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $3)
-  AND ($4::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $4)
+  AND ($3::date = '0001-01-01'::date OR e.start_datetime >= ($3::date::timestamp AT TIME ZONE v.timezone))
+  AND ($4::date = '0001-01-01'::date OR e.start_datetime < (($4::date + 1)::timestamp AT TIME ZONE v.timezone))
 `
 
 type CountPublishedEventsParams struct {
@@ -73,11 +135,16 @@ type CountPublishedEventsParams struct {
 //	FROM events e
 //	JOIN venues v ON e.venue_id = v.venue_id
 //	WHERE e.status = 'published'
+//	  AND e.deleted_at IS NULL
 //	  AND e.start_datetime > CURRENT_TIMESTAMP
-//	  AND ($1::text = '' OR e.event_type = $1)
+//	  AND ($1::text = '' OR e.event_type = $1 OR e.category_id IN (
+//	        SELECT c.category_id FROM categories c
+//	        JOIN categories p ON p.category_id = c.parent_id
+//	        WHERE p.slug = $1
+//	    ))
 //	  AND ($2::text = '' OR v.city ILIKE '%' || $2 || '%')
-//	  AND ($3::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $3)
-//	  AND ($4::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $4)
+//	  AND ($3::date = '0001-01-01'::date OR e.start_datetime >= ($3::date::timestamp AT TIME ZONE v.timezone))
+//	  AND ($4::date = '0001-01-01'::date OR e.start_datetime < (($4::date + 1)::timestamp AT TIME ZONE v.timezone))
 func (q *Queries) CountPublishedEvents(ctx context.Context, arg CountPublishedEventsParams) (int64, error) {
 	row := q.db.QueryRowContext(ctx, countPublishedEvents,
 		arg.Column1,
@@ -93,39 +160,47 @@ func (q *Queries) CountPublishedEvents(ctx context.Context, arg CountPublishedEv
 const createEvent = `-- name: CreateEvent :one
 INSERT INTO events (
     name, description, venue_id, event_type, start_datetime, end_datetime,
-    total_capacity, available_seats, base_price, max_tickets_per_booking,
-    status, created_by
+    total_capacity, available_seats, base_price_minor, max_tickets_per_booking,
+    status, created_by, organization_id, setup_buffer_minutes, teardown_buffer_minutes, currency,
+    category_id, tags
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
-RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 `
 
 type CreateEventParams struct {
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	CreatedBy            uuid.UUID      `json:"created_by"`
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	AvailableSeats        int32          `json:"available_seats"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status                sql.NullString `json:"status"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	OrganizationID        uuid.NullUUID  `json:"organization_id"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	Currency              string         `json:"currency"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	Tags                  []string       `json:"tags"`
 }
 
 // CreateEvent
 //
 //	INSERT INTO events (
 //	    name, description, venue_id, event_type, start_datetime, end_datetime,
-//	    total_capacity, available_seats, base_price, max_tickets_per_booking,
-//	    status, created_by
+//	    total_capacity, available_seats, base_price_minor, max_tickets_per_booking,
+//	    status, created_by, organization_id, setup_buffer_minutes, teardown_buffer_minutes, currency,
+//	    category_id, tags
 //	) VALUES (
-//	    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+//	    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 //	)
-//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error) {
 	row := q.db.QueryRowContext(ctx, createEvent,
 		arg.Name,
@@ -136,10 +211,16 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		arg.EndDatetime,
 		arg.TotalCapacity,
 		arg.AvailableSeats,
-		arg.BasePrice,
+		arg.BasePriceMinor,
 		arg.MaxTicketsPerBooking,
 		arg.Status,
 		arg.CreatedBy,
+		arg.OrganizationID,
+		arg.SetupBufferMinutes,
+		arg.TeardownBufferMinutes,
+		arg.Currency,
+		arg.CategoryID,
+		pq.Array(arg.Tags),
 	)
 	var i Event
 	err := row.Scan(
@@ -152,24 +233,34 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		&i.EndDatetime,
 		&i.TotalCapacity,
 		&i.AvailableSeats,
-		&i.BasePrice,
+		&i.BasePriceMinor,
 		&i.MaxTicketsPerBooking,
 		&i.Status,
 		&i.Version,
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
 	)
 	return i, err
 }
 
-const deleteEvent = `-- name: DeleteEvent :exec
+const deleteEvent = `-- name: DeleteEvent :one
 UPDATE events
 SET status = 'cancelled',
+    deleted_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP,
     version = version + 1
 WHERE event_id = $1
   AND version = $2
+  AND deleted_at IS NULL
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 `
 
 type DeleteEventParams struct {
@@ -181,13 +272,42 @@ type DeleteEventParams struct {
 //
 //	UPDATE events
 //	SET status = 'cancelled',
+//	    deleted_at = CURRENT_TIMESTAMP,
 //	    updated_at = CURRENT_TIMESTAMP,
 //	    version = version + 1
 //	WHERE event_id = $1
 //	  AND version = $2
-func (q *Queries) DeleteEvent(ctx context.Context, arg DeleteEventParams) error {
-	_, err := q.db.ExecContext(ctx, deleteEvent, arg.EventID, arg.Version)
-	return err
+//	  AND deleted_at IS NULL
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+func (q *Queries) DeleteEvent(ctx context.Context, arg DeleteEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, deleteEvent, arg.EventID, arg.Version)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Name,
+		&i.Description,
+		&i.VenueID,
+		&i.EventType,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.TotalCapacity,
+		&i.AvailableSeats,
+		&i.BasePriceMinor,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
+	)
+	return i, err
 }
 
 const getEventAnalytics = `-- name: GetEventAnalytics :one
@@ -198,21 +318,23 @@ SELECT
     e.available_seats,
     (e.total_capacity - e.available_seats) as tickets_sold,
     ROUND(((e.total_capacity - e.available_seats)::decimal / e.total_capacity::decimal) * 100, 2) as capacity_utilization,
-    e.base_price,
-    ROUND((e.total_capacity - e.available_seats) * e.base_price::decimal, 2) as estimated_revenue
+    e.base_price_minor,
+    e.currency,
+    ((e.total_capacity - e.available_seats) * e.base_price_minor)::bigint as estimated_revenue_minor
 FROM events e
 WHERE e.event_id = $1
 `
 
 type GetEventAnalyticsRow struct {
-	EventID             uuid.UUID `json:"event_id"`
-	Name                string    `json:"name"`
-	TotalCapacity       int32     `json:"total_capacity"`
-	AvailableSeats      int32     `json:"available_seats"`
-	TicketsSold         int32     `json:"tickets_sold"`
-	CapacityUtilization string    `json:"capacity_utilization"`
-	BasePrice           string    `json:"base_price"`
-	EstimatedRevenue    string    `json:"estimated_revenue"`
+	EventID               uuid.UUID `json:"event_id"`
+	Name                  string    `json:"name"`
+	TotalCapacity         int32     `json:"total_capacity"`
+	AvailableSeats        int32     `json:"available_seats"`
+	TicketsSold           int32     `json:"tickets_sold"`
+	CapacityUtilization   string    `json:"capacity_utilization"`
+	BasePriceMinor        int64     `json:"base_price_minor"`
+	Currency              string    `json:"currency"`
+	EstimatedRevenueMinor int64     `json:"estimated_revenue_minor"`
 }
 
 // GetEventAnalytics
@@ -224,8 +346,9 @@ type GetEventAnalyticsRow struct {
 //	    e.available_seats,
 //	    (e.total_capacity - e.available_seats) as tickets_sold,
 //	    ROUND(((e.total_capacity - e.available_seats)::decimal / e.total_capacity::decimal) * 100, 2) as capacity_utilization,
-//	    e.base_price,
-//	    ROUND((e.total_capacity - e.available_seats) * e.base_price::decimal, 2) as estimated_revenue
+//	    e.base_price_minor,
+//	    e.currency,
+//	    ((e.total_capacity - e.available_seats) * e.base_price_minor)::bigint as estimated_revenue_minor
 //	FROM events e
 //	WHERE e.event_id = $1
 func (q *Queries) GetEventAnalytics(ctx context.Context, eventID uuid.UUID) (GetEventAnalyticsRow, error) {
@@ -238,49 +361,64 @@ func (q *Queries) GetEventAnalytics(ctx context.Context, eventID uuid.UUID) (Get
 		&i.AvailableSeats,
 		&i.TicketsSold,
 		&i.CapacityUtilization,
-		&i.BasePrice,
-		&i.EstimatedRevenue,
+		&i.BasePriceMinor,
+		&i.Currency,
+		&i.EstimatedRevenueMinor,
 	)
 	return i, err
 }
 
 const getEventByID = `-- name: GetEventByID :one
-SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.address, v.city, v.state, v.country
+SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+       v.latitude as venue_latitude, v.longitude as venue_longitude
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
 WHERE e.event_id = $1
+  AND e.deleted_at IS NULL
 `
 
 type GetEventByIDRow struct {
-	EventID              uuid.UUID      `json:"event_id"`
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	Version              int32          `json:"version"`
-	CreatedBy            uuid.UUID      `json:"created_by"`
-	CreatedAt            sql.NullTime   `json:"created_at"`
-	UpdatedAt            sql.NullTime   `json:"updated_at"`
-	VenueName            string         `json:"venue_name"`
-	Address              string         `json:"address"`
-	City                 string         `json:"city"`
-	State                sql.NullString `json:"state"`
-	Country              string         `json:"country"`
+	EventID               uuid.UUID       `json:"event_id"`
+	Name                  string          `json:"name"`
+	Description           sql.NullString  `json:"description"`
+	VenueID               uuid.UUID       `json:"venue_id"`
+	EventType             string          `json:"event_type"`
+	StartDatetime         time.Time       `json:"start_datetime"`
+	EndDatetime           time.Time       `json:"end_datetime"`
+	TotalCapacity         int32           `json:"total_capacity"`
+	AvailableSeats        int32           `json:"available_seats"`
+	BasePriceMinor        int64           `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32   `json:"max_tickets_per_booking"`
+	Status                sql.NullString  `json:"status"`
+	Version               int32           `json:"version"`
+	CreatedBy             uuid.UUID       `json:"created_by"`
+	CreatedAt             sql.NullTime    `json:"created_at"`
+	UpdatedAt             sql.NullTime    `json:"updated_at"`
+	OrganizationID        uuid.NullUUID   `json:"organization_id"`
+	SetupBufferMinutes    int32           `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32           `json:"teardown_buffer_minutes"`
+	DeletedAt             sql.NullTime    `json:"deleted_at"`
+	Currency              string          `json:"currency"`
+	CategoryID            uuid.UUID       `json:"category_id"`
+	Tags                  []string        `json:"tags"`
+	VenueName             string          `json:"venue_name"`
+	Address               string          `json:"address"`
+	City                  string          `json:"city"`
+	State                 sql.NullString  `json:"state"`
+	Country               string          `json:"country"`
+	VenueTimezone         string          `json:"venue_timezone"`
+	VenueLatitude         sql.NullFloat64 `json:"venue_latitude"`
+	VenueLongitude        sql.NullFloat64 `json:"venue_longitude"`
 }
 
 // GetEventByID
 //
-//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.address, v.city, v.state, v.country
+//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+//	       v.latitude as venue_latitude, v.longitude as venue_longitude
 //	FROM events e
 //	JOIN venues v ON e.venue_id = v.venue_id
 //	WHERE e.event_id = $1
+//	  AND e.deleted_at IS NULL
 func (q *Queries) GetEventByID(ctx context.Context, eventID uuid.UUID) (GetEventByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getEventByID, eventID)
 	var i GetEventByIDRow
@@ -294,28 +432,39 @@ func (q *Queries) GetEventByID(ctx context.Context, eventID uuid.UUID) (GetEvent
 		&i.EndDatetime,
 		&i.TotalCapacity,
 		&i.AvailableSeats,
-		&i.BasePrice,
+		&i.BasePriceMinor,
 		&i.MaxTicketsPerBooking,
 		&i.Status,
 		&i.Version,
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
 		&i.VenueName,
 		&i.Address,
 		&i.City,
 		&i.State,
 		&i.Country,
+		&i.VenueTimezone,
+		&i.VenueLatitude,
+		&i.VenueLongitude,
 	)
 	return i, err
 }
 
 const getEventForBooking = `-- name: GetEventForBooking :one
 SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
-       status, version, base_price, name
+       status, version, base_price_minor, currency, name
 FROM events
 WHERE event_id = $1
   AND (status = 'published' OR status = 'sold_out')
+  AND deleted_at IS NULL
 FOR UPDATE
 `
 
@@ -326,17 +475,19 @@ type GetEventForBookingRow struct {
 	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
 	Status               sql.NullString `json:"status"`
 	Version              int32          `json:"version"`
-	BasePrice            string         `json:"base_price"`
+	BasePriceMinor       int64          `json:"base_price_minor"`
+	Currency             string         `json:"currency"`
 	Name                 string         `json:"name"`
 }
 
 // GetEventForBooking
 //
 //	SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
-//	       status, version, base_price, name
+//	       status, version, base_price_minor, currency, name
 //	FROM events
 //	WHERE event_id = $1
 //	  AND (status = 'published' OR status = 'sold_out')
+//	  AND deleted_at IS NULL
 //	FOR UPDATE
 func (q *Queries) GetEventForBooking(ctx context.Context, eventID uuid.UUID) (GetEventForBookingRow, error) {
 	row := q.db.QueryRowContext(ctx, getEventForBooking, eventID)
@@ -348,58 +499,75 @@ func (q *Queries) GetEventForBooking(ctx context.Context, eventID uuid.UUID) (Ge
 		&i.MaxTicketsPerBooking,
 		&i.Status,
 		&i.Version,
-		&i.BasePrice,
+		&i.BasePriceMinor,
+		&i.Currency,
 		&i.Name,
 	)
 	return i, err
 }
 
 const listEventsByAdmin = `-- name: ListEventsByAdmin :many
-SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city
+SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
-WHERE e.created_by = $3
+WHERE e.deleted_at IS NULL
+  AND (e.organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+    )
+   OR (e.organization_id IS NULL AND e.created_by = $3))
 ORDER BY e.created_at DESC
 LIMIT $1 OFFSET $2
 `
 
 type ListEventsByAdminParams struct {
-	Limit     int32     `json:"limit"`
-	Offset    int32     `json:"offset"`
-	CreatedBy uuid.UUID `json:"created_by"`
+	Limit   int32     `json:"limit"`
+	Offset  int32     `json:"offset"`
+	AdminID uuid.UUID `json:"admin_id"`
 }
 
 type ListEventsByAdminRow struct {
-	EventID              uuid.UUID      `json:"event_id"`
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	Version              int32          `json:"version"`
-	CreatedBy            uuid.UUID      `json:"created_by"`
-	CreatedAt            sql.NullTime   `json:"created_at"`
-	UpdatedAt            sql.NullTime   `json:"updated_at"`
-	VenueName            string         `json:"venue_name"`
-	City                 string         `json:"city"`
+	EventID               uuid.UUID      `json:"event_id"`
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	AvailableSeats        int32          `json:"available_seats"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status                sql.NullString `json:"status"`
+	Version               int32          `json:"version"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	UpdatedAt             sql.NullTime   `json:"updated_at"`
+	OrganizationID        uuid.NullUUID  `json:"organization_id"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	DeletedAt             sql.NullTime   `json:"deleted_at"`
+	Currency              string         `json:"currency"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	Tags                  []string       `json:"tags"`
+	VenueName             string         `json:"venue_name"`
+	City                  string         `json:"city"`
+	VenueTimezone         string         `json:"venue_timezone"`
 }
 
 // ListEventsByAdmin
 //
-//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city
+//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
 //	FROM events e
 //	JOIN venues v ON e.venue_id = v.venue_id
-//	WHERE e.created_by = $3
+//	WHERE e.deleted_at IS NULL
+//	  AND (e.organization_id IN (
+//	        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+//	    )
+//	   OR (e.organization_id IS NULL AND e.created_by = $3))
 //	ORDER BY e.created_at DESC
 //	LIMIT $1 OFFSET $2
 func (q *Queries) ListEventsByAdmin(ctx context.Context, arg ListEventsByAdminParams) ([]ListEventsByAdminRow, error) {
-	rows, err := q.db.QueryContext(ctx, listEventsByAdmin, arg.Limit, arg.Offset, arg.CreatedBy)
+	rows, err := q.db.QueryContext(ctx, listEventsByAdmin, arg.Limit, arg.Offset, arg.AdminID)
 	if err != nil {
 		return nil, err
 	}
@@ -417,15 +585,211 @@ func (q *Queries) ListEventsByAdmin(ctx context.Context, arg ListEventsByAdminPa
 			&i.EndDatetime,
 			&i.TotalCapacity,
 			&i.AvailableSeats,
-			&i.BasePrice,
+			&i.BasePriceMinor,
 			&i.MaxTicketsPerBooking,
 			&i.Status,
 			&i.Version,
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
+			&i.DeletedAt,
+			&i.Currency,
+			&i.CategoryID,
+			pq.Array(&i.Tags),
 			&i.VenueName,
 			&i.City,
+			&i.VenueTimezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsByOrganization = `-- name: ListEventsByOrganization :many
+SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
+FROM events e
+JOIN venues v ON e.venue_id = v.venue_id
+WHERE e.organization_id = $3
+  AND e.deleted_at IS NULL
+ORDER BY e.created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListEventsByOrganizationParams struct {
+	Limit          int32         `json:"limit"`
+	Offset         int32         `json:"offset"`
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+}
+
+type ListEventsByOrganizationRow struct {
+	EventID               uuid.UUID      `json:"event_id"`
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	AvailableSeats        int32          `json:"available_seats"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status                sql.NullString `json:"status"`
+	Version               int32          `json:"version"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	UpdatedAt             sql.NullTime   `json:"updated_at"`
+	OrganizationID        uuid.NullUUID  `json:"organization_id"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	DeletedAt             sql.NullTime   `json:"deleted_at"`
+	Currency              string         `json:"currency"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	Tags                  []string       `json:"tags"`
+	VenueName             string         `json:"venue_name"`
+	City                  string         `json:"city"`
+	VenueTimezone         string         `json:"venue_timezone"`
+}
+
+// ListEventsByOrganization
+//
+//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
+//	FROM events e
+//	JOIN venues v ON e.venue_id = v.venue_id
+//	WHERE e.organization_id = $3
+//	  AND e.deleted_at IS NULL
+//	ORDER BY e.created_at DESC
+//	LIMIT $1 OFFSET $2
+func (q *Queries) ListEventsByOrganization(ctx context.Context, arg ListEventsByOrganizationParams) ([]ListEventsByOrganizationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsByOrganization, arg.Limit, arg.Offset, arg.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEventsByOrganizationRow{}
+	for rows.Next() {
+		var i ListEventsByOrganizationRow
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Name,
+			&i.Description,
+			&i.VenueID,
+			&i.EventType,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.TotalCapacity,
+			&i.AvailableSeats,
+			&i.BasePriceMinor,
+			&i.MaxTicketsPerBooking,
+			&i.Status,
+			&i.Version,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
+			&i.DeletedAt,
+			&i.Currency,
+			&i.CategoryID,
+			pq.Array(&i.Tags),
+			&i.VenueName,
+			&i.City,
+			&i.VenueTimezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVenueOccupancy = `-- name: ListVenueOccupancy :many
+SELECT event_id, name, status, organization_id, start_datetime, end_datetime,
+       setup_buffer_minutes, teardown_buffer_minutes
+FROM events
+WHERE venue_id = $1
+  AND event_id <> $2
+  AND status <> 'cancelled'
+  AND deleted_at IS NULL
+  AND start_datetime < $3::timestamptz + interval '1 day'
+  AND end_datetime > $4::timestamptz - interval '1 day'
+  AND start_datetime - make_interval(mins => setup_buffer_minutes) < $3::timestamptz
+  AND end_datetime + make_interval(mins => teardown_buffer_minutes) > $4::timestamptz
+ORDER BY start_datetime
+`
+
+type ListVenueOccupancyParams struct {
+	VenueID        uuid.UUID `json:"venue_id"`
+	ExcludeEventID uuid.UUID `json:"exclude_event_id"`
+	OccupiedUntil  time.Time `json:"occupied_until"`
+	OccupiedFrom   time.Time `json:"occupied_from"`
+}
+
+type ListVenueOccupancyRow struct {
+	EventID               uuid.UUID      `json:"event_id"`
+	Name                  string         `json:"name"`
+	Status                sql.NullString `json:"status"`
+	OrganizationID        uuid.NullUUID  `json:"organization_id"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+}
+
+// ListVenueOccupancy
+//
+//	SELECT event_id, name, status, organization_id, start_datetime, end_datetime,
+//	       setup_buffer_minutes, teardown_buffer_minutes
+//	FROM events
+//	WHERE venue_id = $1
+//	  AND event_id <> $2
+//	  AND status <> 'cancelled'
+//	  AND deleted_at IS NULL
+//	  AND start_datetime < $3::timestamptz + interval '1 day'
+//	  AND end_datetime > $4::timestamptz - interval '1 day'
+//	  AND start_datetime - make_interval(mins => setup_buffer_minutes) < $3::timestamptz
+//	  AND end_datetime + make_interval(mins => teardown_buffer_minutes) > $4::timestamptz
+//	ORDER BY start_datetime
+func (q *Queries) ListVenueOccupancy(ctx context.Context, arg ListVenueOccupancyParams) ([]ListVenueOccupancyRow, error) {
+	rows, err := q.db.QueryContext(ctx, listVenueOccupancy,
+		arg.VenueID,
+		arg.ExcludeEventID,
+		arg.OccupiedUntil,
+		arg.OccupiedFrom,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListVenueOccupancyRow{}
+	for rows.Next() {
+		var i ListVenueOccupancyRow
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Name,
+			&i.Status,
+			&i.OrganizationID,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -441,15 +805,21 @@ func (q *Queries) ListEventsByAdmin(ctx context.Context, arg ListEventsByAdminPa
 }
 
 const listPublishedEvents = `-- name: ListPublishedEvents :many
-SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city, v.state
+SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.state, v.timezone as venue_timezone,
+       v.latitude as venue_latitude, v.longitude as venue_longitude
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
 WHERE e.status = 'published'
+  AND e.deleted_at IS NULL
   AND e.start_datetime > CURRENT_TIMESTAMP
-  AND ($3::text = '' OR e.event_type = $3)
+  AND ($3::text = '' OR e.event_type = $3 OR e.category_id IN (
+        SELECT c.category_id FROM categories c
+        JOIN categories p ON p.category_id = c.parent_id
+        WHERE p.slug = $3
+    ))
   AND ($4::text = '' OR v.city ILIKE '%' || $4 || '%')
-  AND ($5::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $5)
-  AND ($6::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $6)
+  AND ($5::date = '0001-01-01'::date OR e.start_datetime >= ($5::date::timestamp AT TIME ZONE v.timezone))
+  AND ($6::date = '0001-01-01'::date OR e.start_datetime < (($6::date + 1)::timestamp AT TIME ZONE v.timezone))
 ORDER BY e.start_datetime ASC
 LIMIT $1 OFFSET $2
 `
@@ -464,38 +834,54 @@ type ListPublishedEventsParams struct {
 }
 
 type ListPublishedEventsRow struct {
-	EventID              uuid.UUID      `json:"event_id"`
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	Version              int32          `json:"version"`
-	CreatedBy            uuid.UUID      `json:"created_by"`
-	CreatedAt            sql.NullTime   `json:"created_at"`
-	UpdatedAt            sql.NullTime   `json:"updated_at"`
-	VenueName            string         `json:"venue_name"`
-	City                 string         `json:"city"`
-	State                sql.NullString `json:"state"`
+	EventID               uuid.UUID       `json:"event_id"`
+	Name                  string          `json:"name"`
+	Description           sql.NullString  `json:"description"`
+	VenueID               uuid.UUID       `json:"venue_id"`
+	EventType             string          `json:"event_type"`
+	StartDatetime         time.Time       `json:"start_datetime"`
+	EndDatetime           time.Time       `json:"end_datetime"`
+	TotalCapacity         int32           `json:"total_capacity"`
+	AvailableSeats        int32           `json:"available_seats"`
+	BasePriceMinor        int64           `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32   `json:"max_tickets_per_booking"`
+	Status                sql.NullString  `json:"status"`
+	Version               int32           `json:"version"`
+	CreatedBy             uuid.UUID       `json:"created_by"`
+	CreatedAt             sql.NullTime    `json:"created_at"`
+	UpdatedAt             sql.NullTime    `json:"updated_at"`
+	OrganizationID        uuid.NullUUID   `json:"organization_id"`
+	SetupBufferMinutes    int32           `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32           `json:"teardown_buffer_minutes"`
+	DeletedAt             sql.NullTime    `json:"deleted_at"`
+	Currency              string          `json:"currency"`
+	CategoryID            uuid.UUID       `json:"category_id"`
+	Tags                  []string        `json:"tags"`
+	VenueName             string          `json:"venue_name"`
+	City                  string          `json:"city"`
+	State                 sql.NullString  `json:"state"`
+	VenueTimezone         string          `json:"venue_timezone"`
+	VenueLatitude         sql.NullFloat64 `json:"venue_latitude"`
+	VenueLongitude        sql.NullFloat64 `json:"venue_longitude"`
 }
 
 // ListPublishedEvents
 //
-//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city, v.state
+//	SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.state, v.timezone as venue_timezone,
+//	       v.latitude as venue_latitude, v.longitude as venue_longitude
 //	FROM events e
 //	JOIN venues v ON e.venue_id = v.venue_id
 //	WHERE e.status = 'published'
+//	  AND e.deleted_at IS NULL
 //	  AND e.start_datetime > CURRENT_TIMESTAMP
-//	  AND ($3::text = '' OR e.event_type = $3)
+//	  AND ($3::text = '' OR e.event_type = $3 OR e.category_id IN (
+//	        SELECT c.category_id FROM categories c
+//	        JOIN categories p ON p.category_id = c.parent_id
+//	        WHERE p.slug = $3
+//	    ))
 //	  AND ($4::text = '' OR v.city ILIKE '%' || $4 || '%')
-//	  AND ($5::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $5)
-//	  AND ($6::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $6)
+//	  AND ($5::date = '0001-01-01'::date OR e.start_datetime >= ($5::date::timestamp AT TIME ZONE v.timezone))
+//	  AND ($6::date = '0001-01-01'::date OR e.start_datetime < (($6::date + 1)::timestamp AT TIME ZONE v.timezone))
 //	ORDER BY e.start_datetime ASC
 //	LIMIT $1 OFFSET $2
 func (q *Queries) ListPublishedEvents(ctx context.Context, arg ListPublishedEventsParams) ([]ListPublishedEventsRow, error) {
@@ -524,16 +910,26 @@ func (q *Queries) ListPublishedEvents(ctx context.Context, arg ListPublishedEven
 			&i.EndDatetime,
 			&i.TotalCapacity,
 			&i.AvailableSeats,
-			&i.BasePrice,
+			&i.BasePriceMinor,
 			&i.MaxTicketsPerBooking,
 			&i.Status,
 			&i.Version,
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
+			&i.DeletedAt,
+			&i.Currency,
+			&i.CategoryID,
+			pq.Array(&i.Tags),
 			&i.VenueName,
 			&i.City,
 			&i.State,
+			&i.VenueTimezone,
+			&i.VenueLatitude,
+			&i.VenueLongitude,
 		); err != nil {
 			return nil, err
 		}
@@ -600,6 +996,62 @@ func (q *Queries) ReturnEventSeats(ctx context.Context, arg ReturnEventSeatsPara
 	return i, err
 }
 
+const transferEventOwnership = `-- name: TransferEventOwnership :one
+UPDATE events
+SET created_by = $2,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = $1
+  AND version = $3
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+`
+
+type TransferEventOwnershipParams struct {
+	EventID   uuid.UUID `json:"event_id"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	Version   int32     `json:"version"`
+}
+
+// TransferEventOwnership
+//
+//	UPDATE events
+//	SET created_by = $2,
+//	    updated_at = CURRENT_TIMESTAMP,
+//	    version = version + 1
+//	WHERE event_id = $1
+//	  AND version = $3
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+func (q *Queries) TransferEventOwnership(ctx context.Context, arg TransferEventOwnershipParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, transferEventOwnership, arg.EventID, arg.CreatedBy, arg.Version)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Name,
+		&i.Description,
+		&i.VenueID,
+		&i.EventType,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.TotalCapacity,
+		&i.AvailableSeats,
+		&i.BasePriceMinor,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
+	)
+	return i, err
+}
+
 const updateEvent = `-- name: UpdateEvent :one
 UPDATE events
 SET name = COALESCE($2, name),
@@ -610,30 +1062,40 @@ SET name = COALESCE($2, name),
     end_datetime = COALESCE($7, end_datetime),
     total_capacity = COALESCE($8, total_capacity),
     available_seats = COALESCE($9, available_seats),
-    base_price = COALESCE($10, base_price),
+    base_price_minor = COALESCE($10, base_price_minor),
     max_tickets_per_booking = COALESCE($11, max_tickets_per_booking),
     status = COALESCE($12, status),
+    setup_buffer_minutes = COALESCE($14, setup_buffer_minutes),
+    teardown_buffer_minutes = COALESCE($15, teardown_buffer_minutes),
+    currency = COALESCE($16, currency),
+    category_id = COALESCE($17, category_id),
+    tags = COALESCE($18, tags),
     updated_at = CURRENT_TIMESTAMP,
     version = version + 1
 WHERE event_id = $1
   AND version = $13
-RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 `
 
 type UpdateEventParams struct {
-	EventID              uuid.UUID      `json:"event_id"`
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	Version              int32          `json:"version"`
+	EventID               uuid.UUID      `json:"event_id"`
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	AvailableSeats        int32          `json:"available_seats"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status                sql.NullString `json:"status"`
+	Version               int32          `json:"version"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	Currency              sql.NullString `json:"currency"`
+	CategoryID            uuid.NullUUID  `json:"category_id"`
+	Tags                  []string       `json:"tags"`
 }
 
 // UpdateEvent
@@ -647,14 +1109,19 @@ type UpdateEventParams struct {
 //	    end_datetime = COALESCE($7, end_datetime),
 //	    total_capacity = COALESCE($8, total_capacity),
 //	    available_seats = COALESCE($9, available_seats),
-//	    base_price = COALESCE($10, base_price),
+//	    base_price_minor = COALESCE($10, base_price_minor),
 //	    max_tickets_per_booking = COALESCE($11, max_tickets_per_booking),
 //	    status = COALESCE($12, status),
+//	    setup_buffer_minutes = COALESCE($14, setup_buffer_minutes),
+//	    teardown_buffer_minutes = COALESCE($15, teardown_buffer_minutes),
+//	    currency = COALESCE($16, currency),
+//	    category_id = COALESCE($17, category_id),
+//	    tags = COALESCE($18, tags),
 //	    updated_at = CURRENT_TIMESTAMP,
 //	    version = version + 1
 //	WHERE event_id = $1
 //	  AND version = $13
-//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error) {
 	row := q.db.QueryRowContext(ctx, updateEvent,
 		arg.EventID,
@@ -666,10 +1133,15 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		arg.EndDatetime,
 		arg.TotalCapacity,
 		arg.AvailableSeats,
-		arg.BasePrice,
+		arg.BasePriceMinor,
 		arg.MaxTicketsPerBooking,
 		arg.Status,
 		arg.Version,
+		arg.SetupBufferMinutes,
+		arg.TeardownBufferMinutes,
+		arg.Currency,
+		arg.CategoryID,
+		pq.Array(arg.Tags),
 	)
 	var i Event
 	err := row.Scan(
@@ -682,13 +1154,20 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		&i.EndDatetime,
 		&i.TotalCapacity,
 		&i.AvailableSeats,
-		&i.BasePrice,
+		&i.BasePriceMinor,
 		&i.MaxTicketsPerBooking,
 		&i.Status,
 		&i.Version,
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
 	)
 	return i, err
 }
@@ -746,3 +1225,443 @@ func (q *Queries) UpdateEventAvailability(ctx context.Context, arg UpdateEventAv
 	)
 	return i, err
 }
+
+const lockEvent = `-- name: LockEvent :one
+SELECT event_id FROM events
+WHERE event_id = $1
+FOR UPDATE
+`
+
+// LockEvent
+//
+//	SELECT event_id FROM events
+//	WHERE event_id = $1
+//	FOR UPDATE
+func (q *Queries) LockEvent(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockEvent, eventID)
+	var event_id uuid.UUID
+	err := row.Scan(&event_id)
+	return event_id, err
+}
+
+const getEventForUpdate = `-- name: GetEventForUpdate :one
+SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+WHERE event_id = $1
+FOR UPDATE
+`
+
+// GetEventForUpdate
+//
+//	SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+//	WHERE event_id = $1
+//	FOR UPDATE
+func (q *Queries) GetEventForUpdate(ctx context.Context, eventID uuid.UUID) (Event, error) {
+	row := q.db.QueryRowContext(ctx, getEventForUpdate, eventID)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Name,
+		&i.Description,
+		&i.VenueID,
+		&i.EventType,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.TotalCapacity,
+		&i.AvailableSeats,
+		&i.BasePriceMinor,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
+	)
+	return i, err
+}
+
+const revertEvent = `-- name: RevertEvent :one
+UPDATE events
+SET name = $1,
+    description = $2,
+    venue_id = $3,
+    event_type = $4,
+    start_datetime = $5,
+    end_datetime = $6,
+    available_seats = available_seats + ($7::int - total_capacity),
+    total_capacity = $7::int,
+    base_price_minor = $8,
+    currency = $9,
+    max_tickets_per_booking = $10,
+    setup_buffer_minutes = $11,
+    teardown_buffer_minutes = $12,
+    category_id = $13,
+    tags = $14,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = $15
+  AND version = $16
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+`
+
+type RevertEventParams struct {
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	Currency              string         `json:"currency"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	Tags                  []string       `json:"tags"`
+	EventID               uuid.UUID      `json:"event_id"`
+	Version               int32          `json:"version"`
+}
+
+// RevertEvent
+//
+//	UPDATE events
+//	SET name = $1,
+//	    description = $2,
+//	    venue_id = $3,
+//	    event_type = $4,
+//	    start_datetime = $5,
+//	    end_datetime = $6,
+//	    available_seats = available_seats + ($7::int - total_capacity),
+//	    total_capacity = $7::int,
+//	    base_price_minor = $8,
+//	    currency = $9,
+//	    max_tickets_per_booking = $10,
+//	    setup_buffer_minutes = $11,
+//	    teardown_buffer_minutes = $12,
+//	    category_id = $13,
+//	    tags = $14,
+//	    updated_at = CURRENT_TIMESTAMP,
+//	    version = version + 1
+//	WHERE event_id = $15
+//	  AND version = $16
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+func (q *Queries) RevertEvent(ctx context.Context, arg RevertEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, revertEvent,
+		arg.Name,
+		arg.Description,
+		arg.VenueID,
+		arg.EventType,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.TotalCapacity,
+		arg.BasePriceMinor,
+		arg.Currency,
+		arg.MaxTicketsPerBooking,
+		arg.SetupBufferMinutes,
+		arg.TeardownBufferMinutes,
+		arg.CategoryID,
+		pq.Array(arg.Tags),
+		arg.EventID,
+		arg.Version,
+	)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Name,
+		&i.Description,
+		&i.VenueID,
+		&i.EventType,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.TotalCapacity,
+		&i.AvailableSeats,
+		&i.BasePriceMinor,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
+	)
+	return i, err
+}
+
+const getEventRecord = `-- name: GetEventRecord :one
+SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
+       status, version, base_price_minor, currency, name, deleted_at
+FROM events
+WHERE event_id = $1
+`
+
+type GetEventRecordRow struct {
+	EventID              uuid.UUID      `json:"event_id"`
+	AvailableSeats       int32          `json:"available_seats"`
+	TotalCapacity        int32          `json:"total_capacity"`
+	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status               sql.NullString `json:"status"`
+	Version              int32          `json:"version"`
+	BasePriceMinor       int64          `json:"base_price_minor"`
+	Currency             string         `json:"currency"`
+	Name                 string         `json:"name"`
+	DeletedAt            sql.NullTime   `json:"deleted_at"`
+}
+
+// GetEventRecord
+//
+//	SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
+//	       status, version, base_price_minor, currency, name, deleted_at
+//	FROM events
+//	WHERE event_id = $1
+func (q *Queries) GetEventRecord(ctx context.Context, eventID uuid.UUID) (GetEventRecordRow, error) {
+	row := q.db.QueryRowContext(ctx, getEventRecord, eventID)
+	var i GetEventRecordRow
+	err := row.Scan(
+		&i.EventID,
+		&i.AvailableSeats,
+		&i.TotalCapacity,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.BasePriceMinor,
+		&i.Currency,
+		&i.Name,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreEvent = `-- name: RestoreEvent :one
+UPDATE events
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = $1
+  AND version = $2
+  AND deleted_at IS NOT NULL
+RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+`
+
+type RestoreEventParams struct {
+	EventID uuid.UUID `json:"event_id"`
+	Version int32     `json:"version"`
+}
+
+// RestoreEvent
+//
+//	UPDATE events
+//	SET deleted_at = NULL,
+//	    updated_at = CURRENT_TIMESTAMP,
+//	    version = version + 1
+//	WHERE event_id = $1
+//	  AND version = $2
+//	  AND deleted_at IS NOT NULL
+//	RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+func (q *Queries) RestoreEvent(ctx context.Context, arg RestoreEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, restoreEvent, arg.EventID, arg.Version)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Name,
+		&i.Description,
+		&i.VenueID,
+		&i.EventType,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.TotalCapacity,
+		&i.AvailableSeats,
+		&i.BasePriceMinor,
+		&i.MaxTicketsPerBooking,
+		&i.Status,
+		&i.Version,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.SetupBufferMinutes,
+		&i.TeardownBufferMinutes,
+		&i.DeletedAt,
+		&i.Currency,
+		&i.CategoryID,
+		pq.Array(&i.Tags),
+	)
+	return i, err
+}
+
+const listDeletedEvents = `-- name: ListDeletedEvents :many
+SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+WHERE deleted_at IS NOT NULL
+  AND (organization_id = $1
+    OR ($1::uuid IS NULL AND organization_id IS NULL AND created_by = $2))
+ORDER BY deleted_at DESC
+LIMIT $3
+`
+
+type ListDeletedEventsParams struct {
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	PageSize       int32         `json:"page_size"`
+}
+
+// ListDeletedEvents
+//
+//	SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+//	WHERE deleted_at IS NOT NULL
+//	  AND (organization_id = $1
+//	    OR ($1::uuid IS NULL AND organization_id IS NULL AND created_by = $2))
+//	ORDER BY deleted_at DESC
+//	LIMIT $3
+func (q *Queries) ListDeletedEvents(ctx context.Context, arg ListDeletedEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedEvents, arg.OrganizationID, arg.CreatedBy, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Event{}
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Name,
+			&i.Description,
+			&i.VenueID,
+			&i.EventType,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.TotalCapacity,
+			&i.AvailableSeats,
+			&i.BasePriceMinor,
+			&i.MaxTicketsPerBooking,
+			&i.Status,
+			&i.Version,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
+			&i.DeletedAt,
+			&i.Currency,
+			&i.CategoryID,
+			pq.Array(&i.Tags),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableEvents = `-- name: ListPurgeableEvents :many
+SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+WHERE deleted_at < $1
+  AND (deleted_at, event_id) > ($2::timestamp, $3::uuid)
+  AND available_seats = total_capacity
+ORDER BY deleted_at, event_id
+LIMIT $4
+`
+
+type ListPurgeableEventsParams struct {
+	DeletedBefore  sql.NullTime `json:"deleted_before"`
+	AfterDeletedAt time.Time    `json:"after_deleted_at"`
+	AfterEventID   uuid.UUID    `json:"after_event_id"`
+	BatchSize      int32        `json:"batch_size"`
+}
+
+// ListPurgeableEvents
+//
+//	SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+//	WHERE deleted_at < $1
+//	  AND (deleted_at, event_id) > ($2::timestamp, $3::uuid)
+//	  AND available_seats = total_capacity
+//	ORDER BY deleted_at, event_id
+//	LIMIT $4
+func (q *Queries) ListPurgeableEvents(ctx context.Context, arg ListPurgeableEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableEvents,
+		arg.DeletedBefore,
+		arg.AfterDeletedAt,
+		arg.AfterEventID,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Event{}
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Name,
+			&i.Description,
+			&i.VenueID,
+			&i.EventType,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.TotalCapacity,
+			&i.AvailableSeats,
+			&i.BasePriceMinor,
+			&i.MaxTicketsPerBooking,
+			&i.Status,
+			&i.Version,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.SetupBufferMinutes,
+			&i.TeardownBufferMinutes,
+			&i.DeletedAt,
+			&i.Currency,
+			&i.CategoryID,
+			pq.Array(&i.Tags),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeEvent = `-- name: PurgeEvent :execrows
+DELETE FROM events
+WHERE event_id = $1
+  AND deleted_at IS NOT NULL
+`
+
+// PurgeEvent
+//
+//	DELETE FROM events
+//	WHERE event_id = $1
+//	  AND deleted_at IS NOT NULL
+func (q *Queries) PurgeEvent(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeEvent, eventID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
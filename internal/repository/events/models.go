@@ -6,6 +6,7 @@ package events
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,22 @@ type Admin struct {
 	UpdatedAt    sql.NullTime          `json:"updated_at"`
 }
 
+type AdminLoginChallenge struct {
+	Token     string       `json:"token"`
+	AdminID   uuid.UUID    `json:"admin_id"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	Attempts  int32        `json:"attempts"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type AdminRecoveryCode struct {
+	CodeID    uuid.UUID    `json:"code_id"`
+	AdminID   uuid.UUID    `json:"admin_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
 type AdminRefreshToken struct {
 	Token     string       `json:"token"`
 	AdminID   uuid.UUID    `json:"admin_id"`
@@ -34,35 +51,131 @@ type AdminRefreshToken struct {
 	UpdatedAt sql.NullTime `json:"updated_at"`
 }
 
+type AdminTwoFactor struct {
+	AdminID      uuid.UUID    `json:"admin_id"`
+	Secret       string       `json:"secret"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
+	LastUsedStep int64        `json:"last_used_step"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+}
+
+type Category struct {
+	CategoryID uuid.UUID     `json:"category_id"`
+	Slug       string        `json:"slug"`
+	Name       string        `json:"name"`
+	ParentID   uuid.NullUUID `json:"parent_id"`
+	CreatedAt  sql.NullTime  `json:"created_at"`
+	UpdatedAt  sql.NullTime  `json:"updated_at"`
+}
+
+type EntityHistory struct {
+	HistoryID           uuid.UUID       `json:"history_id"`
+	EntityType          string          `json:"entity_type"`
+	EntityID            uuid.UUID       `json:"entity_id"`
+	Version             int32           `json:"version"`
+	Action              string          `json:"action"`
+	OrganizationID      uuid.NullUUID   `json:"organization_id"`
+	ChangedBy           uuid.NullUUID   `json:"changed_by"`
+	Changes             json.RawMessage `json:"changes"`
+	Snapshot            json.RawMessage `json:"snapshot"`
+	RevertedFromVersion sql.NullInt32   `json:"reverted_from_version"`
+	CreatedAt           sql.NullTime    `json:"created_at"`
+}
+
 type Event struct {
-	EventID              uuid.UUID      `json:"event_id"`
-	Name                 string         `json:"name"`
-	Description          sql.NullString `json:"description"`
-	VenueID              uuid.UUID      `json:"venue_id"`
-	EventType            string         `json:"event_type"`
-	StartDatetime        time.Time      `json:"start_datetime"`
-	EndDatetime          time.Time      `json:"end_datetime"`
-	TotalCapacity        int32          `json:"total_capacity"`
-	AvailableSeats       int32          `json:"available_seats"`
-	BasePrice            string         `json:"base_price"`
-	MaxTicketsPerBooking sql.NullInt32  `json:"max_tickets_per_booking"`
-	Status               sql.NullString `json:"status"`
-	Version              int32          `json:"version"`
-	CreatedBy            uuid.UUID      `json:"created_by"`
-	CreatedAt            sql.NullTime   `json:"created_at"`
-	UpdatedAt            sql.NullTime   `json:"updated_at"`
+	EventID               uuid.UUID      `json:"event_id"`
+	Name                  string         `json:"name"`
+	Description           sql.NullString `json:"description"`
+	VenueID               uuid.UUID      `json:"venue_id"`
+	EventType             string         `json:"event_type"`
+	StartDatetime         time.Time      `json:"start_datetime"`
+	EndDatetime           time.Time      `json:"end_datetime"`
+	TotalCapacity         int32          `json:"total_capacity"`
+	AvailableSeats        int32          `json:"available_seats"`
+	BasePriceMinor        int64          `json:"base_price_minor"`
+	MaxTicketsPerBooking  sql.NullInt32  `json:"max_tickets_per_booking"`
+	Status                sql.NullString `json:"status"`
+	Version               int32          `json:"version"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	UpdatedAt             sql.NullTime   `json:"updated_at"`
+	OrganizationID        uuid.NullUUID  `json:"organization_id"`
+	SetupBufferMinutes    int32          `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32          `json:"teardown_buffer_minutes"`
+	DeletedAt             sql.NullTime   `json:"deleted_at"`
+	Currency              string         `json:"currency"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	Tags                  []string       `json:"tags"`
+}
+
+type EventMedium struct {
+	MediaID     uuid.UUID       `json:"media_id"`
+	EventID     uuid.UUID       `json:"event_id"`
+	Kind        string          `json:"kind"`
+	ContentType string          `json:"content_type"`
+	Width       int32           `json:"width"`
+	Height      int32           `json:"height"`
+	SizeBytes   int64           `json:"size_bytes"`
+	StorageKey  string          `json:"storage_key"`
+	Variants    json.RawMessage `json:"variants"`
+	Position    int32           `json:"position"`
+	UploadedBy  uuid.UUID       `json:"uploaded_by"`
+	CreatedAt   sql.NullTime    `json:"created_at"`
+}
+
+type ExchangeRate struct {
+	FromCurrency  string       `json:"from_currency"`
+	ToCurrency    string       `json:"to_currency"`
+	EffectiveDate time.Time    `json:"effective_date"`
+	Rate          float64      `json:"rate"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	UpdatedAt     sql.NullTime `json:"updated_at"`
+}
+
+type Organization struct {
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	Name           string       `json:"name"`
+	CreatedBy      uuid.UUID    `json:"created_by"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	AdminID        uuid.UUID    `json:"admin_id"`
+	Role           string       `json:"role"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+}
+
+type SearchOutbox struct {
+	OutboxID      int64          `json:"outbox_id"`
+	EventID       uuid.UUID      `json:"event_id"`
+	Operation     string         `json:"operation"`
+	Attempts      int32          `json:"attempts"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	LastError     sql.NullString `json:"last_error"`
+	DeliveredAt   sql.NullTime   `json:"delivered_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	LeasedUntil   sql.NullTime   `json:"leased_until"`
 }
 
 type Venue struct {
-	VenueID      uuid.UUID             `json:"venue_id"`
-	Name         string                `json:"name"`
-	Address      string                `json:"address"`
-	City         string                `json:"city"`
-	State        sql.NullString        `json:"state"`
-	Country      string                `json:"country"`
-	PostalCode   sql.NullString        `json:"postal_code"`
-	Capacity     int32                 `json:"capacity"`
-	LayoutConfig pqtype.NullRawMessage `json:"layout_config"`
-	CreatedAt    sql.NullTime          `json:"created_at"`
-	UpdatedAt    sql.NullTime          `json:"updated_at"`
+	VenueID        uuid.UUID             `json:"venue_id"`
+	Name           string                `json:"name"`
+	Address        string                `json:"address"`
+	City           string                `json:"city"`
+	State          sql.NullString        `json:"state"`
+	Country        string                `json:"country"`
+	PostalCode     sql.NullString        `json:"postal_code"`
+	Capacity       int32                 `json:"capacity"`
+	LayoutConfig   pqtype.NullRawMessage `json:"layout_config"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
+	UpdatedAt      sql.NullTime          `json:"updated_at"`
+	OrganizationID uuid.NullUUID         `json:"organization_id"`
+	DeletedAt      sql.NullTime          `json:"deleted_at"`
+	Timezone       string                `json:"timezone"`
+	Latitude       sql.NullFloat64       `json:"latitude"`
+	Longitude      sql.NullFloat64       `json:"longitude"`
 }
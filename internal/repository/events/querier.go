@@ -6,12 +6,24 @@ package events
 
 import (
 	"context"
-	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	//AddOrganizationMember
+	//
+	//  INSERT INTO organization_members (organization_id, admin_id, role)
+	//  VALUES ($1, $2, $3)
+	//  RETURNING organization_id, admin_id, role, created_at, updated_at
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (OrganizationMember, error)
+	//AttemptAdminLoginChallenge
+	//
+	//  UPDATE admin_login_challenges
+	//  SET attempts = attempts + 1
+	//  WHERE token = $1 AND expires_at > CURRENT_TIMESTAMP AND attempts < $2
+	//  RETURNING token, admin_id, expires_at, attempts, created_at
+	AttemptAdminLoginChallenge(ctx context.Context, arg AttemptAdminLoginChallengeParams) (AdminLoginChallenge, error)
 	//CheckAdminPermissions
 	//
 	//  SELECT admin_id, role, permissions, is_active
@@ -20,36 +32,91 @@ type Querier interface {
 	CheckAdminPermissions(ctx context.Context, adminID uuid.UUID) (CheckAdminPermissionsRow, error)
 	//CheckEventOwnership
 	//
-	//  SELECT event_id, created_by, status, version
-	//  FROM events
-	//  WHERE event_id = $1 AND created_by = $2
+	//  SELECT e.event_id, e.created_by, e.organization_id, e.status, e.version,
+	//         COALESCE(m.role, 'owner')::text as role
+	//  FROM events e
+	//  LEFT JOIN organization_members m
+	//      ON m.organization_id = e.organization_id AND m.admin_id = $2
+	//  WHERE e.event_id = $1
+	//    AND e.deleted_at IS NULL
+	//    AND (m.admin_id IS NOT NULL OR (e.organization_id IS NULL AND e.created_by = $2))
 	CheckEventOwnership(ctx context.Context, arg CheckEventOwnershipParams) (CheckEventOwnershipRow, error)
 	//CleanupExpiredAdminTokens
 	//
 	//  DELETE FROM admin_refresh_tokens
 	//  WHERE expires_at < CURRENT_TIMESTAMP OR revoked_at IS NOT NULL
 	CleanupExpiredAdminTokens(ctx context.Context) error
+	//CountActiveVenueEvents
+	//
+	//  SELECT COUNT(*) FROM events
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NULL
+	//    AND status <> 'cancelled'
+	//    AND end_datetime > CURRENT_TIMESTAMP
+	CountActiveVenueEvents(ctx context.Context, venueID uuid.UUID) (int64, error)
 	//CountAdmins
 	//
 	//  SELECT COUNT(*) FROM admins
 	CountAdmins(ctx context.Context) (int64, error)
+	//CountCategoryUsage
+	//
+	//  SELECT
+	//      (SELECT COUNT(*) FROM events e WHERE e.category_id = $1) AS event_count,
+	//      (SELECT COUNT(*) FROM categories c WHERE c.parent_id = $1) AS subcategory_count
+	CountCategoryUsage(ctx context.Context, categoryID uuid.UUID) (CountCategoryUsageRow, error)
+	//CountDuplicateEvents
+	//
+	//  SELECT COUNT(*) FROM events
+	//  WHERE LOWER(name) = LOWER($1::text)
+	//    AND venue_id = $2
+	//    AND start_datetime = $3
+	//    AND status != 'cancelled'
+	CountDuplicateEvents(ctx context.Context, arg CountDuplicateEventsParams) (int64, error)
+	//CountEventMediaByKind
+	//
+	//  SELECT COUNT(*) FROM event_media
+	//  WHERE event_id = $1 AND kind = $2
+	CountEventMediaByKind(ctx context.Context, arg CountEventMediaByKindParams) (int64, error)
+	//CountEventsByOrganization
+	//
+	//  SELECT COUNT(*) FROM events WHERE organization_id = $1 AND deleted_at IS NULL
+	CountEventsByOrganization(ctx context.Context, organizationID uuid.NullUUID) (int64, error)
+	//CountOrganizationOwners
+	//
+	//  SELECT COUNT(*) FROM organization_members
+	//  WHERE organization_id = $1 AND role = 'owner'
+	CountOrganizationOwners(ctx context.Context, organizationID uuid.UUID) (int64, error)
 	//CountPublishedEvents
 	//
 	//  SELECT COUNT(*)
 	//  FROM events e
 	//  JOIN venues v ON e.venue_id = v.venue_id
 	//  WHERE e.status = 'published'
+	//    AND e.deleted_at IS NULL
 	//    AND e.start_datetime > CURRENT_TIMESTAMP
-	//    AND ($1::text = '' OR e.event_type = $1)
+	//    AND ($1::text = '' OR e.event_type = $1 OR e.category_id IN (
+	//          SELECT c.category_id FROM categories c
+	//          JOIN categories p ON p.category_id = c.parent_id
+	//          WHERE p.slug = $1
+	//      ))
 	//    AND ($2::text = '' OR v.city ILIKE '%' || $2 || '%')
-	//    AND ($3::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $3)
-	//    AND ($4::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $4)
+	//    AND ($3::date = '0001-01-01'::date OR e.start_datetime >= ($3::date::timestamp AT TIME ZONE v.timezone))
+	//    AND ($4::date = '0001-01-01'::date OR e.start_datetime < (($4::date + 1)::timestamp AT TIME ZONE v.timezone))
 	CountPublishedEvents(ctx context.Context, arg CountPublishedEventsParams) (int64, error)
+	//CountUnusedAdminRecoveryCodes
+	//
+	//  SELECT COUNT(*) FROM admin_recovery_codes
+	//  WHERE admin_id = $1 AND used_at IS NULL
+	CountUnusedAdminRecoveryCodes(ctx context.Context, adminID uuid.UUID) (int64, error)
 	//CountVenues
 	//
 	//  SELECT COUNT(*) FROM venues
-	//  WHERE ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
+	//  WHERE deleted_at IS NULL
+	//    AND ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
 	//    AND ($2::text IS NULL OR state ILIKE '%' || $2 || '%')
+	//    AND (organization_id IS NULL OR organization_id IN (
+	//          SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+	//      ))
 	CountVenues(ctx context.Context, arg CountVenuesParams) (int64, error)
 	// CREATE ADMIN
 	//
@@ -60,6 +127,22 @@ type Querier interface {
 	//  )
 	//  RETURNING admin_id, email, name, phone_number, role, permissions, is_active, created_at
 	CreateAdmin(ctx context.Context, arg CreateAdminParams) (CreateAdminRow, error)
+	//CreateAdminLoginChallenge
+	//
+	//  INSERT INTO admin_login_challenges (
+	//      token, admin_id, expires_at
+	//  ) VALUES (
+	//      $1, $2, $3
+	//  ) RETURNING token, admin_id, expires_at, attempts, created_at
+	CreateAdminLoginChallenge(ctx context.Context, arg CreateAdminLoginChallengeParams) (AdminLoginChallenge, error)
+	//CreateAdminRecoveryCode
+	//
+	//  INSERT INTO admin_recovery_codes (
+	//      admin_id, code_hash
+	//  ) VALUES (
+	//      $1, $2
+	//  )
+	CreateAdminRecoveryCode(ctx context.Context, arg CreateAdminRecoveryCodeParams) error
 	//CreateAdminRefreshToken
 	//
 	//  INSERT INTO admin_refresh_tokens (
@@ -68,25 +151,54 @@ type Querier interface {
 	//      $1, $2, $3
 	//  ) RETURNING token, admin_id, expires_at, revoked_at, created_at, updated_at
 	CreateAdminRefreshToken(ctx context.Context, arg CreateAdminRefreshTokenParams) (AdminRefreshToken, error)
+	//CreateCategory
+	//
+	//  INSERT INTO categories (slug, name, parent_id)
+	//  VALUES ($1, $2, $3)
+	//  RETURNING category_id, slug, name, parent_id, created_at, updated_at
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	//CreateEntityHistory
+	//
+	//  INSERT INTO entity_history (
+	//      entity_type, entity_id, version, action, organization_id,
+	//      changed_by, changes, snapshot, reverted_from_version
+	//  ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	//  RETURNING history_id, entity_type, entity_id, version, action, organization_id, changed_by, changes, snapshot, reverted_from_version, created_at
+	CreateEntityHistory(ctx context.Context, arg CreateEntityHistoryParams) (EntityHistory, error)
 	//CreateEvent
 	//
 	//  INSERT INTO events (
 	//      name, description, venue_id, event_type, start_datetime, end_datetime,
-	//      total_capacity, available_seats, base_price, max_tickets_per_booking,
-	//      status, created_by
+	//      total_capacity, available_seats, base_price_minor, max_tickets_per_booking,
+	//      status, created_by, organization_id, setup_buffer_minutes, teardown_buffer_minutes, currency,
+	//      category_id, tags
 	//  ) VALUES (
-	//      $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+	//      $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 	//  )
-	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
+	//CreateEventMedia
+	//
+	//  INSERT INTO event_media (
+	//      media_id, event_id, kind, content_type, width, height,
+	//      size_bytes, storage_key, variants, position, uploaded_by
+	//  ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	//  RETURNING media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at
+	CreateEventMedia(ctx context.Context, arg CreateEventMediaParams) (EventMedium, error)
+	//CreateOrganization
+	//
+	//  INSERT INTO organizations (name, created_by)
+	//  VALUES ($1, $2)
+	//  RETURNING organization_id, name, created_by, created_at, updated_at
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
 	//CreateVenue
 	//
 	//  INSERT INTO venues (
-	//      name, address, city, state, country, postal_code, capacity, layout_config
+	//      name, address, city, state, country, postal_code, capacity, layout_config, organization_id, timezone, latitude, longitude
 	//  ) VALUES (
-	//      $1, $2, $3, $4, $5, $6, $7, $8
+	//      $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 	//  )
-	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 	CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error)
 	//DeactivateAdmin
 	//
@@ -94,19 +206,102 @@ type Querier interface {
 	//  SET is_active = false, updated_at = CURRENT_TIMESTAMP
 	//  WHERE admin_id = $1
 	DeactivateAdmin(ctx context.Context, adminID uuid.UUID) error
+	//DeferSearchOutbox
+	//
+	//  UPDATE search_outbox
+	//  SET attempts = attempts + 1,
+	//      next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $1::float8),
+	//      last_error = $2,
+	//      leased_until = NULL
+	//  WHERE event_id = $3 AND outbox_id <= $4 AND delivered_at IS NULL
+	DeferSearchOutbox(ctx context.Context, arg DeferSearchOutboxParams) error
+	//DeleteAdminLoginChallenge
+	//
+	//  DELETE FROM admin_login_challenges
+	//  WHERE token = $1
+	DeleteAdminLoginChallenge(ctx context.Context, token string) error
+	//DeleteAdminLoginChallenges
+	//
+	//  DELETE FROM admin_login_challenges
+	//  WHERE admin_id = $1 OR expires_at < CURRENT_TIMESTAMP
+	DeleteAdminLoginChallenges(ctx context.Context, adminID uuid.UUID) error
+	//DeleteAdminRecoveryCodes
+	//
+	//  DELETE FROM admin_recovery_codes
+	//  WHERE admin_id = $1
+	DeleteAdminRecoveryCodes(ctx context.Context, adminID uuid.UUID) error
+	//DeleteAdminTwoFactor
+	//
+	//  DELETE FROM admin_two_factor
+	//  WHERE admin_id = $1
+	DeleteAdminTwoFactor(ctx context.Context, adminID uuid.UUID) (int64, error)
+	//DeleteCategory
+	//
+	//  DELETE FROM categories
+	//  WHERE category_id = $1
+	DeleteCategory(ctx context.Context, categoryID uuid.UUID) (int64, error)
+	//DeleteDeliveredSearchOutbox
+	//
+	//  DELETE FROM search_outbox
+	//  WHERE delivered_at < CURRENT_TIMESTAMP - make_interval(secs => $1::float8)
+	DeleteDeliveredSearchOutbox(ctx context.Context, retentionSeconds float64) (int64, error)
 	//DeleteEvent
 	//
 	//  UPDATE events
 	//  SET status = 'cancelled',
+	//      deleted_at = CURRENT_TIMESTAMP,
 	//      updated_at = CURRENT_TIMESTAMP,
 	//      version = version + 1
 	//  WHERE event_id = $1
 	//    AND version = $2
-	DeleteEvent(ctx context.Context, arg DeleteEventParams) error
+	//    AND deleted_at IS NULL
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+	DeleteEvent(ctx context.Context, arg DeleteEventParams) (Event, error)
+	//DeleteEventMedia
+	//
+	//  DELETE FROM event_media
+	//  WHERE media_id = $1 AND event_id = $2
+	//  RETURNING media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at
+	DeleteEventMedia(ctx context.Context, arg DeleteEventMediaParams) (EventMedium, error)
+	//DeleteEventMediaByKind
+	//
+	//  DELETE FROM event_media
+	//  WHERE event_id = $1 AND kind = $2
+	//  RETURNING media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at
+	DeleteEventMediaByKind(ctx context.Context, arg DeleteEventMediaByKindParams) ([]EventMedium, error)
 	//DeleteVenue
 	//
-	//  DELETE FROM venues WHERE venue_id = $1
-	DeleteVenue(ctx context.Context, venueID uuid.UUID) error
+	//  UPDATE venues
+	//  SET deleted_at = CURRENT_TIMESTAMP,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NULL
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+	DeleteVenue(ctx context.Context, venueID uuid.UUID) (Venue, error)
+	//EnableAdminTwoFactor
+	//
+	//  UPDATE admin_two_factor
+	//  SET enabled_at = CURRENT_TIMESTAMP,
+	//      last_used_step = $1,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE admin_id = $2 AND enabled_at IS NULL AND last_used_step < $1
+	EnableAdminTwoFactor(ctx context.Context, arg EnableAdminTwoFactorParams) (int64, error)
+	//EnqueueSearchOutbox
+	//
+	//  INSERT INTO search_outbox (
+	//      event_id, operation
+	//  ) VALUES (
+	//      $1, $2
+	//  )
+	EnqueueSearchOutbox(ctx context.Context, arg EnqueueSearchOutboxParams) error
+	//EnqueueVenueSearchOutbox
+	//
+	//  INSERT INTO search_outbox (event_id, operation)
+	//  SELECT event_id, 'upsert'
+	//  FROM events
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NULL
+	EnqueueVenueSearchOutbox(ctx context.Context, venueID uuid.UUID) error
 	//GetAdminByEmail
 	//
 	//  SELECT admin_id, email, name, phone_number, password_hash, role, permissions, is_active, created_at, updated_at FROM admins
@@ -118,11 +313,35 @@ type Querier interface {
 	//  FROM admins
 	//  WHERE admin_id = $1
 	GetAdminByID(ctx context.Context, adminID uuid.UUID) (GetAdminByIDRow, error)
+	//GetAdminLoginChallenge
+	//
+	//  SELECT token, admin_id, expires_at, attempts, created_at FROM admin_login_challenges
+	//  WHERE token = $1 AND expires_at > CURRENT_TIMESTAMP
+	GetAdminLoginChallenge(ctx context.Context, token string) (AdminLoginChallenge, error)
 	//GetAdminRefreshToken
 	//
 	//  SELECT token, admin_id, expires_at, revoked_at, created_at, updated_at FROM admin_refresh_tokens
 	//  WHERE token = $1 AND expires_at > CURRENT_TIMESTAMP AND revoked_at IS NULL
 	GetAdminRefreshToken(ctx context.Context, token string) (AdminRefreshToken, error)
+	//GetAdminTwoFactor
+	//
+	//  SELECT admin_id, secret, enabled_at, last_used_step, created_at, updated_at FROM admin_two_factor
+	//  WHERE admin_id = $1
+	GetAdminTwoFactor(ctx context.Context, adminID uuid.UUID) (AdminTwoFactor, error)
+	//GetCategoryBySlug
+	//
+	//  SELECT category_id, slug, name, parent_id, created_at, updated_at FROM categories
+	//  WHERE slug = $1
+	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
+	//GetDeletedVenueForUpdate
+	//
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NOT NULL FOR UPDATE
+	GetDeletedVenueForUpdate(ctx context.Context, venueID uuid.UUID) (Venue, error)
+	//GetEntityHistoryVersion
+	//
+	//  SELECT history_id, entity_type, entity_id, version, action, organization_id, changed_by, changes, snapshot, reverted_from_version, created_at FROM entity_history
+	//  WHERE entity_type = $1 AND entity_id = $2 AND version = $3
+	GetEntityHistoryVersion(ctx context.Context, arg GetEntityHistoryVersionParams) (EntityHistory, error)
 	//GetEventAnalytics
 	//
 	//  SELECT
@@ -132,37 +351,160 @@ type Querier interface {
 	//      e.available_seats,
 	//      (e.total_capacity - e.available_seats) as tickets_sold,
 	//      ROUND(((e.total_capacity - e.available_seats)::decimal / e.total_capacity::decimal) * 100, 2) as capacity_utilization,
-	//      e.base_price,
-	//      ROUND((e.total_capacity - e.available_seats) * e.base_price::decimal, 2) as estimated_revenue
+	//      e.base_price_minor,
+	//      e.currency,
+	//      ((e.total_capacity - e.available_seats) * e.base_price_minor)::bigint as estimated_revenue_minor
 	//  FROM events e
 	//  WHERE e.event_id = $1
 	GetEventAnalytics(ctx context.Context, eventID uuid.UUID) (GetEventAnalyticsRow, error)
 	//GetEventByID
 	//
-	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.address, v.city, v.state, v.country
+	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+	//         v.latitude as venue_latitude, v.longitude as venue_longitude
 	//  FROM events e
 	//  JOIN venues v ON e.venue_id = v.venue_id
 	//  WHERE e.event_id = $1
+	//    AND e.deleted_at IS NULL
 	GetEventByID(ctx context.Context, eventID uuid.UUID) (GetEventByIDRow, error)
 	//GetEventForBooking
 	//
 	//  SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
-	//         status, version, base_price, name
+	//         status, version, base_price_minor, currency, name
 	//  FROM events
 	//  WHERE event_id = $1
 	//    AND (status = 'published' OR status = 'sold_out')
+	//    AND deleted_at IS NULL
 	//  FOR UPDATE
 	GetEventForBooking(ctx context.Context, eventID uuid.UUID) (GetEventForBookingRow, error)
+	//GetEventForUpdate
+	//
+	//  SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+	//  WHERE event_id = $1
+	//  FOR UPDATE
+	GetEventForUpdate(ctx context.Context, eventID uuid.UUID) (Event, error)
+	//GetEventMedia
+	//
+	//  SELECT media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at FROM event_media
+	//  WHERE media_id = $1 AND event_id = $2
+	GetEventMedia(ctx context.Context, arg GetEventMediaParams) (EventMedium, error)
+	//GetEventRecord
+	//
+	//  SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
+	//         status, version, base_price_minor, currency, name, deleted_at
+	//  FROM events
+	//  WHERE event_id = $1
+	GetEventRecord(ctx context.Context, eventID uuid.UUID) (GetEventRecordRow, error)
+	//GetExchangeRate
+	//
+	//  SELECT r.rate::double precision AS rate
+	//  FROM (
+	//      SELECT effective_date, rate FROM exchange_rates
+	//      WHERE from_currency = $1 AND to_currency = $2
+	//        AND effective_date <= $3
+	//      UNION ALL
+	//      SELECT effective_date, 1 / rate FROM exchange_rates
+	//      WHERE from_currency = $2 AND to_currency = $1
+	//        AND effective_date <= $3
+	//  ) r
+	//  ORDER BY r.effective_date DESC
+	//  LIMIT 1
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (float64, error)
+	//GetLatestEntityHistoryVersion
+	//
+	//  SELECT COALESCE(MAX(version), 0)::int FROM entity_history
+	//  WHERE entity_type = $1 AND entity_id = $2
+	GetLatestEntityHistoryVersion(ctx context.Context, arg GetLatestEntityHistoryVersionParams) (int32, error)
+	//GetLatestSearchOutboxError
+	//
+	//  SELECT event_id, attempts, last_error, next_attempt_at
+	//  FROM search_outbox
+	//  WHERE delivered_at IS NULL AND last_error IS NOT NULL
+	//  ORDER BY outbox_id DESC
+	//  LIMIT 1
+	GetLatestSearchOutboxError(ctx context.Context) (GetLatestSearchOutboxErrorRow, error)
+	//GetOrganizationAnalytics
+	//
+	//  SELECT
+	//      COUNT(*) as total_events,
+	//      COUNT(*) FILTER (WHERE status = 'published') as published_events,
+	//      COALESCE(SUM(total_capacity), 0)::bigint as total_capacity,
+	//      COALESCE(SUM(total_capacity - available_seats), 0)::bigint as tickets_sold
+	//  FROM events
+	//  WHERE organization_id = $1
+	//    AND status != 'cancelled'
+	GetOrganizationAnalytics(ctx context.Context, organizationID uuid.NullUUID) (GetOrganizationAnalyticsRow, error)
+	//GetOrganizationByID
+	//
+	//  SELECT organization_id, name, created_by, created_at, updated_at FROM organizations WHERE organization_id = $1
+	GetOrganizationByID(ctx context.Context, organizationID uuid.UUID) (Organization, error)
+	//GetOrganizationMember
+	//
+	//  SELECT organization_id, admin_id, role, created_at, updated_at FROM organization_members
+	//  WHERE organization_id = $1 AND admin_id = $2
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	//GetOrganizationRevenueByCurrency
+	//
+	//  SELECT
+	//      currency,
+	//      COALESCE(SUM(total_capacity - available_seats), 0)::bigint as tickets_sold,
+	//      COALESCE(SUM((total_capacity - available_seats)::bigint * base_price_minor), 0)::bigint as estimated_revenue_minor
+	//  FROM events
+	//  WHERE organization_id = $1
+	//    AND status != 'cancelled'
+	//  GROUP BY currency
+	//  ORDER BY currency
+	GetOrganizationRevenueByCurrency(ctx context.Context, organizationID uuid.NullUUID) ([]GetOrganizationRevenueByCurrencyRow, error)
+	//GetSearchOutboxStats
+	//
+	//  SELECT COUNT(*) AS pending_changes,
+	//         COUNT(DISTINCT event_id) AS pending_events,
+	//         COUNT(*) FILTER (WHERE attempts > 0) AS retrying_changes,
+	//         COALESCE(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - MIN(created_at))), 0)::float8 AS lag_seconds
+	//  FROM search_outbox
+	//  WHERE delivered_at IS NULL
+	GetSearchOutboxStats(ctx context.Context) (GetSearchOutboxStatsRow, error)
 	//GetVenueByID
 	//
-	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues WHERE venue_id = $1
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL
 	GetVenueByID(ctx context.Context, venueID uuid.UUID) (Venue, error)
+	//GetVenueByIDForUpdate
+	//
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL FOR UPDATE
+	GetVenueByIDForUpdate(ctx context.Context, venueID uuid.UUID) (Venue, error)
+	//GetVenueByNameAndCity
+	//
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+	//  WHERE LOWER(name) = LOWER($1::text)
+	//    AND LOWER(city) = LOWER($2::text)
+	//    AND (organization_id IS NULL OR organization_id = $3)
+	//    AND deleted_at IS NULL
+	//  LIMIT 1
+	GetVenueByNameAndCity(ctx context.Context, arg GetVenueByNameAndCityParams) (Venue, error)
+	//GetVenueTimezone
+	//
+	//  SELECT timezone FROM venues WHERE venue_id = $1
+	GetVenueTimezone(ctx context.Context, venueID uuid.UUID) (string, error)
 	//GetVenuesByCity
 	//
 	//  SELECT venue_id, name, capacity, address FROM venues
 	//  WHERE city = $1
+	//    AND deleted_at IS NULL
 	//  ORDER BY name
 	GetVenuesByCity(ctx context.Context, city string) ([]GetVenuesByCityRow, error)
+	//LeaseSearchOutbox
+	//
+	//  UPDATE search_outbox
+	//  SET leased_until = CURRENT_TIMESTAMP + make_interval(secs => $1::float8)
+	//  WHERE event_id = $2 AND outbox_id <= $3 AND delivered_at IS NULL
+	LeaseSearchOutbox(ctx context.Context, arg LeaseSearchOutboxParams) error
+	//ListAdminOrganizations
+	//
+	//  SELECT o.organization_id, o.name, o.created_by, o.created_at, o.updated_at, m.role
+	//  FROM organizations o
+	//  JOIN organization_members m ON o.organization_id = m.organization_id
+	//  WHERE m.admin_id = $1
+	//  ORDER BY o.name
+	ListAdminOrganizations(ctx context.Context, adminID uuid.UUID) ([]ListAdminOrganizationsRow, error)
 	//ListAdmins
 	//
 	//  SELECT admin_id, email, name, phone_number, role, is_active, created_at
@@ -170,37 +512,268 @@ type Querier interface {
 	//  ORDER BY created_at DESC
 	//  LIMIT $1 OFFSET $2
 	ListAdmins(ctx context.Context, arg ListAdminsParams) ([]ListAdminsRow, error)
+	//ListCategories
+	//
+	//  SELECT category_id, slug, name, parent_id, created_at, updated_at FROM categories
+	//  ORDER BY name, slug
+	ListCategories(ctx context.Context) ([]Category, error)
+	//ListDeletedEvents
+	//
+	//  SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+	//  WHERE deleted_at IS NOT NULL
+	//    AND (organization_id = $1
+	//      OR ($1::uuid IS NULL AND organization_id IS NULL AND created_by = $2))
+	//  ORDER BY deleted_at DESC
+	//  LIMIT $3
+	ListDeletedEvents(ctx context.Context, arg ListDeletedEventsParams) ([]Event, error)
+	//ListDeletedVenues
+	//
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+	//  WHERE deleted_at IS NOT NULL
+	//    AND organization_id IS NOT DISTINCT FROM $1::uuid
+	//  ORDER BY deleted_at DESC
+	//  LIMIT $2
+	ListDeletedVenues(ctx context.Context, arg ListDeletedVenuesParams) ([]Venue, error)
+	//ListEntityHistory
+	//
+	//  SELECT history_id, entity_type, entity_id, version, action, organization_id, changed_by, changes, snapshot, reverted_from_version, created_at FROM entity_history
+	//  WHERE entity_type = $1
+	//    AND entity_id = $2
+	//    AND ($3::int = 0 OR version < $3::int)
+	//  ORDER BY version DESC
+	//  LIMIT $4
+	ListEntityHistory(ctx context.Context, arg ListEntityHistoryParams) ([]EntityHistory, error)
+	//ListEventChanges
+	//
+	//  SELECT c.event_id, c.changed_at, c.purged
+	//  FROM (
+	//      SELECT event_id, updated_at AS changed_at, false AS purged
+	//      FROM events
+	//      WHERE updated_at >= $1::timestamp
+	//      UNION ALL
+	//      SELECT entity_id, created_at, true
+	//      FROM entity_history
+	//      WHERE entity_type = 'event'
+	//        AND action = 'purged'
+	//        AND created_at >= $1::timestamp
+	//  ) c
+	//  WHERE (c.changed_at, c.event_id) > ($1::timestamp, $2::uuid)
+	//  ORDER BY c.changed_at, c.event_id
+	//  LIMIT $3
+	ListEventChanges(ctx context.Context, arg ListEventChangesParams) ([]ListEventChangesRow, error)
+	//ListEventMedia
+	//
+	//  SELECT media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at FROM event_media
+	//  WHERE event_id = $1
+	//  ORDER BY kind, position, created_at
+	ListEventMedia(ctx context.Context, eventID uuid.UUID) ([]EventMedium, error)
+	//ListEventMediaByEvents
+	//
+	//  SELECT media_id, event_id, kind, content_type, width, height, size_bytes, storage_key, variants, position, uploaded_by, created_at FROM event_media
+	//  WHERE event_id = ANY($1::uuid[])
+	//  ORDER BY event_id, kind, position, created_at
+	ListEventMediaByEvents(ctx context.Context, eventIds []uuid.UUID) ([]EventMedium, error)
 	//ListEventsByAdmin
 	//
-	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city
+	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
 	//  FROM events e
 	//  JOIN venues v ON e.venue_id = v.venue_id
-	//  WHERE e.created_by = $3
+	//  WHERE e.deleted_at IS NULL
+	//    AND (e.organization_id IN (
+	//          SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+	//      )
+	//     OR (e.organization_id IS NULL AND e.created_by = $3))
 	//  ORDER BY e.created_at DESC
 	//  LIMIT $1 OFFSET $2
 	ListEventsByAdmin(ctx context.Context, arg ListEventsByAdminParams) ([]ListEventsByAdminRow, error)
+	//ListEventsByOrganization
+	//
+	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.timezone as venue_timezone
+	//  FROM events e
+	//  JOIN venues v ON e.venue_id = v.venue_id
+	//  WHERE e.organization_id = $3
+	//    AND e.deleted_at IS NULL
+	//  ORDER BY e.created_at DESC
+	//  LIMIT $1 OFFSET $2
+	ListEventsByOrganization(ctx context.Context, arg ListEventsByOrganizationParams) ([]ListEventsByOrganizationRow, error)
+	//ListEventsWithVenues
+	//
+	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+	//         v.latitude as venue_latitude, v.longitude as venue_longitude
+	//  FROM events e
+	//  JOIN venues v ON e.venue_id = v.venue_id
+	//  WHERE e.event_id = ANY($1::uuid[])
+	ListEventsWithVenues(ctx context.Context, eventIds []uuid.UUID) ([]ListEventsWithVenuesRow, error)
+	//ListLatestExchangeRates
+	//
+	//  SELECT DISTINCT ON (from_currency, to_currency) *
+	//  FROM exchange_rates
+	//  ORDER BY from_currency, to_currency, effective_date DESC
+	ListLatestExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	//ListOrganizationAudit
+	//
+	//  SELECT history_id, entity_type, entity_id, version, action, organization_id, changed_by, changes, snapshot, reverted_from_version, created_at FROM entity_history
+	//  WHERE organization_id = $1
+	//    AND action <> 'baseline'
+	//    AND ($2::text = '' OR entity_type = $2::text)
+	//    AND ($3::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR changed_by = $3::uuid)
+	//    AND ($4::timestamp = '0001-01-01'::timestamp OR created_at < $4::timestamp)
+	//  ORDER BY created_at DESC
+	//  LIMIT $5
+	ListOrganizationAudit(ctx context.Context, arg ListOrganizationAuditParams) ([]EntityHistory, error)
+	//ListOrganizationMembers
+	//
+	//  SELECT m.organization_id, m.admin_id, m.role, m.created_at, a.email, a.name
+	//  FROM organization_members m
+	//  JOIN admins a ON m.admin_id = a.admin_id
+	//  WHERE m.organization_id = $1
+	//  ORDER BY m.created_at
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationMembersRow, error)
+	//ListPendingSearchOutbox
+	//
+	//  SELECT o.event_id, o.outbox_id, o.operation, pending.attempts, pending.changes
+	//  FROM (
+	//      SELECT event_id,
+	//             MIN(outbox_id)::bigint AS first_outbox_id,
+	//             MAX(outbox_id)::bigint AS last_outbox_id,
+	//             MAX(attempts)::integer AS attempts,
+	//             COUNT(*) AS changes
+	//      FROM search_outbox
+	//      WHERE delivered_at IS NULL
+	//      GROUP BY event_id
+	//      HAVING MAX(next_attempt_at) <= CURRENT_TIMESTAMP
+	//         AND (MAX(leased_until) IS NULL OR MAX(leased_until) <= CURRENT_TIMESTAMP)
+	//  ) pending
+	//  JOIN search_outbox o ON o.outbox_id = pending.last_outbox_id
+	//  ORDER BY pending.first_outbox_id
+	//  LIMIT $1
+	ListPendingSearchOutbox(ctx context.Context, limit int32) ([]ListPendingSearchOutboxRow, error)
 	//ListPublishedEvents
 	//
-	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, v.name as venue_name, v.city, v.state
+	//  SELECT e.event_id, e.name, e.description, e.venue_id, e.event_type, e.start_datetime, e.end_datetime, e.total_capacity, e.available_seats, e.base_price_minor, e.max_tickets_per_booking, e.status, e.version, e.created_by, e.created_at, e.updated_at, e.organization_id, e.setup_buffer_minutes, e.teardown_buffer_minutes, e.deleted_at, e.currency, e.category_id, e.tags, v.name as venue_name, v.city, v.state, v.timezone as venue_timezone,
+	//         v.latitude as venue_latitude, v.longitude as venue_longitude
 	//  FROM events e
 	//  JOIN venues v ON e.venue_id = v.venue_id
 	//  WHERE e.status = 'published'
+	//    AND e.deleted_at IS NULL
 	//    AND e.start_datetime > CURRENT_TIMESTAMP
-	//    AND ($3::text = '' OR e.event_type = $3)
+	//    AND ($3::text = '' OR e.event_type = $3 OR e.category_id IN (
+	//          SELECT c.category_id FROM categories c
+	//          JOIN categories p ON p.category_id = c.parent_id
+	//          WHERE p.slug = $3
+	//      ))
 	//    AND ($4::text = '' OR v.city ILIKE '%' || $4 || '%')
-	//    AND ($5::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $5)
-	//    AND ($6::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $6)
+	//    AND ($5::date = '0001-01-01'::date OR e.start_datetime >= ($5::date::timestamp AT TIME ZONE v.timezone))
+	//    AND ($6::date = '0001-01-01'::date OR e.start_datetime < (($6::date + 1)::timestamp AT TIME ZONE v.timezone))
 	//  ORDER BY e.start_datetime ASC
 	//  LIMIT $1 OFFSET $2
 	ListPublishedEvents(ctx context.Context, arg ListPublishedEventsParams) ([]ListPublishedEventsRow, error)
+	//ListPurgeableEvents
+	//
+	//  SELECT event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags FROM events
+	//  WHERE deleted_at < $1
+	//    AND (deleted_at, event_id) > ($2::timestamp, $3::uuid)
+	//    AND available_seats = total_capacity
+	//  ORDER BY deleted_at, event_id
+	//  LIMIT $4
+	ListPurgeableEvents(ctx context.Context, arg ListPurgeableEventsParams) ([]Event, error)
+	//ListPurgeableVenues
+	//
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues v
+	//  WHERE v.deleted_at < $1
+	//    AND (v.deleted_at, v.venue_id) > ($2::timestamp, $3::uuid)
+	//    AND NOT EXISTS (SELECT 1 FROM events e WHERE e.venue_id = v.venue_id)
+	//  ORDER BY v.deleted_at, v.venue_id
+	//  LIMIT $4
+	ListPurgeableVenues(ctx context.Context, arg ListPurgeableVenuesParams) ([]Venue, error)
+	//ListVenueEventIDs
+	//
+	//  SELECT event_id FROM events
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NULL
+	//  ORDER BY start_datetime
+	ListVenueEventIDs(ctx context.Context, venueID uuid.UUID) ([]uuid.UUID, error)
+	//ListVenueOccupancy
+	//
+	//  SELECT event_id, name, status, organization_id, start_datetime, end_datetime,
+	//         setup_buffer_minutes, teardown_buffer_minutes
+	//  FROM events
+	//  WHERE venue_id = $1
+	//    AND event_id <> $2
+	//    AND status <> 'cancelled'
+	//    AND deleted_at IS NULL
+	//    AND start_datetime < $3::timestamptz + interval '1 day'
+	//    AND end_datetime > $4::timestamptz - interval '1 day'
+	//    AND start_datetime - make_interval(mins => setup_buffer_minutes) < $3::timestamptz
+	//    AND end_datetime + make_interval(mins => teardown_buffer_minutes) > $4::timestamptz
+	//  ORDER BY start_datetime
+	ListVenueOccupancy(ctx context.Context, arg ListVenueOccupancyParams) ([]ListVenueOccupancyRow, error)
 	//ListVenues
 	//
-	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-	//  WHERE ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+	//  WHERE deleted_at IS NULL
+	//    AND ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
 	//    AND ($4::text IS NULL OR state ILIKE '%' || $4 || '%')
+	//    AND (organization_id IS NULL OR organization_id IN (
+	//          SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $5
+	//      ))
 	//  ORDER BY name
 	//  LIMIT $1 OFFSET $2
 	ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue, error)
+	//LockEvent
+	//
+	//  SELECT event_id FROM events
+	//  WHERE event_id = $1
+	//  FOR UPDATE
+	LockEvent(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error)
+	//MarkSearchOutboxDelivered
+	//
+	//  UPDATE search_outbox
+	//  SET delivered_at = CURRENT_TIMESTAMP
+	//  WHERE event_id = $1 AND outbox_id <= $2 AND delivered_at IS NULL
+	MarkSearchOutboxDelivered(ctx context.Context, arg MarkSearchOutboxDeliveredParams) error
+	//NextEventMediaPosition
+	//
+	//  SELECT COALESCE(MAX(position) + 1, 0)::int FROM event_media
+	//  WHERE event_id = $1 AND kind = $2
+	NextEventMediaPosition(ctx context.Context, arg NextEventMediaPositionParams) (int32, error)
+	//PurgeEvent
+	//
+	//  DELETE FROM events
+	//  WHERE event_id = $1
+	//    AND deleted_at IS NOT NULL
+	PurgeEvent(ctx context.Context, eventID uuid.UUID) (int64, error)
+	//PurgeVenue
+	//
+	//  DELETE FROM venues
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NOT NULL
+	//    AND NOT EXISTS (SELECT 1 FROM events WHERE venue_id = $1)
+	PurgeVenue(ctx context.Context, venueID uuid.UUID) (int64, error)
+	//RemoveOrganizationMember
+	//
+	//  DELETE FROM organization_members
+	//  WHERE organization_id = $1 AND admin_id = $2
+	RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) error
+	//RestoreEvent
+	//
+	//  UPDATE events
+	//  SET deleted_at = NULL,
+	//      updated_at = CURRENT_TIMESTAMP,
+	//      version = version + 1
+	//  WHERE event_id = $1
+	//    AND version = $2
+	//    AND deleted_at IS NOT NULL
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+	RestoreEvent(ctx context.Context, arg RestoreEventParams) (Event, error)
+	//RestoreVenue
+	//
+	//  UPDATE venues
+	//  SET deleted_at = NULL,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE venue_id = $1
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+	RestoreVenue(ctx context.Context, venueID uuid.UUID) (Venue, error)
 	//ReturnEventSeats
 	//
 	//  UPDATE events
@@ -215,6 +788,48 @@ type Querier interface {
 	//    AND version = $3
 	//  RETURNING event_id, available_seats, status, version
 	ReturnEventSeats(ctx context.Context, arg ReturnEventSeatsParams) (ReturnEventSeatsRow, error)
+	//RevertEvent
+	//
+	//  UPDATE events
+	//  SET name = $1,
+	//      description = $2,
+	//      venue_id = $3,
+	//      event_type = $4,
+	//      start_datetime = $5,
+	//      end_datetime = $6,
+	//      available_seats = available_seats + ($7::int - total_capacity),
+	//      total_capacity = $7::int,
+	//      base_price_minor = $8,
+	//      currency = $9,
+	//      max_tickets_per_booking = $10,
+	//      setup_buffer_minutes = $11,
+	//      teardown_buffer_minutes = $12,
+	//      category_id = $13,
+	//      tags = $14,
+	//      updated_at = CURRENT_TIMESTAMP,
+	//      version = version + 1
+	//  WHERE event_id = $15
+	//    AND version = $16
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+	RevertEvent(ctx context.Context, arg RevertEventParams) (Event, error)
+	//RevertVenue
+	//
+	//  UPDATE venues
+	//  SET name = $2,
+	//      address = $3,
+	//      city = $4,
+	//      state = $5,
+	//      country = $6,
+	//      postal_code = $7,
+	//      capacity = $8,
+	//      layout_config = $9,
+	//      timezone = $10,
+	//      latitude = $11,
+	//      longitude = $12,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE venue_id = $1
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+	RevertVenue(ctx context.Context, arg RevertVenueParams) (Venue, error)
 	//RevokeAdminRefreshToken
 	//
 	//  UPDATE admin_refresh_tokens
@@ -233,15 +848,46 @@ type Querier interface {
 	RevokeAllAdminTokens(ctx context.Context, adminID uuid.UUID) error
 	//SearchVenues
 	//
-	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-	//  WHERE name ILIKE '%' || $1 || '%'
+	//  SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+	//  WHERE deleted_at IS NULL
+	//    AND (name ILIKE '%' || $1 || '%'
 	//     OR city ILIKE '%' || $1 || '%'
-	//     OR address ILIKE '%' || $1 || '%'
+	//     OR address ILIKE '%' || $1 || '%')
+	//    AND (organization_id IS NULL OR organization_id IN (
+	//          SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $2
+	//      ))
 	//  ORDER BY
 	//      CASE WHEN name ILIKE $1 || '%' THEN 1 ELSE 2 END,
 	//      name
 	//  LIMIT 10
-	SearchVenues(ctx context.Context, dollar_1 sql.NullString) ([]Venue, error)
+	SearchVenues(ctx context.Context, arg SearchVenuesParams) ([]Venue, error)
+	//TouchEvent
+	//
+	//  UPDATE events
+	//  SET updated_at = CURRENT_TIMESTAMP
+	//  WHERE event_id = $1
+	TouchEvent(ctx context.Context, eventID uuid.UUID) error
+	//TouchVenueEvents
+	//
+	//  UPDATE events
+	//  SET updated_at = CURRENT_TIMESTAMP
+	//  WHERE venue_id = $1
+	//    AND deleted_at IS NULL
+	TouchVenueEvents(ctx context.Context, venueID uuid.UUID) error
+	//TransferEventOwnership
+	//
+	//  UPDATE events
+	//  SET created_by = $2,
+	//      updated_at = CURRENT_TIMESTAMP,
+	//      version = version + 1
+	//  WHERE event_id = $1
+	//    AND version = $3
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
+	TransferEventOwnership(ctx context.Context, arg TransferEventOwnershipParams) (Event, error)
+	//TryLockSearchOutboxRelay
+	//
+	//  SELECT pg_try_advisory_xact_lock(hashtext('search_outbox_relay'))::boolean AS locked
+	TryLockSearchOutboxRelay(ctx context.Context) (bool, error)
 	//UpdateAdminPermissions
 	//
 	//  UPDATE admins
@@ -261,6 +907,14 @@ type Querier interface {
 	//  WHERE admin_id = $1 AND is_active = true
 	//  RETURNING admin_id, email, name, phone_number, role, permissions, is_active, created_at, updated_at
 	UpdateAdminProfile(ctx context.Context, arg UpdateAdminProfileParams) (UpdateAdminProfileRow, error)
+	//UpdateCategory
+	//
+	//  UPDATE categories
+	//  SET name = $2,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE slug = $1
+	//  RETURNING category_id, slug, name, parent_id, created_at, updated_at
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
 	//UpdateEvent
 	//
 	//  UPDATE events
@@ -272,14 +926,19 @@ type Querier interface {
 	//      end_datetime = COALESCE($7, end_datetime),
 	//      total_capacity = COALESCE($8, total_capacity),
 	//      available_seats = COALESCE($9, available_seats),
-	//      base_price = COALESCE($10, base_price),
+	//      base_price_minor = COALESCE($10, base_price_minor),
 	//      max_tickets_per_booking = COALESCE($11, max_tickets_per_booking),
 	//      status = COALESCE($12, status),
+	//      setup_buffer_minutes = COALESCE($14, setup_buffer_minutes),
+	//      teardown_buffer_minutes = COALESCE($15, teardown_buffer_minutes),
+	//      currency = COALESCE($16, currency),
+	//      category_id = COALESCE($17, category_id),
+	//      tags = COALESCE($18, tags),
 	//      updated_at = CURRENT_TIMESTAMP,
 	//      version = version + 1
 	//  WHERE event_id = $1
 	//    AND version = $13
-	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price, max_tickets_per_booking, status, version, created_by, created_at, updated_at
+	//  RETURNING event_id, name, description, venue_id, event_type, start_datetime, end_datetime, total_capacity, available_seats, base_price_minor, max_tickets_per_booking, status, version, created_by, created_at, updated_at, organization_id, setup_buffer_minutes, teardown_buffer_minutes, deleted_at, currency, category_id, tags
 	UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error)
 	//UpdateEventAvailability
 	//
@@ -296,6 +955,22 @@ type Querier interface {
 	//    AND available_seats >= $2
 	//  RETURNING event_id, available_seats, status, version
 	UpdateEventAvailability(ctx context.Context, arg UpdateEventAvailabilityParams) (UpdateEventAvailabilityRow, error)
+	//UpdateOrganization
+	//
+	//  UPDATE organizations
+	//  SET name = $2,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE organization_id = $1
+	//  RETURNING organization_id, name, created_by, created_at, updated_at
+	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
+	//UpdateOrganizationMemberRole
+	//
+	//  UPDATE organization_members
+	//  SET role = $3,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE organization_id = $1 AND admin_id = $2
+	//  RETURNING organization_id, admin_id, role, created_at, updated_at
+	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (OrganizationMember, error)
 	//UpdateVenue
 	//
 	//  UPDATE venues
@@ -307,10 +982,57 @@ type Querier interface {
 	//      postal_code = COALESCE($7, postal_code),
 	//      capacity = COALESCE($8, capacity),
 	//      layout_config = COALESCE($9, layout_config),
+	//      timezone = COALESCE($10, timezone),
+	//      latitude = $11,
+	//      longitude = $12,
 	//      updated_at = CURRENT_TIMESTAMP
 	//  WHERE venue_id = $1
-	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 	UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue, error)
+	//UpdateVenueLayout
+	//
+	//  UPDATE venues
+	//  SET layout_config = $2,
+	//      capacity = $3,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE venue_id = $1
+	//  RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+	UpdateVenueLayout(ctx context.Context, arg UpdateVenueLayoutParams) (Venue, error)
+	//UpsertExchangeRate
+	//
+	//  INSERT INTO exchange_rates (from_currency, to_currency, effective_date, rate)
+	//  VALUES ($1, $2, $3, $4)
+	//  ON CONFLICT (from_currency, to_currency, effective_date)
+	//  DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+	//  RETURNING from_currency, to_currency, effective_date, rate, created_at, updated_at
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	//UpsertPendingAdminTwoFactor
+	//
+	//  INSERT INTO admin_two_factor (
+	//      admin_id, secret
+	//  ) VALUES (
+	//      $1, $2
+	//  )
+	//  ON CONFLICT (admin_id) DO UPDATE
+	//  SET secret = EXCLUDED.secret,
+	//      last_used_step = 0,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE admin_two_factor.enabled_at IS NULL
+	//  RETURNING admin_id, secret, enabled_at, last_used_step, created_at, updated_at
+	UpsertPendingAdminTwoFactor(ctx context.Context, arg UpsertPendingAdminTwoFactorParams) (AdminTwoFactor, error)
+	//UseAdminRecoveryCode
+	//
+	//  UPDATE admin_recovery_codes
+	//  SET used_at = CURRENT_TIMESTAMP
+	//  WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL
+	UseAdminRecoveryCode(ctx context.Context, arg UseAdminRecoveryCodeParams) (int64, error)
+	//UseAdminTwoFactorStep
+	//
+	//  UPDATE admin_two_factor
+	//  SET last_used_step = $1,
+	//      updated_at = CURRENT_TIMESTAMP
+	//  WHERE admin_id = $2 AND enabled_at IS NOT NULL AND last_used_step < $1
+	UseAdminTwoFactorStep(ctx context.Context, arg UseAdminTwoFactorStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
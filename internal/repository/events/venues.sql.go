@@ -8,6 +8,7 @@ package events
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
@@ -15,22 +16,31 @@ import (
 
 const countVenues = `-- name: CountVenues :one
 SELECT COUNT(*) FROM venues
-WHERE ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
   AND ($2::text IS NULL OR state ILIKE '%' || $2 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+    ))
 `
 
 type CountVenuesParams struct {
-	Column1 string `json:"column_1"`
-	Column2 string `json:"column_2"`
+	Column1 string    `json:"column_1"`
+	Column2 string    `json:"column_2"`
+	AdminID uuid.UUID `json:"admin_id"`
 }
 
 // CountVenues
 //
 //	SELECT COUNT(*) FROM venues
-//	WHERE ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
+//	WHERE deleted_at IS NULL
+//	  AND ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
 //	  AND ($2::text IS NULL OR state ILIKE '%' || $2 || '%')
+//	  AND (organization_id IS NULL OR organization_id IN (
+//	        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+//	    ))
 func (q *Queries) CountVenues(ctx context.Context, arg CountVenuesParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countVenues, arg.Column1, arg.Column2)
+	row := q.db.QueryRowContext(ctx, countVenues, arg.Column1, arg.Column2, arg.AdminID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -38,32 +48,36 @@ func (q *Queries) CountVenues(ctx context.Context, arg CountVenuesParams) (int64
 
 const createVenue = `-- name: CreateVenue :one
 INSERT INTO venues (
-    name, address, city, state, country, postal_code, capacity, layout_config
+    name, address, city, state, country, postal_code, capacity, layout_config, organization_id, timezone, latitude, longitude
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
-RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 `
 
 type CreateVenueParams struct {
-	Name         string                `json:"name"`
-	Address      string                `json:"address"`
-	City         string                `json:"city"`
-	State        sql.NullString        `json:"state"`
-	Country      string                `json:"country"`
-	PostalCode   sql.NullString        `json:"postal_code"`
-	Capacity     int32                 `json:"capacity"`
-	LayoutConfig pqtype.NullRawMessage `json:"layout_config"`
+	Name           string                `json:"name"`
+	Address        string                `json:"address"`
+	City           string                `json:"city"`
+	State          sql.NullString        `json:"state"`
+	Country        string                `json:"country"`
+	PostalCode     sql.NullString        `json:"postal_code"`
+	Capacity       int32                 `json:"capacity"`
+	LayoutConfig   pqtype.NullRawMessage `json:"layout_config"`
+	OrganizationID uuid.NullUUID         `json:"organization_id"`
+	Timezone       string                `json:"timezone"`
+	Latitude       sql.NullFloat64       `json:"latitude"`
+	Longitude      sql.NullFloat64       `json:"longitude"`
 }
 
 // CreateVenue
 //
 //	INSERT INTO venues (
-//	    name, address, city, state, country, postal_code, capacity, layout_config
+//	    name, address, city, state, country, postal_code, capacity, layout_config, organization_id, timezone, latitude, longitude
 //	) VALUES (
-//	    $1, $2, $3, $4, $5, $6, $7, $8
+//	    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 //	)
-//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error) {
 	row := q.db.QueryRowContext(ctx, createVenue,
 		arg.Name,
@@ -74,6 +88,10 @@ func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue
 		arg.PostalCode,
 		arg.Capacity,
 		arg.LayoutConfig,
+		arg.OrganizationID,
+		arg.Timezone,
+		arg.Latitude,
+		arg.Longitude,
 	)
 	var i Venue
 	err := row.Scan(
@@ -88,29 +106,63 @@ func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue
 		&i.LayoutConfig,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
 
-const deleteVenue = `-- name: DeleteVenue :exec
-DELETE FROM venues WHERE venue_id = $1
+const deleteVenue = `-- name: DeleteVenue :one
+UPDATE venues
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 `
 
 // DeleteVenue
 //
-//	DELETE FROM venues WHERE venue_id = $1
-func (q *Queries) DeleteVenue(ctx context.Context, venueID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteVenue, venueID)
-	return err
+//	UPDATE venues
+//	SET deleted_at = CURRENT_TIMESTAMP,
+//	    updated_at = CURRENT_TIMESTAMP
+//	WHERE venue_id = $1
+//	  AND deleted_at IS NULL
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+func (q *Queries) DeleteVenue(ctx context.Context, venueID uuid.UUID) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, deleteVenue, venueID)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
 }
 
 const getVenueByID = `-- name: GetVenueByID :one
-SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues WHERE venue_id = $1
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL
 `
 
 // GetVenueByID
 //
-//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues WHERE venue_id = $1
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL
 func (q *Queries) GetVenueByID(ctx context.Context, venueID uuid.UUID) (Venue, error) {
 	row := q.db.QueryRowContext(ctx, getVenueByID, venueID)
 	var i Venue
@@ -126,6 +178,89 @@ func (q *Queries) GetVenueByID(ctx context.Context, venueID uuid.UUID) (Venue, e
 		&i.LayoutConfig,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
+const getVenueByIDForUpdate = `-- name: GetVenueByIDForUpdate :one
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL FOR UPDATE
+`
+
+// GetVenueByIDForUpdate
+//
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NULL FOR UPDATE
+func (q *Queries) GetVenueByIDForUpdate(ctx context.Context, venueID uuid.UUID) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, getVenueByIDForUpdate, venueID)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
+const getVenueByNameAndCity = `-- name: GetVenueByNameAndCity :one
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+WHERE LOWER(name) = LOWER($1::text)
+  AND LOWER(city) = LOWER($2::text)
+  AND (organization_id IS NULL OR organization_id = $3)
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetVenueByNameAndCityParams struct {
+	Name           string        `json:"name"`
+	City           string        `json:"city"`
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+}
+
+// GetVenueByNameAndCity
+//
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+//	WHERE LOWER(name) = LOWER($1::text)
+//	  AND LOWER(city) = LOWER($2::text)
+//	  AND (organization_id IS NULL OR organization_id = $3)
+//	  AND deleted_at IS NULL
+//	LIMIT 1
+func (q *Queries) GetVenueByNameAndCity(ctx context.Context, arg GetVenueByNameAndCityParams) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, getVenueByNameAndCity, arg.Name, arg.City, arg.OrganizationID)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
@@ -133,6 +268,7 @@ func (q *Queries) GetVenueByID(ctx context.Context, venueID uuid.UUID) (Venue, e
 const getVenuesByCity = `-- name: GetVenuesByCity :many
 SELECT venue_id, name, capacity, address FROM venues
 WHERE city = $1
+  AND deleted_at IS NULL
 ORDER BY name
 `
 
@@ -147,6 +283,7 @@ type GetVenuesByCityRow struct {
 //
 //	SELECT venue_id, name, capacity, address FROM venues
 //	WHERE city = $1
+//	  AND deleted_at IS NULL
 //	ORDER BY name
 func (q *Queries) GetVenuesByCity(ctx context.Context, city string) ([]GetVenuesByCityRow, error) {
 	rows, err := q.db.QueryContext(ctx, getVenuesByCity, city)
@@ -177,25 +314,34 @@ func (q *Queries) GetVenuesByCity(ctx context.Context, city string) ([]GetVenues
 }
 
 const listVenues = `-- name: ListVenues :many
-SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-WHERE ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+WHERE deleted_at IS NULL
+  AND ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
   AND ($4::text IS NULL OR state ILIKE '%' || $4 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $5
+    ))
 ORDER BY name
 LIMIT $1 OFFSET $2
 `
 
 type ListVenuesParams struct {
-	Limit   int32  `json:"limit"`
-	Offset  int32  `json:"offset"`
-	Column3 string `json:"column_3"`
-	Column4 string `json:"column_4"`
+	Limit   int32     `json:"limit"`
+	Offset  int32     `json:"offset"`
+	Column3 string    `json:"column_3"`
+	Column4 string    `json:"column_4"`
+	AdminID uuid.UUID `json:"admin_id"`
 }
 
 // ListVenues
 //
-//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-//	WHERE ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+//	WHERE deleted_at IS NULL
+//	  AND ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
 //	  AND ($4::text IS NULL OR state ILIKE '%' || $4 || '%')
+//	  AND (organization_id IS NULL OR organization_id IN (
+//	        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $5
+//	    ))
 //	ORDER BY name
 //	LIMIT $1 OFFSET $2
 func (q *Queries) ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue, error) {
@@ -204,6 +350,7 @@ func (q *Queries) ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue
 		arg.Offset,
 		arg.Column3,
 		arg.Column4,
+		arg.AdminID,
 	)
 	if err != nil {
 		return nil, err
@@ -224,6 +371,11 @@ func (q *Queries) ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue
 			&i.LayoutConfig,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.DeletedAt,
+			&i.Timezone,
+			&i.Latitude,
+			&i.Longitude,
 		); err != nil {
 			return nil, err
 		}
@@ -239,28 +391,41 @@ func (q *Queries) ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue
 }
 
 const searchVenues = `-- name: SearchVenues :many
-SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-WHERE name ILIKE '%' || $1 || '%'
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+WHERE deleted_at IS NULL
+  AND (name ILIKE '%' || $1 || '%'
    OR city ILIKE '%' || $1 || '%'
-   OR address ILIKE '%' || $1 || '%'
+   OR address ILIKE '%' || $1 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $2
+    ))
 ORDER BY
     CASE WHEN name ILIKE $1 || '%' THEN 1 ELSE 2 END,
     name
 LIMIT 10
 `
 
+type SearchVenuesParams struct {
+	Column1 sql.NullString `json:"column_1"`
+	AdminID uuid.UUID      `json:"admin_id"`
+}
+
 // SearchVenues
 //
-//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at FROM venues
-//	WHERE name ILIKE '%' || $1 || '%'
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+//	WHERE deleted_at IS NULL
+//	  AND (name ILIKE '%' || $1 || '%'
 //	   OR city ILIKE '%' || $1 || '%'
-//	   OR address ILIKE '%' || $1 || '%'
+//	   OR address ILIKE '%' || $1 || '%')
+//	  AND (organization_id IS NULL OR organization_id IN (
+//	        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $2
+//	    ))
 //	ORDER BY
 //	    CASE WHEN name ILIKE $1 || '%' THEN 1 ELSE 2 END,
 //	    name
 //	LIMIT 10
-func (q *Queries) SearchVenues(ctx context.Context, dollar_1 sql.NullString) ([]Venue, error) {
-	rows, err := q.db.QueryContext(ctx, searchVenues, dollar_1)
+func (q *Queries) SearchVenues(ctx context.Context, arg SearchVenuesParams) ([]Venue, error) {
+	rows, err := q.db.QueryContext(ctx, searchVenues, arg.Column1, arg.AdminID)
 	if err != nil {
 		return nil, err
 	}
@@ -280,6 +445,11 @@ func (q *Queries) SearchVenues(ctx context.Context, dollar_1 sql.NullString) ([]
 			&i.LayoutConfig,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.DeletedAt,
+			&i.Timezone,
+			&i.Latitude,
+			&i.Longitude,
 		); err != nil {
 			return nil, err
 		}
@@ -304,9 +474,12 @@ SET name = COALESCE($2, name),
     postal_code = COALESCE($7, postal_code),
     capacity = COALESCE($8, capacity),
     layout_config = COALESCE($9, layout_config),
+    timezone = COALESCE($10, timezone),
+    latitude = $11,
+    longitude = $12,
     updated_at = CURRENT_TIMESTAMP
 WHERE venue_id = $1
-RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 `
 
 type UpdateVenueParams struct {
@@ -319,6 +492,9 @@ type UpdateVenueParams struct {
 	PostalCode   sql.NullString        `json:"postal_code"`
 	Capacity     int32                 `json:"capacity"`
 	LayoutConfig pqtype.NullRawMessage `json:"layout_config"`
+	Timezone     string                `json:"timezone"`
+	Latitude     sql.NullFloat64       `json:"latitude"`
+	Longitude    sql.NullFloat64       `json:"longitude"`
 }
 
 // UpdateVenue
@@ -332,9 +508,12 @@ type UpdateVenueParams struct {
 //	    postal_code = COALESCE($7, postal_code),
 //	    capacity = COALESCE($8, capacity),
 //	    layout_config = COALESCE($9, layout_config),
+//	    timezone = COALESCE($10, timezone),
+//	    latitude = $11,
+//	    longitude = $12,
 //	    updated_at = CURRENT_TIMESTAMP
 //	WHERE venue_id = $1
-//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
 func (q *Queries) UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue, error) {
 	row := q.db.QueryRowContext(ctx, updateVenue,
 		arg.VenueID,
@@ -346,7 +525,214 @@ func (q *Queries) UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue
 		arg.PostalCode,
 		arg.Capacity,
 		arg.LayoutConfig,
+		arg.Timezone,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
+const updateVenueLayout = `-- name: UpdateVenueLayout :one
+UPDATE venues
+SET layout_config = $2,
+    capacity = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+`
+
+type UpdateVenueLayoutParams struct {
+	VenueID      uuid.UUID             `json:"venue_id"`
+	LayoutConfig pqtype.NullRawMessage `json:"layout_config"`
+	Capacity     int32                 `json:"capacity"`
+}
+
+// UpdateVenueLayout
+//
+//	UPDATE venues
+//	SET layout_config = $2,
+//	    capacity = $3,
+//	    updated_at = CURRENT_TIMESTAMP
+//	WHERE venue_id = $1
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+func (q *Queries) UpdateVenueLayout(ctx context.Context, arg UpdateVenueLayoutParams) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, updateVenueLayout, arg.VenueID, arg.LayoutConfig, arg.Capacity)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
+const revertVenue = `-- name: RevertVenue :one
+UPDATE venues
+SET name = $2,
+    address = $3,
+    city = $4,
+    state = $5,
+    country = $6,
+    postal_code = $7,
+    capacity = $8,
+    layout_config = $9,
+    timezone = $10,
+    latitude = $11,
+    longitude = $12,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+`
+
+type RevertVenueParams struct {
+	VenueID      uuid.UUID             `json:"venue_id"`
+	Name         string                `json:"name"`
+	Address      string                `json:"address"`
+	City         string                `json:"city"`
+	State        sql.NullString        `json:"state"`
+	Country      string                `json:"country"`
+	PostalCode   sql.NullString        `json:"postal_code"`
+	Capacity     int32                 `json:"capacity"`
+	LayoutConfig pqtype.NullRawMessage `json:"layout_config"`
+	Timezone     string                `json:"timezone"`
+	Latitude     sql.NullFloat64       `json:"latitude"`
+	Longitude    sql.NullFloat64       `json:"longitude"`
+}
+
+// RevertVenue
+//
+//	UPDATE venues
+//	SET name = $2,
+//	    address = $3,
+//	    city = $4,
+//	    state = $5,
+//	    country = $6,
+//	    postal_code = $7,
+//	    capacity = $8,
+//	    layout_config = $9,
+//	    timezone = $10,
+//	    latitude = $11,
+//	    longitude = $12,
+//	    updated_at = CURRENT_TIMESTAMP
+//	WHERE venue_id = $1
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+func (q *Queries) RevertVenue(ctx context.Context, arg RevertVenueParams) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, revertVenue,
+		arg.VenueID,
+		arg.Name,
+		arg.Address,
+		arg.City,
+		arg.State,
+		arg.Country,
+		arg.PostalCode,
+		arg.Capacity,
+		arg.LayoutConfig,
+		arg.Timezone,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
+const getDeletedVenueForUpdate = `-- name: GetDeletedVenueForUpdate :one
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NOT NULL FOR UPDATE
+`
+
+// GetDeletedVenueForUpdate
+//
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues WHERE venue_id = $1 AND deleted_at IS NOT NULL FOR UPDATE
+func (q *Queries) GetDeletedVenueForUpdate(ctx context.Context, venueID uuid.UUID) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedVenueForUpdate, venueID)
+	var i Venue
+	err := row.Scan(
+		&i.VenueID,
+		&i.Name,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.PostalCode,
+		&i.Capacity,
+		&i.LayoutConfig,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
 	)
+	return i, err
+}
+
+const restoreVenue = `-- name: RestoreVenue :one
+UPDATE venues
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+`
+
+// RestoreVenue
+//
+//	UPDATE venues
+//	SET deleted_at = NULL,
+//	    updated_at = CURRENT_TIMESTAMP
+//	WHERE venue_id = $1
+//	RETURNING venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude
+func (q *Queries) RestoreVenue(ctx context.Context, venueID uuid.UUID) (Venue, error) {
+	row := q.db.QueryRowContext(ctx, restoreVenue, venueID)
 	var i Venue
 	err := row.Scan(
 		&i.VenueID,
@@ -360,6 +746,233 @@ func (q *Queries) UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue
 		&i.LayoutConfig,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
+
+const countActiveVenueEvents = `-- name: CountActiveVenueEvents :one
+SELECT COUNT(*) FROM events
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+  AND status <> 'cancelled'
+  AND end_datetime > CURRENT_TIMESTAMP
+`
+
+// CountActiveVenueEvents
+//
+//	SELECT COUNT(*) FROM events
+//	WHERE venue_id = $1
+//	  AND deleted_at IS NULL
+//	  AND status <> 'cancelled'
+//	  AND end_datetime > CURRENT_TIMESTAMP
+func (q *Queries) CountActiveVenueEvents(ctx context.Context, venueID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveVenueEvents, venueID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listDeletedVenues = `-- name: ListDeletedVenues :many
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+WHERE deleted_at IS NOT NULL
+  AND organization_id IS NOT DISTINCT FROM $1::uuid
+ORDER BY deleted_at DESC
+LIMIT $2
+`
+
+type ListDeletedVenuesParams struct {
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+	PageSize       int32         `json:"page_size"`
+}
+
+// ListDeletedVenues
+//
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues
+//	WHERE deleted_at IS NOT NULL
+//	  AND organization_id IS NOT DISTINCT FROM $1::uuid
+//	ORDER BY deleted_at DESC
+//	LIMIT $2
+func (q *Queries) ListDeletedVenues(ctx context.Context, arg ListDeletedVenuesParams) ([]Venue, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedVenues, arg.OrganizationID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Venue{}
+	for rows.Next() {
+		var i Venue
+		if err := rows.Scan(
+			&i.VenueID,
+			&i.Name,
+			&i.Address,
+			&i.City,
+			&i.State,
+			&i.Country,
+			&i.PostalCode,
+			&i.Capacity,
+			&i.LayoutConfig,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.DeletedAt,
+			&i.Timezone,
+			&i.Latitude,
+			&i.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableVenues = `-- name: ListPurgeableVenues :many
+SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues v
+WHERE v.deleted_at < $1
+  AND (v.deleted_at, v.venue_id) > ($2::timestamp, $3::uuid)
+  AND NOT EXISTS (SELECT 1 FROM events e WHERE e.venue_id = v.venue_id)
+ORDER BY v.deleted_at, v.venue_id
+LIMIT $4
+`
+
+type ListPurgeableVenuesParams struct {
+	DeletedBefore  sql.NullTime `json:"deleted_before"`
+	AfterDeletedAt time.Time    `json:"after_deleted_at"`
+	AfterVenueID   uuid.UUID    `json:"after_venue_id"`
+	BatchSize      int32        `json:"batch_size"`
+}
+
+// ListPurgeableVenues
+//
+//	SELECT venue_id, name, address, city, state, country, postal_code, capacity, layout_config, created_at, updated_at, organization_id, deleted_at, timezone, latitude, longitude FROM venues v
+//	WHERE v.deleted_at < $1
+//	  AND (v.deleted_at, v.venue_id) > ($2::timestamp, $3::uuid)
+//	  AND NOT EXISTS (SELECT 1 FROM events e WHERE e.venue_id = v.venue_id)
+//	ORDER BY v.deleted_at, v.venue_id
+//	LIMIT $4
+func (q *Queries) ListPurgeableVenues(ctx context.Context, arg ListPurgeableVenuesParams) ([]Venue, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableVenues,
+		arg.DeletedBefore,
+		arg.AfterDeletedAt,
+		arg.AfterVenueID,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Venue{}
+	for rows.Next() {
+		var i Venue
+		if err := rows.Scan(
+			&i.VenueID,
+			&i.Name,
+			&i.Address,
+			&i.City,
+			&i.State,
+			&i.Country,
+			&i.PostalCode,
+			&i.Capacity,
+			&i.LayoutConfig,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.DeletedAt,
+			&i.Timezone,
+			&i.Latitude,
+			&i.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeVenue = `-- name: PurgeVenue :execrows
+DELETE FROM venues
+WHERE venue_id = $1
+  AND deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM events WHERE venue_id = $1)
+`
+
+// PurgeVenue
+//
+//	DELETE FROM venues
+//	WHERE venue_id = $1
+//	  AND deleted_at IS NOT NULL
+//	  AND NOT EXISTS (SELECT 1 FROM events WHERE venue_id = $1)
+func (q *Queries) PurgeVenue(ctx context.Context, venueID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeVenue, venueID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getVenueTimezone = `-- name: GetVenueTimezone :one
+SELECT timezone FROM venues WHERE venue_id = $1
+`
+
+// GetVenueTimezone
+//
+//	SELECT timezone FROM venues WHERE venue_id = $1
+func (q *Queries) GetVenueTimezone(ctx context.Context, venueID uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, getVenueTimezone, venueID)
+	var timezone string
+	err := row.Scan(&timezone)
+	return timezone, err
+}
+
+const listVenueEventIDs = `-- name: ListVenueEventIDs :many
+SELECT event_id FROM events
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+ORDER BY start_datetime
+`
+
+// ListVenueEventIDs
+//
+//	SELECT event_id FROM events
+//	WHERE venue_id = $1
+//	  AND deleted_at IS NULL
+//	ORDER BY start_datetime
+func (q *Queries) ListVenueEventIDs(ctx context.Context, venueID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listVenueEventIDs, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var event_id uuid.UUID
+		if err := rows.Scan(&event_id); err != nil {
+			return nil, err
+		}
+		items = append(items, event_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -6,6 +6,7 @@ package users
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,28 @@ type RefreshToken struct {
 	UpdatedAt sql.NullTime `json:"updated_at"`
 }
 
+type SavedSearch struct {
+	SavedSearchID uuid.UUID       `json:"saved_search_id"`
+	UserID        uuid.UUID       `json:"user_id"`
+	Name          string          `json:"name"`
+	Criteria      json.RawMessage `json:"criteria"`
+	Frequency     string          `json:"frequency"`
+	AlertsEnabled bool            `json:"alerts_enabled"`
+	LastAlertedAt sql.NullTime    `json:"last_alerted_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type SearchAlert struct {
+	AlertID         uuid.UUID       `json:"alert_id"`
+	UserID          uuid.UUID       `json:"user_id"`
+	SavedSearchID   uuid.UUID       `json:"saved_search_id"`
+	SavedSearchName string          `json:"saved_search_name"`
+	Events          json.RawMessage `json:"events"`
+	MatchCount      int32           `json:"match_count"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
 type User struct {
 	UserID       uuid.UUID      `json:"user_id"`
 	Email        string         `json:"email"`
@@ -30,3 +53,12 @@ type User struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 }
+
+type UserPreference struct {
+	UserID     uuid.UUID    `json:"user_id"`
+	Cities     []string     `json:"cities"`
+	Categories []string     `json:"categories"`
+	Tags       []string     `json:"tags"`
+	CreatedAt  sql.NullTime `json:"created_at"`
+	UpdatedAt  sql.NullTime `json:"updated_at"`
+}
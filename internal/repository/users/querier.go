@@ -13,16 +13,33 @@ import (
 type Querier interface {
 	CheckUserExists(ctx context.Context, db DBTX, email string) (bool, error)
 	CleanupExpiredTokens(ctx context.Context, db DBTX) error
+	CountUserSavedSearches(ctx context.Context, db DBTX, userID uuid.UUID) (int64, error)
 	CreateRefreshToken(ctx context.Context, db DBTX, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateSavedSearch(ctx context.Context, db DBTX, arg CreateSavedSearchParams) (SavedSearch, error)
+	CreateSearchAlert(ctx context.Context, db DBTX, arg CreateSearchAlertParams) (SearchAlert, error)
 	CreateUser(ctx context.Context, db DBTX, arg CreateUserParams) (User, error)
 	DeactivateUser(ctx context.Context, db DBTX, userID uuid.UUID) error
+	DeleteSavedSearch(ctx context.Context, db DBTX, arg DeleteSavedSearchParams) (int64, error)
+	DisableUserSearchAlerts(ctx context.Context, db DBTX, userID uuid.UUID) ([]uuid.UUID, error)
 	GetRefreshToken(ctx context.Context, db DBTX, token string) (RefreshToken, error)
+	GetSavedSearch(ctx context.Context, db DBTX, savedSearchID uuid.UUID) (SavedSearch, error)
 	GetUserByEmail(ctx context.Context, db DBTX, email string) (User, error)
 	GetUserByID(ctx context.Context, db DBTX, userID uuid.UUID) (User, error)
+	GetUserPreferences(ctx context.Context, db DBTX, userID uuid.UUID) (UserPreference, error)
+	GetUserSavedSearch(ctx context.Context, db DBTX, arg GetUserSavedSearchParams) (SavedSearch, error)
+	GetUsersByIDs(ctx context.Context, db DBTX, userIds []uuid.UUID) ([]GetUsersByIDsRow, error)
+	ListAlertingSavedSearches(ctx context.Context, db DBTX, arg ListAlertingSavedSearchesParams) ([]SavedSearch, error)
+	ListUserSavedSearches(ctx context.Context, db DBTX, userID uuid.UUID) ([]SavedSearch, error)
+	ListUserSearchAlerts(ctx context.Context, db DBTX, arg ListUserSearchAlertsParams) ([]SearchAlert, error)
+	LockUserForSavedSearch(ctx context.Context, db DBTX, userID uuid.UUID) (uuid.UUID, error)
+	MarkSavedSearchAlerted(ctx context.Context, db DBTX, arg MarkSavedSearchAlertedParams) error
+	PruneUserSearchAlerts(ctx context.Context, db DBTX, userID uuid.UUID) error
 	RevokeAllUserTokens(ctx context.Context, db DBTX, userID uuid.UUID) error
 	RevokeRefreshToken(ctx context.Context, db DBTX, token string) error
+	UpdateSavedSearch(ctx context.Context, db DBTX, arg UpdateSavedSearchParams) (SavedSearch, error)
 	UpdateUser(ctx context.Context, db DBTX, arg UpdateUserParams) (User, error)
 	UpdateUserPassword(ctx context.Context, db DBTX, arg UpdateUserPasswordParams) error
+	UpsertUserPreferences(ctx context.Context, db DBTX, arg UpsertUserPreferencesParams) (UserPreference, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const checkUserExists = `-- name: CheckUserExists :one
@@ -117,6 +118,44 @@ func (q *Queries) GetUserByID(ctx context.Context, db DBTX, userID uuid.UUID) (U
 	return i, err
 }
 
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT user_id, email, name FROM users
+WHERE user_id = ANY($1::uuid[])
+`
+
+type GetUsersByIDsRow struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, db DBTX, userIds []uuid.UUID) ([]GetUsersByIDsRow, error) {
+	rows, err := db.QueryContext(ctx, getUsersByIDs, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUsersByIDsRow{}
+	for rows.Next() {
+		var i GetUsersByIDsRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Email,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET 
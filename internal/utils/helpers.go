@@ -5,11 +5,12 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"math"
 	mathrand "math/rand"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -37,24 +38,39 @@ func StringPtrFromNullString(ns sql.NullString) *string {
 	return nil
 }
 
-func ParsePrice(priceStr sql.NullString) float64 {
-	if !priceStr.Valid {
-		return 0.0
+func NullStringFromStringPtr(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
 	}
+	return sql.NullString{String: *s, Valid: true}
+}
 
-	var price float64
-	if _, err := fmt.Sscanf(priceStr.String, "%f", &price); err != nil {
-		return 0.0
+func UUIDPtrFromNullUUID(nu uuid.NullUUID) *uuid.UUID {
+	if nu.Valid {
+		return &nu.UUID
 	}
-	return price
+	return nil
 }
 
-func ParseAmount(amountStr string) float64 {
-	amount, err := strconv.ParseFloat(amountStr, 64)
-	if err != nil {
-		return 0.0
+func TimePtrFromNullTime(nt sql.NullTime) *time.Time {
+	if nt.Valid {
+		return &nt.Time
 	}
-	return amount
+	return nil
+}
+
+func Float64PtrFromNullFloat64(nf sql.NullFloat64) *float64 {
+	if nf.Valid {
+		return &nf.Float64
+	}
+	return nil
+}
+
+func NullFloat64FromFloat64Ptr(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
 }
 
 func GenerateBookingReference() string {
@@ -74,14 +90,16 @@ func GenerateGatewayTransactionID() string {
 	return "txn_" + strconv.FormatInt(time.Now().UnixNano(), 36)
 }
 
-func FormatCurrency(amount float64, currency string) string {
-	if currency == "" {
-		currency = "USD"
+func PercentOf(part, total int64) float64 {
+	if total <= 0 {
+		return 0.0
 	}
-	return currency + " " + strconv.FormatFloat(amount, 'f', 2, 64)
+	return math.Round(float64(part)/float64(total)*10000) / 100
 }
 
-func CalculateRefundAmount(originalAmount float64, bookingTime time.Time, eventDateTime time.Time) float64 {
+// CalculateRefundAmount works in minor units; a half refund of an odd
+// amount rounds down.
+func CalculateRefundAmount(originalAmount int64, bookingTime time.Time, eventDateTime time.Time) int64 {
 	hoursUntilEvent := time.Until(eventDateTime).Hours()
 
 	if hoursUntilEvent > 24 {
@@ -89,10 +107,10 @@ func CalculateRefundAmount(originalAmount float64, bookingTime time.Time, eventD
 	}
 
 	if hoursUntilEvent > 2 {
-		return originalAmount * 0.5
+		return originalAmount / 2
 	}
 
-	return 0.0
+	return 0
 }
 
 func NullRawMessageToJSONRawMessage(nullRM pqtype.NullRawMessage) json.RawMessage {
@@ -112,6 +130,22 @@ func GetStringFromInterface(i any) string {
 	return ""
 }
 
+// GetStringsFromInterface reads a JSON array of strings, skipping anything
+// that is not a string.
+func GetStringsFromInterface(i any) []string {
+	items, ok := i.([]any)
+	if !ok {
+		return nil
+	}
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
 func GetFloatFromInterface(i any) float64 {
 	if i == nil {
 		return 0.0
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Track refunds issued on cancellation so analytics can report net revenue
+ALTER TABLE payments ADD COLUMN refund_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN refunded_at TIMESTAMP;
+
+ALTER TABLE payments ADD CONSTRAINT check_refund_amount CHECK (refund_amount >= 0 AND refund_amount <= amount);
+
+-- Sales time series and cancellation analytics
+CREATE INDEX idx_payments_refunded_at ON payments(event_id, refunded_at) WHERE refunded_at IS NOT NULL;
+CREATE INDEX idx_bookings_event_confirmed ON bookings(event_id, confirmed_at) WHERE status = 'confirmed';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_bookings_event_confirmed;
+DROP INDEX IF EXISTS idx_payments_refunded_at;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_refund_amount;
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS refund_amount;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Door check-in for attendee manifests
+ALTER TABLE bookings ADD COLUMN checked_in_at TIMESTAMP;
+
+-- Keyset pagination over an event's confirmed bookings
+CREATE INDEX idx_bookings_event_confirmed_id ON bookings(event_id, booking_id) WHERE status = 'confirmed';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_bookings_event_confirmed_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,73 @@
+-- +goose Up
+-- Amounts become integers in the currency's minor unit and bookings carry
+-- the currency of their event. Everything stored so far was charged in INR,
+-- which has two decimal places.
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS check_total_amount;
+ALTER TABLE bookings RENAME COLUMN total_amount TO total_amount_minor;
+ALTER TABLE bookings ALTER COLUMN total_amount_minor TYPE BIGINT USING ROUND(total_amount_minor * 100)::BIGINT;
+ALTER TABLE bookings ADD CONSTRAINT check_total_amount CHECK (total_amount_minor >= 0);
+
+ALTER TABLE bookings ADD COLUMN currency CHAR(3);
+UPDATE bookings b
+SET currency = COALESCE(
+    (SELECT p.currency FROM payments p WHERE p.booking_id = b.booking_id LIMIT 1),
+    'INR'
+);
+ALTER TABLE bookings ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE bookings ADD CONSTRAINT check_booking_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_refund_amount;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_amount;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_currency;
+ALTER TABLE payments RENAME COLUMN amount TO amount_minor;
+ALTER TABLE payments ALTER COLUMN amount_minor TYPE BIGINT USING ROUND(amount_minor * 100)::BIGINT;
+ALTER TABLE payments RENAME COLUMN refund_amount TO refund_amount_minor;
+ALTER TABLE payments ALTER COLUMN refund_amount_minor DROP DEFAULT;
+ALTER TABLE payments ALTER COLUMN refund_amount_minor TYPE BIGINT USING ROUND(refund_amount_minor * 100)::BIGINT;
+ALTER TABLE payments ALTER COLUMN refund_amount_minor SET DEFAULT 0;
+UPDATE payments SET currency = 'INR' WHERE currency IS NULL;
+ALTER TABLE payments ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE payments ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE payments ADD CONSTRAINT check_amount CHECK (amount_minor >= 0);
+ALTER TABLE payments ADD CONSTRAINT check_refund_amount CHECK (refund_amount_minor >= 0 AND refund_amount_minor <= amount_minor);
+ALTER TABLE payments ADD CONSTRAINT check_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+-- +goose Down
+-- Amounts go back to major units using each row's currency exponent, the
+-- same table as internal/money: no decimals for JPY, KRW and VND, three for
+-- BHD, JOD, KWD and OMR, two otherwise. The old DECIMAL(10, 2) columns round
+-- three-decimal amounts to two places. The old currency list is restored
+-- without checking existing rows, which may be in other currencies.
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_currency;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_refund_amount;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_amount;
+ALTER TABLE payments ALTER COLUMN currency DROP NOT NULL;
+ALTER TABLE payments ALTER COLUMN currency SET DEFAULT 'USD';
+ALTER TABLE payments ALTER COLUMN refund_amount_minor DROP DEFAULT;
+ALTER TABLE payments ALTER COLUMN refund_amount_minor TYPE DECIMAL(10, 2) USING refund_amount_minor / CASE
+    WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1.0
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 1000.0
+    ELSE 100.0
+END;
+ALTER TABLE payments ALTER COLUMN refund_amount_minor SET DEFAULT 0;
+ALTER TABLE payments RENAME COLUMN refund_amount_minor TO refund_amount;
+ALTER TABLE payments ALTER COLUMN amount_minor TYPE DECIMAL(10, 2) USING amount_minor / CASE
+    WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1.0
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 1000.0
+    ELSE 100.0
+END;
+ALTER TABLE payments RENAME COLUMN amount_minor TO amount;
+ALTER TABLE payments ADD CONSTRAINT check_amount CHECK (amount >= 0);
+ALTER TABLE payments ADD CONSTRAINT check_refund_amount CHECK (refund_amount >= 0 AND refund_amount <= amount);
+ALTER TABLE payments ADD CONSTRAINT check_currency CHECK (currency IN ('USD', 'EUR', 'GBP', 'INR')) NOT VALID;
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS check_booking_currency;
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS check_total_amount;
+ALTER TABLE bookings ALTER COLUMN total_amount_minor TYPE DECIMAL(10, 2) USING total_amount_minor / CASE
+    WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1.0
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 1000.0
+    ELSE 100.0
+END;
+ALTER TABLE bookings RENAME COLUMN total_amount_minor TO total_amount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS currency;
+ALTER TABLE bookings ADD CONSTRAINT check_total_amount CHECK (total_amount >= 0);
@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizations (
+    organization_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_organization_creator
+        FOREIGN KEY(created_by)
+        REFERENCES admins(admin_id)
+);
+
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL,
+    admin_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'manager' CHECK (role IN ('owner', 'manager', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, admin_id),
+    CONSTRAINT fk_member_organization
+        FOREIGN KEY(organization_id)
+        REFERENCES organizations(organization_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_member_admin
+        FOREIGN KEY(admin_id)
+        REFERENCES admins(admin_id)
+        ON DELETE CASCADE
+);
+
+-- Events are owned by an organization; venues without one are shared by everybody
+ALTER TABLE events ADD COLUMN organization_id UUID;
+ALTER TABLE venues ADD COLUMN organization_id UUID;
+
+ALTER TABLE events ADD CONSTRAINT fk_event_organization
+    FOREIGN KEY(organization_id) REFERENCES organizations(organization_id);
+ALTER TABLE venues ADD CONSTRAINT fk_venue_organization
+    FOREIGN KEY(organization_id) REFERENCES organizations(organization_id);
+
+-- Backfill: every existing admin gets a personal organization owning their events
+INSERT INTO organizations (name, created_by)
+SELECT a.name || '''s Organization', a.admin_id
+FROM admins a;
+
+INSERT INTO organization_members (organization_id, admin_id, role)
+SELECT o.organization_id, o.created_by, 'owner'
+FROM organizations o;
+
+UPDATE events e
+SET organization_id = o.organization_id
+FROM organizations o
+WHERE o.created_by = e.created_by;
+
+-- A venue only one organization's events use becomes that organization's.
+-- Venues used by several organizations, or by none, stay shared.
+UPDATE venues v
+SET organization_id = owners.organization_id
+FROM (
+    SELECT venue_id, MIN(organization_id::text)::uuid AS organization_id
+    FROM events
+    WHERE organization_id IS NOT NULL
+    GROUP BY venue_id
+    HAVING COUNT(DISTINCT organization_id) = 1
+) owners
+WHERE owners.venue_id = v.venue_id;
+
+CREATE INDEX idx_organization_members_admin ON organization_members(admin_id);
+CREATE INDEX idx_events_organization ON events(organization_id, created_at DESC);
+CREATE INDEX idx_venues_organization ON venues(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_venues_organization;
+DROP INDEX IF EXISTS idx_events_organization;
+ALTER TABLE venues DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE events DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE venues SET layout_config = '{}' WHERE jsonb_typeof(layout_config) <> 'object';
+
+ALTER TABLE venues
+    ADD CONSTRAINT chk_venues_layout_object CHECK (jsonb_typeof(layout_config) = 'object');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE venues DROP CONSTRAINT IF EXISTS chk_venues_layout_object;
+-- +goose StatementEnd
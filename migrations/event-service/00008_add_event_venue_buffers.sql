@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events
+    ADD COLUMN setup_buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (setup_buffer_minutes BETWEEN 0 AND 1440),
+    ADD COLUMN teardown_buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (teardown_buffer_minutes BETWEEN 0 AND 1440);
+
+-- Venue calendar and overlap checks scan a venue's non-cancelled events by time
+CREATE INDEX idx_events_venue_schedule
+ON events(venue_id, start_datetime, end_datetime)
+WHERE status <> 'cancelled';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_venue_schedule;
+ALTER TABLE events
+    DROP COLUMN IF EXISTS teardown_buffer_minutes,
+    DROP COLUMN IF EXISTS setup_buffer_minutes;
+-- +goose StatementEnd
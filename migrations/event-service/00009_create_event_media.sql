@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE event_media (
+    media_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('poster', 'banner', 'gallery')),
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL CHECK (width > 0),
+    height INTEGER NOT NULL CHECK (height > 0),
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_key TEXT NOT NULL,
+    variants JSONB NOT NULL DEFAULT '{}',
+    position INTEGER NOT NULL DEFAULT 0,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_event_media_event
+        FOREIGN KEY(event_id)
+        REFERENCES events(event_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_media_event ON event_media(event_id, kind, position);
+
+-- An event has at most one poster and one banner; galleries hold many images
+CREATE UNIQUE INDEX uq_event_media_single_kind
+ON event_media(event_id, kind)
+WHERE kind IN ('poster', 'banner');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS event_media;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE entity_history (
+    history_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('event', 'venue')),
+    entity_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('baseline', 'created', 'updated', 'cancelled', 'deleted', 'transferred', 'reverted')),
+    organization_id UUID,
+    changed_by UUID,
+    changes JSONB NOT NULL DEFAULT '[]',
+    snapshot JSONB NOT NULL,
+    reverted_from_version INTEGER,
+    -- clock_timestamp() so entries written in one transaction (imports)
+    -- keep distinct, ordered times for audit paging
+    created_at TIMESTAMP DEFAULT clock_timestamp()
+);
+
+-- No foreign keys: history must outlive the rows it describes
+CREATE UNIQUE INDEX uq_entity_history_version ON entity_history(entity_type, entity_id, version);
+CREATE INDEX idx_entity_history_organization ON entity_history(organization_id, created_at DESC) WHERE organization_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS entity_history;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE venues ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE events ADD COLUMN deleted_at TIMESTAMP;
+
+-- The purge job scans for rows whose retention window has passed
+CREATE INDEX idx_venues_deleted_at ON venues(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE entity_history DROP CONSTRAINT entity_history_action_check;
+ALTER TABLE entity_history ADD CONSTRAINT entity_history_action_check
+    CHECK (action IN ('baseline', 'created', 'updated', 'cancelled', 'deleted', 'transferred', 'reverted', 'restored', 'purged'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM entity_history WHERE action IN ('restored', 'purged');
+ALTER TABLE entity_history DROP CONSTRAINT entity_history_action_check;
+ALTER TABLE entity_history ADD CONSTRAINT entity_history_action_check
+    CHECK (action IN ('baseline', 'created', 'updated', 'cancelled', 'deleted', 'transferred', 'reverted'));
+
+DROP INDEX IF EXISTS idx_events_deleted_at;
+DROP INDEX IF EXISTS idx_venues_deleted_at;
+ALTER TABLE events DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE venues DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE venues ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Event times were stored as UTC wall-clock values; keep them as instants
+ALTER TABLE events
+    ALTER COLUMN start_datetime TYPE TIMESTAMPTZ USING start_datetime AT TIME ZONE 'UTC',
+    ALTER COLUMN end_datetime TYPE TIMESTAMPTZ USING end_datetime AT TIME ZONE 'UTC';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events
+    ALTER COLUMN start_datetime TYPE TIMESTAMP USING start_datetime AT TIME ZONE 'UTC',
+    ALTER COLUMN end_datetime TYPE TIMESTAMP USING end_datetime AT TIME ZONE 'UTC';
+
+ALTER TABLE venues DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Prices become integer minor units (cents, paise) in the event's own
+-- currency. Every existing event was charged in INR, which has two decimal
+-- places.
+ALTER TABLE events RENAME COLUMN base_price TO base_price_minor;
+ALTER TABLE events ALTER COLUMN base_price_minor TYPE BIGINT USING ROUND(base_price_minor * 100)::bigint;
+ALTER TABLE events
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR'
+        CONSTRAINT check_event_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Conversion rates for reports that add up amounts in different
+-- currencies: one unit of from_currency buys rate units of to_currency
+-- from effective_date until a newer rate for the pair takes over.
+CREATE TABLE exchange_rates (
+    from_currency CHAR(3) NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+    to_currency CHAR(3) NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+    effective_date DATE NOT NULL,
+    rate DOUBLE PRECISION NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (from_currency, to_currency, effective_date),
+    CONSTRAINT check_exchange_rate_pair CHECK (from_currency <> to_currency)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Prices go back to major units using each event's currency exponent, as
+-- in internal/money. The old DECIMAL(10, 2) column rounds three-decimal
+-- prices to two places.
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE events ALTER COLUMN base_price_minor TYPE DECIMAL(10, 2) USING base_price_minor / CASE
+    WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1.0
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 1000.0
+    ELSE 100.0
+END;
+ALTER TABLE events RENAME COLUMN base_price_minor TO base_price;
+ALTER TABLE events DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
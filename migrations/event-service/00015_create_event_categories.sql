@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A managed two-level taxonomy replaces free-form event types. Slugs are
+-- what search filters and facets on, so they never change once created;
+-- only the display name can be edited.
+CREATE TABLE categories (
+    category_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    parent_id UUID REFERENCES categories(category_id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_category_slug CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    CONSTRAINT check_category_parent CHECK (parent_id <> category_id)
+);
+
+CREATE INDEX idx_categories_parent ON categories(parent_id);
+
+INSERT INTO categories (slug, name) VALUES
+    ('concert', 'Concert'),
+    ('sports', 'Sports'),
+    ('theater', 'Theater'),
+    ('conference', 'Conference'),
+    ('comedy', 'Comedy'),
+    ('festival', 'Festival'),
+    ('other', 'Other');
+
+-- Existing event types become top-level categories under their normalized
+-- spelling, so "Concert" and "concert " end up in the same place.
+INSERT INTO categories (slug, name)
+SELECT DISTINCT slug, initcap(replace(slug, '-', ' '))
+FROM (
+    SELECT trim(both '-' from left(trim(both '-' from lower(regexp_replace(event_type, '[^a-zA-Z0-9]+', '-', 'g'))), 50)) AS slug
+    FROM events
+) normalized
+WHERE slug <> ''
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE events
+    ADD COLUMN category_id UUID REFERENCES categories(category_id) ON DELETE RESTRICT,
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE events e
+SET event_type = c.slug,
+    category_id = c.category_id
+FROM categories c
+WHERE c.slug = COALESCE(NULLIF(trim(both '-' from left(trim(both '-' from lower(regexp_replace(e.event_type, '[^a-zA-Z0-9]+', '-', 'g'))), 50)), ''), 'other');
+
+ALTER TABLE events ALTER COLUMN category_id SET NOT NULL;
+
+CREATE INDEX idx_events_category ON events(category_id);
+CREATE INDEX idx_events_tags ON events USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_tags;
+DROP INDEX IF EXISTS idx_events_category;
+ALTER TABLE events
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS categories;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- An enrollment starts pending (enabled_at IS NULL) and only protects the
+-- login once the admin has proven they can produce a code. last_used_step
+-- is the TOTP time step of the last accepted code, so a code cannot be
+-- replayed within its validity window.
+CREATE TABLE admin_two_factor (
+    admin_id UUID PRIMARY KEY REFERENCES admins(admin_id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE admin_recovery_codes (
+    code_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL REFERENCES admins(admin_id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_admin_recovery_code UNIQUE (admin_id, code_hash)
+);
+
+-- A login challenge is handed out after the password check and exchanged
+-- for tokens once the second factor is verified.
+CREATE TABLE admin_login_challenges (
+    token TEXT PRIMARY KEY,
+    admin_id UUID NOT NULL REFERENCES admins(admin_id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_login_challenges_admin_id ON admin_login_challenges(admin_id);
+CREATE INDEX idx_admin_login_challenges_expires ON admin_login_challenges(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS admin_login_challenges;
+DROP TABLE IF EXISTS admin_recovery_codes;
+DROP TABLE IF EXISTS admin_two_factor;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Changes that search-service has to hear about, written in the same
+-- transaction as the change itself. The relay always sends an event's
+-- current state, so only the newest pending row per event matters; older
+-- ones are marked delivered along with it. There is no foreign key because
+-- deletions must still be delivered after an event is purged.
+CREATE TABLE search_outbox (
+    outbox_id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('upsert', 'delete')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX idx_search_outbox_pending ON search_outbox(event_id, outbox_id) WHERE delivered_at IS NULL;
+CREATE INDEX idx_search_outbox_delivered ON search_outbox(delivered_at) WHERE delivered_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS search_outbox;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- search-service pages through changed events by (updated_at, event_id)
+-- whatever their status, so that deletions and unpublishes reach it too.
+-- This replaces the published-only index that was meant for the same job.
+DROP INDEX IF EXISTS idx_events_search_sync;
+CREATE INDEX idx_events_changes ON events(updated_at, event_id);
+
+-- Purged events have no row left; their history entry stands in for it
+CREATE INDEX idx_entity_history_purged_events ON entity_history(created_at)
+WHERE entity_type = 'event' AND action = 'purged';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_entity_history_purged_events;
+DROP INDEX IF EXISTS idx_events_changes;
+CREATE INDEX idx_events_search_sync ON events(updated_at, event_id) WHERE status = 'published';
+-- +goose StatementEnd
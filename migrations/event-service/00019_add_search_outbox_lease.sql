@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The relay claims a batch by leasing its rows, commits, and only then
+-- calls search-service, so no transaction stays open across the network.
+-- Rows whose lease has not run out are not listed again; if the relay dies
+-- mid-batch, the lease runs out and another pass picks them up.
+ALTER TABLE search_outbox ADD COLUMN leased_until TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE search_outbox DROP COLUMN IF EXISTS leased_until;
+-- +goose StatementEnd
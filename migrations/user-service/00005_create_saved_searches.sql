@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Searches users saved, with the alerts they get for them. search-service
+-- owns what a search means: the criteria are kept as it sent them, and it
+-- keeps its own copy of the searches with alerts on for matching new
+-- events, rebuilt from here whenever it is lost.
+CREATE TABLE saved_searches (
+    saved_search_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    criteria JSONB NOT NULL,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    alerts_enabled BOOLEAN NOT NULL DEFAULT true,
+    last_alerted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_saved_searches_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+-- An alert outlives the search it was for, so it keeps the search's ID and
+-- name rather than referring to it.
+CREATE TABLE search_alerts (
+    alert_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    saved_search_id UUID NOT NULL,
+    saved_search_name VARCHAR(100) NOT NULL,
+    events JSONB NOT NULL,
+    match_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_search_alerts_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_saved_searches_user ON saved_searches(user_id, created_at DESC);
+CREATE INDEX idx_saved_searches_alerting ON saved_searches(saved_search_id) WHERE alerts_enabled;
+CREATE INDEX idx_search_alerts_user ON search_alerts(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS search_alerts;
+DROP TABLE IF EXISTS saved_searches;
+-- +goose StatementEnd
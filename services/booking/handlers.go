@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/bookings"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
 	"github.com/google/uuid"
@@ -95,6 +96,7 @@ func (cfg *APIConfig) CheckAvailability(w http.ResponseWriter, r *http.Request)
 		AvailableSeats: event.AvailableSeats,
 		MaxPerBooking:  event.MaxTicketsPerBooking,
 		BasePrice:      event.BasePrice,
+		Currency:       event.Currency,
 	}
 
 	cfg.Logger.Info("Availability check completed",
@@ -106,6 +108,11 @@ func (cfg *APIConfig) CheckAvailability(w http.ResponseWriter, r *http.Request)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+// majorAmount converts a stored minor-unit amount for a JSON response.
+func majorAmount(minor int64, currency string) float64 {
+	return money.MustLookup(currency).ToMajor(minor)
+}
+
 func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
@@ -150,7 +157,8 @@ func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 			ReservationID:    existingBooking.BookingID,
 			BookingReference: existingBooking.BookingReference,
 			ExpiresAt:        existingBooking.ExpiresAt.Time,
-			TotalAmount:      utils.ParseAmount(existingBooking.TotalAmount),
+			TotalAmount:      majorAmount(existingBooking.TotalAmountMinor, existingBooking.Currency),
+			Currency:         existingBooking.Currency,
 		}
 		utils.RespondWithJSON(w, http.StatusOK, response)
 		return
@@ -194,7 +202,7 @@ func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	totalAmount := event.BasePrice * float64(req.Quantity)
+	totalAmount := event.BasePriceMinor * int64(req.Quantity)
 	bookingRef := utils.GenerateBookingReference()
 
 	var expiresAt time.Time
@@ -215,11 +223,12 @@ func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 		EventID:          req.EventID,
 		BookingReference: bookingRef,
 		Quantity:         req.Quantity,
-		TotalAmount:      fmt.Sprintf("%.2f", totalAmount),
+		TotalAmountMinor: totalAmount,
 		Status:           "pending",
 		PaymentStatus:    "pending",
 		IdempotencyKey:   sql.NullString{String: req.IdempotencyKey, Valid: true},
 		ExpiresAt:        sql.NullTime{Time: expiresAt, Valid: true},
+		Currency:         event.Currency,
 	})
 	if err != nil {
 		cfg.Logger.Error("Failed to create booking", "error", err)
@@ -232,7 +241,8 @@ func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 		UserID:           userID,
 		EventID:          req.EventID,
 		Quantity:         req.Quantity,
-		Amount:           totalAmount,
+		AmountMinor:      totalAmount,
+		Currency:         booking.Currency,
 		BookingID:        booking.BookingID,
 		BookingReference: bookingRef,
 		ExpiresAt:        expiresAt,
@@ -253,7 +263,8 @@ func (cfg *APIConfig) ReserveSeats(w http.ResponseWriter, r *http.Request) {
 		ReservationID:    booking.BookingID,
 		BookingReference: bookingRef,
 		ExpiresAt:        expiresAt,
-		TotalAmount:      totalAmount,
+		TotalAmount:      majorAmount(totalAmount, booking.Currency),
+		Currency:         booking.Currency,
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -310,8 +321,8 @@ func (cfg *APIConfig) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
 		BookingID:            booking.BookingID,
 		UserID:               userID,
 		EventID:              booking.EventID,
-		Amount:               booking.TotalAmount,
-		Currency:             sql.NullString{String: "INR", Valid: true},
+		AmountMinor:          booking.TotalAmountMinor,
+		Currency:             booking.Currency,
 		PaymentMethod:        sql.NullString{String: req.PaymentMethod, Valid: true},
 		PaymentGateway:       sql.NullString{String: "mock_gateway", Valid: true},
 		GatewayTransactionID: sql.NullString{String: gatewayTxnID, Valid: true},
@@ -383,7 +394,8 @@ func (cfg *APIConfig) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
 		Payment: PaymentInfo{
 			TransactionID: gatewayTxnID,
 			Status:        "completed",
-			Amount:        utils.ParseAmount(booking.TotalAmount),
+			Amount:        majorAmount(booking.TotalAmountMinor, booking.Currency),
+			Currency:      booking.Currency,
 		},
 	}
 
@@ -416,7 +428,7 @@ func (cfg *APIConfig) GetBookingDetails(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	event, err := cfg.EventServiceClient.GetEventForBooking(r.Context(), bookingWithPayment.EventID)
+	event, err := cfg.EventServiceClient.GetEventRecord(r.Context(), bookingWithPayment.EventID)
 	if err != nil {
 		cfg.Logger.Error("Failed to get event details", "error", err, "event_id", bookingWithPayment.EventID)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get event details")
@@ -432,7 +444,8 @@ func (cfg *APIConfig) GetBookingDetails(w http.ResponseWriter, r *http.Request)
 			DateTime: time.Now().Add(24 * time.Hour),
 		},
 		Quantity:      bookingWithPayment.Quantity,
-		TotalAmount:   utils.ParseAmount(bookingWithPayment.TotalAmount),
+		TotalAmount:   majorAmount(bookingWithPayment.TotalAmountMinor, bookingWithPayment.Currency),
+		Currency:      bookingWithPayment.Currency,
 		Status:        bookingWithPayment.Status,
 		PaymentStatus: bookingWithPayment.PaymentStatus,
 		BookedAt:      bookingWithPayment.BookedAt.Time,
@@ -485,8 +498,7 @@ func (cfg *APIConfig) CancelBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	originalAmount := utils.ParseAmount(booking.TotalAmount)
-	refundAmount := utils.CalculateRefundAmount(originalAmount, booking.BookedAt.Time, time.Now().Add(48*time.Hour))
+	refundAmount := utils.CalculateRefundAmount(booking.TotalAmountMinor, booking.BookedAt.Time, time.Now().Add(48*time.Hour))
 
 	_, err = cfg.DB.UpdateBookingStatus(r.Context(), cfg.DB_Conn, bookings.UpdateBookingStatusParams{
 		BookingID: bookingID,
@@ -499,7 +511,7 @@ func (cfg *APIConfig) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if booking.Status == "confirmed" {
-		event, err := cfg.EventServiceClient.GetEventForBooking(r.Context(), booking.EventID)
+		event, err := cfg.EventServiceClient.GetEventRecord(r.Context(), booking.EventID)
 		if err != nil {
 			cfg.Logger.Error("Failed to get event for cancellation", "error", err, "event_id", booking.EventID)
 		} else {
@@ -509,6 +521,24 @@ func (cfg *APIConfig) CancelBooking(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if refundAmount > 0 {
+			_, err = cfg.DB.RecordPaymentRefund(r.Context(), cfg.DB_Conn, bookings.RecordPaymentRefundParams{
+				BookingID:         bookingID,
+				RefundAmountMinor: refundAmount,
+			})
+			if err != nil {
+				cfg.Logger.Error("Failed to record refund", "error", err, "booking_id", bookingID)
+			} else {
+				_, err = cfg.DB.UpdateBookingPaymentStatus(r.Context(), cfg.DB_Conn, bookings.UpdateBookingPaymentStatusParams{
+					BookingID:     bookingID,
+					PaymentStatus: "refunded",
+				})
+				if err != nil {
+					cfg.Logger.Error("Failed to update payment status", "error", err, "booking_id", bookingID)
+				}
+			}
+		}
+
 		cfg.ProcessWaitlist(r.Context(), booking.EventID, booking.Quantity)
 	}
 
@@ -523,12 +553,13 @@ func (cfg *APIConfig) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	cfg.Logger.Info("Booking cancelled",
 		"booking_id", bookingID,
 		"user_id", userID,
-		"refund_amount", refundAmount)
+		"refund_amount", money.MustLookup(booking.Currency).Format(refundAmount))
 
 	response := CancellationResponse{
 		Message:      "Booking cancelled successfully",
 		RefundStatus: refundStatus,
-		RefundAmount: refundAmount,
+		RefundAmount: majorAmount(refundAmount, booking.Currency),
+		Currency:     booking.Currency,
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -576,7 +607,8 @@ func (cfg *APIConfig) GetUserBookings(w http.ResponseWriter, r *http.Request) {
 			BookingID:        booking.BookingID,
 			BookingReference: booking.BookingReference,
 			Quantity:         booking.Quantity,
-			TotalAmount:      utils.ParseAmount(booking.TotalAmount),
+			TotalAmount:      majorAmount(booking.TotalAmountMinor, booking.Currency),
+			Currency:         booking.Currency,
 			Status:           booking.Status,
 			PaymentStatus:    booking.PaymentStatus,
 			BookedAt:         booking.BookedAt.Time,
@@ -802,7 +834,8 @@ func (cfg *APIConfig) GetBookingInternal(w http.ResponseWriter, r *http.Request)
 		"event_id":          booking.EventID,
 		"booking_reference": booking.BookingReference,
 		"quantity":          booking.Quantity,
-		"total_amount":      utils.ParseAmount(booking.TotalAmount),
+		"total_amount":      majorAmount(booking.TotalAmountMinor, booking.Currency),
+		"currency":          booking.Currency,
 		"status":            booking.Status,
 		"payment_status":    booking.PaymentStatus,
 		"booked_at":         booking.BookedAt,
@@ -815,6 +848,344 @@ func (cfg *APIConfig) GetBookingInternal(w http.ResponseWriter, r *http.Request)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+func (cfg *APIConfig) GetEventSalesAnalytics(w http.ResponseWriter, r *http.Request) {
+	eventIDStr := r.PathValue("eventId")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID format")
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "day"
+	}
+	if interval != "hour" && interval != "day" {
+		utils.RespondWithError(w, http.StatusBadRequest, "interval must be 'hour' or 'day'")
+		return
+	}
+
+	to := time.Now().UTC()
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to timestamp, expected RFC3339")
+			return
+		}
+	}
+
+	maxWindow := 366 * 24 * time.Hour
+	from := to.Add(-30 * 24 * time.Hour)
+	if interval == "hour" {
+		maxWindow = 14 * 24 * time.Hour
+		from = to.Add(-48 * time.Hour)
+	}
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from timestamp, expected RFC3339")
+			return
+		}
+	}
+
+	if !from.Before(to) {
+		utils.RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	if to.Sub(from) > maxWindow {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Time range too large for %s buckets", interval))
+		return
+	}
+
+	revenue, err := cfg.DB.GetEventRevenueSummary(r.Context(), cfg.DB_Conn, eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get revenue summary", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch sales analytics")
+		return
+	}
+
+	stats, err := cfg.DB.GetEventBookingStats(r.Context(), cfg.DB_Conn, eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get booking stats", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch sales analytics")
+		return
+	}
+
+	waitlist, err := cfg.DB.GetEventWaitlistConversion(r.Context(), cfg.DB_Conn, eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get waitlist conversion", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch sales analytics")
+		return
+	}
+
+	series, err := cfg.DB.GetEventSalesTimeSeries(r.Context(), cfg.DB_Conn, bookings.GetEventSalesTimeSeriesParams{
+		EventID:       eventID,
+		Column2:       interval,
+		CompletedAt:   sql.NullTime{Time: from, Valid: true},
+		CompletedAt_2: sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to get sales time series", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch sales analytics")
+		return
+	}
+
+	timeSeries := make([]SalesTimeBucket, len(series))
+	for i, bucket := range series {
+		timeSeries[i] = SalesTimeBucket{
+			Bucket:         bucket.Bucket,
+			Orders:         bucket.Orders,
+			TicketsSold:    bucket.TicketsSold,
+			GrossRevenue:   majorAmount(bucket.GrossRevenueMinor, revenue.Currency),
+			RefundedAmount: majorAmount(bucket.RefundedAmountMinor, revenue.Currency),
+			NetRevenue:     majorAmount(bucket.GrossRevenueMinor-bucket.RefundedAmountMinor, revenue.Currency),
+		}
+	}
+
+	response := EventSalesAnalyticsResponse{
+		EventID:  eventID,
+		Currency: revenue.Currency,
+		Revenue: SalesRevenueSummary{
+			TotalPayments:     revenue.TotalPayments,
+			GrossRevenue:      majorAmount(revenue.GrossRevenueMinor, revenue.Currency),
+			RefundedAmount:    majorAmount(revenue.RefundedAmountMinor, revenue.Currency),
+			NetRevenue:        majorAmount(revenue.NetRevenueMinor, revenue.Currency),
+			AverageOrderValue: majorAmount(revenue.AverageOrderValueMinor, revenue.Currency),
+		},
+		Bookings: BookingStatusSummary{
+			TotalBookings:     stats.TotalBookings,
+			ConfirmedBookings: stats.ConfirmedBookings,
+			CancelledBookings: stats.CancelledBookings,
+			ExpiredBookings:   stats.ExpiredBookings,
+			PendingBookings:   stats.PendingBookings,
+			TicketsSold:       stats.TicketsSold,
+			TicketsCancelled:  stats.TicketsCancelled,
+			CancellationRate:  utils.PercentOf(stats.CancelledBookings, stats.TotalBookings),
+			ExpiryRate:        utils.PercentOf(stats.ExpiredBookings, stats.TotalBookings),
+		},
+		Waitlist: WaitlistConversionSummary{
+			TotalJoined:    waitlist.TotalJoined,
+			Waiting:        waitlist.Waiting,
+			TotalOffered:   waitlist.TotalOffered,
+			Converted:      waitlist.Converted,
+			Expired:        waitlist.Expired,
+			ConversionRate: utils.PercentOf(waitlist.Converted, waitlist.TotalOffered),
+		},
+		Interval:   interval,
+		From:       from,
+		To:         to,
+		TimeSeries: timeSeries,
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// GetEventBookingCount counts every booking of an event whatever its status,
+// so the event service never purges an event that bookings still refer to.
+func (cfg *APIConfig) GetEventBookingCount(w http.ResponseWriter, r *http.Request) {
+	eventIDStr := r.PathValue("eventId")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID format")
+		return
+	}
+
+	count, err := cfg.DB.CountEventBookings(r.Context(), cfg.DB_Conn, eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count event bookings", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to count bookings")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, EventBookingCountResponse{
+		EventID:  eventID,
+		Bookings: count,
+	})
+}
+
+// GetUserBookedEvents lists the events a user holds confirmed bookings for,
+// most recently booked first. search-service uses it to personalize
+// recommendations.
+func (cfg *APIConfig) GetUserBookedEvents(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("userId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			utils.RespondWithError(w, http.StatusBadRequest, "limit must be between 1 and 200")
+			return
+		}
+	}
+
+	rows, err := cfg.DB.ListUserBookedEvents(r.Context(), cfg.DB_Conn, bookings.ListUserBookedEventsParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list booked events", "error", err, "user_id", userID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list booked events")
+		return
+	}
+
+	events := make([]UserBookedEvent, len(rows))
+	for i, row := range rows {
+		events[i] = UserBookedEvent{
+			EventID:      row.EventID,
+			Tickets:      row.Tickets,
+			LastBookedAt: row.LastBookedAt,
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, UserBookedEventsResponse{
+		UserID: userID,
+		Events: events,
+	})
+}
+
+func (cfg *APIConfig) GetEventAttendees(w http.ResponseWriter, r *http.Request) {
+	eventIDStr := r.PathValue("eventId")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID format")
+		return
+	}
+
+	limit := 500
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 1000 {
+			utils.RespondWithError(w, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+	}
+
+	after := uuid.Nil
+	if afterStr := r.URL.Query().Get("after"); afterStr != "" {
+		after, err = uuid.Parse(afterStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid after cursor")
+			return
+		}
+	}
+
+	counts, err := cfg.DB.CountEventAttendees(r.Context(), cfg.DB_Conn, eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count attendees", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch attendees")
+		return
+	}
+
+	rows, err := cfg.DB.ListEventAttendees(r.Context(), cfg.DB_Conn, bookings.ListEventAttendeesParams{
+		EventID:        eventID,
+		AfterBookingID: after,
+		PageSize:       int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list attendees", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch attendees")
+		return
+	}
+
+	attendees := make([]EventAttendee, len(rows))
+	for i, row := range rows {
+		seats := []string{}
+		if row.Seats != "" {
+			seats = strings.Split(row.Seats, ",")
+		}
+		attendees[i] = EventAttendee{
+			BookingID:        row.BookingID,
+			UserID:           row.UserID,
+			BookingReference: row.BookingReference,
+			Quantity:         row.Quantity,
+			Seats:            seats,
+		}
+		if row.ConfirmedAt.Valid {
+			attendees[i].ConfirmedAt = &row.ConfirmedAt.Time
+		}
+		if row.CheckedInAt.Valid {
+			attendees[i].CheckedInAt = &row.CheckedInAt.Time
+		}
+	}
+
+	response := EventAttendeesResponse{
+		EventID:           eventID,
+		TotalBookings:     counts.TotalBookings,
+		TotalTickets:      counts.TotalTickets,
+		CheckedInBookings: counts.CheckedInBookings,
+		Attendees:         attendees,
+	}
+	if len(rows) == limit {
+		response.NextCursor = &rows[len(rows)-1].BookingID
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) CheckInAttendee(w http.ResponseWriter, r *http.Request) {
+	eventIDStr := r.PathValue("eventId")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID format")
+		return
+	}
+
+	var req CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	reference := strings.ToUpper(strings.TrimSpace(req.BookingReference))
+	if reference == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "booking_reference is required")
+		return
+	}
+
+	booking, err := cfg.DB.GetBookingByReference(r.Context(), cfg.DB_Conn, reference)
+	if err != nil || booking.EventID != eventID {
+		utils.RespondWithError(w, http.StatusNotFound, "Booking not found for this event")
+		return
+	}
+
+	if booking.Status != "confirmed" {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Booking is %s and cannot be checked in", booking.Status))
+		return
+	}
+	if booking.CheckedInAt.Valid {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Booking already checked in at %s", booking.CheckedInAt.Time.Format(time.RFC3339)))
+		return
+	}
+
+	checkedIn, err := cfg.DB.CheckInBooking(r.Context(), cfg.DB_Conn, booking.BookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusConflict, "Booking already checked in")
+			return
+		}
+		cfg.Logger.Error("Failed to check in booking", "error", err, "booking_id", booking.BookingID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to check in booking")
+		return
+	}
+
+	cfg.Logger.Info("Attendee checked in",
+		"booking_id", checkedIn.BookingID,
+		"event_id", eventID,
+		"quantity", checkedIn.Quantity)
+
+	utils.RespondWithJSON(w, http.StatusOK, CheckInResponse{
+		BookingID:        checkedIn.BookingID,
+		UserID:           checkedIn.UserID,
+		BookingReference: checkedIn.BookingReference,
+		Quantity:         checkedIn.Quantity,
+		CheckedInAt:      checkedIn.CheckedInAt.Time,
+	})
+}
+
 func (cfg *APIConfig) ExpireReservations(w http.ResponseWriter, r *http.Request) {
 	expiredBookings, err := cfg.DB.GetExpiredBookings(r.Context(), cfg.DB_Conn, 100)
 	if err != nil {
@@ -834,7 +1205,7 @@ func (cfg *APIConfig) ExpireReservations(w http.ResponseWriter, r *http.Request)
 			continue
 		}
 
-		event, err := cfg.EventServiceClient.GetEventForBooking(r.Context(), booking.EventID)
+		event, err := cfg.EventServiceClient.GetEventRecord(r.Context(), booking.EventID)
 		if err != nil {
 			cfg.Logger.Error("Failed to get event for seat return", "error", err, "event_id", booking.EventID)
 		} else {
@@ -886,7 +1257,7 @@ func (cfg *APIConfig) ForceExpireAll(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		event, err := cfg.EventServiceClient.GetEventForBooking(r.Context(), booking.EventID)
+		event, err := cfg.EventServiceClient.GetEventRecord(r.Context(), booking.EventID)
 		if err != nil {
 			cfg.Logger.Error("Failed to get event for seat return in force expiry", "error", err, "event_id", booking.EventID)
 		} else {
@@ -961,7 +1332,7 @@ func (cfg *APIConfig) ManualExpireReservation(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	event, err := cfg.EventServiceClient.GetEventForBooking(r.Context(), booking.EventID)
+	event, err := cfg.EventServiceClient.GetEventRecord(r.Context(), booking.EventID)
 	if err != nil {
 		cfg.Logger.Error("Failed to get event for manual seat return", "error", err, "event_id", booking.EventID)
 	} else {
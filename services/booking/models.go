@@ -30,6 +30,7 @@ type CheckAvailabilityResponse struct {
 	AvailableSeats int32   `json:"available_seats"`
 	MaxPerBooking  int32   `json:"max_per_booking"`
 	BasePrice      float64 `json:"base_price"`
+	Currency       string  `json:"currency,omitempty"`
 }
 
 type ReservationRequest struct {
@@ -43,6 +44,7 @@ type ReservationResponse struct {
 	BookingReference string    `json:"booking_reference"`
 	ExpiresAt        time.Time `json:"expires_at"`
 	TotalAmount      float64   `json:"total_amount"`
+	Currency         string    `json:"currency"`
 }
 
 type ConfirmationRequest struct {
@@ -55,6 +57,7 @@ type PaymentInfo struct {
 	TransactionID string  `json:"transaction_id"`
 	Status        string  `json:"status"`
 	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
 }
 
 type ConfirmationResponse struct {
@@ -71,6 +74,7 @@ type BookingDetailsResponse struct {
 	Event            EventInfo  `json:"event"`
 	Quantity         int32      `json:"quantity"`
 	TotalAmount      float64    `json:"total_amount"`
+	Currency         string     `json:"currency"`
 	Status           string     `json:"status"`
 	PaymentStatus    string     `json:"payment_status"`
 	TicketURL        string     `json:"ticket_url,omitempty"`
@@ -88,6 +92,7 @@ type CancellationResponse struct {
 	Message      string  `json:"message"`
 	RefundStatus string  `json:"refund_status"`
 	RefundAmount float64 `json:"refund_amount"`
+	Currency     string  `json:"currency"`
 }
 
 type JoinWaitlistRequest struct {
@@ -116,14 +121,33 @@ type LeaveWaitlistRequest struct {
 	EventID uuid.UUID `json:"event_id"`
 }
 
+type EventBookingCountResponse struct {
+	EventID  uuid.UUID `json:"event_id"`
+	Bookings int64     `json:"bookings"`
+}
+
+type UserBookedEvent struct {
+	EventID      uuid.UUID `json:"event_id"`
+	Tickets      int64     `json:"tickets"`
+	LastBookedAt time.Time `json:"last_booked_at"`
+}
+
+type UserBookedEventsResponse struct {
+	UserID uuid.UUID         `json:"user_id"`
+	Events []UserBookedEvent `json:"events"`
+}
+
 type EventServiceEvent struct {
-	EventID              uuid.UUID `json:"event_id"`
-	AvailableSeats       int32     `json:"available_seats"`
-	MaxTicketsPerBooking int32     `json:"max_tickets_per_booking"`
-	BasePrice            float64   `json:"base_price"`
-	Version              int32     `json:"version"`
-	Status               string    `json:"status"`
-	Name                 string    `json:"name"`
+	EventID              uuid.UUID  `json:"event_id"`
+	AvailableSeats       int32      `json:"available_seats"`
+	MaxTicketsPerBooking int32      `json:"max_tickets_per_booking"`
+	BasePrice            float64    `json:"base_price"`
+	BasePriceMinor       int64      `json:"base_price_minor"`
+	Currency             string     `json:"currency"`
+	Version              int32      `json:"version"`
+	Status               string     `json:"status"`
+	Name                 string     `json:"name"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty"`
 }
 
 type UserServiceUser struct {
@@ -136,7 +160,8 @@ type ReservationData struct {
 	UserID           uuid.UUID `json:"user_id"`
 	EventID          uuid.UUID `json:"event_id"`
 	Quantity         int32     `json:"quantity"`
-	Amount           float64   `json:"amount"`
+	AmountMinor      int64     `json:"amount_minor"`
+	Currency         string    `json:"currency"`
 	BookingID        uuid.UUID `json:"booking_id"`
 	BookingReference string    `json:"booking_reference"`
 	ExpiresAt        time.Time `json:"expires_at"`
@@ -153,3 +178,84 @@ type UpdateAvailabilityResponse struct {
 	Status         string    `json:"status"`
 	Version        int32     `json:"version"`
 }
+
+type SalesRevenueSummary struct {
+	TotalPayments     int64   `json:"total_payments"`
+	GrossRevenue      float64 `json:"gross_revenue"`
+	RefundedAmount    float64 `json:"refunded_amount"`
+	NetRevenue        float64 `json:"net_revenue"`
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+type BookingStatusSummary struct {
+	TotalBookings     int64   `json:"total_bookings"`
+	ConfirmedBookings int64   `json:"confirmed_bookings"`
+	CancelledBookings int64   `json:"cancelled_bookings"`
+	ExpiredBookings   int64   `json:"expired_bookings"`
+	PendingBookings   int64   `json:"pending_bookings"`
+	TicketsSold       int64   `json:"tickets_sold"`
+	TicketsCancelled  int64   `json:"tickets_cancelled"`
+	CancellationRate  float64 `json:"cancellation_rate"`
+	ExpiryRate        float64 `json:"expiry_rate"`
+}
+
+type WaitlistConversionSummary struct {
+	TotalJoined    int64   `json:"total_joined"`
+	Waiting        int64   `json:"waiting"`
+	TotalOffered   int64   `json:"total_offered"`
+	Converted      int64   `json:"converted"`
+	Expired        int64   `json:"expired"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+type SalesTimeBucket struct {
+	Bucket         time.Time `json:"bucket"`
+	Orders         int64     `json:"orders"`
+	TicketsSold    int64     `json:"tickets_sold"`
+	GrossRevenue   float64   `json:"gross_revenue"`
+	RefundedAmount float64   `json:"refunded_amount"`
+	NetRevenue     float64   `json:"net_revenue"`
+}
+
+type EventSalesAnalyticsResponse struct {
+	EventID    uuid.UUID                 `json:"event_id"`
+	Currency   string                    `json:"currency,omitempty"`
+	Revenue    SalesRevenueSummary       `json:"revenue"`
+	Bookings   BookingStatusSummary      `json:"bookings"`
+	Waitlist   WaitlistConversionSummary `json:"waitlist"`
+	Interval   string                    `json:"interval"`
+	From       time.Time                 `json:"from"`
+	To         time.Time                 `json:"to"`
+	TimeSeries []SalesTimeBucket         `json:"time_series"`
+}
+
+type EventAttendee struct {
+	BookingID        uuid.UUID  `json:"booking_id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	BookingReference string     `json:"booking_reference"`
+	Quantity         int32      `json:"quantity"`
+	Seats            []string   `json:"seats"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CheckedInAt      *time.Time `json:"checked_in_at,omitempty"`
+}
+
+type EventAttendeesResponse struct {
+	EventID           uuid.UUID       `json:"event_id"`
+	TotalBookings     int64           `json:"total_bookings"`
+	TotalTickets      int64           `json:"total_tickets"`
+	CheckedInBookings int64           `json:"checked_in_bookings"`
+	Attendees         []EventAttendee `json:"attendees"`
+	NextCursor        *uuid.UUID      `json:"next_cursor,omitempty"`
+}
+
+type CheckInRequest struct {
+	BookingReference string `json:"booking_reference"`
+}
+
+type CheckInResponse struct {
+	BookingID        uuid.UUID `json:"booking_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	BookingReference string    `json:"booking_reference"`
+	Quantity         int32     `json:"quantity"`
+	CheckedInAt      time.Time `json:"checked_in_at"`
+}
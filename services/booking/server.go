@@ -38,6 +38,11 @@ func SetupRoutes(config *APIConfig) *http.ServeMux {
 
 	internalAuth := auth.RequireInternalAuth(config.Config.InternalAPIKey)
 	mux.HandleFunc("GET /internal/bookings/{id}", internalAuth(config.GetBookingInternal))
+	mux.HandleFunc("GET /internal/bookings/events/{eventId}/analytics", internalAuth(config.GetEventSalesAnalytics))
+	mux.HandleFunc("GET /internal/bookings/events/{eventId}/attendees", internalAuth(config.GetEventAttendees))
+	mux.HandleFunc("GET /internal/bookings/events/{eventId}/count", internalAuth(config.GetEventBookingCount))
+	mux.HandleFunc("POST /internal/bookings/events/{eventId}/check-in", internalAuth(config.CheckInAttendee))
+	mux.HandleFunc("GET /internal/bookings/users/{userId}/events", internalAuth(config.GetUserBookedEvents))
 	mux.HandleFunc("POST /internal/bookings/expire-reservations", internalAuth(config.ExpireReservations))
 	mux.HandleFunc("POST /internal/bookings/force-expire-all", internalAuth(config.ForceExpireAll))
 
@@ -133,7 +138,7 @@ func (cfg *APIConfig) processExpiredReservations() {
 			continue
 		}
 
-		event, err := cfg.EventServiceClient.GetEventForBooking(ctx, booking.EventID)
+		event, err := cfg.EventServiceClient.GetEventRecord(ctx, booking.EventID)
 		if err != nil {
 			cfg.Logger.Error("Failed to get event for seat return in background worker", "error", err, "event_id", booking.EventID)
 		} else {
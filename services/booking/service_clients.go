@@ -109,6 +109,38 @@ func (c *EventServiceClient) GetEventForBooking(ctx context.Context, eventID uui
 	return &event, nil
 }
 
+// GetEventRecord looks an event up regardless of its status, deleted events
+// included, for work on bookings that already exist.
+func (c *EventServiceClient) GetEventRecord(ctx context.Context, eventID uuid.UUID) (*EventServiceEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/internal/events/%s?include_deleted=true", c.BaseURL, eventID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("event service returned status %d", resp.StatusCode)
+	}
+
+	var event EventServiceEvent
+	if err := json.NewDecoder(resp.Body).Decode(&event); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &event, nil
+}
+
 
 
 
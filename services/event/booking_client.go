@@ -0,0 +1,209 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+type BookingServiceClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	Logger     *logger.Logger
+}
+
+type SalesAnalyticsQuery struct {
+	Interval string
+	From     *time.Time
+	To       *time.Time
+}
+
+func NewBookingServiceClient(baseURL, apiKey string, logger *logger.Logger) *BookingServiceClient {
+	return &BookingServiceClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		APIKey: apiKey,
+		Logger: logger,
+	}
+}
+
+func (c *BookingServiceClient) GetEventSalesAnalytics(ctx context.Context, eventID uuid.UUID, query SalesAnalyticsQuery) (*BookingSalesAnalytics, error) {
+	params := url.Values{}
+	if query.Interval != "" {
+		params.Set("interval", query.Interval)
+	}
+	if query.From != nil {
+		params.Set("from", query.From.UTC().Format(time.RFC3339))
+	}
+	if query.To != nil {
+		params.Set("to", query.To.UTC().Format(time.RFC3339))
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/bookings/events/%s/analytics", c.BaseURL, eventID)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.Logger.Error("Failed to fetch sales analytics from booking service", "error", err, "event_id", eventID)
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		var errorResp struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errorResp)
+		return nil, fmt.Errorf("invalid analytics request: %s", errorResp.Error)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		c.Logger.Error("Booking service returned error", "status", resp.StatusCode, "event_id", eventID)
+		return nil, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var analytics BookingSalesAnalytics
+	if err := json.NewDecoder(resp.Body).Decode(&analytics); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &analytics, nil
+}
+
+func (c *BookingServiceClient) GetEventAttendees(ctx context.Context, eventID, after uuid.UUID, limit int) (*BookingAttendeePage, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	if after != uuid.Nil {
+		params.Set("after", after.String())
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/bookings/events/%s/attendees?%s", c.BaseURL, eventID, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.Logger.Error("Failed to fetch attendees from booking service", "error", err, "event_id", eventID)
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.Logger.Error("Booking service returned error", "status", resp.StatusCode, "event_id", eventID)
+		return nil, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var page BookingAttendeePage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &page, nil
+}
+
+func (c *BookingServiceClient) CheckInAttendee(ctx context.Context, eventID uuid.UUID, bookingReference string) (*CheckInResponse, error) {
+	jsonBody, err := json.Marshal(CheckInRequest{BookingReference: bookingReference})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/bookings/events/%s/check-in", c.BaseURL, eventID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.Logger.Error("Failed to check in attendee via booking service", "error", err, "event_id", eventID)
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResp struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errorResp)
+
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, fmt.Errorf("invalid check-in request: %s", errorResp.Error)
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("booking not found: %s", errorResp.Error)
+		case http.StatusConflict:
+			return nil, fmt.Errorf("check-in conflict: %s", errorResp.Error)
+		}
+
+		c.Logger.Error("Booking service returned error", "status", resp.StatusCode, "event_id", eventID)
+		return nil, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var result CheckInResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CountEventBookings reports how many bookings of any status refer to an
+// event.
+func (c *BookingServiceClient) CountEventBookings(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	endpoint := fmt.Sprintf("%s/internal/bookings/events/%s/count", c.BaseURL, eventID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.Logger.Error("Failed to count bookings via booking service", "error", err, "event_id", eventID)
+		return 0, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.Logger.Error("Booking service returned error", "status", resp.StatusCode, "event_id", eventID)
+		return 0, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Bookings int64 `json:"bookings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Bookings, nil
+}
@@ -0,0 +1,397 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	maxSlugLength         = 50
+	maxCategoryNameLength = 100
+	maxEventTags          = 20
+)
+
+var errUnknownCategory = errors.New("unknown category")
+
+// normalizeSlug turns free text such as "Live Music!" into the slug form
+// categories and tags are stored in ("live-music").
+func normalizeSlug(value string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// normalizeTags slugifies and de-duplicates free tags, keeping the order
+// they were given in. The result is never nil so it can be stored as-is.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		slug := normalizeSlug(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		if len(slug) > maxSlugLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, maxSlugLength)
+		}
+		seen[slug] = true
+		normalized = append(normalized, slug)
+	}
+	if len(normalized) > maxEventTags {
+		return nil, fmt.Errorf("an event can have at most %d tags", maxEventTags)
+	}
+	return normalized, nil
+}
+
+// resolveCategory finds the category an event type names. Event types are
+// matched by slug, so "Concert" and "concert" are the same category.
+func resolveCategory(ctx context.Context, q events.Querier, eventType string) (events.Category, error) {
+	slug := normalizeSlug(eventType)
+	if slug == "" {
+		return events.Category{}, fmt.Errorf("%w: event_type is required", errUnknownCategory)
+	}
+	category, err := q.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return events.Category{}, fmt.Errorf("%w %q; see GET /api/v1/categories", errUnknownCategory, slug)
+		}
+		return events.Category{}, err
+	}
+	return category, nil
+}
+
+// eventCategory resolves the category an admin filed an event under,
+// answering 400 for one that is not in the taxonomy.
+func (cfg *APIConfig) eventCategory(w http.ResponseWriter, r *http.Request, eventType string) (events.Category, bool) {
+	category, err := resolveCategory(r.Context(), cfg.DB, eventType)
+	if err != nil {
+		if errors.Is(err, errUnknownCategory) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return category, false
+		}
+		cfg.Logger.Error("Failed to resolve category", "error", err, "event_type", eventType)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to resolve category")
+		return category, false
+	}
+	return category, true
+}
+
+// taxonomy is the whole category tree, which is small enough to load in
+// one query whenever event responses need category paths.
+type taxonomy struct {
+	bySlug map[string]events.Category
+	byID   map[uuid.UUID]events.Category
+}
+
+func (cfg *APIConfig) loadTaxonomy(ctx context.Context) (*taxonomy, error) {
+	categories, err := cfg.DB.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := &taxonomy{
+		bySlug: make(map[string]events.Category, len(categories)),
+		byID:   make(map[uuid.UUID]events.Category, len(categories)),
+	}
+	for _, category := range categories {
+		t.bySlug[category.Slug] = category
+		t.byID[category.CategoryID] = category
+	}
+	return t, nil
+}
+
+// path lists a category's slug after its parent's, so searching for a
+// parent also finds events filed under its subcategories.
+func (t *taxonomy) path(slug string) []string {
+	category, ok := t.bySlug[slug]
+	if !ok {
+		return []string{slug}
+	}
+	if parent, ok := t.byID[category.ParentID.UUID]; ok && category.ParentID.Valid {
+		return []string{parent.Slug, category.Slug}
+	}
+	return []string{category.Slug}
+}
+
+// loadEventCategories returns the category path of one event. Like images,
+// paths are decoration: on failure the event's own type stands in.
+func (cfg *APIConfig) loadEventCategories(ctx context.Context, eventType string) []string {
+	t, err := cfg.loadTaxonomy(ctx)
+	if err != nil {
+		cfg.Logger.Warn("Failed to load categories", "error", err)
+		return []string{eventType}
+	}
+	return t.path(eventType)
+}
+
+// attachEventCategories fills in the category paths of the given events
+// with one query.
+func (cfg *APIConfig) attachEventCategories(ctx context.Context, responses []EventResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	t, err := cfg.loadTaxonomy(ctx)
+	if err != nil {
+		cfg.Logger.Warn("Failed to load categories", "error", err, "events", len(responses))
+		for i := range responses {
+			responses[i].Categories = []string{responses[i].EventType}
+		}
+		return
+	}
+	for i := range responses {
+		responses[i].Categories = t.path(responses[i].EventType)
+	}
+}
+
+// requireSuperAdmin guards the taxonomy, which every organization shares.
+func (cfg *APIConfig) requireSuperAdmin(w http.ResponseWriter, r *http.Request, adminID uuid.UUID) bool {
+	admin, err := cfg.DB.GetAdminByID(r.Context(), adminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusUnauthorized, "Admin not found")
+			return false
+		}
+		cfg.Logger.Error("Failed to load admin", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify admin role")
+		return false
+	}
+	if admin.Role.String != "super_admin" {
+		utils.RespondWithError(w, http.StatusForbidden, "This action requires the super_admin role")
+		return false
+	}
+	return true
+}
+
+// ListCategories returns the taxonomy as a tree: top-level categories with
+// their subcategories, both sorted by name.
+func (cfg *APIConfig) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := cfg.DB.ListCategories(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to list categories", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list categories")
+		return
+	}
+
+	children := make(map[uuid.UUID][]CategoryResponse)
+	for _, category := range categories {
+		if category.ParentID.Valid {
+			children[category.ParentID.UUID] = append(children[category.ParentID.UUID], categoryToResponse(category, ""))
+		}
+	}
+
+	response := CategoryListResponse{Categories: []CategoryResponse{}}
+	for _, category := range categories {
+		if category.ParentID.Valid {
+			continue
+		}
+		node := categoryToResponse(category, "")
+		node.Subcategories = children[category.CategoryID]
+		for i := range node.Subcategories {
+			node.Subcategories[i].Parent = category.Slug
+		}
+		response.Categories = append(response.Categories, node)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+	if !cfg.requireSuperAdmin(w, r, adminID) {
+		return
+	}
+
+	var requestBody CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	name := strings.TrimSpace(requestBody.Name)
+	if name == "" || len(name) > maxCategoryNameLength {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("name is required and must be at most %d characters", maxCategoryNameLength))
+		return
+	}
+	slug := requestBody.Slug
+	if slug == "" {
+		slug = name
+	}
+	slug = normalizeSlug(slug)
+	if slug == "" || len(slug) > maxSlugLength {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("slug must contain letters or digits and be at most %d characters", maxSlugLength))
+		return
+	}
+
+	// The taxonomy is two levels deep: a subcategory's parent must be a
+	// top-level category.
+	var parentID uuid.NullUUID
+	parentSlug := ""
+	if requestBody.Parent != "" {
+		parent, err := cfg.DB.GetCategoryBySlug(r.Context(), normalizeSlug(requestBody.Parent))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				utils.RespondWithError(w, http.StatusBadRequest, "Parent category not found")
+				return
+			}
+			cfg.Logger.Error("Failed to get parent category", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create category")
+			return
+		}
+		if parent.ParentID.Valid {
+			utils.RespondWithError(w, http.StatusBadRequest, "Subcategories cannot have subcategories of their own")
+			return
+		}
+		parentID = uuid.NullUUID{UUID: parent.CategoryID, Valid: true}
+		parentSlug = parent.Slug
+	}
+
+	category, err := cfg.DB.CreateCategory(r.Context(), events.CreateCategoryParams{
+		Slug:     slug,
+		Name:     name,
+		ParentID: parentID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
+			utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Category %q already exists", slug))
+			return
+		}
+		cfg.Logger.Error("Failed to create category", "error", err, "slug", slug)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create category")
+		return
+	}
+
+	cfg.Logger.Info("Category created", "slug", category.Slug, "parent", parentSlug, "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusCreated, categoryToResponse(category, parentSlug))
+}
+
+// UpdateCategory renames a category. Slugs are what events and the search
+// index refer to, so they cannot change.
+func (cfg *APIConfig) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+	if !cfg.requireSuperAdmin(w, r, adminID) {
+		return
+	}
+
+	var requestBody UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	name := strings.TrimSpace(requestBody.Name)
+	if name == "" || len(name) > maxCategoryNameLength {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("name is required and must be at most %d characters", maxCategoryNameLength))
+		return
+	}
+
+	category, err := cfg.DB.UpdateCategory(r.Context(), events.UpdateCategoryParams{
+		Slug: r.PathValue("slug"),
+		Name: name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		cfg.Logger.Error("Failed to update category", "error", err, "slug", r.PathValue("slug"))
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update category")
+		return
+	}
+
+	parentSlug := ""
+	if category.ParentID.Valid {
+		t, err := cfg.loadTaxonomy(r.Context())
+		if err == nil {
+			parentSlug = t.byID[category.ParentID.UUID].Slug
+		}
+	}
+
+	cfg.Logger.Info("Category renamed", "slug", category.Slug, "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, categoryToResponse(category, parentSlug))
+}
+
+// DeleteCategory removes a category nothing refers to. Events, including
+// deleted ones still in the trash, and subcategories keep it in place.
+func (cfg *APIConfig) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+	if !cfg.requireSuperAdmin(w, r, adminID) {
+		return
+	}
+
+	category, err := cfg.DB.GetCategoryBySlug(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get category", "error", err, "slug", r.PathValue("slug"))
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete category")
+		return
+	}
+
+	usage, err := cfg.DB.CountCategoryUsage(r.Context(), category.CategoryID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count category usage", "error", err, "slug", category.Slug)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete category")
+		return
+	}
+	if usage.EventCount > 0 || usage.SubcategoryCount > 0 {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Category is used by %d events and %d subcategories", usage.EventCount, usage.SubcategoryCount))
+		return
+	}
+
+	// An event filed under the category between the count and the delete
+	// still trips the foreign key.
+	if _, err := cfg.DB.DeleteCategory(r.Context(), category.CategoryID); err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			utils.RespondWithError(w, http.StatusConflict, "Category is in use")
+			return
+		}
+		cfg.Logger.Error("Failed to delete category", "error", err, "slug", category.Slug)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete category")
+		return
+	}
+
+	cfg.Logger.Info("Category deleted", "slug", category.Slug, "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, SuccessResponse{Message: fmt.Sprintf("Category %q deleted", category.Slug)})
+}
+
+func categoryToResponse(category events.Category, parent string) CategoryResponse {
+	return CategoryResponse{
+		Slug:      category.Slug,
+		Name:      category.Name,
+		Parent:    parent,
+		CreatedAt: category.CreatedAt.Time,
+		UpdatedAt: category.UpdatedAt.Time,
+	}
+}
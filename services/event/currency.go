@@ -0,0 +1,192 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const maxExchangeRatesPerRequest = 200
+
+var (
+	errCurrencyLocked = errors.New("currency cannot change once an event has bookings")
+	errNoExchangeRate = errors.New("no exchange rate")
+)
+
+// eventPrice converts a stored minor-unit amount for a JSON response.
+func eventPrice(minor int64, currency string) float64 {
+	return money.MustLookup(currency).ToMajor(minor)
+}
+
+// requestedCurrency resolves the currency an admin priced an event in,
+// falling back to the service default.
+func (cfg *APIConfig) requestedCurrency(code string) (money.Currency, error) {
+	if strings.TrimSpace(code) == "" {
+		code = cfg.Config.DefaultCurrency
+	}
+	return money.Lookup(code)
+}
+
+// checkCurrencyChange keeps every booking and payment of an event in one
+// currency: an event can only be re-priced in another currency before
+// anyone has booked it, including bookings that were later cancelled.
+func (cfg *APIConfig) checkCurrencyChange(ctx context.Context, eventID uuid.UUID, seatsSold int32) error {
+	if seatsSold > 0 {
+		return errCurrencyLocked
+	}
+	if cfg.BookingClient == nil {
+		return nil
+	}
+	count, err := cfg.BookingClient.CountEventBookings(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to check event bookings: %w", err)
+	}
+	if count > 0 {
+		return errCurrencyLocked
+	}
+	return nil
+}
+
+func respondCurrencyChangeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errCurrencyLocked) {
+		utils.RespondWithError(w, http.StatusConflict, "Currency cannot change once the event has bookings")
+		return
+	}
+	utils.RespondWithError(w, http.StatusBadGateway, "Could not verify event bookings")
+}
+
+// convertAmount converts minor units between currencies with the latest
+// rate in effect on the given day.
+func (cfg *APIConfig) convertAmount(ctx context.Context, minor int64, from, to money.Currency, on time.Time) (int64, error) {
+	if from.Code == to.Code {
+		return minor, nil
+	}
+	rate, err := cfg.DB.GetExchangeRate(ctx, events.GetExchangeRateParams{
+		FromCurrency: from.Code,
+		ToCurrency:   to.Code,
+		OnDate:       on,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoExchangeRate
+		}
+		return 0, err
+	}
+	return int64(math.Round(from.ToMajor(minor) * rate * math.Pow10(to.Exponent))), nil
+}
+
+func (cfg *APIConfig) ListExchangeRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := cfg.DB.ListLatestExchangeRates(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to list exchange rates", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list exchange rates")
+		return
+	}
+
+	response := ExchangeRatesResponse{Rates: make([]ExchangeRateResponse, len(rates))}
+	for i, rate := range rates {
+		response.Rates[i] = exchangeRateToResponse(rate)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// PutExchangeRates records reporting rates. A rate for a pair and day that
+// already exists is overwritten, so a daily feed can be replayed safely.
+func (cfg *APIConfig) PutExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var requestBody ExchangeRatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if len(requestBody.Rates) == 0 || len(requestBody.Rates) > maxExchangeRatesPerRequest {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates must contain between 1 and %d entries", maxExchangeRatesPerRequest))
+		return
+	}
+
+	params := make([]events.UpsertExchangeRateParams, len(requestBody.Rates))
+	today := time.Now().UTC().Format(time.DateOnly)
+	for i, rate := range requestBody.Rates {
+		from, err := money.Lookup(rate.FromCurrency)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates[%d]: %v", i, err))
+			return
+		}
+		to, err := money.Lookup(rate.ToCurrency)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates[%d]: %v", i, err))
+			return
+		}
+		if from.Code == to.Code {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates[%d]: from_currency and to_currency must differ", i))
+			return
+		}
+		if !(rate.Rate > 0) || math.IsInf(rate.Rate, 0) {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates[%d]: rate must be greater than 0", i))
+			return
+		}
+		if rate.EffectiveDate == "" {
+			rate.EffectiveDate = today
+		}
+		effective, err := time.Parse(time.DateOnly, rate.EffectiveDate)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("rates[%d]: effective_date must be YYYY-MM-DD", i))
+			return
+		}
+		params[i] = events.UpsertExchangeRateParams{
+			FromCurrency:  from.Code,
+			ToCurrency:    to.Code,
+			EffectiveDate: effective,
+			Rate:          rate.Rate,
+		}
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save exchange rates")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	response := ExchangeRatesResponse{Rates: make([]ExchangeRateResponse, len(params))}
+	for i, p := range params {
+		rate, err := qtx.UpsertExchangeRate(r.Context(), p)
+		if err != nil {
+			cfg.Logger.Error("Failed to save exchange rate", "error", err, "from", p.FromCurrency, "to", p.ToCurrency)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save exchange rates")
+			return
+		}
+		response.Rates[i] = exchangeRateToResponse(rate)
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit exchange rates", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save exchange rates")
+		return
+	}
+
+	cfg.Logger.Info("Exchange rates updated", "count", len(params))
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func exchangeRateToResponse(rate events.ExchangeRate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		FromCurrency:  rate.FromCurrency,
+		ToCurrency:    rate.ToCurrency,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate.Format(time.DateOnly),
+		UpdatedAt:     rate.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,262 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// fakeDB is a database/sql driver that answers sqlc queries with test
+// functions, keyed by the query's name. Anything else fails, so a test
+// states every query its path runs.
+type fakeDB struct {
+	mu      sync.Mutex
+	queries map[string]fakeQuery
+	// log lists the queries run in order, with begin, commit and rollback.
+	log []string
+}
+
+// fakeQuery answers one run of a query: rows to return, or rows affected
+// for statements.
+type fakeQuery func(args []driver.Value) (fakeResult, error)
+
+type fakeResult struct {
+	rows     [][]driver.Value
+	affected int64
+}
+
+// newFakeDB returns a connection pool backed by a fresh fakeDB.
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{queries: map[string]fakeQuery{}}
+	db := sql.OpenDB(fakeConnector{fake})
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+// on answers the named query with answer.
+func (f *fakeDB) on(name string, answer fakeQuery) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries[name] = answer
+}
+
+// returns answers the named query with the given rows every time. Each row
+// is a sqlc row struct, whose fields are in column order.
+func (f *fakeDB) returns(name string, rows ...any) {
+	result := fakeResult{affected: int64(len(rows))}
+	for _, row := range rows {
+		result.rows = append(result.rows, fakeRow(row))
+	}
+	f.on(name, func([]driver.Value) (fakeResult, error) { return result, nil })
+}
+
+// ran returns the log entries, queries and transaction steps alike.
+func (f *fakeDB) ran() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.log...)
+}
+
+// count returns how many times the named query ran.
+func (f *fakeDB) count(name string) int {
+	n := 0
+	for _, entry := range f.ran() {
+		if entry == name {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakeDB) run(query string, args []driver.NamedValue) (result fakeResult, err error) {
+	name := fakeQueryName(query)
+	// A failing answer would otherwise leave database/sql holding the
+	// connection, and the test hanging instead of failing.
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("fake db: answer to %s panicked: %v", name, p)
+		}
+	}()
+	f.mu.Lock()
+	f.log = append(f.log, name)
+	answer, ok := f.queries[name]
+	f.mu.Unlock()
+	if !ok {
+		return fakeResult{}, fmt.Errorf("fake db: unexpected query %s", name)
+	}
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return answer(values)
+}
+
+func (f *fakeDB) record(entry string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.log = append(f.log, entry)
+}
+
+// fakeQueryName reads the name sqlc puts on the first line of a query, or
+// returns the query itself when it has none.
+func fakeQueryName(query string) string {
+	query = strings.TrimSpace(query)
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok {
+			return name
+		}
+	}
+	return query
+}
+
+// fakeRow turns a sqlc row struct into driver values, in field order.
+func fakeRow(row any) []driver.Value {
+	value := reflect.ValueOf(row)
+	if value.Kind() != reflect.Struct {
+		converted, err := fakeValue(row)
+		if err != nil {
+			panic(err)
+		}
+		return []driver.Value{converted}
+	}
+
+	values := make([]driver.Value, value.NumField())
+	for i := range values {
+		converted, err := fakeValue(value.Field(i).Interface())
+		if err != nil {
+			panic(fmt.Sprintf("fake db: field %s: %v", value.Type().Field(i).Name, err))
+		}
+		values[i] = converted
+	}
+	return values
+}
+
+func fakeValue(value any) (driver.Value, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if texts, ok := value.([]string); ok {
+		return pq.StringArray(texts).Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(value)
+}
+
+type fakeConnector struct{ db *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: c.db}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake db: open through the connector")
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fake db: prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.record("begin")
+	return fakeTx{c.db}, nil
+}
+
+// CheckNamedValue converts arguments as a real driver would, and passes
+// the ones it cannot, like arrays, through as they are, since the queries
+// are answered in Go.
+func (c *fakeConn) CheckNamedValue(arg *driver.NamedValue) error {
+	if value, err := fakeValue(arg.Value); err == nil {
+		arg.Value = value
+	}
+	return nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result, err := c.db.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{rows: result.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.db.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(result.affected), nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error   { tx.db.record("commit"); return nil }
+func (tx fakeTx) Rollback() error { tx.db.record("rollback"); return nil }
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+// testEventConfig returns a service config whose database is a fakeDB.
+func testEventConfig(t *testing.T) (*APIConfig, *fakeDB) {
+	t.Helper()
+	db, fake := newFakeDB(t)
+	cfg := &APIConfig{
+		DB:             events.New(db),
+		DB_Conn:        db,
+		Config:         &config.EventServiceConfig{},
+		Logger:         logger.New("error"),
+		searchSyncWake: make(chan struct{}, 1),
+	}
+	return cfg, fake
+}
+
+// testAdminRequest returns a request as the admin middleware passes it on.
+func testAdminRequest(method, target, body string, adminID uuid.UUID) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	return r.WithContext(context.WithValue(r.Context(), auth.AdminIDKey, adminID))
+}
@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+)
+
+const geocodeTimeout = 5 * time.Second
+
+// requestedCoordinates validates coordinates sent by an admin. Both or
+// neither must be present; nil means none were sent.
+func requestedCoordinates(latitude, longitude *float64) (*geocode.Point, error) {
+	if latitude == nil && longitude == nil {
+		return nil, nil
+	}
+	if latitude == nil || longitude == nil {
+		return nil, fmt.Errorf("latitude and longitude must be set together")
+	}
+	point := geocode.Point{Lat: *latitude, Lon: *longitude}
+	if err := point.Validate(); err != nil {
+		return nil, err
+	}
+	return &point, nil
+}
+
+// geocodeVenue looks up a venue address. Lookups that fail or find nothing
+// leave the venue without coordinates rather than failing the request.
+func (cfg *APIConfig) geocodeVenue(ctx context.Context, address geocode.Address) *geocode.Point {
+	if cfg.Geocoder == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, geocodeTimeout)
+	defer cancel()
+
+	point, err := cfg.Geocoder.Geocode(ctx, address)
+	if err != nil {
+		if errors.Is(err, geocode.ErrNotFound) {
+			cfg.Logger.Debug("Venue address not found by geocoder", "address", address.String())
+		} else {
+			cfg.Logger.Warn("Failed to geocode venue address", "error", err, "address", address.String())
+		}
+		return nil
+	}
+	return &point
+}
+
+func nullCoordinates(point *geocode.Point) (sql.NullFloat64, sql.NullFloat64) {
+	if point == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: point.Lat, Valid: true}, sql.NullFloat64{Float64: point.Lon, Valid: true}
+}
@@ -1,8 +1,10 @@
 package event
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -70,7 +72,16 @@ func (cfg *APIConfig) AdminRegister(w http.ResponseWriter, r *http.Request) {
 		Permissions:  pqtype.NullRawMessage{RawMessage: json.RawMessage("{}"), Valid: true},
 	}
 
-	admin, err := cfg.DB.CreateAdmin(r.Context(), params)
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create admin")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	admin, err := qtx.CreateAdmin(r.Context(), params)
 	if err != nil {
 		if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
 			cfg.Logger.WithFields(map[string]any{"email": requestBody.Email}).Warn("Admin registration with existing email")
@@ -82,46 +93,59 @@ func (cfg *APIConfig) AdminRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jwtSecret := cfg.Config.JWTSecret
-	accessToken, err := auth.MakeAdminJWT(admin.AdminID, role, "{}", jwtSecret, cfg.Config.JWTAccessDuration)
+	// Every admin starts with a personal organization they own, so events always
+	// have an organization that outlives any single admin.
+	orgName := requestBody.OrganizationName
+	if orgName == "" {
+		orgName = admin.Name + "'s Organization"
+	}
+	org, err := createOrganizationWithOwner(r.Context(), qtx, orgName, admin.AdminID)
 	if err != nil {
-		cfg.Logger.Error("Failed to create access token", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create access token")
+		cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "error": err.Error()}).Error("Personal organization creation failed")
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create admin")
 		return
 	}
 
-	refreshTokenString, err := auth.MakeRefreshToken()
-	if err != nil {
-		cfg.Logger.Error("Failed to create refresh token", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create refresh token")
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit admin registration", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create admin")
 		return
 	}
 
-	refreshTokenParams := events.CreateAdminRefreshTokenParams{
-		Token:     refreshTokenString,
-		AdminID:   admin.AdminID,
-		ExpiresAt: time.Now().UTC().Add(cfg.Config.JWTRefreshDuration),
+	cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "email": admin.Email, "role": role}).Info("Admin registered successfully")
+
+	response := AdminAuthResponse{
+		AdminID: admin.AdminID,
+		Email:   admin.Email,
+		Name:    admin.Name,
+		Role:    role,
 	}
 
-	_, err = cfg.DB.CreateAdminRefreshToken(r.Context(), refreshTokenParams)
-	if err != nil {
-		cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "error": err.Error()}).Error("Admin refresh token storage failed")
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to store refresh token")
+	// Roles that require two-factor authentication enroll before their
+	// first session is issued.
+	if cfg.challengeSecondFactor(w, r, response, http.StatusCreated) {
 		return
 	}
 
-	cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "email": admin.Email, "role": role}).Info("Admin registered successfully")
-
-	response := AdminAuthResponse{
-		AdminID:      admin.AdminID,
-		Email:        admin.Email,
-		Name:         admin.Name,
-		Role:         role,
-		Permissions:  "{}",
-		AccessToken:  accessToken,
-		RefreshToken: refreshTokenString,
+	accessToken, refreshTokenString, err := cfg.issueAdminSession(r.Context(), admin.AdminID, role, "{}")
+	if err != nil {
+		cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "error": err.Error()}).Error("Admin session creation failed")
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create session")
+		return
 	}
 
+	response.Permissions = "{}"
+	response.AccessToken = accessToken
+	response.RefreshToken = refreshTokenString
+	response.Organizations = []OrganizationResponse{{
+		OrganizationID: org.OrganizationID,
+		Name:           org.Name,
+		CreatedBy:      org.CreatedBy,
+		Role:           OrgRoleOwner,
+		CreatedAt:      org.CreatedAt.Time,
+		UpdatedAt:      org.UpdatedAt.Time,
+	}}
+
 	utils.RespondWithJSON(w, http.StatusCreated, response)
 }
 
@@ -159,47 +183,68 @@ func (cfg *APIConfig) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		permissions = string(admin.Permissions.RawMessage)
 	}
 
-	jwtSecret := cfg.Config.JWTSecret
-	accessToken, err := auth.MakeAdminJWT(admin.AdminID, role, permissions, jwtSecret, cfg.Config.JWTAccessDuration)
-	if err != nil {
-		cfg.Logger.Error("Failed to create access token", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create access token")
+	response := AdminAuthResponse{
+		AdminID: admin.AdminID,
+		Email:   admin.Email,
+		Name:    admin.Name,
+		Role:    role,
+	}
+
+	if cfg.challengeSecondFactor(w, r, response, http.StatusOK) {
 		return
 	}
 
-	refreshTokenString, err := auth.MakeRefreshToken()
+	cfg.completeAdminLogin(w, r, response, permissions)
+}
+
+// completeAdminLogin issues the session for an admin who has passed every
+// required factor.
+func (cfg *APIConfig) completeAdminLogin(w http.ResponseWriter, r *http.Request, response AdminAuthResponse, permissions string) {
+	accessToken, refreshTokenString, err := cfg.issueAdminSession(r.Context(), response.AdminID, response.Role, permissions)
 	if err != nil {
-		cfg.Logger.Error("Failed to create refresh token", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create refresh token")
+		cfg.Logger.WithFields(map[string]any{"admin_id": response.AdminID, "error": err.Error()}).Error("Admin session creation failed")
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create session")
 		return
 	}
 
-	refreshTokenParams := events.CreateAdminRefreshTokenParams{
-		Token:     refreshTokenString,
-		AdminID:   admin.AdminID,
-		ExpiresAt: time.Now().UTC().Add(cfg.Config.JWTRefreshDuration),
+	cfg.Logger.WithFields(map[string]any{"admin_id": response.AdminID, "email": response.Email, "role": response.Role}).Info("Admin logged in successfully")
+
+	organizations, err := cfg.organizationsForAdmin(r.Context(), response.AdminID)
+	if err != nil {
+		cfg.Logger.WithFields(map[string]any{"admin_id": response.AdminID, "error": err.Error()}).Warn("Failed to load admin organizations")
 	}
 
-	_, err = cfg.DB.CreateAdminRefreshToken(r.Context(), refreshTokenParams)
+	response.Permissions = permissions
+	response.AccessToken = accessToken
+	response.RefreshToken = refreshTokenString
+	response.Organizations = organizations
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// issueAdminSession creates the access token and stored refresh token for
+// an authenticated admin.
+func (cfg *APIConfig) issueAdminSession(ctx context.Context, adminID uuid.UUID, role, permissions string) (string, string, error) {
+	accessToken, err := auth.MakeAdminJWT(adminID, role, permissions, cfg.Config.JWTSecret, cfg.Config.JWTAccessDuration)
 	if err != nil {
-		cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "error": err.Error()}).Error("Admin refresh token storage failed")
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to store refresh token")
-		return
+		return "", "", fmt.Errorf("failed to create access token: %w", err)
 	}
 
-	cfg.Logger.WithFields(map[string]any{"admin_id": admin.AdminID, "email": admin.Email, "role": role}).Info("Admin logged in successfully")
+	refreshTokenString, err := auth.MakeRefreshToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create refresh token: %w", err)
+	}
 
-	response := AdminAuthResponse{
-		AdminID:      admin.AdminID,
-		Email:        admin.Email,
-		Name:         admin.Name,
-		Role:         role,
-		Permissions:  permissions,
-		AccessToken:  accessToken,
-		RefreshToken: refreshTokenString,
+	_, err = cfg.DB.CreateAdminRefreshToken(ctx, events.CreateAdminRefreshTokenParams{
+		Token:     refreshTokenString,
+		AdminID:   adminID,
+		ExpiresAt: time.Now().UTC().Add(cfg.Config.JWTRefreshDuration),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, response)
+	return accessToken, refreshTokenString, nil
 }
 
 func (cfg *APIConfig) AdminRefreshToken(w http.ResponseWriter, r *http.Request) {
@@ -228,6 +273,21 @@ func (cfg *APIConfig) AdminRefreshToken(w http.ResponseWriter, r *http.Request)
 		permissions = string(admin.Permissions.RawMessage)
 	}
 
+	// Sessions that predate a role becoming 2FA-only, or a reset by a super
+	// admin, cannot be extended without enrolling.
+	if cfg.twoFactorRequired(role) {
+		enabled, err := cfg.twoFactorEnabled(r.Context(), admin.AdminID)
+		if err != nil {
+			cfg.Logger.Error("Failed to load admin two-factor status", "error", err, "admin_id", admin.AdminID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch admin information")
+			return
+		}
+		if !enabled {
+			utils.RespondWithError(w, http.StatusUnauthorized, "Two-factor authentication is required; log in again")
+			return
+		}
+	}
+
 	newAccessToken, err := auth.MakeAdminJWT(admin.AdminID, role, permissions, cfg.Config.JWTSecret, cfg.Config.JWTAccessDuration)
 	if err != nil {
 		cfg.Logger.Error("Failed to create access token", "error", err)
@@ -306,11 +366,12 @@ func (cfg *APIConfig) ListPublishedEvents(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	eventType := r.URL.Query().Get("type")
+	eventType := normalizeSlug(r.URL.Query().Get("type"))
 	city := r.URL.Query().Get("city")
 	dateFromStr := r.URL.Query().Get("date_from")
 	dateToStr := r.URL.Query().Get("date_to")
 
+	// Dates are calendar days at each event's venue, both ends inclusive.
 	var dateFrom, dateTo time.Time
 
 	if dateFromStr != "" {
@@ -366,18 +427,26 @@ func (cfg *APIConfig) ListPublishedEvents(w http.ResponseWriter, r *http.Request
 			VenueName:            &event.VenueName,
 			VenueCity:            &event.City,
 			VenueState:           utils.StringPtrFromNullString(event.State),
+			VenueLatitude:        utils.Float64PtrFromNullFloat64(event.VenueLatitude),
+			VenueLongitude:       utils.Float64PtrFromNullFloat64(event.VenueLongitude),
 			EventType:            event.EventType,
+			Tags:                 event.Tags,
 			StartDatetime:        event.StartDatetime,
 			EndDatetime:          event.EndDatetime,
 			TotalCapacity:        event.TotalCapacity,
 			AvailableSeats:       event.AvailableSeats,
-			BasePrice:            utils.ParsePrice(sql.NullString{String: event.BasePrice, Valid: event.BasePrice != ""}),
+			BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+			Currency:             event.Currency,
 			MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
 			Status:               event.Status.String,
 			CreatedAt:            event.CreatedAt.Time,
 		}
+		eventResponses[i].inVenueZone(event.VenueTimezone)
 	}
 
+	cfg.attachEventImages(r.Context(), eventResponses)
+	cfg.attachEventCategories(r.Context(), eventResponses)
+
 	response := EventListResponse{
 		Events:  eventResponses,
 		Total:   total,
@@ -418,19 +487,26 @@ func (cfg *APIConfig) GetEventByID(w http.ResponseWriter, r *http.Request) {
 		VenueCity:            &event.City,
 		VenueState:           utils.StringPtrFromNullString(event.State),
 		VenueCountry:         &event.Country,
+		VenueLatitude:        utils.Float64PtrFromNullFloat64(event.VenueLatitude),
+		VenueLongitude:       utils.Float64PtrFromNullFloat64(event.VenueLongitude),
 		EventType:            event.EventType,
+		Categories:           cfg.loadEventCategories(r.Context(), event.EventType),
+		Tags:                 event.Tags,
 		StartDatetime:        event.StartDatetime,
 		EndDatetime:          event.EndDatetime,
 		TotalCapacity:        event.TotalCapacity,
 		AvailableSeats:       event.AvailableSeats,
-		BasePrice:            utils.ParsePrice(sql.NullString{String: event.BasePrice, Valid: event.BasePrice != ""}),
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:             event.Currency,
 		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
 		Status:               event.Status.String,
 		Version:              event.Version,
 		CreatedBy:            event.CreatedBy,
 		CreatedAt:            event.CreatedAt.Time,
 		UpdatedAt:            event.UpdatedAt.Time,
+		Images:               cfg.loadEventImages(r.Context(), event.EventID),
 	}
+	response.inVenueZone(event.VenueTimezone)
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
@@ -1,7 +1,6 @@
 package event
 
 import (
-	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -11,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
 	"github.com/google/uuid"
@@ -68,27 +68,90 @@ func (cfg *APIConfig) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	currency, err := cfg.requestedCurrency(requestBody.Currency)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	basePrice, err := currency.ToMinor(requestBody.BasePrice)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid base_price: "+err.Error())
+		return
+	}
+
+	category, ok := cfg.eventCategory(w, r, requestBody.EventType)
+	if !ok {
+		return
+	}
+	tags, err := normalizeTags(requestBody.Tags)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	maxTickets := requestBody.MaxTicketsPerBooking
 	if maxTickets <= 0 {
 		maxTickets = 10
 	}
 
+	setupBuffer, teardownBuffer, err := cfg.venueBuffers(requestBody.SetupBufferMinutes, requestBody.TeardownBufferMinutes)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	organizationID, ok := cfg.resolveOrganization(w, r, adminID, requestBody.OrganizationID)
+	if !ok {
+		return
+	}
+
+	if !cfg.checkVenueAvailableToOrganization(w, r, requestBody.VenueID, organizationID) {
+		return
+	}
+
 	params := events.CreateEventParams{
-		Name:                 requestBody.Name,
-		Description:          sql.NullString{String: requestBody.Description, Valid: requestBody.Description != ""},
-		VenueID:              requestBody.VenueID,
-		EventType:            requestBody.EventType,
-		StartDatetime:        requestBody.StartDatetime,
-		EndDatetime:          requestBody.EndDatetime,
-		TotalCapacity:        requestBody.TotalCapacity,
-		AvailableSeats:       requestBody.TotalCapacity,
-		BasePrice:            fmt.Sprintf("%.2f", requestBody.BasePrice),
-		MaxTicketsPerBooking: sql.NullInt32{Int32: maxTickets, Valid: true},
-		Status:               sql.NullString{String: "draft", Valid: true},
-		CreatedBy:            adminID,
-	}
-
-	event, err := cfg.DB.CreateEvent(r.Context(), params)
+		Name:                  requestBody.Name,
+		Description:           sql.NullString{String: requestBody.Description, Valid: requestBody.Description != ""},
+		VenueID:               requestBody.VenueID,
+		EventType:             category.Slug,
+		StartDatetime:         requestBody.StartDatetime,
+		EndDatetime:           requestBody.EndDatetime,
+		TotalCapacity:         requestBody.TotalCapacity,
+		AvailableSeats:        requestBody.TotalCapacity,
+		BasePriceMinor:        basePrice,
+		MaxTicketsPerBooking:  sql.NullInt32{Int32: maxTickets, Valid: true},
+		Status:                sql.NullString{String: "draft", Valid: true},
+		CreatedBy:             adminID,
+		OrganizationID:        uuid.NullUUID{UUID: organizationID, Valid: true},
+		SetupBufferMinutes:    setupBuffer,
+		TeardownBufferMinutes: teardownBuffer,
+		Currency:              currency.Code,
+		CategoryID:            category.CategoryID,
+		Tags:                  tags,
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	if !cfg.checkVenueSchedule(w, r, qtx, venueSchedule{
+		venueID:        requestBody.VenueID,
+		organizationID: params.OrganizationID,
+		start:          requestBody.StartDatetime,
+		end:            requestBody.EndDatetime,
+		setup:          setupBuffer,
+		teardown:       teardownBuffer,
+		allowConflict:  requestBody.AllowVenueConflict,
+	}) {
+		return
+	}
+
+	event, err := qtx.CreateEvent(r.Context(), params)
 	if err != nil {
 		if strings.Contains(err.Error(), "foreign key") ||
 			strings.Contains(err.Error(), "violates foreign key constraint") ||
@@ -102,61 +165,57 @@ func (cfg *APIConfig) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := cfg.recordEventHistory(r.Context(), qtx, nil, event, adminID, HistoryActionCreated, 0); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", event.EventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create event")
+		return
+	}
+
+	if err := cfg.queueSearchSync(r.Context(), qtx, event.EventID, SearchSyncUpsert); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", event.EventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create event")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event creation", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create event")
+		return
+	}
+	cfg.wakeSearchSync()
+
 	cfg.Logger.WithFields(map[string]any{"event_id": event.EventID, "event_name": event.Name, "admin_id": adminID}).Info("Event created successfully")
 
 	response := EventResponse{
-		EventID:              event.EventID,
-		Name:                 event.Name,
-		Description:          utils.StringPtrFromNullString(event.Description),
-		VenueID:              event.VenueID,
-		EventType:            event.EventType,
-		StartDatetime:        event.StartDatetime,
-		EndDatetime:          event.EndDatetime,
-		TotalCapacity:        event.TotalCapacity,
-		AvailableSeats:       event.AvailableSeats,
-		BasePrice:            requestBody.BasePrice,
-		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
-		Status:               event.Status.String,
-		Version:              event.Version,
-		CreatedBy:            event.CreatedBy,
-		CreatedAt:            event.CreatedAt.Time,
-		UpdatedAt:            event.UpdatedAt.Time,
+		EventID:               event.EventID,
+		Name:                  event.Name,
+		Description:           utils.StringPtrFromNullString(event.Description),
+		VenueID:               event.VenueID,
+		EventType:             event.EventType,
+		Categories:            cfg.loadEventCategories(r.Context(), event.EventType),
+		Tags:                  event.Tags,
+		StartDatetime:         event.StartDatetime,
+		EndDatetime:           event.EndDatetime,
+		TotalCapacity:         event.TotalCapacity,
+		AvailableSeats:        event.AvailableSeats,
+		BasePrice:             eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:              event.Currency,
+		MaxTicketsPerBooking:  event.MaxTicketsPerBooking.Int32,
+		Status:                event.Status.String,
+		Version:               event.Version,
+		CreatedBy:             event.CreatedBy,
+		OrganizationID:        utils.UUIDPtrFromNullUUID(event.OrganizationID),
+		SetupBufferMinutes:    event.SetupBufferMinutes,
+		TeardownBufferMinutes: event.TeardownBufferMinutes,
+		CreatedAt:             event.CreatedAt.Time,
+		UpdatedAt:             event.UpdatedAt.Time,
 	}
 
 	venue, err := cfg.DB.GetVenueByID(r.Context(), event.VenueID)
 	if err != nil {
-		cfg.Logger.Error("Failed to get venue for search indexing", "error", err, "venue_id", event.VenueID)
+		cfg.Logger.Error("Failed to get venue for event response", "error", err, "venue_id", event.VenueID)
 	} else {
-		fmt.Printf("DEBUG: SearchClient status: %v (nil=%t)\n", cfg.SearchClient, cfg.SearchClient == nil)
-		if cfg.SearchClient != nil {
-			fmt.Printf("DEBUG: Attempting to index event %s in search service\n", event.Name)
-			go func() {
-				venueResp := VenueResponse{
-					VenueID:      venue.VenueID,
-					Name:         venue.Name,
-					Address:      venue.Address,
-					City:         venue.City,
-					State:        utils.StringPtrFromNullString(venue.State),
-					Country:      venue.Country,
-					PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-					Capacity:     venue.Capacity,
-					LayoutConfig: utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
-					CreatedAt:    venue.CreatedAt.Time,
-					UpdatedAt:    venue.UpdatedAt.Time,
-				}
-
-				fmt.Printf("DEBUG: Calling SearchClient.IndexEvent for event %s\n", response.Name)
-				ctx := context.Background()
-				if err := cfg.SearchClient.IndexEvent(ctx, response, venueResp); err != nil {
-					cfg.Logger.Error("Failed to index event in search service", "error", err, "event_id", event.EventID)
-					fmt.Printf("DEBUG: IndexEvent failed: %v\n", err)
-				} else {
-					fmt.Printf("DEBUG: IndexEvent succeeded for event %s\n", response.Name)
-				}
-			}()
-		} else {
-			fmt.Printf("DEBUG: SearchClient is nil, search indexing disabled\n")
-		}
+		response.inVenueZone(venue.Timezone)
 	}
 
 	utils.RespondWithJSON(w, http.StatusCreated, response)
@@ -182,17 +241,8 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = cfg.DB.CheckEventOwnership(r.Context(), events.CheckEventOwnershipParams{
-		EventID:   eventID,
-		CreatedBy: adminID,
-	})
-	if err != nil {
-		if err == sql.ErrNoRows {
-			utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
-			return
-		}
-		cfg.Logger.Error("Failed to check event ownership", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify event ownership")
+	ownership, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager)
+	if !ok {
 		return
 	}
 
@@ -203,6 +253,47 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if requestBody.VenueID != nil && *requestBody.VenueID != currentEvent.VenueID && ownership.OrganizationID.Valid {
+		if !cfg.checkVenueAvailableToOrganization(w, r, *requestBody.VenueID, ownership.OrganizationID.UUID) {
+			return
+		}
+	}
+
+	currency := money.MustLookup(currentEvent.Currency)
+	if requestBody.Currency != nil {
+		currency, err = money.Lookup(*requestBody.Currency)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	currencyChanged := currency.Code != currentEvent.Currency
+	if currencyChanged {
+		if err := cfg.checkCurrencyChange(r.Context(), eventID, currentEvent.TotalCapacity-currentEvent.AvailableSeats); err != nil {
+			respondCurrencyChangeError(w, err)
+			return
+		}
+	}
+
+	// A new currency without a new price keeps the amount, so 150 INR
+	// becomes 150 USD.
+	basePrice := currentEvent.BasePriceMinor
+	if requestBody.BasePrice != nil || currencyChanged {
+		price := eventPrice(currentEvent.BasePriceMinor, currentEvent.Currency)
+		if requestBody.BasePrice != nil {
+			if *requestBody.BasePrice < 0 {
+				utils.RespondWithError(w, http.StatusBadRequest, "Base price cannot be negative")
+				return
+			}
+			price = *requestBody.BasePrice
+		}
+		basePrice, err = currency.ToMinor(price)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid base_price: "+err.Error())
+			return
+		}
+	}
+
 	params := events.UpdateEventParams{
 		EventID: eventID,
 		Name: func() string {
@@ -223,12 +314,7 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 			}
 			return currentEvent.VenueID
 		}(),
-		EventType: func() string {
-			if requestBody.EventType != nil {
-				return *requestBody.EventType
-			}
-			return currentEvent.EventType
-		}(),
+		EventType: currentEvent.EventType,
 		StartDatetime: func() time.Time {
 			if requestBody.StartDatetime != nil {
 				return *requestBody.StartDatetime
@@ -253,12 +339,8 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 			}
 			return currentEvent.AvailableSeats
 		}(),
-		BasePrice: func() string {
-			if requestBody.BasePrice != nil {
-				return fmt.Sprintf("%.2f", *requestBody.BasePrice)
-			}
-			return currentEvent.BasePrice
-		}(),
+		BasePriceMinor: basePrice,
+		Currency:       sql.NullString{String: currency.Code, Valid: true},
 		MaxTicketsPerBooking: func() sql.NullInt32 {
 			if requestBody.MaxTicketsPerBooking != nil {
 				return sql.NullInt32{Int32: *requestBody.MaxTicketsPerBooking, Valid: true}
@@ -274,7 +356,81 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		Version: requestBody.Version,
 	}
 
-	updatedEvent, err := cfg.DB.UpdateEvent(r.Context(), params)
+	if requestBody.EventType != nil {
+		category, ok := cfg.eventCategory(w, r, *requestBody.EventType)
+		if !ok {
+			return
+		}
+		params.EventType = category.Slug
+		params.CategoryID = uuid.NullUUID{UUID: category.CategoryID, Valid: true}
+	}
+	if requestBody.Tags != nil {
+		params.Tags, err = normalizeTags(*requestBody.Tags)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	params.SetupBufferMinutes, params.TeardownBufferMinutes = currentEvent.SetupBufferMinutes, currentEvent.TeardownBufferMinutes
+	buffersChanged := requestBody.SetupBufferMinutes != nil || requestBody.TeardownBufferMinutes != nil
+	if buffersChanged {
+		setup, teardown := requestBody.SetupBufferMinutes, requestBody.TeardownBufferMinutes
+		if setup == nil {
+			setup = &currentEvent.SetupBufferMinutes
+		}
+		if teardown == nil {
+			teardown = &currentEvent.TeardownBufferMinutes
+		}
+		params.SetupBufferMinutes, params.TeardownBufferMinutes, err = cfg.venueBuffers(setup, teardown)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	// Re-read under the row lock so the recorded diff is against the exact
+	// state this update replaces.
+	lockedEvent, err := qtx.GetEventForUpdate(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+	if currencyChanged && lockedEvent.AvailableSeats < lockedEvent.TotalCapacity {
+		respondCurrencyChangeError(w, errCurrencyLocked)
+		return
+	}
+
+	// Only changes that move the event's slot at the venue, or bring a
+	// cancelled event back, need a conflict check.
+	rescheduled := requestBody.VenueID != nil || requestBody.StartDatetime != nil || requestBody.EndDatetime != nil || buffersChanged ||
+		(currentEvent.Status.String == "cancelled" && params.Status.String != "cancelled")
+	if rescheduled && params.Status.String != "cancelled" {
+		if !cfg.checkVenueSchedule(w, r, qtx, venueSchedule{
+			venueID:        params.VenueID,
+			excludeEventID: eventID,
+			organizationID: ownership.OrganizationID,
+			start:          params.StartDatetime,
+			end:            params.EndDatetime,
+			setup:          params.SetupBufferMinutes,
+			teardown:       params.TeardownBufferMinutes,
+			allowConflict:  requestBody.AllowVenueConflict,
+		}) {
+			return
+		}
+	}
+
+	updatedEvent, err := qtx.UpdateEvent(r.Context(), params)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
@@ -289,58 +445,63 @@ func (cfg *APIConfig) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	action := HistoryActionUpdated
+	if updatedEvent.Status.String == "cancelled" && lockedEvent.Status.String != "cancelled" {
+		action = HistoryActionCancelled
+	}
+	if err := cfg.recordEventHistory(r.Context(), qtx, &lockedEvent, updatedEvent, adminID, action, 0); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+
+	if err := cfg.queueSearchSync(r.Context(), qtx, eventID, SearchSyncUpsert); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event update", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+	cfg.wakeSearchSync()
+
 	fmt.Printf("Update event in db: %s (ID: %s)\n", updatedEvent.Name, updatedEvent.EventID.String())
 	cfg.Logger.Info("Event updated successfully", "event_id", updatedEvent.EventID, "event_name", updatedEvent.Name, "admin_id", adminID)
 
 	response := EventResponse{
-		EventID:        updatedEvent.EventID,
-		Name:           updatedEvent.Name,
-		Description:    utils.StringPtrFromNullString(updatedEvent.Description),
-		VenueID:        updatedEvent.VenueID,
-		EventType:      updatedEvent.EventType,
-		StartDatetime:  updatedEvent.StartDatetime,
-		EndDatetime:    updatedEvent.EndDatetime,
-		TotalCapacity:  updatedEvent.TotalCapacity,
-		AvailableSeats: updatedEvent.AvailableSeats,
-		BasePrice: func() float64 {
-			var price float64
-			if _, err := fmt.Sscanf(updatedEvent.BasePrice, "%f", &price); err != nil {
-				return 0.0
-			}
-			return price
-		}(),
-		MaxTicketsPerBooking: updatedEvent.MaxTicketsPerBooking.Int32,
-		Status:               updatedEvent.Status.String,
-		Version:              updatedEvent.Version,
-		CreatedBy:            updatedEvent.CreatedBy,
-		CreatedAt:            updatedEvent.CreatedAt.Time,
-		UpdatedAt:            updatedEvent.UpdatedAt.Time,
+		EventID:               updatedEvent.EventID,
+		Name:                  updatedEvent.Name,
+		Description:           utils.StringPtrFromNullString(updatedEvent.Description),
+		VenueID:               updatedEvent.VenueID,
+		EventType:             updatedEvent.EventType,
+		Categories:            cfg.loadEventCategories(r.Context(), updatedEvent.EventType),
+		Tags:                  updatedEvent.Tags,
+		StartDatetime:         updatedEvent.StartDatetime,
+		EndDatetime:           updatedEvent.EndDatetime,
+		TotalCapacity:         updatedEvent.TotalCapacity,
+		AvailableSeats:        updatedEvent.AvailableSeats,
+		BasePrice:             eventPrice(updatedEvent.BasePriceMinor, updatedEvent.Currency),
+		Currency:              updatedEvent.Currency,
+		MaxTicketsPerBooking:  updatedEvent.MaxTicketsPerBooking.Int32,
+		Status:                updatedEvent.Status.String,
+		Version:               updatedEvent.Version,
+		CreatedBy:             updatedEvent.CreatedBy,
+		OrganizationID:        utils.UUIDPtrFromNullUUID(updatedEvent.OrganizationID),
+		SetupBufferMinutes:    updatedEvent.SetupBufferMinutes,
+		TeardownBufferMinutes: updatedEvent.TeardownBufferMinutes,
+		CreatedAt:             updatedEvent.CreatedAt.Time,
+		UpdatedAt:             updatedEvent.UpdatedAt.Time,
+		Images:                cfg.loadEventImages(r.Context(), updatedEvent.EventID),
 	}
 
 	venue, err := cfg.DB.GetVenueByID(r.Context(), updatedEvent.VenueID)
 	if err != nil {
-		cfg.Logger.Error("Failed to get venue for search indexing", "error", err, "venue_id", updatedEvent.VenueID)
-	} else if cfg.SearchClient != nil {
-		go func() {
-			venueResp := VenueResponse{
-				VenueID:      venue.VenueID,
-				Name:         venue.Name,
-				Address:      venue.Address,
-				City:         venue.City,
-				State:        utils.StringPtrFromNullString(venue.State),
-				Country:      venue.Country,
-				PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-				Capacity:     venue.Capacity,
-				LayoutConfig: utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
-				CreatedAt:    venue.CreatedAt.Time,
-				UpdatedAt:    venue.UpdatedAt.Time,
-			}
-
-			ctx := context.Background()
-			if err := cfg.SearchClient.UpdateEvent(ctx, response, venueResp); err != nil {
-				cfg.Logger.Error("Failed to update event in search service", "error", err, "event_id", updatedEvent.EventID)
-			}
-		}()
+		cfg.Logger.Error("Failed to get venue for event response", "error", err, "venue_id", updatedEvent.VenueID)
+	} else {
+		response.inVenueZone(venue.Timezone)
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -368,26 +529,36 @@ func (cfg *APIConfig) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = cfg.DB.CheckEventOwnership(r.Context(), events.CheckEventOwnershipParams{
-		EventID:   eventID,
-		CreatedBy: adminID,
-	})
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager); !ok {
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
-			return
-		}
-		cfg.Logger.Error("Failed to check event ownership", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify event ownership")
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	currentEvent, err := qtx.GetEventForUpdate(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
+		return
+	}
+	if currentEvent.Version != requestBody.Version {
+		utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
 		return
 	}
 
-	err = cfg.DB.DeleteEvent(r.Context(), events.DeleteEventParams{
+	deletedEvent, err := qtx.DeleteEvent(r.Context(), events.DeleteEventParams{
 		EventID: eventID,
 		Version: requestBody.Version,
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "version") {
+		if err == sql.ErrNoRows {
 			utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
 			return
 		}
@@ -396,21 +567,30 @@ func (cfg *APIConfig) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fmt.Printf("deleted event eventservice: %s\n", eventID.String())
-	cfg.Logger.Info("Event deleted successfully", "event_id", eventID, "admin_id", adminID)
+	if err := cfg.recordEventHistory(r.Context(), qtx, &currentEvent, deletedEvent, adminID, HistoryActionDeleted, 0); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
+		return
+	}
 
-	if cfg.SearchClient != nil {
-		go func() {
-			if err := cfg.SearchClient.DeleteEvent(r.Context(), eventID); err != nil {
-				cfg.Logger.Error("Failed to delete event from search service", "error", err, "event_id", eventID)
-			} else {
-				cfg.Logger.Info("Event removed from search service", "event_id", eventID)
-			}
-		}()
+	if err := cfg.queueSearchSync(r.Context(), qtx, eventID, SearchSyncDelete); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
+		return
 	}
 
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event deletion", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
+		return
+	}
+	cfg.wakeSearchSync()
+
+	fmt.Printf("deleted event eventservice: %s\n", eventID.String())
+	cfg.Logger.Info("Event deleted successfully", "event_id", eventID, "admin_id", adminID)
+
 	response := SuccessResponse{
-		Message: "Event cancelled successfully",
+		Message: fmt.Sprintf("Event deleted; it can be restored until %s", deletedEvent.DeletedAt.Time.Add(cfg.Config.DeletedRetention).UTC().Format(time.RFC3339)),
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -439,49 +619,86 @@ func (cfg *APIConfig) ListAdminEvents(w http.ResponseWriter, r *http.Request) {
 
 	offset := (page - 1) * limit
 
-	params := events.ListEventsByAdminParams{
-		Limit:     int32(limit),
-		Offset:    int32(offset),
-		CreatedBy: adminID,
-	}
+	var eventsList []events.ListEventsByAdminRow
+	total := int64(-1)
+	if orgIDStr := r.URL.Query().Get("organization_id"); orgIDStr != "" {
+		orgID, err := uuid.Parse(orgIDStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+			return
+		}
 
-	eventsList, err := cfg.DB.ListEventsByAdmin(r.Context(), params)
-	if err != nil {
-		cfg.Logger.Error("Failed to fetch admin events", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch events")
-		return
+		if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer); !ok {
+			return
+		}
+
+		orgEvents, err := cfg.DB.ListEventsByOrganization(r.Context(), events.ListEventsByOrganizationParams{
+			Limit:          int32(limit),
+			Offset:         int32(offset),
+			OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true},
+		})
+		if err != nil {
+			cfg.Logger.Error("Failed to fetch organization events", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch events")
+			return
+		}
+		for _, event := range orgEvents {
+			eventsList = append(eventsList, events.ListEventsByAdminRow(event))
+		}
+
+		total, err = cfg.DB.CountEventsByOrganization(r.Context(), uuid.NullUUID{UUID: orgID, Valid: true})
+		if err != nil {
+			cfg.Logger.Error("Failed to count organization events", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch events")
+			return
+		}
+	} else {
+		params := events.ListEventsByAdminParams{
+			Limit:   int32(limit),
+			Offset:  int32(offset),
+			AdminID: adminID,
+		}
+
+		var err error
+		eventsList, err = cfg.DB.ListEventsByAdmin(r.Context(), params)
+		if err != nil {
+			cfg.Logger.Error("Failed to fetch admin events", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch events")
+			return
+		}
 	}
 
 	eventResponses := make([]EventResponse, len(eventsList))
 	for i, event := range eventsList {
 		eventResponses[i] = EventResponse{
-			EventID:        event.EventID,
-			Name:           event.Name,
-			Description:    utils.StringPtrFromNullString(event.Description),
-			VenueID:        event.VenueID,
-			VenueName:      &event.VenueName,
-			VenueCity:      &event.City,
-			EventType:      event.EventType,
-			StartDatetime:  event.StartDatetime,
-			EndDatetime:    event.EndDatetime,
-			TotalCapacity:  event.TotalCapacity,
-			AvailableSeats: event.AvailableSeats,
-			BasePrice: func() float64 {
-				var price float64
-				if _, err := fmt.Sscanf(event.BasePrice, "%f", &price); err != nil {
-					return 0.0
-				}
-				return price
-			}(),
+			EventID:              event.EventID,
+			Name:                 event.Name,
+			Description:          utils.StringPtrFromNullString(event.Description),
+			VenueID:              event.VenueID,
+			VenueName:            &event.VenueName,
+			VenueCity:            &event.City,
+			EventType:            event.EventType,
+			Tags:                 event.Tags,
+			StartDatetime:        event.StartDatetime,
+			EndDatetime:          event.EndDatetime,
+			TotalCapacity:        event.TotalCapacity,
+			AvailableSeats:       event.AvailableSeats,
+			BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+			Currency:             event.Currency,
 			MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
 			Status:               event.Status.String,
 			Version:              event.Version,
 			CreatedBy:            event.CreatedBy,
+			OrganizationID:       utils.UUIDPtrFromNullUUID(event.OrganizationID),
 			CreatedAt:            event.CreatedAt.Time,
 			UpdatedAt:            event.UpdatedAt.Time,
 		}
+		eventResponses[i].inVenueZone(event.VenueTimezone)
 	}
 
+	cfg.attachEventImages(r.Context(), eventResponses)
+	cfg.attachEventCategories(r.Context(), eventResponses)
+
 	response := EventListResponse{
 		Events:  eventResponses,
 		Total:   int64(len(eventResponses)),
@@ -489,6 +706,10 @@ func (cfg *APIConfig) ListAdminEvents(w http.ResponseWriter, r *http.Request) {
 		Limit:   limit,
 		HasMore: len(eventResponses) == limit,
 	}
+	if total >= 0 {
+		response.Total = total
+		response.HasMore = int64(offset+len(eventResponses)) < total
+	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
@@ -507,17 +728,7 @@ func (cfg *APIConfig) GetEventAnalytics(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	_, err = cfg.DB.CheckEventOwnership(r.Context(), events.CheckEventOwnershipParams{
-		EventID:   eventID,
-		CreatedBy: adminID,
-	})
-	if err != nil {
-		if err == sql.ErrNoRows {
-			utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
-			return
-		}
-		cfg.Logger.Error("Failed to check event ownership", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify event ownership")
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer); !ok {
 		return
 	}
 
@@ -541,21 +752,96 @@ func (cfg *APIConfig) GetEventAnalytics(w http.ResponseWriter, r *http.Request)
 			}
 			return util
 		}(),
-		BasePrice: func() float64 {
-			var price float64
-			if _, err := fmt.Sscanf(analytics.BasePrice, "%f", &price); err != nil {
-				return 0.0
-			}
-			return price
-		}(),
-		EstimatedRevenue: func() float64 {
-			var revenue float64
-			if _, err := fmt.Sscanf(analytics.EstimatedRevenue, "%f", &revenue); err != nil {
-				return 0.0
-			}
-			return revenue
-		}(),
+		BasePrice:        eventPrice(analytics.BasePriceMinor, analytics.Currency),
+		Currency:         analytics.Currency,
+		EstimatedRevenue: eventPrice(analytics.EstimatedRevenueMinor, analytics.Currency),
+	}
+
+	if cfg.BookingClient != nil {
+		sales, err := cfg.BookingClient.GetEventSalesAnalytics(r.Context(), eventID, SalesAnalyticsQuery{})
+		if err != nil {
+			cfg.Logger.WithFields(map[string]any{"error": err.Error(), "event_id": eventID}).Warn("Sales analytics unavailable, returning estimate only")
+		} else {
+			response.Revenue = &sales.Revenue
+			response.Bookings = &sales.Bookings
+			response.Waitlist = &sales.Waitlist
+		}
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
+
+func (cfg *APIConfig) GetEventSalesAnalytics(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	if cfg.BookingClient == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Sales analytics are not configured")
+		return
+	}
+
+	query := SalesAnalyticsQuery{Interval: r.URL.Query().Get("interval")}
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from timestamp, expected RFC3339")
+			return
+		}
+		query.From = &from
+	}
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to timestamp, expected RFC3339")
+			return
+		}
+		query.To = &to
+	}
+
+	analytics, err := cfg.DB.GetEventAnalytics(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch event analytics", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+		return
+	}
+
+	sales, err := cfg.BookingClient.GetEventSalesAnalytics(r.Context(), eventID, query)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid analytics request") {
+			utils.RespondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "invalid analytics request: "))
+			return
+		}
+		cfg.Logger.Error("Failed to fetch sales analytics", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to fetch sales analytics")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, EventSalesAnalyticsResponse{
+		EventID:        analytics.EventID,
+		Name:           analytics.Name,
+		TotalCapacity:  analytics.TotalCapacity,
+		AvailableSeats: analytics.AvailableSeats,
+		Currency:       analytics.Currency,
+		Revenue:        sales.Revenue,
+		Bookings:       sales.Bookings,
+		Waitlist:       sales.Waitlist,
+		Interval:       sales.Interval,
+		From:           sales.From,
+		To:             sales.To,
+		TimeSeries:     sales.TimeSeries,
+	})
+}
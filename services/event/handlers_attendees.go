@@ -0,0 +1,344 @@
+package event
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+// attendeePageSize is how many bookings are pulled from booking-service per
+// round trip; it also stays within user-service's batch lookup limit.
+const attendeePageSize = 500
+
+var attendeeCSVHeader = []string{
+	"booking_reference",
+	"name",
+	"email",
+	"user_id",
+	"quantity",
+	"seats",
+	"confirmed_at",
+	"checked_in",
+	"checked_in_at",
+}
+
+// manifestWriter streams attendee rows in one export format.
+type manifestWriter interface {
+	Begin(page *BookingAttendeePage) error
+	Write(entry AttendeeManifestEntry) error
+	Flush() error
+	End() error
+}
+
+type csvManifestWriter struct {
+	w *csv.Writer
+}
+
+func (m *csvManifestWriter) Begin(page *BookingAttendeePage) error {
+	return m.w.Write(attendeeCSVHeader)
+}
+
+func (m *csvManifestWriter) Write(entry AttendeeManifestEntry) error {
+	return m.w.Write([]string{
+		csvText(entry.BookingReference),
+		csvText(entry.Name),
+		csvText(entry.Email),
+		entry.UserID.String(),
+		strconv.Itoa(int(entry.Quantity)),
+		csvText(strings.Join(entry.Seats, ";")),
+		formatOptionalTime(entry.ConfirmedAt),
+		strconv.FormatBool(entry.CheckedIn),
+		formatOptionalTime(entry.CheckedInAt),
+	})
+}
+
+func (m *csvManifestWriter) Flush() error {
+	m.w.Flush()
+	return m.w.Error()
+}
+
+func (m *csvManifestWriter) End() error {
+	return m.Flush()
+}
+
+// csvText keeps a text cell from being read as a formula when the file is
+// opened in a spreadsheet, by quoting values that start like one.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// jsonManifestWriter writes a single JSON document whose attendees array is
+// emitted element by element, so the full manifest never sits in memory.
+type jsonManifestWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (m *jsonManifestWriter) Begin(page *BookingAttendeePage) error {
+	header, err := json.Marshal(map[string]any{
+		"event_id":            page.EventID,
+		"total_bookings":      page.TotalBookings,
+		"total_tickets":       page.TotalTickets,
+		"checked_in_bookings": page.CheckedInBookings,
+		"generated_at":        time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(m.w, `%s,"attendees":[`, header[:len(header)-1])
+	return err
+}
+
+func (m *jsonManifestWriter) Write(entry AttendeeManifestEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if m.count > 0 {
+		if _, err := io.WriteString(m.w, ","); err != nil {
+			return err
+		}
+	}
+	m.count++
+	_, err = m.w.Write(data)
+	return err
+}
+
+func (m *jsonManifestWriter) Flush() error {
+	return nil
+}
+
+func (m *jsonManifestWriter) End() error {
+	_, err := io.WriteString(m.w, "]}\n")
+	return err
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (cfg *APIConfig) ExportEventAttendees(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		utils.RespondWithError(w, http.StatusBadRequest, "format must be 'csv' or 'json'")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	if cfg.BookingClient == nil || cfg.UserClient == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Attendee export is not configured")
+		return
+	}
+
+	// The first page is fetched before any bytes are written so upstream
+	// failures can still be reported with a proper status code.
+	page, err := cfg.BookingClient.GetEventAttendees(r.Context(), eventID, uuid.Nil, attendeePageSize)
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch attendees", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to fetch attendees")
+		return
+	}
+	users, err := cfg.lookupAttendeeUsers(r, page.Attendees)
+	if err != nil {
+		cfg.Logger.Error("Failed to resolve attendee users", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to resolve attendee details")
+		return
+	}
+
+	var writer manifestWriter
+	filename := fmt.Sprintf("attendees-%s.%s", eventID, format)
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		writer = &csvManifestWriter{w: csv.NewWriter(w)}
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		writer = &jsonManifestWriter{w: w}
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	exported := 0
+
+	if err := writer.Begin(page); err != nil {
+		cfg.Logger.Error("Failed to write attendee manifest", "error", err, "event_id", eventID)
+		return
+	}
+
+	for {
+		for _, attendee := range page.Attendees {
+			user := users[attendee.UserID]
+			entry := AttendeeManifestEntry{
+				BookingReference: attendee.BookingReference,
+				UserID:           attendee.UserID,
+				Name:             user.Name,
+				Email:            user.Email,
+				Quantity:         attendee.Quantity,
+				Seats:            attendee.Seats,
+				ConfirmedAt:      attendee.ConfirmedAt,
+				CheckedIn:        attendee.CheckedInAt != nil,
+				CheckedInAt:      attendee.CheckedInAt,
+			}
+			if err := writer.Write(entry); err != nil {
+				cfg.Logger.Error("Failed to write attendee manifest", "error", err, "event_id", eventID)
+				return
+			}
+			exported++
+		}
+
+		if err := writer.Flush(); err != nil {
+			cfg.Logger.Error("Failed to write attendee manifest", "error", err, "event_id", eventID)
+			return
+		}
+		if err := controller.Flush(); err != nil {
+			cfg.Logger.Error("Failed to flush attendee manifest", "error", err, "event_id", eventID)
+			return
+		}
+
+		if page.NextCursor == nil {
+			break
+		}
+
+		// Headers are already sent, so a mid-stream failure can only be
+		// logged; the truncated file is missing its closing rows.
+		page, err = cfg.BookingClient.GetEventAttendees(r.Context(), eventID, *page.NextCursor, attendeePageSize)
+		if err != nil {
+			cfg.Logger.Error("Attendee export aborted", "error", err, "event_id", eventID, "exported", exported)
+			return
+		}
+		users, err = cfg.lookupAttendeeUsers(r, page.Attendees)
+		if err != nil {
+			cfg.Logger.Error("Attendee export aborted", "error", err, "event_id", eventID, "exported", exported)
+			return
+		}
+	}
+
+	if err := writer.End(); err != nil {
+		cfg.Logger.Error("Failed to write attendee manifest", "error", err, "event_id", eventID)
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		cfg.Logger.Error("Failed to flush attendee manifest", "error", err, "event_id", eventID)
+		return
+	}
+
+	cfg.Logger.Info("Attendee manifest exported",
+		"event_id", eventID,
+		"admin_id", adminID,
+		"format", format,
+		"bookings", exported)
+}
+
+func (cfg *APIConfig) lookupAttendeeUsers(r *http.Request, attendees []BookingAttendee) (map[uuid.UUID]UserSummary, error) {
+	seen := make(map[uuid.UUID]bool, len(attendees))
+	userIDs := make([]uuid.UUID, 0, len(attendees))
+	for _, attendee := range attendees {
+		if !seen[attendee.UserID] {
+			seen[attendee.UserID] = true
+			userIDs = append(userIDs, attendee.UserID)
+		}
+	}
+	return cfg.UserClient.GetUsersByIDs(r.Context(), userIDs)
+}
+
+func (cfg *APIConfig) CheckInAttendee(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.BookingReference) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "booking_reference is required")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager); !ok {
+		return
+	}
+
+	if cfg.BookingClient == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Check-in is not configured")
+		return
+	}
+
+	result, err := cfg.BookingClient.CheckInAttendee(r.Context(), eventID, req.BookingReference)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid check-in request"):
+			utils.RespondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "invalid check-in request: "))
+		case strings.Contains(err.Error(), "booking not found"):
+			utils.RespondWithError(w, http.StatusNotFound, strings.TrimPrefix(err.Error(), "booking not found: "))
+		case strings.Contains(err.Error(), "check-in conflict"):
+			utils.RespondWithError(w, http.StatusConflict, strings.TrimPrefix(err.Error(), "check-in conflict: "))
+		default:
+			cfg.Logger.Error("Failed to check in attendee", "error", err, "event_id", eventID)
+			utils.RespondWithError(w, http.StatusBadGateway, "Failed to check in attendee")
+		}
+		return
+	}
+
+	if cfg.UserClient != nil {
+		users, err := cfg.UserClient.GetUsersByIDs(r.Context(), []uuid.UUID{result.UserID})
+		if err != nil {
+			cfg.Logger.Warn("Failed to resolve checked-in attendee", "error", err, "user_id", result.UserID)
+		} else if user, ok := users[result.UserID]; ok {
+			result.Name = user.Name
+			result.Email = user.Email
+		}
+	}
+
+	cfg.Logger.Info("Attendee checked in",
+		"event_id", eventID,
+		"admin_id", adminID,
+		"booking_reference", result.BookingReference)
+
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
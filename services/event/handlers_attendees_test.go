@@ -0,0 +1,63 @@
+package event
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "Asha Rao", want: "Asha Rao"},
+		{value: "asha@example.com", want: "asha@example.com"},
+		{value: "a=b", want: "a=b"},
+		{value: `=HYPERLINK("http://evil.example","x")`, want: `'=HYPERLINK("http://evil.example","x")`},
+		{value: "+91 98450 12345", want: "'+91 98450 12345"},
+		{value: "-2+3", want: "'-2+3"},
+		{value: "@SUM(A1:A9)", want: "'@SUM(A1:A9)"},
+		{value: "\t=1+1", want: "'\t=1+1"},
+	}
+
+	for _, tt := range tests {
+		if got := csvText(tt.value); got != tt.want {
+			t.Errorf("csvText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCSVManifestWriterNeutralizesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	writer := &csvManifestWriter{w: csv.NewWriter(&buf)}
+	entry := AttendeeManifestEntry{
+		BookingReference: "BK-1001",
+		UserID:           uuid.MustParse("0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e"),
+		Name:             "=cmd|' /C calc'!A0",
+		Email:            "@evil.example",
+		Quantity:         2,
+		Seats:            []string{"A-1-1", "A-1-2"},
+	}
+	if err := writer.Write(entry); err != nil {
+		t.Fatalf("Write error = %v", err)
+	}
+	if err := writer.End(); err != nil {
+		t.Fatalf("End error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	row := rows[0]
+	if row[1] != "'=cmd|' /C calc'!A0" || row[2] != "'@evil.example" {
+		t.Errorf("name and email = %q, %q, want them prefixed with '", row[1], row[2])
+	}
+	if row[0] != "BK-1001" || row[5] != "A-1-1;A-1-2" {
+		t.Errorf("reference and seats = %q, %q, want them unchanged", row[0], row[5])
+	}
+}
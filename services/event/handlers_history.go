@@ -0,0 +1,637 @@
+package event
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+func historyLimit(r *http.Request) int32 {
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	return int32(limit)
+}
+
+// listHistory serves one page of an entity's history, newest first. Pages
+// are keyed by version so concurrent edits never shift them.
+func (cfg *APIConfig) listHistory(w http.ResponseWriter, r *http.Request, entityType string, entityID uuid.UUID, currentVersion int32) {
+	var beforeVersion int32
+	if b := r.URL.Query().Get("before_version"); b != "" {
+		parsed, err := strconv.ParseInt(b, 10, 32)
+		if err != nil || parsed <= 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "before_version must be a positive integer")
+			return
+		}
+		beforeVersion = int32(parsed)
+	}
+	limit := historyLimit(r)
+
+	history, err := cfg.DB.ListEntityHistory(r.Context(), events.ListEntityHistoryParams{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		BeforeVersion: beforeVersion,
+		PageSize:      limit,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list history", "error", err, "entity_type", entityType, "entity_id", entityID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch history")
+		return
+	}
+
+	response := HistoryListResponse{
+		EntityType:     entityType,
+		EntityID:       entityID,
+		CurrentVersion: currentVersion,
+		Entries:        make([]HistoryEntry, len(history)),
+	}
+	for i, h := range history {
+		response.Entries[i] = historyToEntry(h, false)
+	}
+	if len(history) == int(limit) {
+		next := history[len(history)-1].Version
+		response.NextBeforeVersion = &next
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) getHistoryVersion(w http.ResponseWriter, r *http.Request, entityType string, entityID uuid.UUID) {
+	version, err := strconv.ParseInt(r.PathValue("version"), 10, 32)
+	if err != nil || version <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid version")
+		return
+	}
+
+	history, err := cfg.DB.GetEntityHistoryVersion(r.Context(), events.GetEntityHistoryVersionParams{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Version:    int32(version),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Version not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get history version", "error", err, "entity_type", entityType, "entity_id", entityID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch history")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, historyToEntry(history, true))
+}
+
+func (cfg *APIConfig) ListEventHistory(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	ownership, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer)
+	if !ok {
+		return
+	}
+
+	cfg.listHistory(w, r, HistoryEntityEvent, eventID, ownership.Version)
+}
+
+func (cfg *APIConfig) GetEventHistoryVersion(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	cfg.getHistoryVersion(w, r, HistoryEntityEvent, eventID)
+}
+
+// RevertEvent restores the editable fields of an event from a recorded
+// version. It is an ordinary optimistic-locked update: the caller passes the
+// event's current version and the revert becomes a new version on top of it.
+// Publication status and ownership are not reverted; they have their own
+// workflows.
+func (cfg *APIConfig) RevertEvent(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var requestBody RevertRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if requestBody.ToVersion <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "to_version is required")
+		return
+	}
+
+	ownership, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager)
+	if !ok {
+		return
+	}
+
+	target, err := cfg.DB.GetEntityHistoryVersion(r.Context(), events.GetEntityHistoryVersionParams{
+		EntityType: HistoryEntityEvent,
+		EntityID:   eventID,
+		Version:    requestBody.ToVersion,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Version not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get history version", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	var snapshot eventSnapshot
+	if err := json.Unmarshal(target.Snapshot, &snapshot); err != nil {
+		cfg.Logger.Error("Failed to decode event snapshot", "error", err, "event_id", eventID, "version", target.Version)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	if snapshot.VenueID != uuid.Nil && ownership.OrganizationID.Valid {
+		if !cfg.checkVenueAvailableToOrganization(w, r, snapshot.VenueID, ownership.OrganizationID.UUID) {
+			return
+		}
+	}
+
+	// Snapshots taken before events carried a currency were priced in the
+	// original default.
+	if snapshot.Currency == "" {
+		snapshot.Currency = money.DefaultCurrency
+	}
+	currency := money.MustLookup(snapshot.Currency)
+	basePrice, err := currency.ToMinor(snapshot.BasePrice)
+	if err != nil {
+		cfg.Logger.Error("Invalid price in event snapshot", "error", err, "event_id", eventID, "version", target.Version)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	// Snapshots taken before the taxonomy hold free-form event types, which
+	// resolve the way the migration filed them.
+	category, err := resolveCategory(r.Context(), cfg.DB, snapshot.EventType)
+	if err != nil {
+		if errors.Is(err, errUnknownCategory) {
+			utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Cannot revert to version %d: its category %q no longer exists", target.Version, snapshot.EventType))
+			return
+		}
+		cfg.Logger.Error("Failed to resolve category", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+	if snapshot.Tags == nil {
+		snapshot.Tags = []string{}
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	current, err := qtx.GetEventForUpdate(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+	if current.Version != requestBody.Version {
+		utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
+		return
+	}
+
+	sold := current.TotalCapacity - current.AvailableSeats
+	if snapshot.TotalCapacity < sold {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Cannot revert to version %d: its capacity of %d is below the %d seats already sold", target.Version, snapshot.TotalCapacity, sold))
+		return
+	}
+	if currency.Code != current.Currency {
+		if err := cfg.checkCurrencyChange(r.Context(), eventID, sold); err != nil {
+			respondCurrencyChangeError(w, err)
+			return
+		}
+	}
+
+	if current.Status.String != "cancelled" {
+		if !cfg.checkVenueSchedule(w, r, qtx, venueSchedule{
+			venueID:        snapshot.VenueID,
+			excludeEventID: eventID,
+			organizationID: current.OrganizationID,
+			start:          snapshot.StartDatetime,
+			end:            snapshot.EndDatetime,
+			setup:          snapshot.SetupBufferMinutes,
+			teardown:       snapshot.TeardownBufferMinutes,
+			allowConflict:  requestBody.AllowVenueConflict,
+		}) {
+			return
+		}
+	}
+
+	reverted, err := qtx.RevertEvent(r.Context(), events.RevertEventParams{
+		Name:                  snapshot.Name,
+		Description:           utils.NullStringFromStringPtr(snapshot.Description),
+		VenueID:               snapshot.VenueID,
+		EventType:             category.Slug,
+		StartDatetime:         snapshot.StartDatetime,
+		EndDatetime:           snapshot.EndDatetime,
+		TotalCapacity:         snapshot.TotalCapacity,
+		BasePriceMinor:        basePrice,
+		Currency:              currency.Code,
+		MaxTicketsPerBooking:  sql.NullInt32{Int32: snapshot.MaxTicketsPerBooking, Valid: snapshot.MaxTicketsPerBooking > 0},
+		SetupBufferMinutes:    snapshot.SetupBufferMinutes,
+		TeardownBufferMinutes: snapshot.TeardownBufferMinutes,
+		CategoryID:            category.CategoryID,
+		Tags:                  snapshot.Tags,
+		EventID:               eventID,
+		Version:               requestBody.Version,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
+			return
+		}
+		cfg.Logger.Error("Failed to revert event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	if err := cfg.recordEventHistory(r.Context(), qtx, &current, reverted, adminID, HistoryActionReverted, target.Version); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	if err := cfg.queueSearchSync(r.Context(), qtx, eventID, SearchSyncUpsert); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event revert", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert event")
+		return
+	}
+	cfg.wakeSearchSync()
+
+	cfg.Logger.Info("Event reverted", "event_id", eventID, "to_version", target.Version, "version", reverted.Version, "admin_id", adminID)
+
+	response := eventToResponse(reverted)
+	response.Categories = cfg.loadEventCategories(r.Context(), reverted.EventType)
+	response.Images = cfg.loadEventImages(r.Context(), eventID)
+	response.inVenueZone(cfg.venueTimezone(r.Context(), reverted.VenueID))
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// venueForHistory loads a venue and checks the admin may read it; venues
+// outside an organization are visible to every admin, as in ListVenues.
+func (cfg *APIConfig) venueForHistory(w http.ResponseWriter, r *http.Request, adminID uuid.UUID) (events.Venue, bool) {
+	venueID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return events.Venue{}, false
+	}
+
+	venue, err := cfg.DB.GetVenueByID(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
+			return events.Venue{}, false
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch history")
+		return events.Venue{}, false
+	}
+
+	if venue.OrganizationID.Valid {
+		if _, ok := cfg.authorizeOrganization(w, r, venue.OrganizationID.UUID, adminID, OrgRoleViewer); !ok {
+			return events.Venue{}, false
+		}
+	}
+	return venue, true
+}
+
+// currentVenueVersion is the version a venue revert must name. Venues that
+// have never been changed since history tracking began are at version 1.
+func (cfg *APIConfig) currentVenueVersion(r *http.Request, q events.Querier, venueID uuid.UUID) (int32, error) {
+	latest, err := q.GetLatestEntityHistoryVersion(r.Context(), events.GetLatestEntityHistoryVersionParams{
+		EntityType: HistoryEntityVenue,
+		EntityID:   venueID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return max(latest, 1), nil
+}
+
+func (cfg *APIConfig) ListVenueHistory(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venue, ok := cfg.venueForHistory(w, r, adminID)
+	if !ok {
+		return
+	}
+
+	current, err := cfg.currentVenueVersion(r, cfg.DB, venue.VenueID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get venue version", "error", err, "venue_id", venue.VenueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch history")
+		return
+	}
+
+	cfg.listHistory(w, r, HistoryEntityVenue, venue.VenueID, current)
+}
+
+func (cfg *APIConfig) GetVenueHistoryVersion(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venue, ok := cfg.venueForHistory(w, r, adminID)
+	if !ok {
+		return
+	}
+
+	cfg.getHistoryVersion(w, r, HistoryEntityVenue, venue.VenueID)
+}
+
+// RevertVenue restores a venue's details and layout from a recorded version.
+// The layout is re-validated against the current schema, and a typed layout
+// still owns the venue's capacity.
+func (cfg *APIConfig) RevertVenue(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venueID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	var requestBody RevertRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if requestBody.ToVersion <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "to_version is required")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	current, err := qtx.GetVenueByIDForUpdate(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+
+	if !cfg.authorizeVenue(w, r, current, adminID) {
+		return
+	}
+
+	currentVersion, err := cfg.currentVenueVersion(r, qtx, venueID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get venue version", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+	if currentVersion != requestBody.Version {
+		utils.RespondWithError(w, http.StatusConflict, "Venue was updated by another admin. Please refresh and try again.")
+		return
+	}
+
+	target, err := qtx.GetEntityHistoryVersion(r.Context(), events.GetEntityHistoryVersionParams{
+		EntityType: HistoryEntityVenue,
+		EntityID:   venueID,
+		Version:    requestBody.ToVersion,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Version not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get history version", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+
+	var snapshot venueSnapshot
+	if err := json.Unmarshal(target.Snapshot, &snapshot); err != nil {
+		cfg.Logger.Error("Failed to decode venue snapshot", "error", err, "venue_id", venueID, "version", target.Version)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+
+	layout, err := decodeVenueLayout(snapshot.LayoutConfig)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Cannot revert to version %d: its layout is no longer valid: %v", target.Version, err))
+		return
+	}
+	layoutConfig, err := encodeVenueLayout(layout)
+	if err != nil {
+		cfg.Logger.Error("Failed to encode venue layout", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+	capacity := snapshot.Capacity
+	if layout != nil {
+		capacity = layout.Capacity()
+	}
+	if capacity <= 0 {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Cannot revert to version %d: capacity must be positive", target.Version))
+		return
+	}
+
+	// Snapshots taken before venues had a zone or coordinates keep the
+	// current ones.
+	timezone := snapshot.Timezone
+	if timezone == "" {
+		timezone = current.Timezone
+	}
+	latitude, longitude := current.Latitude, current.Longitude
+	if snapshot.Latitude != nil && snapshot.Longitude != nil {
+		latitude = utils.NullFloat64FromFloat64Ptr(snapshot.Latitude)
+		longitude = utils.NullFloat64FromFloat64Ptr(snapshot.Longitude)
+	}
+
+	reverted, err := qtx.RevertVenue(r.Context(), events.RevertVenueParams{
+		VenueID:      venueID,
+		Name:         snapshot.Name,
+		Address:      snapshot.Address,
+		City:         snapshot.City,
+		State:        utils.NullStringFromStringPtr(snapshot.State),
+		Country:      snapshot.Country,
+		PostalCode:   utils.NullStringFromStringPtr(snapshot.PostalCode),
+		Capacity:     capacity,
+		LayoutConfig: layoutConfig,
+		Timezone:     timezone,
+		Latitude:     latitude,
+		Longitude:    longitude,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to revert venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+
+	if err := cfg.recordVenueHistory(r.Context(), qtx, &current, reverted, adminID, HistoryActionReverted, target.Version); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+
+	if venueSearchFieldsChanged(current, reverted) {
+		if err := cfg.queueVenueSearchSync(r.Context(), qtx, venueID); err != nil {
+			cfg.Logger.Error("Failed to queue search sync for venue events", "error", err, "venue_id", venueID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue revert", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revert venue")
+		return
+	}
+	cfg.wakeSearchSync()
+
+	cfg.Logger.Info("Venue reverted", "venue_id", venueID, "to_version", target.Version, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusOK, venueToResponse(reverted))
+}
+
+// GetOrganizationAudit lists every recorded change to the organization's
+// events and venues, newest first, optionally narrowed to one entity type or
+// one acting admin.
+func (cfg *APIConfig) GetOrganizationAudit(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	params := events.ListOrganizationAuditParams{
+		OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true},
+		EntityType:     query.Get("entity_type"),
+		PageSize:       historyLimit(r),
+	}
+	if params.EntityType != "" && params.EntityType != HistoryEntityEvent && params.EntityType != HistoryEntityVenue {
+		utils.RespondWithError(w, http.StatusBadRequest, "entity_type must be event or venue")
+		return
+	}
+	if a := query.Get("admin_id"); a != "" {
+		params.ChangedBy, err = uuid.Parse(a)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid admin ID")
+			return
+		}
+	}
+	if b := query.Get("before"); b != "" {
+		params.Before, err = time.Parse(time.RFC3339Nano, b)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "before must be an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	history, err := cfg.DB.ListOrganizationAudit(r.Context(), params)
+	if err != nil {
+		cfg.Logger.Error("Failed to list organization audit", "error", err, "organization_id", orgID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch audit trail")
+		return
+	}
+
+	response := AuditResponse{
+		OrganizationID: orgID,
+		Entries:        make([]HistoryEntry, len(history)),
+	}
+	for i, h := range history {
+		response.Entries[i] = historyToEntry(h, false)
+	}
+	if len(history) == int(params.PageSize) {
+		next := history[len(history)-1].CreatedAt.Time
+		response.NextBefore = &next
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
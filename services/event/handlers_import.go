@@ -0,0 +1,790 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const (
+	maxImportBytes = 10 << 20
+	maxImportRows  = 5000
+)
+
+// importRecord is one line of an import file with its columns keyed by name,
+// so CSV and NDJSON share a single validation path.
+type importRecord struct {
+	line   int
+	fields map[string]string
+}
+
+func (rec importRecord) get(name string) string {
+	return strings.TrimSpace(rec.fields[name])
+}
+
+type importVenue struct {
+	line   int
+	ref    string
+	params events.CreateVenueParams
+}
+
+type importEvent struct {
+	line     int
+	venueRef string
+	params   events.CreateEventParams
+}
+
+// importSlot is the buffered time range a planned event occupies at its venue.
+type importSlot struct {
+	line  int
+	from  time.Time
+	until time.Time
+}
+
+type importPlan struct {
+	venues         []importVenue
+	events         []importEvent
+	existingVenues map[uuid.UUID]events.Venue
+	errors         []ImportRowError
+}
+
+func (p *importPlan) addError(line int, rowType, field, message string) {
+	p.errors = append(p.errors, ImportRowError{Line: line, Type: rowType, Field: field, Message: message})
+}
+
+func parseImportCSV(body io.Reader) ([]importRecord, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("file is empty")
+		}
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var records []importRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) >= maxImportRows {
+			return nil, fmt.Errorf("file exceeds %d rows", maxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		fields := make(map[string]string, len(header))
+		for i, column := range header {
+			fields[column] = row[i]
+		}
+		records = append(records, importRecord{line: line, fields: fields})
+	}
+
+	return records, nil
+}
+
+func parseImportNDJSON(body io.Reader) ([]importRecord, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var records []importRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(records) >= maxImportRows {
+			return nil, fmt.Errorf("file exceeds %d rows", maxImportRows)
+		}
+
+		var raw map[string]any
+		if err := json.Unmarshal([]byte(text), &raw); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON", line)
+		}
+
+		fields := make(map[string]string, len(raw))
+		for key, value := range raw {
+			switch v := value.(type) {
+			case nil:
+				fields[key] = ""
+			case string:
+				fields[key] = v
+			case float64:
+				fields[key] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				fields[key] = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("line %d: field %s must be a string, number or boolean", line, key)
+			}
+		}
+		records = append(records, importRecord{line: line, fields: fields})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (cfg *APIConfig) planImport(ctx context.Context, records []importRecord, adminID, organizationID uuid.UUID, allowConflicts bool) (*importPlan, error) {
+	plan := &importPlan{existingVenues: map[uuid.UUID]events.Venue{}}
+	venueCapacity := map[string]int32{}
+	invalidVenueRefs := map[string]bool{}
+	venueKeys := map[string]int{}
+
+	var eventRecords []importRecord
+	for _, rec := range records {
+		switch strings.ToLower(rec.get("type")) {
+		case "venue":
+		case "event":
+			eventRecords = append(eventRecords, rec)
+			continue
+		default:
+			plan.addError(rec.line, rec.get("type"), "type", "type must be 'venue' or 'event'")
+			continue
+		}
+
+		errCount := len(plan.errors)
+		name, address, city := rec.get("name"), rec.get("address"), rec.get("city")
+		if name == "" {
+			plan.addError(rec.line, "venue", "name", "name is required")
+		}
+		if address == "" {
+			plan.addError(rec.line, "venue", "address", "address is required")
+		}
+		if city == "" {
+			plan.addError(rec.line, "venue", "city", "city is required")
+		}
+
+		capacity, err := strconv.ParseInt(rec.get("capacity"), 10, 32)
+		if err != nil || capacity <= 0 {
+			plan.addError(rec.line, "venue", "capacity", "capacity must be a positive integer")
+		}
+
+		country := rec.get("country")
+		if country == "" {
+			country = "USA"
+		}
+
+		timezone := rec.get("timezone")
+		if timezone == "" {
+			timezone = defaultVenueTimezone
+		}
+		if err := validateTimezone(timezone); err != nil {
+			plan.addError(rec.line, "venue", "timezone", err.Error())
+		}
+
+		var point *geocode.Point
+		latStr, lonStr := rec.get("latitude"), rec.get("longitude")
+		if latStr != "" || lonStr != "" {
+			latitude, latErr := strconv.ParseFloat(latStr, 64)
+			longitude, lonErr := strconv.ParseFloat(lonStr, 64)
+			if latErr != nil || lonErr != nil {
+				plan.addError(rec.line, "venue", "latitude", "latitude and longitude must both be numbers")
+			} else if point, err = requestedCoordinates(&latitude, &longitude); err != nil {
+				plan.addError(rec.line, "venue", "latitude", err.Error())
+			}
+		}
+
+		ref := rec.get("ref")
+		if ref == "" {
+			ref = name
+		}
+		if ref != "" {
+			if _, seen := venueCapacity[ref]; seen || invalidVenueRefs[ref] {
+				plan.addError(rec.line, "venue", "ref", fmt.Sprintf("Duplicate venue ref %q", ref))
+			}
+		}
+
+		if name != "" && city != "" {
+			key := strings.ToLower(name) + "|" + strings.ToLower(city)
+			if firstLine, seen := venueKeys[key]; seen {
+				plan.addError(rec.line, "venue", "name", fmt.Sprintf("Duplicate of venue on line %d", firstLine))
+			} else {
+				venueKeys[key] = rec.line
+				existing, err := cfg.DB.GetVenueByNameAndCity(ctx, events.GetVenueByNameAndCityParams{
+					Name:           name,
+					City:           city,
+					OrganizationID: uuid.NullUUID{UUID: organizationID, Valid: true},
+				})
+				if err == nil {
+					plan.addError(rec.line, "venue", "name", fmt.Sprintf("Venue already exists (venue_id %s)", existing.VenueID))
+				} else if err != sql.ErrNoRows {
+					return nil, err
+				}
+			}
+		}
+
+		if len(plan.errors) > errCount {
+			if _, valid := venueCapacity[ref]; ref != "" && !valid {
+				invalidVenueRefs[ref] = true
+			}
+			continue
+		}
+
+		if point == nil {
+			point = cfg.geocodeVenue(ctx, geocode.Address{
+				Street:     address,
+				City:       city,
+				State:      rec.get("state"),
+				Country:    country,
+				PostalCode: rec.get("postal_code"),
+			})
+		}
+		latitude, longitude := nullCoordinates(point)
+
+		venueCapacity[ref] = int32(capacity)
+		plan.venues = append(plan.venues, importVenue{
+			line: rec.line,
+			ref:  ref,
+			params: events.CreateVenueParams{
+				Name:           name,
+				Address:        address,
+				City:           city,
+				State:          sql.NullString{String: rec.get("state"), Valid: rec.get("state") != ""},
+				Country:        country,
+				PostalCode:     sql.NullString{String: rec.get("postal_code"), Valid: rec.get("postal_code") != ""},
+				Capacity:       int32(capacity),
+				LayoutConfig:   pqtype.NullRawMessage{RawMessage: json.RawMessage("{}"), Valid: true},
+				OrganizationID: uuid.NullUUID{UUID: organizationID, Valid: true},
+				Timezone:       timezone,
+				Latitude:       latitude,
+				Longitude:      longitude,
+			},
+		})
+	}
+
+	eventKeys := map[string]int{}
+	plannedSlots := map[string][]importSlot{}
+	categories := map[string]events.Category{}
+	now := time.Now()
+	for _, rec := range eventRecords {
+		errCount := len(plan.errors)
+		name, eventType := rec.get("name"), rec.get("event_type")
+		if name == "" {
+			plan.addError(rec.line, "event", "name", "name is required")
+		}
+		category, categoryKnown := categories[normalizeSlug(eventType)]
+		if !categoryKnown {
+			resolved, err := resolveCategory(ctx, cfg.DB, eventType)
+			switch {
+			case errors.Is(err, errUnknownCategory):
+				plan.addError(rec.line, "event", "event_type", err.Error())
+			case err != nil:
+				return nil, err
+			default:
+				category = resolved
+				categories[category.Slug] = category
+			}
+		}
+
+		var venueID uuid.UUID
+		var venueRef string
+		var venueCap int32
+		venueKnown := false
+		switch idStr, ref := rec.get("venue_id"), rec.get("venue_ref"); {
+		case idStr != "" && ref != "":
+			plan.addError(rec.line, "event", "venue_id", "Provide either venue_id or venue_ref, not both")
+		case ref != "":
+			if invalidVenueRefs[ref] {
+				plan.addError(rec.line, "event", "venue_ref", fmt.Sprintf("venue_ref %q refers to an invalid venue row", ref))
+			} else if capacity, ok := venueCapacity[ref]; ok {
+				venueRef, venueCap, venueKnown = ref, capacity, true
+			} else {
+				plan.addError(rec.line, "event", "venue_ref", fmt.Sprintf("Unknown venue_ref %q", ref))
+			}
+		case idStr != "":
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				plan.addError(rec.line, "event", "venue_id", "Invalid venue_id")
+				break
+			}
+			venue, cached := plan.existingVenues[id]
+			if !cached {
+				venue, err = cfg.DB.GetVenueByID(ctx, id)
+				if err == sql.ErrNoRows {
+					plan.addError(rec.line, "event", "venue_id", "Venue does not exist")
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				plan.existingVenues[id] = venue
+			}
+			if venue.OrganizationID.Valid && venue.OrganizationID.UUID != organizationID {
+				plan.addError(rec.line, "event", "venue_id", "Venue belongs to another organization")
+				break
+			}
+			venueID, venueCap, venueKnown = id, venue.Capacity, true
+		default:
+			plan.addError(rec.line, "event", "venue_id", "venue_id or venue_ref is required")
+		}
+
+		start, startErr := time.Parse(time.RFC3339, rec.get("start_datetime"))
+		if startErr != nil {
+			plan.addError(rec.line, "event", "start_datetime", "start_datetime must be an RFC3339 timestamp")
+		} else if start.Before(now) {
+			plan.addError(rec.line, "event", "start_datetime", "Start datetime must be in the future")
+		}
+		end, endErr := time.Parse(time.RFC3339, rec.get("end_datetime"))
+		if endErr != nil {
+			plan.addError(rec.line, "event", "end_datetime", "end_datetime must be an RFC3339 timestamp")
+		} else if startErr == nil && !end.After(start) {
+			plan.addError(rec.line, "event", "end_datetime", "End datetime must be after start datetime")
+		}
+
+		totalCapacity, err := strconv.ParseInt(rec.get("total_capacity"), 10, 32)
+		if err != nil || totalCapacity <= 0 {
+			plan.addError(rec.line, "event", "total_capacity", "total_capacity must be a positive integer")
+		} else if venueKnown && int32(totalCapacity) > venueCap {
+			plan.addError(rec.line, "event", "total_capacity", fmt.Sprintf("total_capacity exceeds venue capacity of %d", venueCap))
+		}
+
+		currency, err := cfg.requestedCurrency(rec.get("currency"))
+		if err != nil {
+			plan.addError(rec.line, "event", "currency", err.Error())
+		}
+
+		tags, err := normalizeTags(strings.Split(rec.get("tags"), ";"))
+		if err != nil {
+			plan.addError(rec.line, "event", "tags", err.Error())
+		}
+
+		var basePrice int64
+		if priceStr := rec.get("base_price"); priceStr != "" {
+			price, err := strconv.ParseFloat(priceStr, 64)
+			if err != nil || price < 0 {
+				plan.addError(rec.line, "event", "base_price", "base_price must be a non-negative number")
+			} else if currency.Code != "" {
+				if basePrice, err = currency.ToMinor(price); err != nil {
+					plan.addError(rec.line, "event", "base_price", err.Error())
+				}
+			}
+		}
+
+		maxTickets := int64(10)
+		if maxStr := rec.get("max_tickets_per_booking"); maxStr != "" {
+			maxTickets, err = strconv.ParseInt(maxStr, 10, 32)
+			if err != nil || maxTickets <= 0 {
+				plan.addError(rec.line, "event", "max_tickets_per_booking", "max_tickets_per_booking must be a positive integer")
+			}
+		}
+
+		var setupOverride, teardownOverride *int32
+		if setupStr := rec.get("setup_buffer_minutes"); setupStr != "" {
+			parsed, err := strconv.ParseInt(setupStr, 10, 32)
+			if err != nil {
+				plan.addError(rec.line, "event", "setup_buffer_minutes", "setup_buffer_minutes must be an integer")
+			} else {
+				value := int32(parsed)
+				setupOverride = &value
+			}
+		}
+		if teardownStr := rec.get("teardown_buffer_minutes"); teardownStr != "" {
+			parsed, err := strconv.ParseInt(teardownStr, 10, 32)
+			if err != nil {
+				plan.addError(rec.line, "event", "teardown_buffer_minutes", "teardown_buffer_minutes must be an integer")
+			} else {
+				value := int32(parsed)
+				teardownOverride = &value
+			}
+		}
+		setupBuffer, teardownBuffer, err := cfg.venueBuffers(setupOverride, teardownOverride)
+		if err != nil {
+			plan.addError(rec.line, "event", "", err.Error())
+		}
+
+		status := strings.ToLower(rec.get("status"))
+		if status == "" {
+			status = "draft"
+		}
+		if status != "draft" && status != "published" {
+			plan.addError(rec.line, "event", "status", "status must be 'draft' or 'published'")
+		}
+
+		if len(plan.errors) > errCount {
+			continue
+		}
+
+		venueKey := venueRef
+		if venueRef == "" {
+			venueKey = venueID.String()
+		}
+		key := strings.ToLower(name) + "|" + venueKey + "|" + start.UTC().Format(time.RFC3339)
+		if firstLine, seen := eventKeys[key]; seen {
+			plan.addError(rec.line, "event", "name", fmt.Sprintf("Duplicate of event on line %d", firstLine))
+			continue
+		}
+		eventKeys[key] = rec.line
+
+		if venueRef == "" {
+			count, err := cfg.DB.CountDuplicateEvents(ctx, events.CountDuplicateEventsParams{
+				Name:          name,
+				VenueID:       venueID,
+				StartDatetime: start,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				plan.addError(rec.line, "event", "name", "An event with the same name, venue and start time already exists")
+				continue
+			}
+		}
+
+		if !allowConflicts {
+			from, until := occupiedWindow(start, end, setupBuffer, teardownBuffer)
+			overlapLine := 0
+			for _, slot := range plannedSlots[venueKey] {
+				if from.Before(slot.until) && until.After(slot.from) {
+					overlapLine = slot.line
+					break
+				}
+			}
+			if overlapLine != 0 {
+				plan.addError(rec.line, "event", "start_datetime", fmt.Sprintf("Overlaps the event on line %d at the same venue", overlapLine))
+				continue
+			}
+			if venueRef == "" {
+				conflicts, err := cfg.findVenueConflicts(ctx, cfg.DB, venueSchedule{
+					venueID:  venueID,
+					start:    start,
+					end:      end,
+					setup:    setupBuffer,
+					teardown: teardownBuffer,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if len(conflicts) > 0 {
+					plan.addError(rec.line, "event", "start_datetime", fmt.Sprintf("Venue is already booked during this time (%d conflicting events)", len(conflicts)))
+					continue
+				}
+			}
+			plannedSlots[venueKey] = append(plannedSlots[venueKey], importSlot{line: rec.line, from: from, until: until})
+		}
+
+		description := rec.get("description")
+		plan.events = append(plan.events, importEvent{
+			line:     rec.line,
+			venueRef: venueRef,
+			params: events.CreateEventParams{
+				Name:                  name,
+				Description:           sql.NullString{String: description, Valid: description != ""},
+				VenueID:               venueID,
+				EventType:             category.Slug,
+				StartDatetime:         start,
+				EndDatetime:           end,
+				TotalCapacity:         int32(totalCapacity),
+				AvailableSeats:        int32(totalCapacity),
+				BasePriceMinor:        basePrice,
+				MaxTicketsPerBooking:  sql.NullInt32{Int32: int32(maxTickets), Valid: true},
+				Status:                sql.NullString{String: status, Valid: true},
+				CreatedBy:             adminID,
+				OrganizationID:        uuid.NullUUID{UUID: organizationID, Valid: true},
+				SetupBufferMinutes:    setupBuffer,
+				TeardownBufferMinutes: teardownBuffer,
+				Currency:              currency.Code,
+				CategoryID:            category.CategoryID,
+				Tags:                  tags,
+			},
+		})
+	}
+
+	sort.SliceStable(plan.errors, func(i, j int) bool {
+		return plan.errors[i].Line < plan.errors[j].Line
+	})
+
+	return plan, nil
+}
+
+func (cfg *APIConfig) ImportCatalog(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	allowConflicts := false
+	if allowStr := r.URL.Query().Get("allow_venue_conflicts"); allowStr != "" {
+		parsed, err := strconv.ParseBool(allowStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "allow_venue_conflicts must be true or false")
+			return
+		}
+		allowConflicts = parsed
+	}
+
+	var requestedOrg uuid.UUID
+	if orgStr := r.URL.Query().Get("organization_id"); orgStr != "" {
+		parsed, err := uuid.Parse(orgStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization_id")
+			return
+		}
+		requestedOrg = parsed
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		contentType := strings.ToLower(r.Header.Get("Content-Type"))
+		switch {
+		case strings.Contains(contentType, "csv"):
+			format = "csv"
+		case strings.Contains(contentType, "ndjson"), strings.Contains(contentType, "jsonl"):
+			format = "ndjson"
+		}
+	}
+	if format != "csv" && format != "ndjson" {
+		utils.RespondWithError(w, http.StatusUnsupportedMediaType, "Send text/csv or application/x-ndjson, or set format=csv|ndjson")
+		return
+	}
+
+	organizationID, ok := cfg.resolveOrganization(w, r, adminID, requestedOrg)
+	if !ok {
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportBytes)
+	var records []importRecord
+	var err error
+	if format == "csv" {
+		records, err = parseImportCSV(body)
+	} else {
+		records, err = parseImportNDJSON(body)
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.RespondWithError(w, http.StatusRequestEntityTooLarge, "Import file is too large")
+			return
+		}
+		cfg.Logger.WithFields(map[string]any{"error": err.Error(), "format": format}).Warn("Unreadable import file")
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid "+format+" file: "+err.Error())
+		return
+	}
+
+	if len(records) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Import file has no rows")
+		return
+	}
+
+	plan, err := cfg.planImport(r.Context(), records, adminID, organizationID, allowConflicts)
+	if err != nil {
+		cfg.Logger.Error("Failed to validate import", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to validate import")
+		return
+	}
+
+	invalidLines := map[int]bool{}
+	for _, rowErr := range plan.errors {
+		invalidLines[rowErr.Line] = true
+	}
+
+	response := ImportResponse{
+		DryRun:         dryRun,
+		OrganizationID: organizationID,
+		TotalRows:      len(records),
+		ValidRows:      len(plan.venues) + len(plan.events),
+		InvalidRows:    len(invalidLines),
+		Errors:         plan.errors,
+	}
+	if response.Errors == nil {
+		response.Errors = []ImportRowError{}
+	}
+
+	if dryRun {
+		utils.RespondWithJSON(w, http.StatusOK, response)
+		return
+	}
+
+	if response.ValidRows == 0 {
+		utils.RespondWithJSON(w, http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin import transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Import failed")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	createdVenues := map[string]events.Venue{}
+	for _, v := range plan.venues {
+		venue, err := qtx.CreateVenue(r.Context(), v.params)
+		if err != nil {
+			cfg.Logger.WithFields(map[string]any{"line": v.line, "error": err.Error()}).Error("Venue import failed")
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", v.line))
+			return
+		}
+		if err := cfg.recordVenueHistory(r.Context(), qtx, nil, venue, adminID, HistoryActionCreated, 0); err != nil {
+			cfg.Logger.WithFields(map[string]any{"line": v.line, "error": err.Error()}).Error("Failed to record venue history")
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", v.line))
+			return
+		}
+		createdVenues[v.ref] = venue
+		response.Venues = append(response.Venues, venueToResponse(venue))
+	}
+
+	var createdEvents []events.Event
+	for _, e := range plan.events {
+		params := e.params
+		if e.venueRef != "" {
+			params.VenueID = createdVenues[e.venueRef].VenueID
+		} else if !allowConflicts {
+			// Re-check under the venue lock: another admin may have booked
+			// the slot since the file was validated.
+			if _, err := qtx.GetVenueByIDForUpdate(r.Context(), params.VenueID); err != nil {
+				cfg.Logger.WithFields(map[string]any{"line": e.line, "error": err.Error()}).Error("Failed to lock venue for import")
+				utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", e.line))
+				return
+			}
+			conflicts, err := cfg.findVenueConflicts(r.Context(), qtx, venueSchedule{
+				venueID:  params.VenueID,
+				start:    params.StartDatetime,
+				end:      params.EndDatetime,
+				setup:    params.SetupBufferMinutes,
+				teardown: params.TeardownBufferMinutes,
+			})
+			if err != nil {
+				cfg.Logger.WithFields(map[string]any{"line": e.line, "error": err.Error()}).Error("Failed to check venue conflicts for import")
+				utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", e.line))
+				return
+			}
+			if len(conflicts) > 0 {
+				utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Line %d now overlaps another event at its venue, no rows were imported", e.line))
+				return
+			}
+		}
+		event, err := qtx.CreateEvent(r.Context(), params)
+		if err != nil {
+			cfg.Logger.WithFields(map[string]any{"line": e.line, "error": err.Error()}).Error("Event import failed")
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", e.line))
+			return
+		}
+		if err := cfg.recordEventHistory(r.Context(), qtx, nil, event, adminID, HistoryActionCreated, 0); err != nil {
+			cfg.Logger.WithFields(map[string]any{"line": e.line, "error": err.Error()}).Error("Failed to record event history")
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", e.line))
+			return
+		}
+		if event.Status.String == "published" {
+			if err := cfg.queueSearchSync(r.Context(), qtx, event.EventID, SearchSyncUpsert); err != nil {
+				cfg.Logger.WithFields(map[string]any{"line": e.line, "error": err.Error()}).Error("Failed to queue search sync")
+				utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed at line %d, no rows were imported", e.line))
+				return
+			}
+		}
+		createdEvents = append(createdEvents, event)
+		response.Events = append(response.Events, eventToResponse(event))
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit import", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Import failed")
+		return
+	}
+	cfg.wakeSearchSync()
+
+	response.VenuesCreated = len(response.Venues)
+	response.EventsCreated = len(response.Events)
+	cfg.localizeEvents(r.Context(), response.Events)
+	cfg.attachEventCategories(r.Context(), response.Events)
+
+	cfg.Logger.WithFields(map[string]any{
+		"admin_id":        adminID,
+		"organization_id": organizationID,
+		"venues":          response.VenuesCreated,
+		"events":          response.EventsCreated,
+		"invalid_rows":    response.InvalidRows,
+	}).Info("Catalog imported")
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func venueToResponse(venue events.Venue) VenueResponse {
+	return VenueResponse{
+		VenueID:        venue.VenueID,
+		Name:           venue.Name,
+		Address:        venue.Address,
+		City:           venue.City,
+		State:          utils.StringPtrFromNullString(venue.State),
+		Country:        venue.Country,
+		PostalCode:     utils.StringPtrFromNullString(venue.PostalCode),
+		Capacity:       venue.Capacity,
+		LayoutConfig:   utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
+		OrganizationID: utils.UUIDPtrFromNullUUID(venue.OrganizationID),
+		Timezone:       venue.Timezone,
+		Latitude:       utils.Float64PtrFromNullFloat64(venue.Latitude),
+		Longitude:      utils.Float64PtrFromNullFloat64(venue.Longitude),
+		CreatedAt:      venue.CreatedAt.Time,
+		UpdatedAt:      venue.UpdatedAt.Time,
+		DeletedAt:      utils.TimePtrFromNullTime(venue.DeletedAt),
+	}
+}
+
+func eventToResponse(event events.Event) EventResponse {
+	return EventResponse{
+		EventID:               event.EventID,
+		Name:                  event.Name,
+		Description:           utils.StringPtrFromNullString(event.Description),
+		VenueID:               event.VenueID,
+		EventType:             event.EventType,
+		Tags:                  event.Tags,
+		StartDatetime:         event.StartDatetime,
+		EndDatetime:           event.EndDatetime,
+		TotalCapacity:         event.TotalCapacity,
+		AvailableSeats:        event.AvailableSeats,
+		BasePrice:             eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:              event.Currency,
+		MaxTicketsPerBooking:  event.MaxTicketsPerBooking.Int32,
+		Status:                event.Status.String,
+		Version:               event.Version,
+		CreatedBy:             event.CreatedBy,
+		OrganizationID:        utils.UUIDPtrFromNullUUID(event.OrganizationID),
+		SetupBufferMinutes:    event.SetupBufferMinutes,
+		TeardownBufferMinutes: event.TeardownBufferMinutes,
+		CreatedAt:             event.CreatedAt.Time,
+		UpdatedAt:             event.UpdatedAt.Time,
+		DeletedAt:             utils.TimePtrFromNullTime(event.DeletedAt),
+	}
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []importRecord
+		wantErr string
+	}{
+		{
+			name: "header is lowered and trimmed",
+			body: "Type, NAME ,city\nvenue,Hall A,Pune\n",
+			want: []importRecord{
+				{line: 2, fields: map[string]string{"type": "venue", "name": "Hall A", "city": "Pune"}},
+			},
+		},
+		{
+			name: "quoted newline keeps the starting line",
+			body: "type,description\nevent,\"two\nlines\"\nvenue,x\n",
+			want: []importRecord{
+				{line: 2, fields: map[string]string{"type": "event", "description": "two\nlines"}},
+				{line: 4, fields: map[string]string{"type": "venue", "description": "x"}},
+			},
+		},
+		{
+			name: "header only",
+			body: "type,name\n",
+			want: nil,
+		},
+		{
+			name:    "empty file",
+			body:    "",
+			wantErr: "file is empty",
+		},
+		{
+			name:    "short row",
+			body:    "type,name\nvenue\n",
+			wantErr: "wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportCSV(strings.NewReader(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseImportCSV error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImportCSV error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImportCSV = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportNDJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []importRecord
+		wantErr string
+	}{
+		{
+			name: "values become strings",
+			body: `{"type":"venue","capacity":250,"latitude":18.52,"active":true,"state":null}`,
+			want: []importRecord{
+				{line: 1, fields: map[string]string{"type": "venue", "capacity": "250", "latitude": "18.52", "active": "true", "state": ""}},
+			},
+		},
+		{
+			name: "blank lines are skipped but counted",
+			body: "{\"type\":\"venue\"}\n\n  \n{\"type\":\"event\"}\n",
+			want: []importRecord{
+				{line: 1, fields: map[string]string{"type": "venue"}},
+				{line: 4, fields: map[string]string{"type": "event"}},
+			},
+		},
+		{
+			name: "large numbers are not exponents",
+			body: `{"base_price":1500000}`,
+			want: []importRecord{
+				{line: 1, fields: map[string]string{"base_price": "1500000"}},
+			},
+		},
+		{
+			name:    "invalid JSON names its line",
+			body:    "{\"type\":\"venue\"}\n{type}\n",
+			wantErr: "line 2: invalid JSON",
+		},
+		{
+			name:    "nested values are refused",
+			body:    `{"type":"event","tags":["a"]}`,
+			wantErr: "field tags must be a string, number or boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportNDJSON(strings.NewReader(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseImportNDJSON error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImportNDJSON error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImportNDJSON = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportRowLimit(t *testing.T) {
+	var csvBody, ndjsonBody strings.Builder
+	csvBody.WriteString("type\n")
+	for i := 0; i <= maxImportRows; i++ {
+		csvBody.WriteString("venue\n")
+		fmt.Fprintf(&ndjsonBody, "{\"type\":\"venue\",\"n\":%d}\n", i)
+	}
+
+	want := fmt.Sprintf("file exceeds %d rows", maxImportRows)
+	if _, err := parseImportCSV(strings.NewReader(csvBody.String())); err == nil || err.Error() != want {
+		t.Errorf("parseImportCSV error = %v, want %q", err, want)
+	}
+	if _, err := parseImportNDJSON(strings.NewReader(ndjsonBody.String())); err == nil || err.Error() != want {
+		t.Errorf("parseImportNDJSON error = %v, want %q", err, want)
+	}
+}
@@ -49,7 +49,12 @@ func (cfg *APIConfig) UpdateEventAvailability(w http.ResponseWriter, r *http.Req
 			Version:        requestBody.Version,
 		}
 
-		result, err := cfg.DB.UpdateEventAvailability(r.Context(), params)
+		var result events.UpdateEventAvailabilityRow
+		err := cfg.withSearchSync(r.Context(), eventID, func(q events.Querier) error {
+			var err error
+			result, err = q.UpdateEventAvailability(r.Context(), params)
+			return err
+		})
 		if err != nil {
 			cfg.Logger.Error("Failed to update event availability (reserve)",
 				"error", err,
@@ -83,7 +88,6 @@ func (cfg *APIConfig) UpdateEventAvailability(w http.ResponseWriter, r *http.Req
 			"new_version", result.Version)
 
 		fmt.Printf("Reserving %d seats for event %s, %d seats remaining\n", seatsToReserve, eventID.String(), result.AvailableSeats)
-		cfg.updateSearchAvailability(r.Context(), eventID, result.AvailableSeats)
 
 		utils.RespondWithJSON(w, http.StatusOK, response)
 
@@ -94,7 +98,12 @@ func (cfg *APIConfig) UpdateEventAvailability(w http.ResponseWriter, r *http.Req
 			Version:        requestBody.Version,
 		}
 
-		result, err := cfg.DB.ReturnEventSeats(r.Context(), params)
+		var result events.ReturnEventSeatsRow
+		err := cfg.withSearchSync(r.Context(), eventID, func(q events.Querier) error {
+			var err error
+			result, err = q.ReturnEventSeats(r.Context(), params)
+			return err
+		})
 		if err != nil {
 			cfg.Logger.Error("Failed to return event seats",
 				"error", err,
@@ -124,7 +133,6 @@ func (cfg *APIConfig) UpdateEventAvailability(w http.ResponseWriter, r *http.Req
 			"new_version", result.Version)
 
 		fmt.Printf("Return here %d seats for event %s, %d seats available\n", requestBody.Quantity, eventID.String(), result.AvailableSeats)
-		cfg.updateSearchAvailability(r.Context(), eventID, result.AvailableSeats)
 		utils.RespondWithJSON(w, http.StatusOK, response)
 	}
 }
@@ -159,7 +167,12 @@ func (cfg *APIConfig) ReturnEventSeats(w http.ResponseWriter, r *http.Request) {
 		Version:        requestBody.Version,
 	}
 
-	result, err := cfg.DB.ReturnEventSeats(r.Context(), params)
+	var result events.ReturnEventSeatsRow
+	err = cfg.withSearchSync(r.Context(), eventID, func(q events.Querier) error {
+		var err error
+		result, err = q.ReturnEventSeats(r.Context(), params)
+		return err
+	})
 	if err != nil {
 		cfg.Logger.Error("Failed to return seats",
 			"error", err,
@@ -189,7 +202,6 @@ func (cfg *APIConfig) ReturnEventSeats(w http.ResponseWriter, r *http.Request) {
 		"new_version", result.Version)
 
 	fmt.Printf("Returned %d seats/internal/events/{id}/return-seats endpoint for event %s, %d seats available\n", requestBody.Quantity, eventID.String(), result.AvailableSeats)
-	cfg.updateSearchAvailability(r.Context(), eventID, result.AvailableSeats)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
@@ -201,6 +213,11 @@ func (cfg *APIConfig) GetEventForBooking(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Get("include_deleted") == "true" {
+		cfg.getEventRecord(w, r, eventID)
+		return
+	}
+
 	event, err := cfg.DB.GetEventForBooking(r.Context(), eventID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -216,16 +233,12 @@ func (cfg *APIConfig) GetEventForBooking(w http.ResponseWriter, r *http.Request)
 		EventID:              event.EventID,
 		AvailableSeats:       event.AvailableSeats,
 		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
-		BasePrice: func() float64 {
-			var price float64
-			if _, err := fmt.Sscanf(event.BasePrice, "%f", &price); err != nil {
-				return 0.0
-			}
-			return price
-		}(),
-		Version: event.Version,
-		Status:  event.Status.String,
-		Name:    event.Name,
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		BasePriceMinor:       event.BasePriceMinor,
+		Currency:             event.Currency,
+		Version:              event.Version,
+		Status:               event.Status.String,
+		Name:                 event.Name,
 	}
 
 	cfg.Logger.Info("Event fetched for booking validation",
@@ -237,67 +250,100 @@ func (cfg *APIConfig) GetEventForBooking(w http.ResponseWriter, r *http.Request)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
-func (cfg *APIConfig) updateSearchAvailability(ctx context.Context, eventID uuid.UUID, availableSeats int32) {
-	if cfg.SearchClient == nil {
+// getEventRecord resolves an event whatever its status, including deleted
+// ones, for work on existing bookings such as showing them or returning
+// their seats.
+func (cfg *APIConfig) getEventRecord(w http.ResponseWriter, r *http.Request, eventID uuid.UUID) {
+	event, err := cfg.DB.GetEventRecord(r.Context(), eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		cfg.Logger.Error("Failed to fetch event record", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch event")
 		return
 	}
 
-	go func() {
-		event, err := cfg.DB.GetEventByID(ctx, eventID)
-		if err != nil {
-			cfg.Logger.Error("Failed to get event for search availability update", "error", err, "event_id", eventID)
-			return
-		}
+	utils.RespondWithJSON(w, http.StatusOK, EventForBookingResponse{
+		EventID:              event.EventID,
+		AvailableSeats:       event.AvailableSeats,
+		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		BasePriceMinor:       event.BasePriceMinor,
+		Currency:             event.Currency,
+		Version:              event.Version,
+		Status:               event.Status.String,
+		Name:                 event.Name,
+		DeletedAt:            utils.TimePtrFromNullTime(event.DeletedAt),
+	})
+}
 
-		venue, err := cfg.DB.GetVenueByID(ctx, event.VenueID)
-		if err != nil {
-			cfg.Logger.Error("Failed to get venue for search availability update", "error", err, "venue_id", event.VenueID)
-			return
-		}
+// venueSearchFieldsChanged reports whether an update touched venue fields
+// that are copied into the search documents of the venue's events.
+func venueSearchFieldsChanged(before, after events.Venue) bool {
+	return before.Name != after.Name ||
+		before.Address != after.Address ||
+		before.City != after.City ||
+		before.State != after.State ||
+		before.Country != after.Country ||
+		before.Timezone != after.Timezone ||
+		before.Latitude != after.Latitude ||
+		before.Longitude != after.Longitude
+}
 
-		response := EventResponse{
-			EventID:        event.EventID,
-			Name:           event.Name,
-			Description:    utils.StringPtrFromNullString(event.Description),
-			VenueID:        event.VenueID,
-			EventType:      event.EventType,
-			StartDatetime:  event.StartDatetime,
-			EndDatetime:    event.EndDatetime,
-			TotalCapacity:  event.TotalCapacity,
-			AvailableSeats: event.AvailableSeats,
-			BasePrice: func() float64 {
-				var price float64
-				if _, err := fmt.Sscanf(event.BasePrice, "%f", &price); err != nil {
-					return 0.0
-				}
-				return price
-			}(),
-			MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
-			Status:               event.Status.String,
-			Version:              event.Version,
-			CreatedBy:            event.CreatedBy,
-			CreatedAt:            event.CreatedAt.Time,
-			UpdatedAt:            event.UpdatedAt.Time,
-		}
+// searchDocument builds the search document for the event's current state,
+// including its images.
+func (cfg *APIConfig) searchDocument(ctx context.Context, eventID uuid.UUID) (SearchEventDocument, error) {
+	event, err := cfg.DB.GetEventByID(ctx, eventID)
+	if err != nil {
+		return SearchEventDocument{}, fmt.Errorf("failed to get event: %w", err)
+	}
 
-		venueResp := VenueResponse{
-			VenueID:      venue.VenueID,
-			Name:         venue.Name,
-			Address:      venue.Address,
-			City:         venue.City,
-			State:        utils.StringPtrFromNullString(venue.State),
-			Country:      venue.Country,
-			PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-			Capacity:     venue.Capacity,
-			LayoutConfig: utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
-			CreatedAt:    venue.CreatedAt.Time,
-			UpdatedAt:    venue.UpdatedAt.Time,
-		}
+	venue, err := cfg.DB.GetVenueByID(ctx, event.VenueID)
+	if err != nil {
+		return SearchEventDocument{}, fmt.Errorf("failed to get venue %s: %w", event.VenueID, err)
+	}
 
-		if err := cfg.SearchClient.UpdateEvent(ctx, response, venueResp); err != nil {
-			cfg.Logger.Error("Failed to update event availability in search service", "error", err, "event_id", eventID)
-		} else {
-			cfg.Logger.Debug("Updated event availability in search service", "event_id", eventID, "available_seats", availableSeats)
-		}
-	}()
+	response := EventResponse{
+		EventID:              event.EventID,
+		Name:                 event.Name,
+		Description:          utils.StringPtrFromNullString(event.Description),
+		VenueID:              event.VenueID,
+		EventType:            event.EventType,
+		Categories:           cfg.loadEventCategories(ctx, event.EventType),
+		Tags:                 event.Tags,
+		StartDatetime:        event.StartDatetime,
+		EndDatetime:          event.EndDatetime,
+		TotalCapacity:        event.TotalCapacity,
+		AvailableSeats:       event.AvailableSeats,
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:             event.Currency,
+		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
+		Status:               event.Status.String,
+		Version:              event.Version,
+		CreatedBy:            event.CreatedBy,
+		CreatedAt:            event.CreatedAt.Time,
+		UpdatedAt:            event.UpdatedAt.Time,
+		Images:               cfg.loadEventImages(ctx, eventID),
+	}
+
+	venueResp := VenueResponse{
+		VenueID:      venue.VenueID,
+		Name:         venue.Name,
+		Address:      venue.Address,
+		City:         venue.City,
+		State:        utils.StringPtrFromNullString(venue.State),
+		Country:      venue.Country,
+		PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
+		Capacity:     venue.Capacity,
+		LayoutConfig: utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
+		Timezone:     venue.Timezone,
+		Latitude:     utils.Float64PtrFromNullFloat64(venue.Latitude),
+		Longitude:    utils.Float64PtrFromNullFloat64(venue.Longitude),
+		CreatedAt:    venue.CreatedAt.Time,
+		UpdatedAt:    venue.UpdatedAt.Time,
+	}
+
+	return newSearchEventDocument(response, venueResp), nil
 }
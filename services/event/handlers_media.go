@@ -0,0 +1,524 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	MediaKindPoster  = "poster"
+	MediaKindBanner  = "banner"
+	MediaKindGallery = "gallery"
+
+	maxGalleryImages = 20
+	// multipartOverhead leaves room for boundaries and form fields on top of
+	// the file itself.
+	multipartOverhead = 1 << 20
+)
+
+func isValidMediaKind(kind string) bool {
+	return kind == MediaKindPoster || kind == MediaKindBanner || kind == MediaKindGallery
+}
+
+// storedVariant is one entry of event_media.variants. URLs are derived from
+// the key at read time so the public base URL can change without a backfill.
+type storedVariant struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Width       int32  `json:"width"`
+	Height      int32  `json:"height"`
+}
+
+func mediaObjectKey(eventID, mediaID uuid.UUID, name, extension string) string {
+	return fmt.Sprintf("events/%s/%s/%s.%s", eventID, mediaID, name, extension)
+}
+
+func (cfg *APIConfig) UploadEventMedia(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager); !ok {
+		return
+	}
+
+	if cfg.MediaStore == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Media storage is not configured")
+		return
+	}
+
+	maxBytes := int64(cfg.Config.MediaMaxUploadBytes)
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+multipartOverhead)
+
+	kind, data, status, err := readMediaUpload(r, maxBytes)
+	if err != nil {
+		utils.RespondWithError(w, status, err.Error())
+		return
+	}
+	if kind == "" {
+		kind = r.URL.Query().Get("kind")
+	}
+	if kind == "" {
+		kind = MediaKindGallery
+	}
+	if !isValidMediaKind(kind) {
+		utils.RespondWithError(w, http.StatusBadRequest, "kind must be one of: poster, banner, gallery")
+		return
+	}
+
+	contentType, err := sniffImageType(data)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	img, err := decodeUploadedImage(data)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mediaID := uuid.New()
+	bounds := img.Bounds()
+	original := storedVariant{
+		Key:         mediaObjectKey(eventID, mediaID, "original", allowedImageTypes[contentType]),
+		ContentType: contentType,
+		Width:       int32(bounds.Dx()),
+		Height:      int32(bounds.Dy()),
+	}
+
+	// Objects are written before the row so the row never points at missing
+	// files; anything written is removed again if the request fails.
+	var storedKeys []string
+	committed := false
+	defer func() {
+		if !committed && len(storedKeys) > 0 {
+			cfg.deleteMediaObjects(context.Background(), storedKeys)
+		}
+	}()
+
+	if err := cfg.MediaStore.Put(r.Context(), original.Key, contentType, data); err != nil {
+		cfg.Logger.Error("Failed to store media", "error", err, "event_id", eventID, "key", original.Key)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to store image")
+		return
+	}
+	storedKeys = append(storedKeys, original.Key)
+
+	variants := make(map[string]storedVariant, len(imageVariantSizes))
+	for _, size := range imageVariantSizes {
+		encoded, resized, err := resizedVariant(img, contentType, size.MaxEdge)
+		if err != nil {
+			cfg.Logger.Error("Failed to generate image variant", "error", err, "event_id", eventID, "variant", size.Name)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to process image")
+			return
+		}
+		if !resized {
+			variants[size.Name] = original
+			continue
+		}
+
+		variant := storedVariant{
+			Key:         mediaObjectKey(eventID, mediaID, size.Name, encoded.Extension),
+			ContentType: encoded.ContentType,
+			Width:       int32(encoded.Width),
+			Height:      int32(encoded.Height),
+		}
+		if err := cfg.MediaStore.Put(r.Context(), variant.Key, variant.ContentType, encoded.Data); err != nil {
+			cfg.Logger.Error("Failed to store media variant", "error", err, "event_id", eventID, "key", variant.Key)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to store image")
+			return
+		}
+		storedKeys = append(storedKeys, variant.Key)
+		variants[size.Name] = variant
+	}
+
+	variantsJSON, err := json.Marshal(variants)
+	if err != nil {
+		cfg.Logger.Error("Failed to encode media variants", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+	defer tx.Rollback()
+
+	qtx := events.New(tx)
+
+	// Locking the event serializes uploads so the gallery limit and
+	// positions hold under concurrent requests.
+	if _, err := qtx.LockEvent(r.Context(), eventID); err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+
+	var replaced []events.EventMedium
+	var position int32
+	if kind == MediaKindGallery {
+		count, err := qtx.CountEventMediaByKind(r.Context(), events.CountEventMediaByKindParams{EventID: eventID, Kind: kind})
+		if err != nil {
+			cfg.Logger.Error("Failed to count event media", "error", err, "event_id", eventID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+			return
+		}
+		if count >= maxGalleryImages {
+			utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("An event gallery can hold at most %d images", maxGalleryImages))
+			return
+		}
+		position, err = qtx.NextEventMediaPosition(r.Context(), events.NextEventMediaPositionParams{EventID: eventID, Kind: kind})
+		if err != nil {
+			cfg.Logger.Error("Failed to get next media position", "error", err, "event_id", eventID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+			return
+		}
+	} else {
+		// Posters and banners are singletons; a new upload replaces the old one.
+		replaced, err = qtx.DeleteEventMediaByKind(r.Context(), events.DeleteEventMediaByKindParams{EventID: eventID, Kind: kind})
+		if err != nil {
+			cfg.Logger.Error("Failed to replace event media", "error", err, "event_id", eventID, "kind", kind)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+			return
+		}
+	}
+
+	media, err := qtx.CreateEventMedia(r.Context(), events.CreateEventMediaParams{
+		MediaID:     mediaID,
+		EventID:     eventID,
+		Kind:        kind,
+		ContentType: contentType,
+		Width:       original.Width,
+		Height:      original.Height,
+		SizeBytes:   int64(len(data)),
+		StorageKey:  original.Key,
+		Variants:    variantsJSON,
+		Position:    position,
+		UploadedBy:  adminID,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to create event media", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+
+	if err := cfg.queueSearchSync(r.Context(), qtx, eventID, SearchSyncUpsert); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event media", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save image")
+		return
+	}
+	committed = true
+	cfg.wakeSearchSync()
+
+	for _, old := range replaced {
+		cfg.deleteMediaObjects(r.Context(), mediaObjectKeys(old))
+	}
+
+	cfg.Logger.WithFields(map[string]any{
+		"event_id":   eventID,
+		"media_id":   mediaID,
+		"kind":       kind,
+		"size_bytes": len(data),
+		"admin_id":   adminID,
+	}).Info("Event media uploaded")
+
+	utils.RespondWithJSON(w, http.StatusCreated, cfg.mediaToResponse(media))
+}
+
+// readMediaUpload streams the multipart body and returns the kind field and
+// the contents of the file part, enforcing the upload size limit.
+func readMediaUpload(r *http.Request, maxBytes int64) (string, []byte, int, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, http.StatusBadRequest, errors.New("request must be multipart/form-data with a file field")
+	}
+
+	var kind string
+	var data []byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return "", nil, http.StatusRequestEntityTooLarge, fmt.Errorf("image exceeds the %d byte upload limit", maxBytes)
+			}
+			return "", nil, http.StatusBadRequest, errors.New("malformed multipart body")
+		}
+
+		switch part.FormName() {
+		case "kind":
+			value, err := io.ReadAll(io.LimitReader(part, 32))
+			if err != nil {
+				return "", nil, http.StatusBadRequest, errors.New("malformed multipart body")
+			}
+			kind = strings.TrimSpace(string(value))
+		case "file":
+			if data != nil {
+				return "", nil, http.StatusBadRequest, errors.New("only one file can be uploaded per request")
+			}
+			data, err = io.ReadAll(io.LimitReader(part, maxBytes+1))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					return "", nil, http.StatusRequestEntityTooLarge, fmt.Errorf("image exceeds the %d byte upload limit", maxBytes)
+				}
+				return "", nil, http.StatusBadRequest, errors.New("failed to read uploaded file")
+			}
+			if int64(len(data)) > maxBytes {
+				return "", nil, http.StatusRequestEntityTooLarge, fmt.Errorf("image exceeds the %d byte upload limit", maxBytes)
+			}
+		}
+		part.Close()
+	}
+
+	if len(data) == 0 {
+		return "", nil, http.StatusBadRequest, errors.New("file is required")
+	}
+	return kind, data, http.StatusOK, nil
+}
+
+func (cfg *APIConfig) ListEventMedia(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	media, err := cfg.DB.ListEventMedia(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list event media", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch event media")
+		return
+	}
+
+	response := EventMediaListResponse{
+		EventID: eventID,
+		Media:   make([]EventMediaResponse, len(media)),
+	}
+	for i, m := range media {
+		response.Media[i] = cfg.mediaToResponse(m)
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) DeleteEventMedia(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	mediaIDStr := r.PathValue("mediaId")
+	mediaID, err := uuid.Parse(mediaIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager); !ok {
+		return
+	}
+
+	var media events.EventMedium
+	err = cfg.withSearchSync(r.Context(), eventID, func(q events.Querier) error {
+		var err error
+		media, err = q.DeleteEventMedia(r.Context(), events.DeleteEventMediaParams{
+			MediaID: mediaID,
+			EventID: eventID,
+		})
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Media not found")
+			return
+		}
+		cfg.Logger.Error("Failed to delete event media", "error", err, "media_id", mediaID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete media")
+		return
+	}
+
+	if cfg.MediaStore != nil {
+		cfg.deleteMediaObjects(r.Context(), mediaObjectKeys(media))
+	}
+
+	cfg.Logger.Info("Event media deleted", "event_id", eventID, "media_id", mediaID, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusOK, SuccessResponse{Message: "Media deleted successfully"})
+}
+
+// mediaObjectKeys lists every stored object of a media row; variants that
+// reuse the original are only listed once.
+func mediaObjectKeys(media events.EventMedium) []string {
+	keys := []string{media.StorageKey}
+	seen := map[string]bool{media.StorageKey: true}
+	for _, variant := range decodeStoredVariants(media.Variants) {
+		if !seen[variant.Key] {
+			seen[variant.Key] = true
+			keys = append(keys, variant.Key)
+		}
+	}
+	return keys
+}
+
+// deleteMediaObjects removes objects best-effort; the rows are already gone,
+// so a failure only leaves an orphaned file behind.
+func (cfg *APIConfig) deleteMediaObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := cfg.MediaStore.Delete(ctx, key); err != nil {
+			cfg.Logger.Warn("Failed to delete media object", "error", err, "key", key)
+		}
+	}
+}
+
+func decodeStoredVariants(raw json.RawMessage) map[string]storedVariant {
+	variants := map[string]storedVariant{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &variants)
+	}
+	return variants
+}
+
+func (cfg *APIConfig) mediaURL(key string) string {
+	if cfg.MediaStore == nil {
+		return ""
+	}
+	return cfg.MediaStore.URL(key)
+}
+
+func (cfg *APIConfig) mediaToResponse(media events.EventMedium) EventMediaResponse {
+	stored := decodeStoredVariants(media.Variants)
+	variants := make(map[string]MediaVariant, len(stored))
+	for name, variant := range stored {
+		variants[name] = MediaVariant{
+			URL:         cfg.mediaURL(variant.Key),
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+		}
+	}
+	return EventMediaResponse{
+		MediaID:     media.MediaID,
+		EventID:     media.EventID,
+		Kind:        media.Kind,
+		URL:         cfg.mediaURL(media.StorageKey),
+		ContentType: media.ContentType,
+		Width:       media.Width,
+		Height:      media.Height,
+		SizeBytes:   media.SizeBytes,
+		Position:    media.Position,
+		Variants:    variants,
+		CreatedAt:   media.CreatedAt.Time,
+	}
+}
+
+// eventImages groups an event's media rows, which must be ordered by kind
+// and position, into the images block of an event response.
+func (cfg *APIConfig) eventImages(media []events.EventMedium) *EventImages {
+	if len(media) == 0 {
+		return nil
+	}
+	images := &EventImages{}
+	for _, m := range media {
+		response := cfg.mediaToResponse(m)
+		switch m.Kind {
+		case MediaKindPoster:
+			images.Poster = &response
+		case MediaKindBanner:
+			images.Banner = &response
+		default:
+			images.Gallery = append(images.Gallery, response)
+		}
+	}
+	return images
+}
+
+func (cfg *APIConfig) loadEventImages(ctx context.Context, eventID uuid.UUID) *EventImages {
+	media, err := cfg.DB.ListEventMedia(ctx, eventID)
+	if err != nil {
+		cfg.Logger.Warn("Failed to load event images", "error", err, "event_id", eventID)
+		return nil
+	}
+	return cfg.eventImages(media)
+}
+
+// attachEventImages fills in the images of the given events with one query.
+// Images are decoration, so a failure is logged and the events are returned
+// without them.
+func (cfg *APIConfig) attachEventImages(ctx context.Context, responses []EventResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	eventIDs := make([]uuid.UUID, len(responses))
+	for i := range responses {
+		eventIDs[i] = responses[i].EventID
+	}
+
+	media, err := cfg.DB.ListEventMediaByEvents(ctx, eventIDs)
+	if err != nil {
+		cfg.Logger.Warn("Failed to load event images", "error", err, "events", len(eventIDs))
+		return
+	}
+
+	byEvent := make(map[uuid.UUID][]events.EventMedium)
+	for _, m := range media {
+		byEvent[m.EventID] = append(byEvent[m.EventID], m)
+	}
+	for i := range responses {
+		responses[i].Images = cfg.eventImages(byEvent[responses[i].EventID])
+	}
+}
@@ -0,0 +1,806 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	OrgRoleOwner   = "owner"
+	OrgRoleManager = "manager"
+	OrgRoleViewer  = "viewer"
+)
+
+var orgRoleRank = map[string]int{
+	OrgRoleViewer:  1,
+	OrgRoleManager: 2,
+	OrgRoleOwner:   3,
+}
+
+func isValidOrgRole(role string) bool {
+	_, ok := orgRoleRank[role]
+	return ok
+}
+
+func orgRoleAtLeast(role, required string) bool {
+	return orgRoleRank[role] >= orgRoleRank[required]
+}
+
+// authorizeEvent verifies the admin reaches the event through one of their
+// organizations with at least the required role. It writes the error response
+// itself and returns false when access is denied.
+func (cfg *APIConfig) authorizeEvent(w http.ResponseWriter, r *http.Request, eventID, adminID uuid.UUID, required string) (events.CheckEventOwnershipRow, bool) {
+	ownership, err := cfg.DB.CheckEventOwnership(r.Context(), events.CheckEventOwnershipParams{
+		EventID: eventID,
+		AdminID: adminID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
+			return ownership, false
+		}
+		cfg.Logger.Error("Failed to check event ownership", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify event ownership")
+		return ownership, false
+	}
+
+	if !orgRoleAtLeast(ownership.Role, required) {
+		utils.RespondWithError(w, http.StatusForbidden, fmt.Sprintf("This action requires the %s role in the event's organization", required))
+		return ownership, false
+	}
+
+	return ownership, true
+}
+
+// authorizeOrganization returns the admin's membership in the organization if
+// their role is at least the required one.
+func (cfg *APIConfig) authorizeOrganization(w http.ResponseWriter, r *http.Request, organizationID, adminID uuid.UUID, required string) (events.OrganizationMember, bool) {
+	member, err := cfg.DB.GetOrganizationMember(r.Context(), events.GetOrganizationMemberParams{
+		OrganizationID: organizationID,
+		AdminID:        adminID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Organization not found or you don't have permission")
+			return member, false
+		}
+		cfg.Logger.Error("Failed to check organization membership", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify organization membership")
+		return member, false
+	}
+
+	if !orgRoleAtLeast(member.Role, required) {
+		utils.RespondWithError(w, http.StatusForbidden, fmt.Sprintf("This action requires the %s role in the organization", required))
+		return member, false
+	}
+
+	return member, true
+}
+
+// resolveOrganization picks the organization a new event belongs to. An explicit
+// organization must be one the admin manages; otherwise the admin must manage
+// exactly one organization.
+func (cfg *APIConfig) resolveOrganization(w http.ResponseWriter, r *http.Request, adminID, requested uuid.UUID) (uuid.UUID, bool) {
+	if requested != uuid.Nil {
+		if _, ok := cfg.authorizeOrganization(w, r, requested, adminID, OrgRoleManager); !ok {
+			return uuid.Nil, false
+		}
+		return requested, true
+	}
+
+	orgs, err := cfg.DB.ListAdminOrganizations(r.Context(), adminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list admin organizations", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to resolve organization")
+		return uuid.Nil, false
+	}
+
+	var managed []uuid.UUID
+	for _, org := range orgs {
+		if orgRoleAtLeast(org.Role, OrgRoleManager) {
+			managed = append(managed, org.OrganizationID)
+		}
+	}
+
+	switch len(managed) {
+	case 0:
+		utils.RespondWithError(w, http.StatusForbidden, "You don't manage any organization")
+		return uuid.Nil, false
+	case 1:
+		return managed[0], true
+	default:
+		utils.RespondWithError(w, http.StatusBadRequest, "organization_id is required when you manage multiple organizations")
+		return uuid.Nil, false
+	}
+}
+
+// authorizeVenue allows changes to organization venues for that
+// organization's managers. Shared venues are used by every organization, so
+// only a super_admin may change them.
+func (cfg *APIConfig) authorizeVenue(w http.ResponseWriter, r *http.Request, venue events.Venue, adminID uuid.UUID) bool {
+	if !venue.OrganizationID.Valid {
+		return cfg.requireSuperAdmin(w, r, adminID)
+	}
+	_, ok := cfg.authorizeOrganization(w, r, venue.OrganizationID.UUID, adminID, OrgRoleManager)
+	return ok
+}
+
+// checkVenueAvailableToOrganization rejects venues owned by a different organization.
+func (cfg *APIConfig) checkVenueAvailableToOrganization(w http.ResponseWriter, r *http.Request, venueID, organizationID uuid.UUID) bool {
+	venue, err := cfg.DB.GetVenueByID(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID - venue does not exist")
+			return false
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify venue")
+		return false
+	}
+
+	if venue.OrganizationID.Valid && venue.OrganizationID.UUID != organizationID {
+		utils.RespondWithError(w, http.StatusForbidden, "Venue belongs to another organization")
+		return false
+	}
+	return true
+}
+
+func (cfg *APIConfig) organizationsForAdmin(ctx context.Context, adminID uuid.UUID) ([]OrganizationResponse, error) {
+	orgs, err := cfg.DB.ListAdminOrganizations(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]OrganizationResponse, len(orgs))
+	for i, org := range orgs {
+		responses[i] = OrganizationResponse{
+			OrganizationID: org.OrganizationID,
+			Name:           org.Name,
+			CreatedBy:      org.CreatedBy,
+			Role:           org.Role,
+			CreatedAt:      org.CreatedAt.Time,
+			UpdatedAt:      org.UpdatedAt.Time,
+		}
+	}
+	return responses, nil
+}
+
+// createOrganizationWithOwner creates an organization and makes the creator its owner.
+func createOrganizationWithOwner(ctx context.Context, q *events.Queries, name string, adminID uuid.UUID) (events.Organization, error) {
+	org, err := q.CreateOrganization(ctx, events.CreateOrganizationParams{
+		Name:      name,
+		CreatedBy: adminID,
+	})
+	if err != nil {
+		return org, err
+	}
+
+	_, err = q.AddOrganizationMember(ctx, events.AddOrganizationMemberParams{
+		OrganizationID: org.OrganizationID,
+		AdminID:        adminID,
+		Role:           OrgRoleOwner,
+	})
+	return org, err
+}
+
+func (cfg *APIConfig) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	var requestBody CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if strings.TrimSpace(requestBody.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create organization")
+		return
+	}
+	defer tx.Rollback()
+
+	org, err := createOrganizationWithOwner(r.Context(), events.New(tx), strings.TrimSpace(requestBody.Name), adminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to create organization", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create organization")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit organization", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create organization")
+		return
+	}
+
+	cfg.Logger.Info("Organization created successfully", "organization_id", org.OrganizationID, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusCreated, OrganizationResponse{
+		OrganizationID: org.OrganizationID,
+		Name:           org.Name,
+		CreatedBy:      org.CreatedBy,
+		Role:           OrgRoleOwner,
+		CreatedAt:      org.CreatedAt.Time,
+		UpdatedAt:      org.UpdatedAt.Time,
+	})
+}
+
+func (cfg *APIConfig) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgs, err := cfg.organizationsForAdmin(r.Context(), adminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list organizations", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch organizations")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, OrganizationListResponse{Organizations: orgs})
+}
+
+func (cfg *APIConfig) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	member, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer)
+	if !ok {
+		return
+	}
+
+	org, err := cfg.DB.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		cfg.Logger.Error("Failed to get organization", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch organization")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, OrganizationResponse{
+		OrganizationID: org.OrganizationID,
+		Name:           org.Name,
+		CreatedBy:      org.CreatedBy,
+		Role:           member.Role,
+		CreatedAt:      org.CreatedAt.Time,
+		UpdatedAt:      org.UpdatedAt.Time,
+	})
+}
+
+func (cfg *APIConfig) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	var requestBody UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if strings.TrimSpace(requestBody.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleOwner); !ok {
+		return
+	}
+
+	org, err := cfg.DB.UpdateOrganization(r.Context(), events.UpdateOrganizationParams{
+		OrganizationID: orgID,
+		Name:           strings.TrimSpace(requestBody.Name),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to update organization", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update organization")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, OrganizationResponse{
+		OrganizationID: org.OrganizationID,
+		Name:           org.Name,
+		CreatedBy:      org.CreatedBy,
+		Role:           OrgRoleOwner,
+		CreatedAt:      org.CreatedAt.Time,
+		UpdatedAt:      org.UpdatedAt.Time,
+	})
+}
+
+func (cfg *APIConfig) ListOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	members, err := cfg.DB.ListOrganizationMembers(r.Context(), orgID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list organization members", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	memberResponses := make([]OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = OrganizationMemberResponse{
+			AdminID:  member.AdminID,
+			Email:    member.Email,
+			Name:     member.Name,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt.Time,
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, OrganizationMemberListResponse{Members: memberResponses})
+}
+
+func (cfg *APIConfig) AddOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	var requestBody AddOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if requestBody.Email == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	role := requestBody.Role
+	if role == "" {
+		role = OrgRoleManager
+	}
+	if !isValidOrgRole(role) {
+		utils.RespondWithError(w, http.StatusBadRequest, "Role must be one of owner, manager, viewer")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleOwner); !ok {
+		return
+	}
+
+	newMember, err := cfg.DB.GetAdminByEmail(r.Context(), requestBody.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Admin not found")
+			return
+		}
+		cfg.Logger.Error("Failed to look up admin", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to add member")
+		return
+	}
+
+	member, err := cfg.DB.AddOrganizationMember(r.Context(), events.AddOrganizationMemberParams{
+		OrganizationID: orgID,
+		AdminID:        newMember.AdminID,
+		Role:           role,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+			utils.RespondWithError(w, http.StatusConflict, "Admin is already a member of this organization")
+			return
+		}
+		cfg.Logger.Error("Failed to add organization member", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to add member")
+		return
+	}
+
+	cfg.Logger.Info("Organization member added", "organization_id", orgID, "member_id", newMember.AdminID, "role", role, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusCreated, OrganizationMemberResponse{
+		AdminID:  member.AdminID,
+		Email:    newMember.Email,
+		Name:     newMember.Name,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt.Time,
+	})
+}
+
+func (cfg *APIConfig) UpdateOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	memberID, err := uuid.Parse(r.PathValue("adminId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid admin ID")
+		return
+	}
+
+	var requestBody UpdateOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if !isValidOrgRole(requestBody.Role) {
+		utils.RespondWithError(w, http.StatusBadRequest, "Role must be one of owner, manager, viewer")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleOwner); !ok {
+		return
+	}
+
+	current, err := cfg.DB.GetOrganizationMember(r.Context(), events.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		AdminID:        memberID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Member not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get organization member", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+
+	if current.Role == OrgRoleOwner && requestBody.Role != OrgRoleOwner {
+		if !cfg.hasAnotherOwner(w, r, orgID) {
+			return
+		}
+	}
+
+	member, err := cfg.DB.UpdateOrganizationMemberRole(r.Context(), events.UpdateOrganizationMemberRoleParams{
+		OrganizationID: orgID,
+		AdminID:        memberID,
+		Role:           requestBody.Role,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to update organization member", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+
+	cfg.Logger.Info("Organization member role updated", "organization_id", orgID, "member_id", memberID, "role", member.Role, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusOK, OrganizationMemberResponse{
+		AdminID:  member.AdminID,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt.Time,
+	})
+}
+
+func (cfg *APIConfig) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	memberID, err := uuid.Parse(r.PathValue("adminId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid admin ID")
+		return
+	}
+
+	// Members may leave on their own; removing someone else takes an owner.
+	required := OrgRoleOwner
+	if memberID == adminID {
+		required = OrgRoleViewer
+	}
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, required); !ok {
+		return
+	}
+
+	current, err := cfg.DB.GetOrganizationMember(r.Context(), events.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		AdminID:        memberID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Member not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get organization member", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	if current.Role == OrgRoleOwner && !cfg.hasAnotherOwner(w, r, orgID) {
+		return
+	}
+
+	err = cfg.DB.RemoveOrganizationMember(r.Context(), events.RemoveOrganizationMemberParams{
+		OrganizationID: orgID,
+		AdminID:        memberID,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to remove organization member", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	cfg.Logger.Info("Organization member removed", "organization_id", orgID, "member_id", memberID, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusOK, SuccessResponse{Message: "Member removed successfully"})
+}
+
+// hasAnotherOwner guards against an organization losing its last owner.
+func (cfg *APIConfig) hasAnotherOwner(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) bool {
+	owners, err := cfg.DB.CountOrganizationOwners(r.Context(), orgID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count organization owners", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify organization owners")
+		return false
+	}
+	if owners <= 1 {
+		utils.RespondWithError(w, http.StatusConflict, "Organization must keep at least one owner")
+		return false
+	}
+	return true
+}
+
+func (cfg *APIConfig) GetOrganizationAnalytics(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer); !ok {
+		return
+	}
+
+	reporting, err := cfg.requestedCurrency(r.URL.Query().Get("currency"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	analytics, err := cfg.DB.GetOrganizationAnalytics(r.Context(), uuid.NullUUID{UUID: orgID, Valid: true})
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch organization analytics", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+		return
+	}
+
+	revenue, err := cfg.DB.GetOrganizationRevenueByCurrency(r.Context(), uuid.NullUUID{UUID: orgID, Valid: true})
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch organization revenue", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+		return
+	}
+
+	response := OrganizationAnalyticsResponse{
+		OrganizationID:    orgID,
+		TotalEvents:       analytics.TotalEvents,
+		PublishedEvents:   analytics.PublishedEvents,
+		TotalCapacity:     analytics.TotalCapacity,
+		TicketsSold:       analytics.TicketsSold,
+		ReportingCurrency: reporting.Code,
+		RevenueByCurrency: make([]CurrencyRevenue, len(revenue)),
+	}
+
+	// Revenue in currencies without a rate is listed but left out of the
+	// total rather than guessed at.
+	var totalMinor int64
+	now := time.Now()
+	for i, row := range revenue {
+		currency := money.MustLookup(row.Currency)
+		response.RevenueByCurrency[i] = CurrencyRevenue{
+			Currency:         currency.Code,
+			TicketsSold:      row.TicketsSold,
+			EstimatedRevenue: currency.ToMajor(row.EstimatedRevenueMinor),
+		}
+		converted, err := cfg.convertAmount(r.Context(), row.EstimatedRevenueMinor, currency, reporting, now)
+		if err != nil {
+			if !errors.Is(err, errNoExchangeRate) {
+				cfg.Logger.Error("Failed to convert revenue", "error", err, "from", currency.Code, "to", reporting.Code)
+				utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+				return
+			}
+			response.UnconvertedCurrencies = append(response.UnconvertedCurrencies, currency.Code)
+			continue
+		}
+		amount := reporting.ToMajor(converted)
+		response.RevenueByCurrency[i].Converted = &amount
+		totalMinor += converted
+	}
+	response.EstimatedRevenue = reporting.ToMajor(totalMinor)
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) TransferEvent(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var requestBody TransferEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if requestBody.AdminID == uuid.Nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "admin_id is required")
+		return
+	}
+
+	ownership, ok := cfg.authorizeEvent(w, r, eventID, adminID, OrgRoleManager)
+	if !ok {
+		return
+	}
+
+	if !ownership.OrganizationID.Valid {
+		utils.RespondWithError(w, http.StatusBadRequest, "Event does not belong to an organization")
+		return
+	}
+
+	target, err := cfg.DB.GetOrganizationMember(r.Context(), events.GetOrganizationMemberParams{
+		OrganizationID: ownership.OrganizationID.UUID,
+		AdminID:        requestBody.AdminID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		cfg.Logger.Error("Failed to check transfer target membership", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+	if err == sql.ErrNoRows || !orgRoleAtLeast(target.Role, OrgRoleManager) {
+		utils.RespondWithError(w, http.StatusBadRequest, "Target admin must be a manager or owner in the event's organization")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	currentEvent, err := qtx.GetEventForUpdate(r.Context(), eventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+
+	event, err := qtx.TransferEventOwnership(r.Context(), events.TransferEventOwnershipParams{
+		EventID:   eventID,
+		CreatedBy: requestBody.AdminID,
+		Version:   requestBody.Version,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
+			return
+		}
+		cfg.Logger.Error("Failed to transfer event", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+
+	if err := cfg.recordEventHistory(r.Context(), qtx, &currentEvent, event, adminID, HistoryActionTransferred, 0); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event transfer", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to transfer event")
+		return
+	}
+
+	cfg.Logger.Info("Event transferred", "event_id", eventID, "from_admin", ownership.CreatedBy, "to_admin", requestBody.AdminID, "admin_id", adminID)
+
+	response := EventResponse{
+		EventID:              event.EventID,
+		Name:                 event.Name,
+		Description:          utils.StringPtrFromNullString(event.Description),
+		VenueID:              event.VenueID,
+		EventType:            event.EventType,
+		Categories:           cfg.loadEventCategories(r.Context(), event.EventType),
+		Tags:                 event.Tags,
+		StartDatetime:        event.StartDatetime,
+		EndDatetime:          event.EndDatetime,
+		TotalCapacity:        event.TotalCapacity,
+		AvailableSeats:       event.AvailableSeats,
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:             event.Currency,
+		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
+		Status:               event.Status.String,
+		Version:              event.Version,
+		CreatedBy:            event.CreatedBy,
+		OrganizationID:       utils.UUIDPtrFromNullUUID(event.OrganizationID),
+		CreatedAt:            event.CreatedAt.Time,
+		UpdatedAt:            event.UpdatedAt.Time,
+	}
+	response.inVenueZone(cfg.venueTimezone(r.Context(), event.VenueID))
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
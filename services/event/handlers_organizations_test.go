@@ -0,0 +1,166 @@
+package event
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+)
+
+var (
+	testAdmin        = uuid.MustParse("00000000-0000-0000-0000-00000000a001")
+	testOrganization = uuid.MustParse("00000000-0000-0000-0000-00000000b001")
+	testVenue        = uuid.MustParse("00000000-0000-0000-0000-00000000c001")
+	testEvent        = uuid.MustParse("00000000-0000-0000-0000-00000000d001")
+)
+
+// answerAdmin serves GetAdminByID for testAdmin with the given global role.
+func answerAdmin(fake *fakeDB, role string) {
+	fake.returns("GetAdminByID", events.GetAdminByIDRow{
+		AdminID: testAdmin,
+		Email:   "admin@example.com",
+		Name:    "Admin",
+		Role:    sql.NullString{String: role, Valid: true},
+	})
+}
+
+// answerMembership serves GetOrganizationMember with the admin's role in
+// testOrganization, or no row when role is empty.
+func answerMembership(fake *fakeDB, role string) {
+	if role == "" {
+		fake.returns("GetOrganizationMember")
+		return
+	}
+	fake.returns("GetOrganizationMember", events.OrganizationMember{
+		OrganizationID: testOrganization,
+		AdminID:        testAdmin,
+		Role:           role,
+	})
+}
+
+func TestAuthorizeVenue(t *testing.T) {
+	shared := events.Venue{VenueID: testVenue}
+	owned := events.Venue{VenueID: testVenue, OrganizationID: uuid.NullUUID{UUID: testOrganization, Valid: true}}
+
+	tests := []struct {
+		name       string
+		venue      events.Venue
+		adminRole  string
+		memberRole string
+		wantOK     bool
+		wantStatus int
+	}{
+		{name: "shared venue, super_admin", venue: shared, adminRole: "super_admin", wantOK: true},
+		{name: "shared venue, event_manager", venue: shared, adminRole: "event_manager", wantStatus: http.StatusForbidden},
+		{name: "organization venue, manager", venue: owned, adminRole: "event_manager", memberRole: OrgRoleManager, wantOK: true},
+		{name: "organization venue, owner", venue: owned, adminRole: "event_manager", memberRole: OrgRoleOwner, wantOK: true},
+		{name: "organization venue, viewer", venue: owned, adminRole: "event_manager", memberRole: OrgRoleViewer, wantStatus: http.StatusForbidden},
+		{name: "organization venue, outsider", venue: owned, adminRole: "event_manager", wantStatus: http.StatusNotFound},
+		{name: "organization venue, outsider super_admin", venue: owned, adminRole: "super_admin", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, fake := testEventConfig(t)
+			answerAdmin(fake, tt.adminRole)
+			answerMembership(fake, tt.memberRole)
+
+			w := httptest.NewRecorder()
+			r := testAdminRequest(http.MethodPut, "/api/v1/admin/venues/"+testVenue.String(), "", testAdmin)
+			if ok := cfg.authorizeVenue(w, r, tt.venue, testAdmin); ok != tt.wantOK {
+				t.Fatalf("authorizeVenue = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAuthorizeEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		found      bool
+		required   string
+		wantOK     bool
+		wantStatus int
+	}{
+		{name: "manager may manage", role: OrgRoleManager, found: true, required: OrgRoleManager, wantOK: true},
+		{name: "owner may manage", role: OrgRoleOwner, found: true, required: OrgRoleManager, wantOK: true},
+		{name: "viewer may view", role: OrgRoleViewer, found: true, required: OrgRoleViewer, wantOK: true},
+		{name: "viewer may not manage", role: OrgRoleViewer, found: true, required: OrgRoleManager, wantStatus: http.StatusForbidden},
+		{name: "manager may not do owner actions", role: OrgRoleManager, found: true, required: OrgRoleOwner, wantStatus: http.StatusForbidden},
+		{name: "outside the organization", required: OrgRoleViewer, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, fake := testEventConfig(t)
+			fake.on("CheckEventOwnership", func(args []driver.Value) (fakeResult, error) {
+				if args[0] != testEvent.String() || args[1] != testAdmin.String() {
+					t.Errorf("CheckEventOwnership args = %v", args)
+				}
+				if !tt.found {
+					return fakeResult{}, nil
+				}
+				return fakeResult{rows: [][]driver.Value{fakeRow(events.CheckEventOwnershipRow{
+					EventID:        testEvent,
+					CreatedBy:      testAdmin,
+					OrganizationID: uuid.NullUUID{UUID: testOrganization, Valid: true},
+					Version:        1,
+					Role:           tt.role,
+				})}}, nil
+			})
+
+			w := httptest.NewRecorder()
+			r := testAdminRequest(http.MethodPut, "/api/v1/admin/events/"+testEvent.String(), "", testAdmin)
+			ownership, ok := cfg.authorizeEvent(w, r, testEvent, testAdmin, tt.required)
+			if ok != tt.wantOK {
+				t.Fatalf("authorizeEvent = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && ownership.OrganizationID.UUID != testOrganization {
+				t.Errorf("ownership organization = %v, want %v", ownership.OrganizationID, testOrganization)
+			}
+			if !ok && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestUpdateSharedVenueRequiresSuperAdmin(t *testing.T) {
+	cfg, fake := testEventConfig(t)
+	fake.returns("GetVenueByIDForUpdate", events.Venue{
+		VenueID:  testVenue,
+		Name:     "City Hall",
+		Address:  "1 Main St",
+		City:     "Pune",
+		Country:  "India",
+		Capacity: 100,
+		Timezone: "Asia/Kolkata",
+	})
+	answerAdmin(fake, "event_manager")
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		w := httptest.NewRecorder()
+		r := testAdminRequest(method, "/api/v1/admin/venues/"+testVenue.String(), `{"name": "Renamed"}`, testAdmin)
+		r.SetPathValue("id", testVenue.String())
+		if method == http.MethodPut {
+			cfg.UpdateVenue(w, r)
+		} else {
+			cfg.DeleteVenue(w, r)
+		}
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s shared venue as event_manager = %d, want %d", method, w.Code, http.StatusForbidden)
+		}
+	}
+	if commits := fake.count("commit"); commits != 0 {
+		t.Errorf("committed %d transactions, want none", commits)
+	}
+}
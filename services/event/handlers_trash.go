@@ -0,0 +1,259 @@
+package event
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+// RestoreEvent brings a soft-deleted event back. Deletion cancelled it, and it
+// stays cancelled: republishing goes through UpdateEvent, which re-checks the
+// venue schedule that may have filled up in the meantime.
+func (cfg *APIConfig) RestoreEvent(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var requestBody struct {
+		Version int32 `json:"version"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	current, err := qtx.GetEventForUpdate(r.Context(), eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
+			return
+		}
+		cfg.Logger.Error("Failed to lock event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+
+	// authorizeEvent only sees live events, so ownership is checked the same
+	// way against the deleted row here.
+	if current.OrganizationID.Valid {
+		if _, ok := cfg.authorizeOrganization(w, r, current.OrganizationID.UUID, adminID, OrgRoleManager); !ok {
+			return
+		}
+	} else if current.CreatedBy != adminID {
+		utils.RespondWithError(w, http.StatusNotFound, "Event not found or you don't have permission")
+		return
+	}
+
+	if !current.DeletedAt.Valid {
+		utils.RespondWithError(w, http.StatusConflict, "Event is not deleted")
+		return
+	}
+	if current.Version != requestBody.Version {
+		utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
+		return
+	}
+
+	if _, err := qtx.GetVenueByID(r.Context(), current.VenueID); err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusConflict, "The event's venue is deleted; restore the venue first")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", current.VenueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+
+	restored, err := qtx.RestoreEvent(r.Context(), events.RestoreEventParams{
+		EventID: eventID,
+		Version: requestBody.Version,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusConflict, "Event was updated by another admin. Please refresh and try again.")
+			return
+		}
+		cfg.Logger.Error("Failed to restore event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+
+	if err := cfg.recordEventHistory(r.Context(), qtx, &current, restored, adminID, HistoryActionRestored, 0); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+
+	if err := cfg.queueSearchSync(r.Context(), qtx, eventID, SearchSyncUpsert); err != nil {
+		cfg.Logger.Error("Failed to queue search sync", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event restore", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+	cfg.wakeSearchSync()
+
+	cfg.Logger.Info("Event restored", "event_id", eventID, "version", restored.Version, "admin_id", adminID)
+
+	response := eventToResponse(restored)
+	response.Categories = cfg.loadEventCategories(r.Context(), restored.EventType)
+	response.Images = cfg.loadEventImages(r.Context(), eventID)
+	response.inVenueZone(cfg.venueTimezone(r.Context(), restored.VenueID))
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) RestoreVenue(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venueID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore venue")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	venue, err := qtx.GetDeletedVenueForUpdate(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Deleted venue not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore venue")
+		return
+	}
+
+	if !cfg.authorizeVenue(w, r, venue, adminID) {
+		return
+	}
+
+	restored, err := qtx.RestoreVenue(r.Context(), venueID)
+	if err != nil {
+		cfg.Logger.Error("Failed to restore venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore venue")
+		return
+	}
+
+	if err := cfg.recordVenueHistory(r.Context(), qtx, &venue, restored, adminID, HistoryActionRestored, 0); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore venue")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue restore", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore venue")
+		return
+	}
+
+	cfg.Logger.Info("Venue restored", "venue_id", venueID, "admin_id", adminID)
+
+	utils.RespondWithJSON(w, http.StatusOK, venueToResponse(restored))
+}
+
+// ListDeletedItems lists what can still be restored, newest deletion first.
+// With an organization_id it covers that organization's events and venues;
+// without one it covers the admin's own unaffiliated events and the shared
+// venues, which only a super_admin may restore.
+func (cfg *APIConfig) ListDeletedItems(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	var organizationID uuid.NullUUID
+	if o := r.URL.Query().Get("organization_id"); o != "" {
+		parsed, err := uuid.Parse(o)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization_id")
+			return
+		}
+		if _, ok := cfg.authorizeOrganization(w, r, parsed, adminID, OrgRoleViewer); !ok {
+			return
+		}
+		organizationID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	deletedEvents, err := cfg.DB.ListDeletedEvents(r.Context(), events.ListDeletedEventsParams{
+		OrganizationID: organizationID,
+		CreatedBy:      adminID,
+		PageSize:       int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list deleted events", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch deleted items")
+		return
+	}
+
+	deletedVenues, err := cfg.DB.ListDeletedVenues(r.Context(), events.ListDeletedVenuesParams{
+		OrganizationID: organizationID,
+		PageSize:       int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list deleted venues", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch deleted items")
+		return
+	}
+
+	response := DeletedItemsResponse{
+		Events:           make([]EventResponse, len(deletedEvents)),
+		Venues:           make([]VenueResponse, len(deletedVenues)),
+		RetentionSeconds: int64(cfg.Config.DeletedRetention.Seconds()),
+	}
+	for i, event := range deletedEvents {
+		response.Events[i] = eventToResponse(event)
+	}
+	for i, venue := range deletedVenues {
+		response.Venues[i] = venueToResponse(venue)
+	}
+	cfg.localizeEvents(r.Context(), response.Events)
+	cfg.attachEventCategories(r.Context(), response.Events)
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,129 @@
+package event
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+)
+
+func TestRestoreEvent(t *testing.T) {
+	deleted := testDeletedEvent(1)
+	deleted.Version = 3
+	live := deleted
+	live.DeletedAt = sql.NullTime{}
+	foreign := deleted
+	foreign.CreatedBy = uuid.UUID{0xa0, 15: 2}
+
+	tests := []struct {
+		name         string
+		event        events.Event
+		version      string
+		venueDeleted bool
+		wantStatus   int
+	}{
+		{name: "restores", event: deleted, version: "3", wantStatus: http.StatusOK},
+		{name: "not deleted", event: live, version: "3", wantStatus: http.StatusConflict},
+		{name: "stale version", event: deleted, version: "2", wantStatus: http.StatusConflict},
+		{name: "venue deleted", event: deleted, version: "3", venueDeleted: true, wantStatus: http.StatusConflict},
+		{name: "another admin's event", event: foreign, version: "3", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, fake := testEventConfig(t)
+			fake.returns("GetEventForUpdate", tt.event)
+			if tt.venueDeleted {
+				fake.returns("GetVenueByID")
+			} else {
+				fake.returns("GetVenueByID", events.Venue{VenueID: testVenue, Timezone: "Asia/Kolkata"})
+			}
+			fake.on("RestoreEvent", func(args []driver.Value) (fakeResult, error) {
+				restored := tt.event
+				restored.DeletedAt = sql.NullTime{}
+				restored.Version++
+				return fakeResult{rows: [][]driver.Value{fakeRow(restored)}}, nil
+			})
+			history := answerHistory(fake)
+			fake.on("TouchEvent", func([]driver.Value) (fakeResult, error) { return fakeResult{affected: 1}, nil })
+			fake.returns("ListCategories")
+			fake.returns("ListEventMedia")
+			fake.returns("GetVenueTimezone", "Asia/Kolkata")
+
+			w := httptest.NewRecorder()
+			r := testAdminRequest(http.MethodPost, "/api/v1/admin/events/"+deleted.EventID.String()+"/restore", `{"version": `+tt.version+`}`, testAdmin)
+			r.SetPathValue("id", deleted.EventID.String())
+			cfg.RestoreEvent(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			restored := tt.wantStatus == http.StatusOK
+			if got := fake.count("commit") == 1; got != restored {
+				t.Errorf("committed = %v, want %v", got, restored)
+			}
+			if got := fake.count("TouchEvent") == 1; got != restored {
+				t.Errorf("queued search sync = %v, want %v", got, restored)
+			}
+			if restored {
+				if got := history(); len(got) != 1 || got[0][1] != HistoryActionRestored {
+					t.Errorf("history = %v, want one restore", got)
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreVenue(t *testing.T) {
+	deletedAt := sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+	shared := events.Venue{VenueID: testVenue, Name: "City Hall", Timezone: "Asia/Kolkata", DeletedAt: deletedAt}
+	owned := shared
+	owned.OrganizationID = uuid.NullUUID{UUID: testOrganization, Valid: true}
+
+	tests := []struct {
+		name       string
+		venue      events.Venue
+		adminRole  string
+		memberRole string
+		wantStatus int
+	}{
+		{name: "shared venue, super_admin", venue: shared, adminRole: "super_admin", wantStatus: http.StatusOK},
+		{name: "shared venue, event_manager", venue: shared, adminRole: "event_manager", wantStatus: http.StatusForbidden},
+		{name: "organization venue, manager", venue: owned, adminRole: "event_manager", memberRole: OrgRoleManager, wantStatus: http.StatusOK},
+		{name: "organization venue, viewer", venue: owned, adminRole: "event_manager", memberRole: OrgRoleViewer, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, fake := testEventConfig(t)
+			fake.returns("GetDeletedVenueForUpdate", tt.venue)
+			answerAdmin(fake, tt.adminRole)
+			answerMembership(fake, tt.memberRole)
+			restored := tt.venue
+			restored.DeletedAt = sql.NullTime{}
+			fake.returns("RestoreVenue", restored)
+			answerHistory(fake)
+
+			w := httptest.NewRecorder()
+			r := testAdminRequest(http.MethodPost, "/api/v1/admin/venues/"+testVenue.String()+"/restore", "", testAdmin)
+			r.SetPathValue("id", testVenue.String())
+			cfg.RestoreVenue(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			want := 0
+			if tt.wantStatus == http.StatusOK {
+				want = 1
+			}
+			if got := fake.count("RestoreVenue"); got != want {
+				t.Errorf("RestoreVenue ran %d times, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package event
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+func (cfg *APIConfig) GetVenueLayout(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venueIDStr := r.PathValue("id")
+	venueID, err := uuid.Parse(venueIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	venue, err := cfg.DB.GetVenueByID(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get venue layout")
+		return
+	}
+
+	if venue.OrganizationID.Valid {
+		if _, ok := cfg.authorizeOrganization(w, r, venue.OrganizationID.UUID, adminID, OrgRoleViewer); !ok {
+			return
+		}
+	}
+
+	layout, err := storedVenueLayout(venue.LayoutConfig)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Venue has a legacy layout_config that does not match the layout schema: "+err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, venueLayoutResponse(venue, layout, r.URL.Query().Get("expand") == "seats"))
+}
+
+func (cfg *APIConfig) PutLayoutSection(w http.ResponseWriter, r *http.Request) {
+	var section LayoutSection
+	if err := json.NewDecoder(r.Body).Decode(&section); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	section.ID = r.PathValue("sectionId")
+
+	cfg.editVenueLayout(w, r, func(layout *VenueLayout) error {
+		layout.UpsertSection(section)
+		return nil
+	})
+}
+
+func (cfg *APIConfig) DeleteLayoutSection(w http.ResponseWriter, r *http.Request) {
+	sectionID := r.PathValue("sectionId")
+	cfg.editVenueLayout(w, r, func(layout *VenueLayout) error {
+		return layout.RemoveSection(sectionID)
+	})
+}
+
+func (cfg *APIConfig) PutLayoutRow(w http.ResponseWriter, r *http.Request) {
+	var row LayoutRow
+	if err := json.NewDecoder(r.Body).Decode(&row); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	row.Label = r.PathValue("row")
+	sectionID := r.PathValue("sectionId")
+
+	cfg.editVenueLayout(w, r, func(layout *VenueLayout) error {
+		return layout.UpsertRow(sectionID, row)
+	})
+}
+
+func (cfg *APIConfig) DeleteLayoutRow(w http.ResponseWriter, r *http.Request) {
+	sectionID := r.PathValue("sectionId")
+	label := r.PathValue("row")
+	cfg.editVenueLayout(w, r, func(layout *VenueLayout) error {
+		return layout.RemoveRow(sectionID, label)
+	})
+}
+
+// editVenueLayout applies one incremental change to a venue's layout under a
+// row lock, re-validates the whole layout and stores it together with the
+// derived capacity.
+func (cfg *APIConfig) editVenueLayout(w http.ResponseWriter, r *http.Request, edit func(layout *VenueLayout) error) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venueIDStr := r.PathValue("id")
+	venueID, err := uuid.Parse(venueIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+	defer tx.Rollback()
+
+	qtx := events.New(tx)
+
+	venue, err := qtx.GetVenueByIDForUpdate(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+
+	if !cfg.authorizeVenue(w, r, venue, adminID) {
+		return
+	}
+
+	layout, err := storedVenueLayout(venue.LayoutConfig)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Venue has a legacy layout_config; replace it through PUT /api/v1/admin/venues/{id} first")
+		return
+	}
+	if layout == nil {
+		layout = &VenueLayout{}
+	}
+
+	if err := edit(layout); err != nil {
+		if errors.Is(err, errLayoutElementNotFound) {
+			utils.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := layout.Validate(); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	layoutConfig, err := encodeVenueLayout(layout)
+	if err != nil {
+		cfg.Logger.Error("Failed to encode venue layout", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+
+	updated, err := qtx.UpdateVenueLayout(r.Context(), events.UpdateVenueLayoutParams{
+		VenueID:      venueID,
+		LayoutConfig: layoutConfig,
+		Capacity:     layout.Capacity(),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to update venue layout", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+
+	if err := cfg.recordVenueHistory(r.Context(), qtx, &venue, updated, adminID, HistoryActionUpdated, 0); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue layout", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue layout")
+		return
+	}
+
+	cfg.Logger.WithFields(map[string]any{
+		"venue_id": venueID,
+		"capacity": updated.Capacity,
+		"sections": len(layout.Sections),
+	}).Info("Venue layout updated")
+
+	utils.RespondWithJSON(w, http.StatusOK, venueLayoutResponse(updated, layout, false))
+}
+
+func venueLayoutResponse(venue events.Venue, layout *VenueLayout, expand bool) VenueLayoutResponse {
+	response := VenueLayoutResponse{
+		VenueID:   venue.VenueID,
+		Capacity:  venue.Capacity,
+		Layout:    layout,
+		UpdatedAt: venue.UpdatedAt.Time,
+	}
+	if layout != nil {
+		summary := layout.Summary()
+		response.Summary = &summary
+		if expand {
+			response.Seats = layout.SeatMap()
+		}
+	}
+	return response
+}
@@ -0,0 +1,227 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	maxVenueBufferMinutes = 1440
+	maxCalendarWindow     = 366 * 24 * time.Hour
+)
+
+// venueBuffers resolves an event's setup and teardown buffers, falling back
+// to the service defaults for values the admin didn't supply.
+func (cfg *APIConfig) venueBuffers(setup, teardown *int32) (int32, int32, error) {
+	resolvedSetup := int32(cfg.Config.VenueSetupBuffer / time.Minute)
+	if setup != nil {
+		resolvedSetup = *setup
+	}
+	resolvedTeardown := int32(cfg.Config.VenueTeardownBuffer / time.Minute)
+	if teardown != nil {
+		resolvedTeardown = *teardown
+	}
+	if resolvedSetup < 0 || resolvedSetup > maxVenueBufferMinutes {
+		return 0, 0, fmt.Errorf("setup_buffer_minutes must be between 0 and %d", maxVenueBufferMinutes)
+	}
+	if resolvedTeardown < 0 || resolvedTeardown > maxVenueBufferMinutes {
+		return 0, 0, fmt.Errorf("teardown_buffer_minutes must be between 0 and %d", maxVenueBufferMinutes)
+	}
+	return resolvedSetup, resolvedTeardown, nil
+}
+
+func occupiedWindow(start, end time.Time, setup, teardown int32) (time.Time, time.Time) {
+	return start.Add(-time.Duration(setup) * time.Minute), end.Add(time.Duration(teardown) * time.Minute)
+}
+
+// venueSchedule is the slot of a new or rescheduled event that must not
+// overlap other events at the venue.
+type venueSchedule struct {
+	venueID        uuid.UUID
+	excludeEventID uuid.UUID
+	organizationID uuid.NullUUID
+	start          time.Time
+	end            time.Time
+	setup          int32
+	teardown       int32
+	allowConflict  bool
+}
+
+// checkVenueSchedule locks the venue row so concurrent bookings of the same
+// venue are serialized, then rejects the schedule with 409 and the list of
+// conflicting slots unless the admin explicitly allowed the overlap. It must
+// run inside the transaction that writes the event.
+func (cfg *APIConfig) checkVenueSchedule(w http.ResponseWriter, r *http.Request, qtx *events.Queries, schedule venueSchedule) bool {
+	if _, err := qtx.GetVenueByIDForUpdate(r.Context(), schedule.venueID); err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID - venue does not exist")
+			return false
+		}
+		cfg.Logger.Error("Failed to lock venue", "error", err, "venue_id", schedule.venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to check venue availability")
+		return false
+	}
+
+	conflicts, err := cfg.findVenueConflicts(r.Context(), qtx, schedule)
+	if err != nil {
+		cfg.Logger.Error("Failed to check venue conflicts", "error", err, "venue_id", schedule.venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to check venue availability")
+		return false
+	}
+	if len(conflicts) == 0 {
+		return true
+	}
+
+	if schedule.allowConflict {
+		cfg.Logger.WithFields(map[string]any{
+			"venue_id":  schedule.venueID,
+			"event_id":  schedule.excludeEventID,
+			"conflicts": len(conflicts),
+		}).Warn("Venue conflict overridden")
+		return true
+	}
+
+	utils.RespondWithJSON(w, http.StatusConflict, VenueConflictResponse{
+		Error:     "Venue is already booked during this time; set allow_venue_conflict to override",
+		Conflicts: venueSlots(conflicts, schedule.organizationID, venueLocation(cfg.venueTimezone(r.Context(), schedule.venueID))),
+	})
+	return false
+}
+
+func (cfg *APIConfig) findVenueConflicts(ctx context.Context, q events.Querier, schedule venueSchedule) ([]events.ListVenueOccupancyRow, error) {
+	from, until := occupiedWindow(schedule.start, schedule.end, schedule.setup, schedule.teardown)
+	return q.ListVenueOccupancy(ctx, events.ListVenueOccupancyParams{
+		VenueID:        schedule.venueID,
+		ExcludeEventID: schedule.excludeEventID,
+		OccupiedUntil:  until,
+		OccupiedFrom:   from,
+	})
+}
+
+// venueSlots converts occupancy rows for display in the venue's zone, hiding
+// the details of events that belong to a different organization than the
+// viewer's.
+func venueSlots(rows []events.ListVenueOccupancyRow, viewerOrganizationID uuid.NullUUID, loc *time.Location) []VenueSlot {
+	slots := make([]VenueSlot, len(rows))
+	for i, row := range rows {
+		from, until := occupiedWindow(row.StartDatetime, row.EndDatetime, row.SetupBufferMinutes, row.TeardownBufferMinutes)
+		slots[i] = VenueSlot{
+			StartDatetime:         row.StartDatetime.In(loc),
+			EndDatetime:           row.EndDatetime.In(loc),
+			OccupiedFrom:          from.In(loc),
+			OccupiedUntil:         until.In(loc),
+			SetupBufferMinutes:    row.SetupBufferMinutes,
+			TeardownBufferMinutes: row.TeardownBufferMinutes,
+		}
+		if viewerOrganizationID.Valid && row.OrganizationID == viewerOrganizationID {
+			eventID := row.EventID
+			slots[i].EventID = &eventID
+			slots[i].Name = row.Name
+			slots[i].Status = row.Status.String
+		} else {
+			slots[i].Private = true
+		}
+	}
+	return slots
+}
+
+func (cfg *APIConfig) GetVenueCalendar(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+
+	venueIDStr := r.PathValue("id")
+	venueID, err := uuid.Parse(venueIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	venue, err := cfg.DB.GetVenueByID(r.Context(), venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
+			return
+		}
+		cfg.Logger.Error("Failed to get venue", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch venue calendar")
+		return
+	}
+
+	// The default window starts at midnight today, venue time.
+	loc := venueLocation(venue.Timezone)
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from timestamp, expected RFC3339")
+			return
+		}
+	}
+	to := from.Add(30 * 24 * time.Hour)
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to timestamp, expected RFC3339")
+			return
+		}
+	}
+	if !from.Before(to) {
+		utils.RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	if to.Sub(from) > maxCalendarWindow {
+		utils.RespondWithError(w, http.StatusBadRequest, "Calendar range cannot exceed 366 days")
+		return
+	}
+
+	// Shared venues show the details of events from the organization passed
+	// in organization_id; organization venues use their owning organization.
+	var viewerOrg uuid.NullUUID
+	if venue.OrganizationID.Valid {
+		if _, ok := cfg.authorizeOrganization(w, r, venue.OrganizationID.UUID, adminID, OrgRoleViewer); !ok {
+			return
+		}
+		viewerOrg = venue.OrganizationID
+	} else if orgStr := r.URL.Query().Get("organization_id"); orgStr != "" {
+		orgID, err := uuid.Parse(orgStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization_id")
+			return
+		}
+		if _, ok := cfg.authorizeOrganization(w, r, orgID, adminID, OrgRoleViewer); !ok {
+			return
+		}
+		viewerOrg = uuid.NullUUID{UUID: orgID, Valid: true}
+	}
+
+	rows, err := cfg.DB.ListVenueOccupancy(r.Context(), events.ListVenueOccupancyParams{
+		VenueID:       venueID,
+		OccupiedUntil: to,
+		OccupiedFrom:  from,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list venue occupancy", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch venue calendar")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, VenueCalendarResponse{
+		VenueID:  venueID,
+		Timezone: loc.String(),
+		From:     from.In(loc),
+		To:       to.In(loc),
+		Slots:    venueSlots(rows, viewerOrg, loc),
+	})
+}
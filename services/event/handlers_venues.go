@@ -3,19 +3,20 @@ package event
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
 	"github.com/google/uuid"
-	"github.com/sqlc-dev/pqtype"
 )
 
 func (cfg *APIConfig) CreateVenue(w http.ResponseWriter, r *http.Request) {
-	_, ok := auth.GetAdminIDFromContext(r.Context())
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
 		return
@@ -34,8 +35,19 @@ func (cfg *APIConfig) CreateVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if requestBody.Capacity <= 0 {
-		cfg.Logger.WithFields(map[string]any{"capacity": requestBody.Capacity}).Warn("Venue creation with invalid capacity")
+	layout, err := decodeVenueLayout(requestBody.LayoutConfig)
+	if err != nil {
+		cfg.Logger.WithFields(map[string]any{"error": err.Error()}).Warn("Venue creation with invalid layout")
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	capacity := requestBody.Capacity
+	if layout != nil {
+		capacity = layout.Capacity()
+	}
+	if capacity <= 0 {
+		cfg.Logger.WithFields(map[string]any{"capacity": capacity}).Warn("Venue creation with invalid capacity")
 		utils.RespondWithError(w, http.StatusBadRequest, "Capacity must be positive")
 		return
 	}
@@ -43,52 +55,113 @@ func (cfg *APIConfig) CreateVenue(w http.ResponseWriter, r *http.Request) {
 	if country == "" {
 		country = "USA"
 	}
-	var layoutConfig pqtype.NullRawMessage
-	if requestBody.LayoutConfig != nil {
-		layoutConfig = pqtype.NullRawMessage{RawMessage: requestBody.LayoutConfig, Valid: true}
-	} else {
-		layoutConfig = pqtype.NullRawMessage{RawMessage: json.RawMessage("{}"), Valid: true}
+	timezone := requestBody.Timezone
+	if timezone == "" {
+		timezone = defaultVenueTimezone
+	}
+	if err := validateTimezone(timezone); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	point, err := requestedCoordinates(requestBody.Latitude, requestBody.Longitude)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var organizationID uuid.NullUUID
+	if requestBody.OrganizationID != nil {
+		if _, ok := cfg.authorizeOrganization(w, r, *requestBody.OrganizationID, adminID, OrgRoleManager); !ok {
+			return
+		}
+		organizationID = uuid.NullUUID{UUID: *requestBody.OrganizationID, Valid: true}
 	}
 
+	layoutConfig, err := encodeVenueLayout(layout)
+	if err != nil {
+		cfg.Logger.Error("Failed to encode venue layout", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create venue")
+		return
+	}
+
+	if point == nil {
+		point = cfg.geocodeVenue(r.Context(), geocode.Address{
+			Street:     requestBody.Address,
+			City:       requestBody.City,
+			State:      requestBody.State,
+			Country:    country,
+			PostalCode: requestBody.PostalCode,
+		})
+	}
+	latitude, longitude := nullCoordinates(point)
+
 	params := events.CreateVenueParams{
-		Name:         requestBody.Name,
-		Address:      requestBody.Address,
-		City:         requestBody.City,
-		State:        sql.NullString{String: requestBody.State, Valid: requestBody.State != ""},
-		Country:      country,
-		PostalCode:   sql.NullString{String: requestBody.PostalCode, Valid: requestBody.PostalCode != ""},
-		Capacity:     requestBody.Capacity,
-		LayoutConfig: layoutConfig,
+		Name:           requestBody.Name,
+		Address:        requestBody.Address,
+		City:           requestBody.City,
+		State:          sql.NullString{String: requestBody.State, Valid: requestBody.State != ""},
+		Country:        country,
+		PostalCode:     sql.NullString{String: requestBody.PostalCode, Valid: requestBody.PostalCode != ""},
+		Capacity:       capacity,
+		LayoutConfig:   layoutConfig,
+		OrganizationID: organizationID,
+		Timezone:       timezone,
+		Latitude:       latitude,
+		Longitude:      longitude,
 	}
 
-	venue, err := cfg.DB.CreateVenue(r.Context(), params)
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create venue")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	venue, err := qtx.CreateVenue(r.Context(), params)
 	if err != nil {
 		cfg.Logger.WithFields(map[string]any{"name": requestBody.Name, "error": err.Error()}).Error("Venue creation failed")
 		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create venue")
 		return
 	}
 
+	if err := cfg.recordVenueHistory(r.Context(), qtx, nil, venue, adminID, HistoryActionCreated, 0); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venue.VenueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create venue")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue creation", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Could not create venue")
+		return
+	}
+
 	cfg.Logger.WithFields(map[string]any{"venue_id": venue.VenueID, "name": venue.Name}).Info("Venue created successfully")
 
 	response := VenueResponse{
-		VenueID:      venue.VenueID,
-		Name:         venue.Name,
-		Address:      venue.Address,
-		City:         venue.City,
-		State:        utils.StringPtrFromNullString(venue.State),
-		Country:      venue.Country,
-		PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-		Capacity:     venue.Capacity,
-		LayoutConfig: venue.LayoutConfig.RawMessage,
-		CreatedAt:    venue.CreatedAt.Time,
-		UpdatedAt:    venue.UpdatedAt.Time,
+		VenueID:        venue.VenueID,
+		Name:           venue.Name,
+		Address:        venue.Address,
+		City:           venue.City,
+		State:          utils.StringPtrFromNullString(venue.State),
+		Country:        venue.Country,
+		PostalCode:     utils.StringPtrFromNullString(venue.PostalCode),
+		Capacity:       venue.Capacity,
+		LayoutConfig:   venue.LayoutConfig.RawMessage,
+		OrganizationID: utils.UUIDPtrFromNullUUID(venue.OrganizationID),
+		Timezone:       venue.Timezone,
+		Latitude:       utils.Float64PtrFromNullFloat64(venue.Latitude),
+		Longitude:      utils.Float64PtrFromNullFloat64(venue.Longitude),
+		CreatedAt:      venue.CreatedAt.Time,
+		UpdatedAt:      venue.UpdatedAt.Time,
 	}
 
 	utils.RespondWithJSON(w, http.StatusCreated, response)
 }
 
 func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
-	_, ok := auth.GetAdminIDFromContext(r.Context())
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
 		return
@@ -115,7 +188,10 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 	offset := (page - 1) * limit
 
 	if search != "" {
-		venues, err := cfg.DB.SearchVenues(r.Context(), sql.NullString{String: search, Valid: search != ""})
+		venues, err := cfg.DB.SearchVenues(r.Context(), events.SearchVenuesParams{
+			Column1: sql.NullString{String: search, Valid: search != ""},
+			AdminID: adminID,
+		})
 		if err != nil {
 			cfg.Logger.Error("Failed to search venues", "error", err)
 			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to search venues")
@@ -125,17 +201,21 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 		venueResponses := make([]VenueResponse, len(venues))
 		for i, venue := range venues {
 			venueResponses[i] = VenueResponse{
-				VenueID:      venue.VenueID,
-				Name:         venue.Name,
-				Address:      venue.Address,
-				City:         venue.City,
-				State:        utils.StringPtrFromNullString(venue.State),
-				Country:      venue.Country,
-				PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-				Capacity:     venue.Capacity,
-				LayoutConfig: venue.LayoutConfig.RawMessage,
-				CreatedAt:    venue.CreatedAt.Time,
-				UpdatedAt:    venue.UpdatedAt.Time,
+				VenueID:        venue.VenueID,
+				Name:           venue.Name,
+				Address:        venue.Address,
+				City:           venue.City,
+				State:          utils.StringPtrFromNullString(venue.State),
+				Country:        venue.Country,
+				PostalCode:     utils.StringPtrFromNullString(venue.PostalCode),
+				Capacity:       venue.Capacity,
+				LayoutConfig:   venue.LayoutConfig.RawMessage,
+				OrganizationID: utils.UUIDPtrFromNullUUID(venue.OrganizationID),
+				Timezone:       venue.Timezone,
+				Latitude:       utils.Float64PtrFromNullFloat64(venue.Latitude),
+				Longitude:      utils.Float64PtrFromNullFloat64(venue.Longitude),
+				CreatedAt:      venue.CreatedAt.Time,
+				UpdatedAt:      venue.UpdatedAt.Time,
 			}
 		}
 
@@ -154,6 +234,7 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 	countParams := events.CountVenuesParams{
 		Column1: city,
 		Column2: state,
+		AdminID: adminID,
 	}
 
 	total, err := cfg.DB.CountVenues(r.Context(), countParams)
@@ -168,6 +249,7 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 		Offset:  int32(offset),
 		Column3: city,
 		Column4: state,
+		AdminID: adminID,
 	}
 
 	venues, err := cfg.DB.ListVenues(r.Context(), listParams)
@@ -180,17 +262,21 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 	venueResponses := make([]VenueResponse, len(venues))
 	for i, venue := range venues {
 		venueResponses[i] = VenueResponse{
-			VenueID:      venue.VenueID,
-			Name:         venue.Name,
-			Address:      venue.Address,
-			City:         venue.City,
-			State:        utils.StringPtrFromNullString(venue.State),
-			Country:      venue.Country,
-			PostalCode:   utils.StringPtrFromNullString(venue.PostalCode),
-			Capacity:     venue.Capacity,
-			LayoutConfig: venue.LayoutConfig.RawMessage,
-			CreatedAt:    venue.CreatedAt.Time,
-			UpdatedAt:    venue.UpdatedAt.Time,
+			VenueID:        venue.VenueID,
+			Name:           venue.Name,
+			Address:        venue.Address,
+			City:           venue.City,
+			State:          utils.StringPtrFromNullString(venue.State),
+			Country:        venue.Country,
+			PostalCode:     utils.StringPtrFromNullString(venue.PostalCode),
+			Capacity:       venue.Capacity,
+			LayoutConfig:   venue.LayoutConfig.RawMessage,
+			OrganizationID: utils.UUIDPtrFromNullUUID(venue.OrganizationID),
+			Timezone:       venue.Timezone,
+			Latitude:       utils.Float64PtrFromNullFloat64(venue.Latitude),
+			Longitude:      utils.Float64PtrFromNullFloat64(venue.Longitude),
+			CreatedAt:      venue.CreatedAt.Time,
+			UpdatedAt:      venue.UpdatedAt.Time,
 		}
 	}
 
@@ -206,7 +292,7 @@ func (cfg *APIConfig) ListVenues(w http.ResponseWriter, r *http.Request) {
 }
 
 func (cfg *APIConfig) UpdateVenue(w http.ResponseWriter, r *http.Request) {
-	_, ok := auth.GetAdminIDFromContext(r.Context())
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
 		return
@@ -225,7 +311,16 @@ func (cfg *APIConfig) UpdateVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	currentVenue, err := cfg.DB.GetVenueByID(r.Context(), venueID)
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	currentVenue, err := qtx.GetVenueByIDForUpdate(r.Context(), venueID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
@@ -236,6 +331,50 @@ func (cfg *APIConfig) UpdateVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !cfg.authorizeVenue(w, r, currentVenue, adminID) {
+		return
+	}
+
+	// A typed layout owns the venue's capacity; a manual capacity only
+	// applies to venues without one.
+	layoutConfig := currentVenue.LayoutConfig
+	if requestBody.LayoutConfig != nil {
+		layout, err := decodeVenueLayout(*requestBody.LayoutConfig)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		layoutConfig, err = encodeVenueLayout(layout)
+		if err != nil {
+			cfg.Logger.Error("Failed to encode venue layout", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue")
+			return
+		}
+	}
+
+	capacity := currentVenue.Capacity
+	if requestBody.Capacity != nil {
+		capacity = *requestBody.Capacity
+	}
+	if layout, err := storedVenueLayout(layoutConfig); err == nil && layout != nil {
+		capacity = layout.Capacity()
+	}
+	if capacity <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Capacity must be positive")
+		return
+	}
+
+	// Changing the zone keeps each event's instant and moves its local time;
+	// events are not shifted to keep their old wall-clock time.
+	timezone := currentVenue.Timezone
+	if requestBody.Timezone != nil {
+		if err := validateTimezone(*requestBody.Timezone); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		timezone = *requestBody.Timezone
+	}
+
 	params := events.UpdateVenueParams{
 		VenueID: venueID,
 		Name: func() string {
@@ -274,21 +413,37 @@ func (cfg *APIConfig) UpdateVenue(w http.ResponseWriter, r *http.Request) {
 			}
 			return currentVenue.PostalCode
 		}(),
-		Capacity: func() int32 {
-			if requestBody.Capacity != nil {
-				return *requestBody.Capacity
-			}
-			return currentVenue.Capacity
-		}(),
-		LayoutConfig: func() pqtype.NullRawMessage {
-			if requestBody.LayoutConfig != nil {
-				return pqtype.NullRawMessage{RawMessage: *requestBody.LayoutConfig, Valid: true}
-			}
-			return currentVenue.LayoutConfig
-		}(),
+		Capacity:     capacity,
+		LayoutConfig: layoutConfig,
+		Timezone:     timezone,
+		Latitude:     currentVenue.Latitude,
+		Longitude:    currentVenue.Longitude,
+	}
+
+	// Coordinates sent with the update win; otherwise a changed address is
+	// geocoded again so the venue doesn't keep its old position.
+	point, err := requestedCoordinates(requestBody.Latitude, requestBody.Longitude)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if point != nil {
+		params.Latitude, params.Longitude = nullCoordinates(point)
+	} else if cfg.Geocoder != nil && (params.Address != currentVenue.Address ||
+		params.City != currentVenue.City ||
+		params.State != currentVenue.State ||
+		params.Country != currentVenue.Country ||
+		params.PostalCode != currentVenue.PostalCode) {
+		params.Latitude, params.Longitude = nullCoordinates(cfg.geocodeVenue(r.Context(), geocode.Address{
+			Street:     params.Address,
+			City:       params.City,
+			State:      params.State.String,
+			Country:    params.Country,
+			PostalCode: params.PostalCode.String,
+		}))
 	}
 
-	updatedVenue, err := cfg.DB.UpdateVenue(r.Context(), params)
+	updatedVenue, err := qtx.UpdateVenue(r.Context(), params)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
@@ -299,25 +454,50 @@ func (cfg *APIConfig) UpdateVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := cfg.recordVenueHistory(r.Context(), qtx, &currentVenue, updatedVenue, adminID, HistoryActionUpdated, 0); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue")
+		return
+	}
+
+	if venueSearchFieldsChanged(currentVenue, updatedVenue) {
+		if err := cfg.queueVenueSearchSync(r.Context(), qtx, venueID); err != nil {
+			cfg.Logger.Error("Failed to queue search sync for venue events", "error", err, "venue_id", venueID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue update", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update venue")
+		return
+	}
+	cfg.wakeSearchSync()
+
 	response := VenueResponse{
-		VenueID:      updatedVenue.VenueID,
-		Name:         updatedVenue.Name,
-		Address:      updatedVenue.Address,
-		City:         updatedVenue.City,
-		State:        utils.StringPtrFromNullString(updatedVenue.State),
-		Country:      updatedVenue.Country,
-		PostalCode:   utils.StringPtrFromNullString(updatedVenue.PostalCode),
-		Capacity:     updatedVenue.Capacity,
-		LayoutConfig: updatedVenue.LayoutConfig.RawMessage,
-		CreatedAt:    updatedVenue.CreatedAt.Time,
-		UpdatedAt:    updatedVenue.UpdatedAt.Time,
+		VenueID:        updatedVenue.VenueID,
+		Name:           updatedVenue.Name,
+		Address:        updatedVenue.Address,
+		City:           updatedVenue.City,
+		State:          utils.StringPtrFromNullString(updatedVenue.State),
+		Country:        updatedVenue.Country,
+		PostalCode:     utils.StringPtrFromNullString(updatedVenue.PostalCode),
+		Capacity:       updatedVenue.Capacity,
+		LayoutConfig:   updatedVenue.LayoutConfig.RawMessage,
+		OrganizationID: utils.UUIDPtrFromNullUUID(updatedVenue.OrganizationID),
+		Timezone:       updatedVenue.Timezone,
+		Latitude:       utils.Float64PtrFromNullFloat64(updatedVenue.Latitude),
+		Longitude:      utils.Float64PtrFromNullFloat64(updatedVenue.Longitude),
+		CreatedAt:      updatedVenue.CreatedAt.Time,
+		UpdatedAt:      updatedVenue.UpdatedAt.Time,
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
 func (cfg *APIConfig) DeleteVenue(w http.ResponseWriter, r *http.Request) {
-	_, ok := auth.GetAdminIDFromContext(r.Context())
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
 		return
@@ -330,23 +510,64 @@ func (cfg *APIConfig) DeleteVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = cfg.DB.DeleteVenue(r.Context(), venueID)
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	venue, err := qtx.GetVenueByIDForUpdate(r.Context(), venueID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.RespondWithError(w, http.StatusNotFound, "Venue not found")
 			return
 		}
-		if strings.Contains(err.Error(), "foreign key") {
-			utils.RespondWithError(w, http.StatusConflict, "Cannot delete venue with associated events")
-			return
-		}
+		cfg.Logger.Error("Failed to get venue", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+
+	if !cfg.authorizeVenue(w, r, venue, adminID) {
+		return
+	}
+
+	// Past and cancelled events keep pointing at the venue; only events that
+	// could still take place block its deletion.
+	activeEvents, err := qtx.CountActiveVenueEvents(r.Context(), venueID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count venue events", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+	if activeEvents > 0 {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("Cannot delete venue with %d upcoming events; cancel or move them first", activeEvents))
+		return
+	}
+
+	deletedVenue, err := qtx.DeleteVenue(r.Context(), venueID)
+	if err != nil {
 		cfg.Logger.Error("Failed to delete venue", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
 		return
 	}
 
+	if err := cfg.recordVenueHistory(r.Context(), qtx, &venue, deletedVenue, adminID, HistoryActionDeleted, 0); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venueID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue deletion", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+
 	response := SuccessResponse{
-		Message: "Venue deleted successfully",
+		Message: fmt.Sprintf("Venue deleted; it can be restored until %s", deletedVenue.DeletedAt.Time.Add(cfg.Config.DeletedRetention).UTC().Format(time.RFC3339)),
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -0,0 +1,309 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	HistoryEntityEvent = "event"
+	HistoryEntityVenue = "venue"
+)
+
+const (
+	HistoryActionBaseline    = "baseline"
+	HistoryActionCreated     = "created"
+	HistoryActionUpdated     = "updated"
+	HistoryActionCancelled   = "cancelled"
+	HistoryActionDeleted     = "deleted"
+	HistoryActionTransferred = "transferred"
+	HistoryActionReverted    = "reverted"
+	HistoryActionRestored    = "restored"
+	HistoryActionPurged      = "purged"
+)
+
+// eventSnapshot is the versioned state of an event. Seat availability is
+// left out on purpose: bookings move it constantly and it is not something
+// an admin edits or reverts.
+type eventSnapshot struct {
+	Name                  string     `json:"name"`
+	Description           *string    `json:"description"`
+	VenueID               uuid.UUID  `json:"venue_id"`
+	EventType             string     `json:"event_type"`
+	Tags                  []string   `json:"tags,omitempty"`
+	StartDatetime         time.Time  `json:"start_datetime"`
+	EndDatetime           time.Time  `json:"end_datetime"`
+	TotalCapacity         int32      `json:"total_capacity"`
+	BasePrice             float64    `json:"base_price"`
+	Currency              string     `json:"currency,omitempty"`
+	MaxTicketsPerBooking  int32      `json:"max_tickets_per_booking"`
+	Status                string     `json:"status"`
+	SetupBufferMinutes    int32      `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32      `json:"teardown_buffer_minutes"`
+	OrganizationID        *uuid.UUID `json:"organization_id"`
+	CreatedBy             uuid.UUID  `json:"created_by"`
+}
+
+func newEventSnapshot(event events.Event) eventSnapshot {
+	return eventSnapshot{
+		Name:                  event.Name,
+		Description:           utils.StringPtrFromNullString(event.Description),
+		VenueID:               event.VenueID,
+		EventType:             event.EventType,
+		Tags:                  event.Tags,
+		StartDatetime:         event.StartDatetime.UTC(),
+		EndDatetime:           event.EndDatetime.UTC(),
+		TotalCapacity:         event.TotalCapacity,
+		BasePrice:             eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:              event.Currency,
+		MaxTicketsPerBooking:  event.MaxTicketsPerBooking.Int32,
+		Status:                event.Status.String,
+		SetupBufferMinutes:    event.SetupBufferMinutes,
+		TeardownBufferMinutes: event.TeardownBufferMinutes,
+		OrganizationID:        utils.UUIDPtrFromNullUUID(event.OrganizationID),
+		CreatedBy:             event.CreatedBy,
+	}
+}
+
+type venueSnapshot struct {
+	Name           string          `json:"name"`
+	Address        string          `json:"address"`
+	City           string          `json:"city"`
+	State          *string         `json:"state"`
+	Country        string          `json:"country"`
+	PostalCode     *string         `json:"postal_code"`
+	Capacity       int32           `json:"capacity"`
+	LayoutConfig   json.RawMessage `json:"layout_config"`
+	OrganizationID *uuid.UUID      `json:"organization_id"`
+	Timezone       string          `json:"timezone"`
+	Latitude       *float64        `json:"latitude"`
+	Longitude      *float64        `json:"longitude"`
+}
+
+func newVenueSnapshot(venue events.Venue) venueSnapshot {
+	return venueSnapshot{
+		Name:           venue.Name,
+		Address:        venue.Address,
+		City:           venue.City,
+		State:          utils.StringPtrFromNullString(venue.State),
+		Country:        venue.Country,
+		PostalCode:     utils.StringPtrFromNullString(venue.PostalCode),
+		Capacity:       venue.Capacity,
+		LayoutConfig:   utils.NullRawMessageToJSONRawMessage(venue.LayoutConfig),
+		OrganizationID: utils.UUIDPtrFromNullUUID(venue.OrganizationID),
+		Timezone:       venue.Timezone,
+		Latitude:       utils.Float64PtrFromNullFloat64(venue.Latitude),
+		Longitude:      utils.Float64PtrFromNullFloat64(venue.Longitude),
+	}
+}
+
+// diffSnapshots compares two snapshots field by field on their JSON form and
+// returns the fields that differ, sorted by name. A nil before yields every
+// set field of after, which is how creations are recorded.
+func diffSnapshots(before, after any) ([]FieldChange, error) {
+	beforeFields := map[string]json.RawMessage{}
+	if before != nil {
+		raw, err := json.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(after)
+	if err != nil {
+		return nil, err
+	}
+	afterFields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &afterFields); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(afterFields))
+	for name := range afterFields {
+		names = append(names, name)
+	}
+	for name := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []FieldChange{}
+	for _, name := range names {
+		old, next := beforeFields[name], afterFields[name]
+		if old == nil {
+			old = json.RawMessage("null")
+		}
+		if next == nil {
+			next = json.RawMessage("null")
+		}
+		if jsonEqual(old, next) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, Old: old, New: next})
+	}
+	return changes, nil
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var left, right bytes.Buffer
+	if json.Compact(&left, a) != nil || json.Compact(&right, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(left.Bytes(), right.Bytes())
+}
+
+type historyRecord struct {
+	entityType     string
+	entityID       uuid.UUID
+	organizationID uuid.NullUUID
+	// version of the new entry; zero means one past the latest entry.
+	version int32
+	action  string
+	adminID uuid.UUID
+	// before is the state being replaced, nil for creations. Entities that
+	// predate history tracking get it stored as a baseline entry at
+	// baselineVersion so their first recorded change can still be reverted.
+	before          any
+	baselineVersion int32
+	after           any
+	revertedFrom    int32
+}
+
+// recordHistory appends one version to an entity's history. It must run in
+// the transaction that applied the change so history and data never diverge.
+func (cfg *APIConfig) recordHistory(ctx context.Context, q *events.Queries, rec historyRecord) error {
+	latest, err := q.GetLatestEntityHistoryVersion(ctx, events.GetLatestEntityHistoryVersionParams{
+		EntityType: rec.entityType,
+		EntityID:   rec.entityID,
+	})
+	if err != nil {
+		return fmt.Errorf("get latest history version: %w", err)
+	}
+
+	if latest == 0 && rec.before != nil {
+		snapshot, err := json.Marshal(rec.before)
+		if err != nil {
+			return err
+		}
+		if _, err := q.CreateEntityHistory(ctx, events.CreateEntityHistoryParams{
+			EntityType:     rec.entityType,
+			EntityID:       rec.entityID,
+			Version:        rec.baselineVersion,
+			Action:         HistoryActionBaseline,
+			OrganizationID: rec.organizationID,
+			Changes:        json.RawMessage("[]"),
+			Snapshot:       snapshot,
+		}); err != nil {
+			return fmt.Errorf("create baseline history: %w", err)
+		}
+		latest = rec.baselineVersion
+	}
+
+	changes, err := diffSnapshots(rec.before, rec.after)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(rec.after)
+	if err != nil {
+		return err
+	}
+
+	version := rec.version
+	if version == 0 {
+		version = latest + 1
+	}
+
+	_, err = q.CreateEntityHistory(ctx, events.CreateEntityHistoryParams{
+		EntityType:          rec.entityType,
+		EntityID:            rec.entityID,
+		Version:             version,
+		Action:              rec.action,
+		OrganizationID:      rec.organizationID,
+		ChangedBy:           uuid.NullUUID{UUID: rec.adminID, Valid: rec.adminID != uuid.Nil},
+		Changes:             changesJSON,
+		Snapshot:            snapshot,
+		RevertedFromVersion: sql.NullInt32{Int32: rec.revertedFrom, Valid: rec.revertedFrom > 0},
+	})
+	if err != nil {
+		return fmt.Errorf("create history: %w", err)
+	}
+	return nil
+}
+
+// recordEventHistory versions an event by its optimistic-lock version, so a
+// history entry and the event row it describes always share a number.
+func (cfg *APIConfig) recordEventHistory(ctx context.Context, q *events.Queries, before *events.Event, after events.Event, adminID uuid.UUID, action string, revertedFrom int32) error {
+	rec := historyRecord{
+		entityType:     HistoryEntityEvent,
+		entityID:       after.EventID,
+		organizationID: after.OrganizationID,
+		version:        after.Version,
+		action:         action,
+		adminID:        adminID,
+		after:          newEventSnapshot(after),
+		revertedFrom:   revertedFrom,
+	}
+	if before != nil {
+		rec.before = newEventSnapshot(*before)
+		rec.baselineVersion = before.Version
+	}
+	return cfg.recordHistory(ctx, q, rec)
+}
+
+// recordVenueHistory versions a venue by counting its history entries, since
+// venues have no version column of their own.
+func (cfg *APIConfig) recordVenueHistory(ctx context.Context, q *events.Queries, before *events.Venue, after events.Venue, adminID uuid.UUID, action string, revertedFrom int32) error {
+	rec := historyRecord{
+		entityType:      HistoryEntityVenue,
+		entityID:        after.VenueID,
+		organizationID:  after.OrganizationID,
+		action:          action,
+		adminID:         adminID,
+		baselineVersion: 1,
+		after:           newVenueSnapshot(after),
+		revertedFrom:    revertedFrom,
+	}
+	if before != nil {
+		rec.before = newVenueSnapshot(*before)
+	}
+	return cfg.recordHistory(ctx, q, rec)
+}
+
+func historyToEntry(history events.EntityHistory, withSnapshot bool) HistoryEntry {
+	entry := HistoryEntry{
+		HistoryID:  history.HistoryID,
+		EntityType: history.EntityType,
+		EntityID:   history.EntityID,
+		Version:    history.Version,
+		Action:     history.Action,
+		ChangedBy:  utils.UUIDPtrFromNullUUID(history.ChangedBy),
+		Changes:    history.Changes,
+		CreatedAt:  history.CreatedAt.Time,
+	}
+	if withSnapshot {
+		entry.Snapshot = history.Snapshot
+	}
+	if history.RevertedFromVersion.Valid {
+		v := history.RevertedFromVersion.Int32
+		entry.RevertedFromVersion = &v
+	}
+	return entry
+}
@@ -0,0 +1,160 @@
+package event
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	// Registers the GIF decoder with image.Decode.
+	_ "image/gif"
+)
+
+const (
+	maxImageDimension  = 12000
+	maxImagePixels     = 50_000_000
+	jpegVariantQuality = 85
+)
+
+// imageVariantSizes caps the longest edge of each generated variant. Images
+// already smaller than a variant reuse the original instead of upscaling.
+var imageVariantSizes = []struct {
+	Name    string
+	MaxEdge int
+}{
+	{"thumbnail", 320},
+	{"medium", 960},
+	{"large", 1920},
+}
+
+// allowedImageTypes maps sniffed content types to the stored file extension.
+var allowedImageTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+var errUnsupportedImage = errors.New("unsupported image type")
+
+type encodedImage struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// sniffImageType checks the upload's magic bytes rather than trusting the
+// client-supplied Content-Type.
+func sniffImageType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := allowedImageTypes[contentType]; !ok {
+		return contentType, fmt.Errorf("%w %s; allowed types are image/jpeg, image/png and image/gif", errUnsupportedImage, contentType)
+	}
+	return contentType, nil
+}
+
+// decodeUploadedImage reads the header first so oversized images are
+// rejected before their pixels are allocated.
+func decodeUploadedImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image could not be decoded: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("image has invalid dimensions %dx%d", config.Width, config.Height)
+	}
+	if config.Width > maxImageDimension || config.Height > maxImageDimension || config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("image dimensions %dx%d exceed the %dpx / %d megapixel limit",
+			config.Width, config.Height, maxImageDimension, maxImagePixels/1_000_000)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image could not be decoded: %w", err)
+	}
+	return img, nil
+}
+
+// resizedVariant scales src so its longest edge is maxEdge. It returns false
+// when the image is already small enough.
+func resizedVariant(src image.Image, sourceType string, maxEdge int) (*encodedImage, bool, error) {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxEdge && height <= maxEdge {
+		return nil, false, nil
+	}
+
+	dstWidth, dstHeight := maxEdge, maxEdge
+	if width >= height {
+		dstHeight = max(1, height*maxEdge/width)
+	} else {
+		dstWidth = max(1, width*maxEdge/height)
+	}
+
+	resized := downscale(src, dstWidth, dstHeight)
+
+	// JPEG stays JPEG; PNG and GIF variants are PNG so transparency survives.
+	var buf bytes.Buffer
+	variant := &encodedImage{Width: dstWidth, Height: dstHeight}
+	if sourceType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegVariantQuality}); err != nil {
+			return nil, false, err
+		}
+		variant.ContentType, variant.Extension = "image/jpeg", "jpg"
+	} else {
+		if err := png.Encode(&buf, resized); err != nil {
+			return nil, false, err
+		}
+		variant.ContentType, variant.Extension = "image/png", "png"
+	}
+	variant.Data = buf.Bytes()
+	return variant, true, nil
+}
+
+// downscale shrinks src with a box filter: every destination pixel is the
+// average of the source pixels it covers. Averaging happens on premultiplied
+// RGBA so transparent edges don't bleed dark halos.
+func downscale(src image.Image, dstWidth, dstHeight int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || rgba.Bounds().Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcWidth, srcHeight := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+
+	xStart := make([]int, dstWidth+1)
+	for x := 0; x <= dstWidth; x++ {
+		xStart[x] = x * srcWidth / dstWidth
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*srcHeight/dstHeight, (y+1)*srcHeight/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := xStart[x], xStart[x+1]
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					b += uint64(row[i+2])
+					a += uint64(row[i+3])
+					n++
+				}
+			}
+			offset := y*dst.Stride + x*4
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
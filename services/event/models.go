@@ -6,25 +6,34 @@ import (
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/storage"
 	"github.com/google/uuid"
 )
 
 type APIConfig struct {
-	DB           events.Querier
-	DB_Conn      *sql.DB
-	Config       *config.EventServiceConfig
-	Logger       *logger.Logger
-	SearchClient *SearchServiceClient
+	DB            events.Querier
+	DB_Conn       *sql.DB
+	Config        *config.EventServiceConfig
+	Logger        *logger.Logger
+	SearchClient  *SearchServiceClient
+	BookingClient *BookingServiceClient
+	UserClient    *UserServiceClient
+	MediaStore    storage.ObjectStore
+	Geocoder      geocode.Geocoder
+
+	searchSyncWake chan struct{}
 }
 
 type AdminRegisterRequest struct {
-	Email       string `json:"email"`
-	Password    string `json:"password"`
-	Name        string `json:"name"`
-	PhoneNumber string `json:"phone_number,omitempty"`
-	Role        string `json:"role,omitempty"`
+	Email            string `json:"email"`
+	Password         string `json:"password"`
+	Name             string `json:"name"`
+	PhoneNumber      string `json:"phone_number,omitempty"`
+	Role             string `json:"role,omitempty"`
+	OrganizationName string `json:"organization_name,omitempty"`
 }
 
 type AdminLoginRequest struct {
@@ -36,14 +45,54 @@ type AdminRefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// AdminAuthResponse carries either a session or, when a second factor is
+// still needed, a challenge token for the 2FA login step.
 type AdminAuthResponse struct {
-	AdminID      uuid.UUID `json:"admin_id"`
-	Email        string    `json:"email"`
-	Name         string    `json:"name"`
-	Role         string    `json:"role"`
-	Permissions  string    `json:"permissions,omitempty"`
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token"`
+	AdminID                uuid.UUID              `json:"admin_id"`
+	Email                  string                 `json:"email"`
+	Name                   string                 `json:"name"`
+	Role                   string                 `json:"role"`
+	Permissions            string                 `json:"permissions,omitempty"`
+	AccessToken            string                 `json:"access_token,omitempty"`
+	RefreshToken           string                 `json:"refresh_token,omitempty"`
+	Organizations          []OrganizationResponse `json:"organizations,omitempty"`
+	TwoFactorRequired      bool                   `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool                   `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken         string                 `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt     *time.Time             `json:"challenge_expires_at,omitempty"`
+	RecoveryCodes          []string               `json:"recovery_codes,omitempty"`
+}
+
+type AdminTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code,omitempty"`
+	RecoveryCode   string `json:"recovery_code,omitempty"`
+}
+
+type TwoFactorSetupRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code         string `json:"code,omitempty"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	Pending                bool       `json:"pending"`
+	Required               bool       `json:"required"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 type AdminRefreshTokenResponse struct {
@@ -52,54 +101,108 @@ type AdminRefreshTokenResponse struct {
 }
 
 type CreateEventRequest struct {
-	Name                 string    `json:"name"`
-	Description          string    `json:"description,omitempty"`
-	VenueID              uuid.UUID `json:"venue_id"`
-	EventType            string    `json:"event_type"`
-	StartDatetime        time.Time `json:"start_datetime"`
-	EndDatetime          time.Time `json:"end_datetime"`
-	TotalCapacity        int32     `json:"total_capacity"`
-	BasePrice            float64   `json:"base_price"`
-	MaxTicketsPerBooking int32     `json:"max_tickets_per_booking,omitempty"`
+	Name                  string    `json:"name"`
+	Description           string    `json:"description,omitempty"`
+	VenueID               uuid.UUID `json:"venue_id"`
+	EventType             string    `json:"event_type"`
+	StartDatetime         time.Time `json:"start_datetime"`
+	EndDatetime           time.Time `json:"end_datetime"`
+	TotalCapacity         int32     `json:"total_capacity"`
+	BasePrice             float64   `json:"base_price"`
+	Currency              string    `json:"currency,omitempty"`
+	MaxTicketsPerBooking  int32     `json:"max_tickets_per_booking,omitempty"`
+	OrganizationID        uuid.UUID `json:"organization_id,omitempty"`
+	SetupBufferMinutes    *int32    `json:"setup_buffer_minutes,omitempty"`
+	TeardownBufferMinutes *int32    `json:"teardown_buffer_minutes,omitempty"`
+	Tags                  []string  `json:"tags,omitempty"`
+	AllowVenueConflict    bool      `json:"allow_venue_conflict,omitempty"`
 }
 
 type UpdateEventRequest struct {
-	Name                 *string    `json:"name,omitempty"`
-	Description          *string    `json:"description,omitempty"`
-	VenueID              *uuid.UUID `json:"venue_id,omitempty"`
-	EventType            *string    `json:"event_type,omitempty"`
-	StartDatetime        *time.Time `json:"start_datetime,omitempty"`
-	EndDatetime          *time.Time `json:"end_datetime,omitempty"`
-	TotalCapacity        *int32     `json:"total_capacity,omitempty"`
-	AvailableSeats       *int32     `json:"available_seats,omitempty"`
-	BasePrice            *float64   `json:"base_price,omitempty"`
-	MaxTicketsPerBooking *int32     `json:"max_tickets_per_booking,omitempty"`
-	Status               *string    `json:"status,omitempty"`
-	Version              int32      `json:"version"`
+	Name                  *string    `json:"name,omitempty"`
+	Description           *string    `json:"description,omitempty"`
+	VenueID               *uuid.UUID `json:"venue_id,omitempty"`
+	EventType             *string    `json:"event_type,omitempty"`
+	StartDatetime         *time.Time `json:"start_datetime,omitempty"`
+	EndDatetime           *time.Time `json:"end_datetime,omitempty"`
+	TotalCapacity         *int32     `json:"total_capacity,omitempty"`
+	AvailableSeats        *int32     `json:"available_seats,omitempty"`
+	BasePrice             *float64   `json:"base_price,omitempty"`
+	Currency              *string    `json:"currency,omitempty"`
+	MaxTicketsPerBooking  *int32     `json:"max_tickets_per_booking,omitempty"`
+	Status                *string    `json:"status,omitempty"`
+	Version               int32      `json:"version"`
+	SetupBufferMinutes    *int32     `json:"setup_buffer_minutes,omitempty"`
+	TeardownBufferMinutes *int32     `json:"teardown_buffer_minutes,omitempty"`
+	Tags                  *[]string  `json:"tags,omitempty"`
+	AllowVenueConflict    bool       `json:"allow_venue_conflict,omitempty"`
 }
 
 type EventResponse struct {
-	EventID              uuid.UUID `json:"event_id"`
-	Name                 string    `json:"name"`
-	Description          *string   `json:"description,omitempty"`
-	VenueID              uuid.UUID `json:"venue_id"`
-	VenueName            *string   `json:"venue_name,omitempty"`
-	VenueAddress         *string   `json:"venue_address,omitempty"`
-	VenueCity            *string   `json:"venue_city,omitempty"`
-	VenueState           *string   `json:"venue_state,omitempty"`
-	VenueCountry         *string   `json:"venue_country,omitempty"`
-	EventType            string    `json:"event_type"`
-	StartDatetime        time.Time `json:"start_datetime"`
-	EndDatetime          time.Time `json:"end_datetime"`
-	TotalCapacity        int32     `json:"total_capacity"`
-	AvailableSeats       int32     `json:"available_seats"`
-	BasePrice            float64   `json:"base_price"`
-	MaxTicketsPerBooking int32     `json:"max_tickets_per_booking"`
-	Status               string    `json:"status"`
-	Version              int32     `json:"version"`
-	CreatedBy            uuid.UUID `json:"created_by"`
-	CreatedAt            time.Time `json:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at"`
+	EventID               uuid.UUID    `json:"event_id"`
+	Name                  string       `json:"name"`
+	Description           *string      `json:"description,omitempty"`
+	VenueID               uuid.UUID    `json:"venue_id"`
+	VenueName             *string      `json:"venue_name,omitempty"`
+	VenueAddress          *string      `json:"venue_address,omitempty"`
+	VenueCity             *string      `json:"venue_city,omitempty"`
+	VenueState            *string      `json:"venue_state,omitempty"`
+	VenueCountry          *string      `json:"venue_country,omitempty"`
+	VenueLatitude         *float64     `json:"venue_latitude,omitempty"`
+	VenueLongitude        *float64     `json:"venue_longitude,omitempty"`
+	EventType             string       `json:"event_type"`
+	Categories            []string     `json:"categories,omitempty"`
+	Tags                  []string     `json:"tags"`
+	StartDatetime         time.Time    `json:"start_datetime"`
+	EndDatetime           time.Time    `json:"end_datetime"`
+	Timezone              string       `json:"timezone,omitempty"`
+	TotalCapacity         int32        `json:"total_capacity"`
+	AvailableSeats        int32        `json:"available_seats"`
+	BasePrice             float64      `json:"base_price"`
+	Currency              string       `json:"currency"`
+	MaxTicketsPerBooking  int32        `json:"max_tickets_per_booking"`
+	Status                string       `json:"status"`
+	Version               int32        `json:"version"`
+	CreatedBy             uuid.UUID    `json:"created_by"`
+	OrganizationID        *uuid.UUID   `json:"organization_id,omitempty"`
+	SetupBufferMinutes    int32        `json:"setup_buffer_minutes,omitempty"`
+	TeardownBufferMinutes int32        `json:"teardown_buffer_minutes,omitempty"`
+	Images                *EventImages `json:"images,omitempty"`
+	CreatedAt             time.Time    `json:"created_at"`
+	UpdatedAt             time.Time    `json:"updated_at"`
+	DeletedAt             *time.Time   `json:"deleted_at,omitempty"`
+}
+
+type EventImages struct {
+	Poster  *EventMediaResponse  `json:"poster,omitempty"`
+	Banner  *EventMediaResponse  `json:"banner,omitempty"`
+	Gallery []EventMediaResponse `json:"gallery,omitempty"`
+}
+
+type MediaVariant struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int32  `json:"width"`
+	Height      int32  `json:"height"`
+}
+
+type EventMediaResponse struct {
+	MediaID     uuid.UUID               `json:"media_id"`
+	EventID     uuid.UUID               `json:"event_id"`
+	Kind        string                  `json:"kind"`
+	URL         string                  `json:"url"`
+	ContentType string                  `json:"content_type"`
+	Width       int32                   `json:"width"`
+	Height      int32                   `json:"height"`
+	SizeBytes   int64                   `json:"size_bytes"`
+	Position    int32                   `json:"position"`
+	Variants    map[string]MediaVariant `json:"variants"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+type EventMediaListResponse struct {
+	EventID uuid.UUID            `json:"event_id"`
+	Media   []EventMediaResponse `json:"media"`
 }
 
 type EventListResponse struct {
@@ -124,18 +227,93 @@ type EventAnalyticsResponse struct {
 	TicketsSold         int32     `json:"tickets_sold"`
 	CapacityUtilization float64   `json:"capacity_utilization"`
 	BasePrice           float64   `json:"base_price"`
+	Currency            string    `json:"currency"`
 	EstimatedRevenue    float64   `json:"estimated_revenue"`
+	// Populated from booking-service when it is reachable
+	Revenue  *SalesRevenueSummary       `json:"revenue,omitempty"`
+	Bookings *BookingStatusSummary      `json:"bookings,omitempty"`
+	Waitlist *WaitlistConversionSummary `json:"waitlist,omitempty"`
+}
+
+type SalesRevenueSummary struct {
+	TotalPayments     int64   `json:"total_payments"`
+	GrossRevenue      float64 `json:"gross_revenue"`
+	RefundedAmount    float64 `json:"refunded_amount"`
+	NetRevenue        float64 `json:"net_revenue"`
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+type BookingStatusSummary struct {
+	TotalBookings     int64   `json:"total_bookings"`
+	ConfirmedBookings int64   `json:"confirmed_bookings"`
+	CancelledBookings int64   `json:"cancelled_bookings"`
+	ExpiredBookings   int64   `json:"expired_bookings"`
+	PendingBookings   int64   `json:"pending_bookings"`
+	TicketsSold       int64   `json:"tickets_sold"`
+	TicketsCancelled  int64   `json:"tickets_cancelled"`
+	CancellationRate  float64 `json:"cancellation_rate"`
+	ExpiryRate        float64 `json:"expiry_rate"`
+}
+
+type WaitlistConversionSummary struct {
+	TotalJoined    int64   `json:"total_joined"`
+	Waiting        int64   `json:"waiting"`
+	TotalOffered   int64   `json:"total_offered"`
+	Converted      int64   `json:"converted"`
+	Expired        int64   `json:"expired"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+type SalesTimeBucket struct {
+	Bucket         time.Time `json:"bucket"`
+	Orders         int64     `json:"orders"`
+	TicketsSold    int64     `json:"tickets_sold"`
+	GrossRevenue   float64   `json:"gross_revenue"`
+	RefundedAmount float64   `json:"refunded_amount"`
+	NetRevenue     float64   `json:"net_revenue"`
+}
+
+// BookingSalesAnalytics mirrors booking-service's event analytics payload
+type BookingSalesAnalytics struct {
+	EventID    uuid.UUID                 `json:"event_id"`
+	Currency   string                    `json:"currency"`
+	Revenue    SalesRevenueSummary       `json:"revenue"`
+	Bookings   BookingStatusSummary      `json:"bookings"`
+	Waitlist   WaitlistConversionSummary `json:"waitlist"`
+	Interval   string                    `json:"interval"`
+	From       time.Time                 `json:"from"`
+	To         time.Time                 `json:"to"`
+	TimeSeries []SalesTimeBucket         `json:"time_series"`
+}
+
+type EventSalesAnalyticsResponse struct {
+	EventID        uuid.UUID                 `json:"event_id"`
+	Name           string                    `json:"name"`
+	TotalCapacity  int32                     `json:"total_capacity"`
+	AvailableSeats int32                     `json:"available_seats"`
+	Currency       string                    `json:"currency"`
+	Revenue        SalesRevenueSummary       `json:"revenue"`
+	Bookings       BookingStatusSummary      `json:"bookings"`
+	Waitlist       WaitlistConversionSummary `json:"waitlist"`
+	Interval       string                    `json:"interval"`
+	From           time.Time                 `json:"from"`
+	To             time.Time                 `json:"to"`
+	TimeSeries     []SalesTimeBucket         `json:"time_series"`
 }
 
 type CreateVenueRequest struct {
-	Name         string          `json:"name"`
-	Address      string          `json:"address"`
-	City         string          `json:"city"`
-	State        string          `json:"state,omitempty"`
-	Country      string          `json:"country"`
-	PostalCode   string          `json:"postal_code,omitempty"`
-	Capacity     int32           `json:"capacity"`
-	LayoutConfig json.RawMessage `json:"layout_config,omitempty"`
+	Name           string          `json:"name"`
+	Address        string          `json:"address"`
+	City           string          `json:"city"`
+	State          string          `json:"state,omitempty"`
+	Country        string          `json:"country"`
+	PostalCode     string          `json:"postal_code,omitempty"`
+	Capacity       int32           `json:"capacity"`
+	LayoutConfig   json.RawMessage `json:"layout_config,omitempty"`
+	OrganizationID *uuid.UUID      `json:"organization_id,omitempty"`
+	Timezone       string          `json:"timezone,omitempty"`
+	Latitude       *float64        `json:"latitude,omitempty"`
+	Longitude      *float64        `json:"longitude,omitempty"`
 }
 
 type UpdateVenueRequest struct {
@@ -147,20 +325,86 @@ type UpdateVenueRequest struct {
 	PostalCode   *string          `json:"postal_code,omitempty"`
 	Capacity     *int32           `json:"capacity,omitempty"`
 	LayoutConfig *json.RawMessage `json:"layout_config,omitempty"`
+	Timezone     *string          `json:"timezone,omitempty"`
+	Latitude     *float64         `json:"latitude,omitempty"`
+	Longitude    *float64         `json:"longitude,omitempty"`
 }
 
 type VenueResponse struct {
-	VenueID      uuid.UUID       `json:"venue_id"`
-	Name         string          `json:"name"`
-	Address      string          `json:"address"`
-	City         string          `json:"city"`
-	State        *string         `json:"state,omitempty"`
-	Country      string          `json:"country"`
-	PostalCode   *string         `json:"postal_code,omitempty"`
-	Capacity     int32           `json:"capacity"`
-	LayoutConfig json.RawMessage `json:"layout_config,omitempty"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
+	VenueID        uuid.UUID       `json:"venue_id"`
+	Name           string          `json:"name"`
+	Address        string          `json:"address"`
+	City           string          `json:"city"`
+	State          *string         `json:"state,omitempty"`
+	Country        string          `json:"country"`
+	PostalCode     *string         `json:"postal_code,omitempty"`
+	Capacity       int32           `json:"capacity"`
+	LayoutConfig   json.RawMessage `json:"layout_config,omitempty"`
+	OrganizationID *uuid.UUID      `json:"organization_id,omitempty"`
+	Timezone       string          `json:"timezone"`
+	Latitude       *float64        `json:"latitude"`
+	Longitude      *float64        `json:"longitude"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	DeletedAt      *time.Time      `json:"deleted_at,omitempty"`
+}
+
+type SeatMapSeat struct {
+	Section         string  `json:"section"`
+	Row             string  `json:"row"`
+	Number          int     `json:"number"`
+	PriceZone       string  `json:"price_zone,omitempty"`
+	PriceMultiplier float64 `json:"price_multiplier"`
+	Accessible      bool    `json:"accessible,omitempty"`
+	Companion       bool    `json:"companion,omitempty"`
+}
+
+type VenueLayoutSummary struct {
+	Sections        int              `json:"sections"`
+	Rows            int              `json:"rows"`
+	Seats           int32            `json:"seats"`
+	AccessibleSeats int32            `json:"accessible_seats"`
+	CompanionSeats  int32            `json:"companion_seats"`
+	UnzonedSeats    int32            `json:"unzoned_seats"`
+	Zones           map[string]int32 `json:"zones"`
+}
+
+type VenueLayoutResponse struct {
+	VenueID   uuid.UUID           `json:"venue_id"`
+	Capacity  int32               `json:"capacity"`
+	Layout    *VenueLayout        `json:"layout"`
+	Summary   *VenueLayoutSummary `json:"summary,omitempty"`
+	Seats     []SeatMapSeat       `json:"seats,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// VenueSlot is a period during which a venue is occupied by an event,
+// including its setup and teardown buffers. Events of other organizations
+// are shown without their details.
+type VenueSlot struct {
+	EventID               *uuid.UUID `json:"event_id,omitempty"`
+	Name                  string     `json:"name,omitempty"`
+	Status                string     `json:"status,omitempty"`
+	Private               bool       `json:"private,omitempty"`
+	StartDatetime         time.Time  `json:"start_datetime"`
+	EndDatetime           time.Time  `json:"end_datetime"`
+	OccupiedFrom          time.Time  `json:"occupied_from"`
+	OccupiedUntil         time.Time  `json:"occupied_until"`
+	SetupBufferMinutes    int32      `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int32      `json:"teardown_buffer_minutes"`
+}
+
+type VenueConflictResponse struct {
+	Error     string      `json:"error"`
+	Conflicts []VenueSlot `json:"conflicts"`
+}
+
+type VenueCalendarResponse struct {
+	VenueID  uuid.UUID   `json:"venue_id"`
+	Timezone string      `json:"timezone"`
+	From     time.Time   `json:"from"`
+	To       time.Time   `json:"to"`
+	Slots    []VenueSlot `json:"slots"`
 }
 
 type VenueListResponse struct {
@@ -171,6 +415,123 @@ type VenueListResponse struct {
 	HasMore bool            `json:"has_more"`
 }
 
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type OrganizationResponse struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           string    `json:"name"`
+	CreatedBy      uuid.UUID `json:"created_by"`
+	Role           string    `json:"role,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type OrganizationListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type OrganizationMemberResponse struct {
+	AdminID  uuid.UUID `json:"admin_id"`
+	Email    string    `json:"email,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type OrganizationMemberListResponse struct {
+	Members []OrganizationMemberResponse `json:"members"`
+}
+
+// OrganizationAnalyticsResponse reports revenue per currency, plus a total
+// converted to ReportingCurrency. Currencies without a rate to it are left
+// out of the total and listed in UnconvertedCurrencies.
+type OrganizationAnalyticsResponse struct {
+	OrganizationID        uuid.UUID         `json:"organization_id"`
+	TotalEvents           int64             `json:"total_events"`
+	PublishedEvents       int64             `json:"published_events"`
+	TotalCapacity         int64             `json:"total_capacity"`
+	TicketsSold           int64             `json:"tickets_sold"`
+	ReportingCurrency     string            `json:"reporting_currency"`
+	EstimatedRevenue      float64           `json:"estimated_revenue"`
+	RevenueByCurrency     []CurrencyRevenue `json:"revenue_by_currency"`
+	UnconvertedCurrencies []string          `json:"unconverted_currencies,omitempty"`
+}
+
+type CurrencyRevenue struct {
+	Currency         string  `json:"currency"`
+	TicketsSold      int64   `json:"tickets_sold"`
+	EstimatedRevenue float64 `json:"estimated_revenue"`
+	// Converted is EstimatedRevenue in the reporting currency, when a rate
+	// is known.
+	Converted *float64 `json:"converted,omitempty"`
+}
+
+type ExchangeRateRequest struct {
+	FromCurrency  string  `json:"from_currency"`
+	ToCurrency    string  `json:"to_currency"`
+	Rate          float64 `json:"rate"`
+	EffectiveDate string  `json:"effective_date,omitempty"`
+}
+
+type ExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates"`
+}
+
+type ExchangeRateResponse struct {
+	FromCurrency  string    `json:"from_currency"`
+	ToCurrency    string    `json:"to_currency"`
+	Rate          float64   `json:"rate"`
+	EffectiveDate string    `json:"effective_date"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ExchangeRatesResponse struct {
+	Rates []ExchangeRateResponse `json:"rates"`
+}
+
+type CreateCategoryRequest struct {
+	Slug   string `json:"slug,omitempty"`
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+}
+
+type UpdateCategoryRequest struct {
+	Name string `json:"name"`
+}
+
+type CategoryResponse struct {
+	Slug          string             `json:"slug"`
+	Name          string             `json:"name"`
+	Parent        string             `json:"parent,omitempty"`
+	Subcategories []CategoryResponse `json:"subcategories,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type CategoryListResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
+
+type TransferEventRequest struct {
+	AdminID uuid.UUID `json:"admin_id"`
+	Version int32     `json:"version"`
+}
+
 type UpdateAvailabilityRequest struct {
 	Quantity int32 `json:"quantity"`
 	Version  int32 `json:"version"`
@@ -196,19 +557,67 @@ type ReturnSeatsResponse struct {
 }
 
 type EventForBookingResponse struct {
-	EventID              uuid.UUID `json:"event_id"`
-	AvailableSeats       int32     `json:"available_seats"`
-	MaxTicketsPerBooking int32     `json:"max_tickets_per_booking"`
-	BasePrice            float64   `json:"base_price"`
-	Version              int32     `json:"version"`
-	Status               string    `json:"status"`
-	Name                 string    `json:"name"`
+	EventID              uuid.UUID  `json:"event_id"`
+	AvailableSeats       int32      `json:"available_seats"`
+	MaxTicketsPerBooking int32      `json:"max_tickets_per_booking"`
+	BasePrice            float64    `json:"base_price"`
+	BasePriceMinor       int64      `json:"base_price_minor"`
+	Currency             string     `json:"currency"`
+	Version              int32      `json:"version"`
+	Status               string     `json:"status"`
+	Name                 string     `json:"name"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty"`
+}
+
+// DeletedItemsResponse lists soft-deleted items that can still be restored.
+// Items are purged for good once RetentionSeconds have passed since deletion.
+type DeletedItemsResponse struct {
+	Events           []EventResponse `json:"events"`
+	Venues           []VenueResponse `json:"venues"`
+	RetentionSeconds int64           `json:"retention_seconds"`
 }
 
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
 
+type SearchSyncStatusResponse struct {
+	Enabled         bool                     `json:"enabled"`
+	PendingChanges  int64                    `json:"pending_changes"`
+	PendingEvents   int64                    `json:"pending_events"`
+	RetryingChanges int64                    `json:"retrying_changes"`
+	LagSeconds      float64                  `json:"lag_seconds"`
+	LastError       *SearchSyncErrorResponse `json:"last_error,omitempty"`
+}
+
+type SearchSyncErrorResponse struct {
+	EventID       uuid.UUID `json:"event_id"`
+	Attempts      int32     `json:"attempts"`
+	Error         string    `json:"error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+type EventChangesResponse struct {
+	Changes []EventChange `json:"changes"`
+	HasMore bool          `json:"has_more"`
+}
+
+type EventChange struct {
+	EventID   uuid.UUID      `json:"event_id"`
+	Operation string         `json:"operation"`
+	ChangedAt time.Time      `json:"changed_at"`
+	Event     *EventResponse `json:"event,omitempty"`
+}
+
+type SearchSynonymsRequest struct {
+	Synonyms []string `json:"synonyms"`
+}
+
+type SearchSynonymsResponse struct {
+	Synonyms []string `json:"synonyms"`
+	Count    int      `json:"count"`
+}
+
 type ErrorResponse struct {
 	Error struct {
 		Code      string    `json:"code"`
@@ -217,3 +626,115 @@ type ErrorResponse struct {
 		Timestamp time.Time `json:"timestamp"`
 	} `json:"error"`
 }
+
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Type    string `json:"type,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResponse struct {
+	DryRun         bool             `json:"dry_run"`
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	TotalRows      int              `json:"total_rows"`
+	ValidRows      int              `json:"valid_rows"`
+	InvalidRows    int              `json:"invalid_rows"`
+	VenuesCreated  int              `json:"venues_created"`
+	EventsCreated  int              `json:"events_created"`
+	Errors         []ImportRowError `json:"errors"`
+	Venues         []VenueResponse  `json:"venues,omitempty"`
+	Events         []EventResponse  `json:"events,omitempty"`
+}
+
+// BookingAttendeePage mirrors one page of booking-service's attendee listing
+type BookingAttendeePage struct {
+	EventID           uuid.UUID         `json:"event_id"`
+	TotalBookings     int64             `json:"total_bookings"`
+	TotalTickets      int64             `json:"total_tickets"`
+	CheckedInBookings int64             `json:"checked_in_bookings"`
+	Attendees         []BookingAttendee `json:"attendees"`
+	NextCursor        *uuid.UUID        `json:"next_cursor,omitempty"`
+}
+
+type BookingAttendee struct {
+	BookingID        uuid.UUID  `json:"booking_id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	BookingReference string     `json:"booking_reference"`
+	Quantity         int32      `json:"quantity"`
+	Seats            []string   `json:"seats"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CheckedInAt      *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// UserSummary mirrors user-service's batch lookup entries
+type UserSummary struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+type AttendeeManifestEntry struct {
+	BookingReference string     `json:"booking_reference"`
+	UserID           uuid.UUID  `json:"user_id"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	Quantity         int32      `json:"quantity"`
+	Seats            []string   `json:"seats"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CheckedIn        bool       `json:"checked_in"`
+	CheckedInAt      *time.Time `json:"checked_in_at,omitempty"`
+}
+
+type CheckInRequest struct {
+	BookingReference string `json:"booking_reference"`
+}
+
+type CheckInResponse struct {
+	BookingID        uuid.UUID `json:"booking_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	BookingReference string    `json:"booking_reference"`
+	Quantity         int32     `json:"quantity"`
+	CheckedInAt      time.Time `json:"checked_in_at"`
+	Name             string    `json:"name,omitempty"`
+	Email            string    `json:"email,omitempty"`
+}
+
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+type HistoryEntry struct {
+	HistoryID           uuid.UUID       `json:"history_id"`
+	EntityType          string          `json:"entity_type"`
+	EntityID            uuid.UUID       `json:"entity_id"`
+	Version             int32           `json:"version"`
+	Action              string          `json:"action"`
+	ChangedBy           *uuid.UUID      `json:"changed_by,omitempty"`
+	Changes             json.RawMessage `json:"changes"`
+	Snapshot            json.RawMessage `json:"snapshot,omitempty"`
+	RevertedFromVersion *int32          `json:"reverted_from_version,omitempty"`
+	CreatedAt           time.Time       `json:"created_at"`
+}
+
+type HistoryListResponse struct {
+	EntityType        string         `json:"entity_type"`
+	EntityID          uuid.UUID      `json:"entity_id"`
+	CurrentVersion    int32          `json:"current_version"`
+	Entries           []HistoryEntry `json:"entries"`
+	NextBeforeVersion *int32         `json:"next_before_version,omitempty"`
+}
+
+type RevertRequest struct {
+	ToVersion          int32 `json:"to_version"`
+	Version            int32 `json:"version"`
+	AllowVenueConflict bool  `json:"allow_venue_conflict"`
+}
+
+type AuditResponse struct {
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	Entries        []HistoryEntry `json:"entries"`
+	NextBefore     *time.Time     `json:"next_before,omitempty"`
+}
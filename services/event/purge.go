@@ -0,0 +1,218 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+)
+
+const purgeBatchSize = 100
+
+// startPurgeWorker permanently removes events and venues whose soft deletion
+// is older than the retention period.
+func (cfg *APIConfig) startPurgeWorker() {
+	interval := cfg.Config.PurgeInterval
+	if interval <= 0 {
+		cfg.Logger.Info("Purge worker disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	cfg.Logger.Info("Started purge worker", "interval", interval.String(), "retention", cfg.Config.DeletedRetention.String())
+
+	for range ticker.C {
+		cfg.purgeDeleted(context.Background())
+	}
+}
+
+// purgeDeleted runs one purge pass. Events go first so that venues whose
+// only remaining events were deleted alongside them can follow in the same
+// pass. Rows that cannot be purged yet are skipped, not retried in a loop.
+func (cfg *APIConfig) purgeDeleted(ctx context.Context) {
+	cutoff := time.Now().Add(-cfg.Config.DeletedRetention)
+
+	purgedEvents := cfg.purgeDeletedEvents(ctx, cutoff)
+	purgedVenues := cfg.purgeDeletedVenues(ctx, cutoff)
+
+	if purgedEvents > 0 || purgedVenues > 0 {
+		cfg.Logger.Info("Purged deleted items", "events", purgedEvents, "venues", purgedVenues)
+	}
+}
+
+func (cfg *APIConfig) purgeDeletedEvents(ctx context.Context, cutoff time.Time) int {
+	// Without the booking service there is no way to tell whether bookings
+	// still refer to an event, so nothing is purged.
+	if cfg.BookingClient == nil {
+		return 0
+	}
+
+	purged := 0
+	var afterDeletedAt time.Time
+	afterEventID := uuid.Nil
+	for {
+		batch, err := cfg.DB.ListPurgeableEvents(ctx, events.ListPurgeableEventsParams{
+			DeletedBefore:  sql.NullTime{Time: cutoff, Valid: true},
+			AfterDeletedAt: afterDeletedAt,
+			AfterEventID:   afterEventID,
+			BatchSize:      purgeBatchSize,
+		})
+		if err != nil {
+			cfg.Logger.Error("Failed to list purgeable events", "error", err)
+			return purged
+		}
+
+		for _, event := range batch {
+			if cfg.purgeEvent(ctx, event) {
+				purged++
+			}
+		}
+
+		if len(batch) < purgeBatchSize {
+			return purged
+		}
+		last := batch[len(batch)-1]
+		afterDeletedAt, afterEventID = last.DeletedAt.Time, last.EventID
+	}
+}
+
+// purgeEvent deletes one event for good. Events with bookings of any status
+// are kept, since booking history still resolves them.
+func (cfg *APIConfig) purgeEvent(ctx context.Context, event events.Event) bool {
+	bookings, err := cfg.BookingClient.CountEventBookings(ctx, event.EventID)
+	if err != nil {
+		cfg.Logger.Warn("Skipping purge, failed to count bookings", "error", err, "event_id", event.EventID)
+		return false
+	}
+	if bookings > 0 {
+		cfg.Logger.Debug("Skipping purge of event with bookings", "event_id", event.EventID, "bookings", bookings)
+		return false
+	}
+
+	media, err := cfg.DB.ListEventMedia(ctx, event.EventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list event media for purge", "error", err, "event_id", event.EventID)
+		return false
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		return false
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	rows, err := qtx.PurgeEvent(ctx, event.EventID)
+	if err != nil {
+		cfg.Logger.Error("Failed to purge event", "error", err, "event_id", event.EventID)
+		return false
+	}
+	if rows == 0 {
+		// Restored since it was listed.
+		return false
+	}
+
+	snapshot := newEventSnapshot(event)
+	if err := cfg.recordHistory(ctx, qtx, historyRecord{
+		entityType:      HistoryEntityEvent,
+		entityID:        event.EventID,
+		organizationID:  event.OrganizationID,
+		action:          HistoryActionPurged,
+		before:          snapshot,
+		baselineVersion: event.Version,
+		after:           snapshot,
+	}); err != nil {
+		cfg.Logger.Error("Failed to record event history", "error", err, "event_id", event.EventID)
+		return false
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit event purge", "error", err, "event_id", event.EventID)
+		return false
+	}
+
+	if cfg.MediaStore != nil {
+		for _, m := range media {
+			cfg.deleteMediaObjects(ctx, mediaObjectKeys(m))
+		}
+	}
+
+	cfg.Logger.Info("Event purged", "event_id", event.EventID, "deleted_at", event.DeletedAt.Time)
+	return true
+}
+
+func (cfg *APIConfig) purgeDeletedVenues(ctx context.Context, cutoff time.Time) int {
+	purged := 0
+	var afterDeletedAt time.Time
+	afterVenueID := uuid.Nil
+	for {
+		batch, err := cfg.DB.ListPurgeableVenues(ctx, events.ListPurgeableVenuesParams{
+			DeletedBefore:  sql.NullTime{Time: cutoff, Valid: true},
+			AfterDeletedAt: afterDeletedAt,
+			AfterVenueID:   afterVenueID,
+			BatchSize:      purgeBatchSize,
+		})
+		if err != nil {
+			cfg.Logger.Error("Failed to list purgeable venues", "error", err)
+			return purged
+		}
+
+		for _, venue := range batch {
+			if cfg.purgeVenue(ctx, venue) {
+				purged++
+			}
+		}
+
+		if len(batch) < purgeBatchSize {
+			return purged
+		}
+		last := batch[len(batch)-1]
+		afterDeletedAt, afterVenueID = last.DeletedAt.Time, last.VenueID
+	}
+}
+
+func (cfg *APIConfig) purgeVenue(ctx context.Context, venue events.Venue) bool {
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		return false
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	rows, err := qtx.PurgeVenue(ctx, venue.VenueID)
+	if err != nil {
+		cfg.Logger.Error("Failed to purge venue", "error", err, "venue_id", venue.VenueID)
+		return false
+	}
+	if rows == 0 {
+		return false
+	}
+
+	snapshot := newVenueSnapshot(venue)
+	if err := cfg.recordHistory(ctx, qtx, historyRecord{
+		entityType:      HistoryEntityVenue,
+		entityID:        venue.VenueID,
+		organizationID:  venue.OrganizationID,
+		action:          HistoryActionPurged,
+		before:          snapshot,
+		baselineVersion: 1,
+		after:           snapshot,
+	}); err != nil {
+		cfg.Logger.Error("Failed to record venue history", "error", err, "venue_id", venue.VenueID)
+		return false
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit venue purge", "error", err, "venue_id", venue.VenueID)
+		return false
+	}
+
+	cfg.Logger.Info("Venue purged", "venue_id", venue.VenueID, "deleted_at", venue.DeletedAt.Time)
+	return true
+}
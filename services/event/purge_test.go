@@ -0,0 +1,200 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+)
+
+// testBookingService answers booking counts from the given map and
+// returns the client for it.
+func testBookingService(t *testing.T, bookings map[uuid.UUID]int64) *BookingServiceClient {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /internal/bookings/events/{id}/count", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int64{"bookings": bookings[uuid.MustParse(r.PathValue("id"))]})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return NewBookingServiceClient(server.URL, "test-key", logger.New("error"))
+}
+
+// answerHistory serves the history queries and returns the actions
+// recorded, as entity ID and action.
+func answerHistory(fake *fakeDB) func() [][2]string {
+	var mu sync.Mutex
+	var recorded [][2]string
+	fake.returns("GetLatestEntityHistoryVersion", int32(2))
+	fake.on("CreateEntityHistory", func(args []driver.Value) (fakeResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, [2]string{args[1].(string), args[3].(string)})
+		return fakeResult{rows: [][]driver.Value{fakeRow(events.EntityHistory{EntityType: args[0].(string), Action: args[3].(string)})}}, nil
+	})
+	return func() [][2]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][2]string(nil), recorded...)
+	}
+}
+
+// answerPurges serves PurgeEvent and PurgeVenue, deleting only the given
+// rows, and returns the IDs each was asked to delete.
+func answerPurges(fake *fakeDB, deletable ...uuid.UUID) func() []string {
+	var mu sync.Mutex
+	var asked []string
+	purge := func(args []driver.Value) (fakeResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		asked = append(asked, args[0].(string))
+		for _, id := range deletable {
+			if args[0] == id.String() {
+				return fakeResult{affected: 1}, nil
+			}
+		}
+		return fakeResult{}, nil
+	}
+	fake.on("PurgeEvent", purge)
+	fake.on("PurgeVenue", purge)
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), asked...)
+	}
+}
+
+func testDeletedEvent(n byte) events.Event {
+	return events.Event{
+		EventID:   uuid.UUID{0xe0, 15: n},
+		Name:      "Deleted",
+		VenueID:   testVenue,
+		EventType: "concert",
+		Status:    sql.NullString{String: "cancelled", Valid: true},
+		Version:   2,
+		CreatedBy: testAdmin,
+		DeletedAt: sql.NullTime{Time: time.Now().Add(-60 * 24 * time.Hour), Valid: true},
+		Currency:  "INR",
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	cfg, fake := testEventConfig(t)
+	cfg.Config.DeletedRetention = 30 * 24 * time.Hour
+
+	unbooked, booked, restored := testDeletedEvent(1), testDeletedEvent(2), testDeletedEvent(3)
+	cfg.BookingClient = testBookingService(t, map[uuid.UUID]int64{booked.EventID: 2})
+
+	emptyVenue := events.Venue{VenueID: uuid.UUID{0xc0, 15: 1}, DeletedAt: unbooked.DeletedAt}
+	usedVenue := events.Venue{VenueID: uuid.UUID{0xc0, 15: 2}, DeletedAt: unbooked.DeletedAt}
+
+	var cutoff time.Time
+	fake.on("ListPurgeableEvents", func(args []driver.Value) (fakeResult, error) {
+		cutoff = args[0].(time.Time)
+		return fakeResult{rows: [][]driver.Value{fakeRow(unbooked), fakeRow(booked), fakeRow(restored)}}, nil
+	})
+	fake.returns("ListPurgeableVenues", emptyVenue, usedVenue)
+	fake.returns("ListEventMedia")
+	history := answerHistory(fake)
+	// The restored event and the venue that still has events are left alone
+	// by the queries' own conditions.
+	asked := answerPurges(fake, unbooked.EventID, emptyVenue.VenueID)
+
+	before := time.Now()
+	cfg.purgeDeleted(context.Background())
+	after := time.Now()
+
+	if cutoff.Before(before.Add(-cfg.Config.DeletedRetention)) || cutoff.After(after.Add(-cfg.Config.DeletedRetention)) {
+		t.Errorf("cutoff = %v, want %v before the pass", cutoff, cfg.Config.DeletedRetention)
+	}
+
+	// Events with bookings are never asked to be purged.
+	wantAsked := []string{unbooked.EventID.String(), restored.EventID.String(), emptyVenue.VenueID.String(), usedVenue.VenueID.String()}
+	if got := asked(); !reflect.DeepEqual(got, wantAsked) {
+		t.Errorf("purged = %v, want %v", got, wantAsked)
+	}
+
+	wantHistory := [][2]string{
+		{unbooked.EventID.String(), HistoryActionPurged},
+		{emptyVenue.VenueID.String(), HistoryActionPurged},
+	}
+	if got := history(); !reflect.DeepEqual(got, wantHistory) {
+		t.Errorf("history = %v, want %v", got, wantHistory)
+	}
+	if commits := fake.count("commit"); commits != 2 {
+		t.Errorf("commits = %d, want 2", commits)
+	}
+}
+
+func TestPurgeDeletedWithoutBookingService(t *testing.T) {
+	cfg, fake := testEventConfig(t)
+	cfg.Config.DeletedRetention = 30 * 24 * time.Hour
+
+	venue := events.Venue{VenueID: testVenue, DeletedAt: sql.NullTime{Time: time.Now().Add(-60 * 24 * time.Hour), Valid: true}}
+	fake.returns("ListPurgeableVenues", venue)
+	answerHistory(fake)
+	asked := answerPurges(fake, testVenue)
+
+	cfg.purgeDeleted(context.Background())
+
+	if n := fake.count("ListPurgeableEvents"); n != 0 {
+		t.Errorf("listed purgeable events %d times without a booking service", n)
+	}
+	if got, want := asked(), []string{testVenue.String()}; !reflect.DeepEqual(got, want) {
+		t.Errorf("purged = %v, want %v", got, want)
+	}
+}
+
+func TestPurgeDeletedPages(t *testing.T) {
+	cfg, fake := testEventConfig(t)
+	cfg.Config.DeletedRetention = 30 * 24 * time.Hour
+
+	page := make([]events.Event, purgeBatchSize)
+	bookings := make(map[uuid.UUID]int64, purgeBatchSize)
+	for i := range page {
+		page[i] = testDeletedEvent(byte(i))
+		page[i].DeletedAt.Time = page[i].DeletedAt.Time.Add(time.Duration(i) * time.Second)
+		bookings[page[i].EventID] = 1
+	}
+	cfg.BookingClient = testBookingService(t, bookings)
+
+	var cursors [][2]any
+	fake.on("ListPurgeableEvents", func(args []driver.Value) (fakeResult, error) {
+		cursors = append(cursors, [2]any{args[1], args[2]})
+		if len(cursors) > 1 {
+			return fakeResult{}, nil
+		}
+		result := fakeResult{}
+		for _, event := range page {
+			result.rows = append(result.rows, fakeRow(event))
+		}
+		return result, nil
+	})
+	fake.returns("ListPurgeableVenues")
+
+	cfg.purgeDeleted(context.Background())
+
+	last := page[len(page)-1]
+	want := [][2]any{
+		{time.Time{}, uuid.Nil.String()},
+		{last.DeletedAt.Time, last.EventID.String()},
+	}
+	if len(cursors) != len(want) {
+		t.Fatalf("listed %d pages, want %d", len(cursors), len(want))
+	}
+	for i := range want {
+		if !cursors[i][0].(time.Time).Equal(want[i][0].(time.Time)) || cursors[i][1] != want[i][1] {
+			t.Errorf("page %d after = %v, want %v", i, cursors[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package event
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultEventChangesLimit = 100
+	maxEventChangesLimit     = 500
+)
+
+// GetEventChanges lists events changed at or after a watermark, oldest
+// change first, so search-service can catch up without reloading
+// everything. Published and sold-out events come with their current state;
+// deleted, purged and unpublished ones are listed for removal. The next page
+// starts from the last change's changed_at and event_id.
+func (cfg *APIConfig) GetEventChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if s := query.Get("since"); s != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "since must be an RFC 3339 timestamp")
+			return
+		}
+		since = parsed.UTC()
+	}
+
+	afterEventID := uuid.Nil
+	if a := query.Get("after"); a != "" {
+		parsed, err := uuid.Parse(a)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid after event ID")
+			return
+		}
+		afterEventID = parsed
+	}
+
+	limit := defaultEventChangesLimit
+	if l := query.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= maxEventChangesLimit {
+			limit = parsed
+		}
+	}
+
+	changes, err := cfg.DB.ListEventChanges(r.Context(), events.ListEventChangesParams{
+		ChangedSince: since,
+		AfterEventID: afterEventID,
+		PageSize:     int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list event changes", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list event changes")
+		return
+	}
+
+	var eventIDs []uuid.UUID
+	for _, change := range changes {
+		if !change.Purged {
+			eventIDs = append(eventIDs, change.EventID)
+		}
+	}
+
+	rows, err := cfg.DB.ListEventsWithVenues(r.Context(), eventIDs)
+	if err != nil {
+		cfg.Logger.Error("Failed to load changed events", "error", err, "events", len(eventIDs))
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list event changes")
+		return
+	}
+
+	var live []EventResponse
+	for _, row := range rows {
+		if row.DeletedAt.Valid || (row.Status.String != "published" && row.Status.String != "sold_out") {
+			continue
+		}
+		live = append(live, eventChangeResponse(row))
+	}
+	cfg.attachEventImages(r.Context(), live)
+	cfg.attachEventCategories(r.Context(), live)
+
+	byID := make(map[uuid.UUID]*EventResponse, len(live))
+	for i := range live {
+		byID[live[i].EventID] = &live[i]
+	}
+
+	// Events purged between the two queries have no row and are removed
+	// like the rest.
+	response := EventChangesResponse{
+		Changes: make([]EventChange, len(changes)),
+		HasMore: len(changes) == limit,
+	}
+	for i, change := range changes {
+		response.Changes[i] = EventChange{
+			EventID:   change.EventID,
+			Operation: SearchSyncDelete,
+			ChangedAt: change.ChangedAt.Time,
+		}
+		if event, ok := byID[change.EventID]; ok {
+			response.Changes[i].Operation = SearchSyncUpsert
+			response.Changes[i].Event = event
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func eventChangeResponse(event events.ListEventsWithVenuesRow) EventResponse {
+	response := EventResponse{
+		EventID:              event.EventID,
+		Name:                 event.Name,
+		Description:          utils.StringPtrFromNullString(event.Description),
+		VenueID:              event.VenueID,
+		VenueName:            &event.VenueName,
+		VenueAddress:         &event.Address,
+		VenueCity:            &event.City,
+		VenueState:           utils.StringPtrFromNullString(event.State),
+		VenueCountry:         &event.Country,
+		VenueLatitude:        utils.Float64PtrFromNullFloat64(event.VenueLatitude),
+		VenueLongitude:       utils.Float64PtrFromNullFloat64(event.VenueLongitude),
+		EventType:            event.EventType,
+		Tags:                 event.Tags,
+		StartDatetime:        event.StartDatetime,
+		EndDatetime:          event.EndDatetime,
+		TotalCapacity:        event.TotalCapacity,
+		AvailableSeats:       event.AvailableSeats,
+		BasePrice:            eventPrice(event.BasePriceMinor, event.Currency),
+		Currency:             event.Currency,
+		MaxTicketsPerBooking: event.MaxTicketsPerBooking.Int32,
+		Status:               event.Status.String,
+		Version:              event.Version,
+		CreatedBy:            event.CreatedBy,
+		CreatedAt:            event.CreatedAt.Time,
+		UpdatedAt:            event.UpdatedAt.Time,
+	}
+	response.inVenueZone(event.VenueTimezone)
+	return response
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
 	"github.com/google/uuid"
 )
@@ -21,29 +22,38 @@ type SearchServiceClient struct {
 }
 
 type SearchEventDocument struct {
-	EventID        uuid.UUID `json:"event_id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description,omitempty"`
-	VenueID        uuid.UUID `json:"venue_id"`
-	VenueName      string    `json:"venue_name"`
-	VenueAddress   string    `json:"venue_address,omitempty"`
-	VenueCity      string    `json:"venue_city"`
-	VenueState     string    `json:"venue_state,omitempty"`
-	VenueCountry   string    `json:"venue_country"`
-	EventType      string    `json:"event_type"`
-	StartDateTime  time.Time `json:"start_datetime"`
-	EndDateTime    time.Time `json:"end_datetime"`
-	BasePrice      float64   `json:"base_price"`
-	AvailableSeats int32     `json:"available_seats"`
-	TotalCapacity  int32     `json:"total_capacity"`
-	Status         string    `json:"status"`
-	Version        int32     `json:"version"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	EventID        uuid.UUID      `json:"event_id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description,omitempty"`
+	VenueID        uuid.UUID      `json:"venue_id"`
+	VenueName      string         `json:"venue_name"`
+	VenueAddress   string         `json:"venue_address,omitempty"`
+	VenueCity      string         `json:"venue_city"`
+	VenueState     string         `json:"venue_state,omitempty"`
+	VenueCountry   string         `json:"venue_country"`
+	Location       *geocode.Point `json:"location,omitempty"`
+	EventType      string         `json:"event_type"`
+	Categories     []string       `json:"categories"`
+	Tags           []string       `json:"tags"`
+	StartDateTime  time.Time      `json:"start_datetime"`
+	EndDateTime    time.Time      `json:"end_datetime"`
+	Timezone       string         `json:"timezone"`
+	StartDate      string         `json:"start_date"`
+	BasePrice      float64        `json:"base_price"`
+	Currency       string         `json:"currency"`
+	AvailableSeats int32          `json:"available_seats"`
+	TotalCapacity  int32          `json:"total_capacity"`
+	Status         string         `json:"status"`
+	Version        int32          `json:"version"`
+	ImageURL       string         `json:"image_url,omitempty"`
+	ThumbnailURL   string         `json:"thumbnail_url,omitempty"`
+	BannerURL      string         `json:"banner_url,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
 }
 
-type SearchIndexRequest struct {
-	Event SearchEventDocument `json:"event"`
+type SearchBulkIndexRequest struct {
+	Events []SearchEventDocument `json:"events"`
 }
 
 func NewSearchServiceClient(baseURL, apiKey string, logger *logger.Logger) *SearchServiceClient {
@@ -60,22 +70,20 @@ func NewSearchServiceClient(baseURL, apiKey string, logger *logger.Logger) *Sear
 	return client
 }
 
-func (c *SearchServiceClient) IndexEvent(ctx context.Context, event EventResponse, venue VenueResponse) error {
-	fmt.Printf("DEBUG: IndexEvent called - BaseURL: '%s', APIKey: '%s'\n", c.BaseURL, c.APIKey)
-	if c.BaseURL == "" {
-		c.Logger.Debug("Search service URL not configured, skipping indexing")
-		fmt.Printf("DEBUG: BaseURL is empty, skipping indexing\n")
-		return nil
-	}
-
+func newSearchEventDocument(event EventResponse, venue VenueResponse) SearchEventDocument {
 	doc := SearchEventDocument{
 		EventID:        event.EventID,
 		Name:           event.Name,
 		VenueID:        event.VenueID,
 		EventType:      event.EventType,
-		StartDateTime:  event.StartDatetime,
-		EndDateTime:    event.EndDatetime,
+		Categories:     event.Categories,
+		Tags:           event.Tags,
+		StartDateTime:  event.StartDatetime.In(venueLocation(venue.Timezone)),
+		EndDateTime:    event.EndDatetime.In(venueLocation(venue.Timezone)),
+		Timezone:       venueLocation(venue.Timezone).String(),
+		StartDate:      localDate(event.StartDatetime, venue.Timezone),
 		BasePrice:      event.BasePrice,
+		Currency:       event.Currency,
 		AvailableSeats: event.AvailableSeats,
 		TotalCapacity:  event.TotalCapacity,
 		Status:         event.Status,
@@ -87,6 +95,10 @@ func (c *SearchServiceClient) IndexEvent(ctx context.Context, event EventRespons
 		VenueCountry:   venue.Country,
 	}
 
+	if len(doc.Categories) == 0 {
+		doc.Categories = []string{event.EventType}
+	}
+
 	if event.Description != nil {
 		doc.Description = *event.Description
 	}
@@ -99,20 +111,53 @@ func (c *SearchServiceClient) IndexEvent(ctx context.Context, event EventRespons
 		doc.VenueState = *venue.State
 	}
 
-	request := SearchIndexRequest{
-		Event: doc,
+	if venue.Latitude != nil && venue.Longitude != nil {
+		doc.Location = &geocode.Point{Lat: *venue.Latitude, Lon: *venue.Longitude}
+	}
+
+	// Search results show the poster, falling back to the first gallery image.
+	if images := event.Images; images != nil {
+		cover := images.Poster
+		if cover == nil && len(images.Gallery) > 0 {
+			cover = &images.Gallery[0]
+		}
+		if cover != nil {
+			doc.ImageURL = mediaVariantURL(*cover, "medium")
+			doc.ThumbnailURL = mediaVariantURL(*cover, "thumbnail")
+		}
+		if images.Banner != nil {
+			doc.BannerURL = mediaVariantURL(*images.Banner, "large")
+		}
+	}
+
+	return doc
+}
+
+func mediaVariantURL(media EventMediaResponse, name string) string {
+	if variant, ok := media.Variants[name]; ok {
+		return variant.URL
+	}
+	return media.URL
+}
+
+func (c *SearchServiceClient) BulkIndexEvents(ctx context.Context, docs []SearchEventDocument) error {
+	if c.BaseURL == "" {
+		c.Logger.Debug("Search service URL not configured, skipping bulk indexing")
+		return nil
 	}
 
-	jsonData, err := json.Marshal(request)
+	if len(docs) == 0 {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(SearchBulkIndexRequest{Events: docs})
 	if err != nil {
-		c.Logger.Error("Failed to marshal search document", "error", err, "event_id", event.EventID)
-		return fmt.Errorf("failed to marshal search document: %w", err)
+		return fmt.Errorf("failed to marshal search documents: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/internal/search/events", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/internal/search/events/bulk", bytes.NewBuffer(jsonData))
 	if err != nil {
-		c.Logger.Error("Failed to create search request", "error", err, "event_id", event.EventID)
-		return fmt.Errorf("failed to create search request: %w", err)
+		return fmt.Errorf("failed to create bulk search request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -120,29 +165,21 @@ func (c *SearchServiceClient) IndexEvent(ctx context.Context, event EventRespons
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		c.Logger.Error("Failed to index event in search service", "error", err, "event_id", event.EventID)
-		fmt.Printf("Failed to index searcSeervice: %s\n", err.Error())
-		return fmt.Errorf("failed to index event in search service: %w", err)
+		c.Logger.Error("Failed to bulk index events in search service", "error", err, "count", len(docs))
+		return fmt.Errorf("failed to bulk index events in search service: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		c.Logger.Error("Search service returned error", "status", resp.StatusCode, "body", string(body), "event_id", event.EventID)
-		fmt.Printf("Search service returned error %d: %s\n", resp.StatusCode, string(body))
+		c.Logger.Error("Search service bulk index returned error", "status", resp.StatusCode, "body", string(body))
 		return fmt.Errorf("search service returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	c.Logger.Info("Successfully indexed event in search service", "event_id", event.EventID, "event_name", event.Name)
+	c.Logger.Info("Successfully bulk indexed events in search service", "count", len(docs))
 	return nil
 }
 
-func (c *SearchServiceClient) UpdateEvent(ctx context.Context, event EventResponse, venue VenueResponse) error {
-	fmt.Printf("Updating event in search service: %s ID: %s\n", event.Name, event.EventID.String())
-
-	return c.IndexEvent(ctx, event, venue)
-}
-
 func (c *SearchServiceClient) DeleteEvent(ctx context.Context, eventID uuid.UUID) error {
 	if c.BaseURL == "" {
 		c.Logger.Debug("Search service URL not configured, skipping deletion")
@@ -173,3 +210,82 @@ func (c *SearchServiceClient) DeleteEvent(ctx context.Context, eventID uuid.UUID
 	c.Logger.Info("Successfully deleted event from search service", "event_id", eventID)
 	return nil
 }
+
+// SearchServiceError is a non-2xx reply from search-service, kept apart so
+// handlers can pass its validation errors through to the admin.
+type SearchServiceError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *SearchServiceError) Error() string {
+	return fmt.Sprintf("search service returned status %d: %s", e.StatusCode, e.Message)
+}
+
+func newSearchServiceError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	var reply struct {
+		Error string `json:"error"`
+	}
+	message := string(body)
+	if err := json.Unmarshal(body, &reply); err == nil && reply.Error != "" {
+		message = reply.Error
+	}
+	return &SearchServiceError{StatusCode: resp.StatusCode, Message: message}
+}
+
+func (c *SearchServiceClient) GetSynonyms(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/internal/search/synonyms", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create synonyms request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms from search service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newSearchServiceError(resp)
+	}
+
+	var reply SearchSynonymsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("failed to decode synonyms: %w", err)
+	}
+	return reply.Synonyms, nil
+}
+
+// PutSynonyms replaces the search synonym set and returns the rules as
+// search-service stored them.
+func (c *SearchServiceClient) PutSynonyms(ctx context.Context, rules []string) ([]string, error) {
+	jsonData, err := json.Marshal(SearchSynonymsRequest{Synonyms: rules})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal synonyms: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.BaseURL+"/internal/search/synonyms", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create synonyms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update synonyms in search service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newSearchServiceError(resp)
+	}
+
+	var reply SearchSynonymsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("failed to decode synonyms: %w", err)
+	}
+	return reply.Synonyms, nil
+}
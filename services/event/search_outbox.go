@@ -0,0 +1,350 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	SearchSyncUpsert = "upsert"
+	SearchSyncDelete = "delete"
+
+	maxSearchSyncBackoff = 5 * time.Minute
+	maxSearchSyncError   = 1000
+
+	// searchSyncLease is how long a claimed batch is held for delivery
+	// before another pass may take what is left of it.
+	searchSyncLease = 2 * time.Minute
+
+	// maxSearchBulkIndex is the most documents search-service takes in one
+	// bulk request.
+	maxSearchBulkIndex = 1000
+)
+
+// queueSearchSync records that an event's search document is stale. It is
+// meant to run in the transaction that changes the event, so the change and
+// the record commit or roll back together. It also stamps the event's
+// updated_at, so the change feed sees changes that leave the row itself
+// alone, like new images.
+func (cfg *APIConfig) queueSearchSync(ctx context.Context, q events.Querier, eventID uuid.UUID, operation string) error {
+	if err := q.TouchEvent(ctx, eventID); err != nil {
+		return err
+	}
+	if cfg.SearchClient == nil {
+		return nil
+	}
+	return q.EnqueueSearchOutbox(ctx, events.EnqueueSearchOutboxParams{
+		EventID:   eventID,
+		Operation: operation,
+	})
+}
+
+// queueVenueSearchSync queues every live event of a venue, for changes to
+// venue fields that are copied into their search documents.
+func (cfg *APIConfig) queueVenueSearchSync(ctx context.Context, q events.Querier, venueID uuid.UUID) error {
+	if err := q.TouchVenueEvents(ctx, venueID); err != nil {
+		return err
+	}
+	if cfg.SearchClient == nil {
+		return nil
+	}
+	return q.EnqueueVenueSearchOutbox(ctx, venueID)
+}
+
+// withSearchSync runs a single change to an event in a transaction that
+// also queues its search sync, for handlers that would otherwise not need
+// one.
+func (cfg *APIConfig) withSearchSync(ctx context.Context, eventID uuid.UUID, change func(q events.Querier) error) error {
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	if err := change(qtx); err != nil {
+		return err
+	}
+	if err := cfg.queueSearchSync(ctx, qtx, eventID, SearchSyncUpsert); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cfg.wakeSearchSync()
+	return nil
+}
+
+// wakeSearchSync asks the relay to run now instead of at its next tick.
+// Call it after the transaction that queued changes has committed.
+func (cfg *APIConfig) wakeSearchSync() {
+	select {
+	case cfg.searchSyncWake <- struct{}{}:
+	default:
+	}
+}
+
+// startSearchSyncRelay delivers queued changes to search-service until the
+// process exits.
+func (cfg *APIConfig) startSearchSyncRelay() {
+	if cfg.SearchClient == nil {
+		return
+	}
+	interval := cfg.Config.SearchSyncInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	batchSize := cfg.Config.SearchSyncBatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	cfg.Logger.Info("Started search sync relay", "interval", interval.String(), "batch_size", batchSize)
+
+	lastCleanup := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+		case <-cfg.searchSyncWake:
+		}
+
+		// Keep going while full batches come back so a backlog drains
+		// without waiting for the ticker between batches.
+		for cfg.relaySearchSync(context.Background(), batchSize) == batchSize {
+		}
+
+		if time.Since(lastCleanup) >= time.Hour {
+			cfg.cleanupSearchSync(context.Background())
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+// relaySearchSync runs one relay pass and returns how many events it
+// claimed. Each event's pending changes collapse into one delivery of its
+// current state, and events are taken oldest change first. The batch's
+// upserts go to search-service in bulk requests; deletions go one by one.
+// No transaction stays open while search-service is called: the batch is
+// claimed by leasing its rows, deliveries run after that commits, and
+// their outcomes are recorded in a second short transaction. A leased
+// event is not listed again until its lease runs out, so two deliveries of
+// one event never overlap and an older state cannot overtake a newer one.
+func (cfg *APIConfig) relaySearchSync(ctx context.Context, batchSize int) int {
+	pending, err := cfg.claimSearchSync(ctx, batchSize)
+	if err != nil {
+		cfg.Logger.Error("Failed to claim pending search changes", "error", err)
+		return 0
+	}
+	if len(pending) == 0 {
+		return 0
+	}
+
+	// Stop well before the lease runs out, leaving the rest to a later
+	// pass rather than racing one that has already taken them.
+	deliverCtx, cancel := context.WithTimeout(ctx, searchSyncLease/2)
+	defer cancel()
+
+	results := make([]error, 0, len(pending))
+	var docs []SearchEventDocument
+	var upserts []int
+	for i, change := range pending {
+		doc, err := cfg.prepareSearchChange(deliverCtx, change)
+		if deliverCtx.Err() != nil {
+			cfg.Logger.Warn("Search sync lease running out, leaving the rest of the batch", "prepared", len(results), "claimed", len(pending))
+			break
+		}
+		if doc != nil {
+			docs = append(docs, *doc)
+			upserts = append(upserts, i)
+		}
+		results = append(results, err)
+	}
+
+	for start := 0; start < len(docs); start += maxSearchBulkIndex {
+		end := min(start+maxSearchBulkIndex, len(docs))
+		if err := cfg.SearchClient.BulkIndexEvents(deliverCtx, docs[start:end]); err != nil {
+			for _, i := range upserts[start:end] {
+				results[i] = err
+			}
+		}
+	}
+
+	if err := cfg.recordSearchSyncResults(ctx, pending[:len(results)], results); err != nil {
+		cfg.Logger.Error("Failed to record search sync results", "error", err)
+		return 0
+	}
+	return len(pending)
+}
+
+// claimSearchSync leases the next batch of pending events. A transaction
+// level advisory lock keeps two replicas from claiming at the same time.
+func (cfg *APIConfig) claimSearchSync(ctx context.Context, batchSize int) ([]events.ListPendingSearchOutboxRow, error) {
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	locked, err := qtx.TryLockSearchOutboxRelay(ctx)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	pending, err := qtx.ListPendingSearchOutbox(ctx, int32(batchSize))
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range pending {
+		err := qtx.LeaseSearchOutbox(ctx, events.LeaseSearchOutboxParams{
+			LeaseSeconds: searchSyncLease.Seconds(),
+			EventID:      change.EventID,
+			OutboxID:     change.OutboxID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// recordSearchSyncResults marks delivered changes done and puts failed ones
+// back with a backoff. results[i] is the outcome of changes[i].
+func (cfg *APIConfig) recordSearchSyncResults(ctx context.Context, changes []events.ListPendingSearchOutboxRow, results []error) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	delivered, failed := 0, 0
+	for i, change := range changes {
+		if deliveryErr := results[i]; deliveryErr != nil {
+			failed++
+			backoff := searchSyncBackoff(change.Attempts)
+			message := deliveryErr.Error()
+			if len(message) > maxSearchSyncError {
+				message = message[:maxSearchSyncError]
+			}
+			cfg.Logger.Warn("Search sync delivery failed", "error", deliveryErr, "event_id", change.EventID, "attempts", change.Attempts+1, "retry_in", backoff.String())
+			err = qtx.DeferSearchOutbox(ctx, events.DeferSearchOutboxParams{
+				BackoffSeconds: backoff.Seconds(),
+				LastError:      sql.NullString{String: message, Valid: true},
+				EventID:        change.EventID,
+				OutboxID:       change.OutboxID,
+			})
+		} else {
+			delivered++
+			err = qtx.MarkSearchOutboxDelivered(ctx, events.MarkSearchOutboxDeliveredParams{
+				EventID:  change.EventID,
+				OutboxID: change.OutboxID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cfg.Logger.Debug("Relayed search changes", "delivered", delivered, "failed", failed)
+	return nil
+}
+
+// prepareSearchChange readies an event's delivery. An event that still
+// exists comes back as a document for the bulk request; one that is gone
+// or soft-deleted by delivery time is removed from the index right away,
+// whatever the queued operation said, and the error is the outcome of that
+// removal.
+func (cfg *APIConfig) prepareSearchChange(ctx context.Context, change events.ListPendingSearchOutboxRow) (*SearchEventDocument, error) {
+	if change.Operation == SearchSyncDelete {
+		return nil, cfg.SearchClient.DeleteEvent(ctx, change.EventID)
+	}
+	doc, err := cfg.searchDocument(ctx, change.EventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, cfg.SearchClient.DeleteEvent(ctx, change.EventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// searchSyncBackoff doubles the wait after each failed attempt, starting at
+// one second and capped at five minutes.
+func searchSyncBackoff(attempts int32) time.Duration {
+	backoff := time.Duration(math.Pow(2, float64(attempts))) * time.Second
+	if backoff <= 0 || backoff > maxSearchSyncBackoff {
+		return maxSearchSyncBackoff
+	}
+	return backoff
+}
+
+func (cfg *APIConfig) cleanupSearchSync(ctx context.Context) {
+	deleted, err := cfg.DB.DeleteDeliveredSearchOutbox(ctx, cfg.Config.SearchSyncRetention.Seconds())
+	if err != nil {
+		cfg.Logger.Error("Failed to clean up delivered search changes", "error", err)
+		return
+	}
+	if deleted > 0 {
+		cfg.Logger.Info("Cleaned up delivered search changes", "count", deleted)
+	}
+}
+
+// GetSearchSyncStatus reports how far search-service lags behind: pending
+// changes, and the age of the oldest one.
+func (cfg *APIConfig) GetSearchSyncStatus(w http.ResponseWriter, r *http.Request) {
+	stats, err := cfg.DB.GetSearchOutboxStats(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to load search sync stats", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to load search sync status")
+		return
+	}
+
+	response := SearchSyncStatusResponse{
+		Enabled:         cfg.SearchClient != nil,
+		PendingChanges:  stats.PendingChanges,
+		PendingEvents:   stats.PendingEvents,
+		RetryingChanges: stats.RetryingChanges,
+		LagSeconds:      math.Round(stats.LagSeconds*10) / 10,
+	}
+
+	lastError, err := cfg.DB.GetLatestSearchOutboxError(r.Context())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		cfg.Logger.Error("Failed to load latest search sync error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to load search sync status")
+		return
+	}
+	if err == nil {
+		response.LastError = &SearchSyncErrorResponse{
+			EventID:       lastError.EventID,
+			Attempts:      lastError.Attempts,
+			Error:         lastError.LastError.String,
+			NextAttemptAt: lastError.NextAttemptAt,
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,339 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/google/uuid"
+)
+
+// testOutbox keeps search_outbox rows in memory and answers the relay's
+// queries the way the SQL does.
+type testOutbox struct {
+	mu     sync.Mutex
+	rows   []*testOutboxRow
+	locked bool // another replica holds the relay lock
+}
+
+type testOutboxRow struct {
+	eventID   uuid.UUID
+	outboxID  int64
+	operation string
+	attempts  int32
+	leased    bool
+	deferred  bool // next_attempt_at is in the future
+	delivered bool
+	lastError string
+}
+
+func (o *testOutbox) add(eventID uuid.UUID, operation string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.rows = append(o.rows, &testOutboxRow{eventID: eventID, outboxID: int64(len(o.rows) + 1), operation: operation})
+}
+
+func (o *testOutbox) serve(fake *fakeDB) {
+	fake.on("TryLockSearchOutboxRelay", func([]driver.Value) (fakeResult, error) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		return fakeResult{rows: [][]driver.Value{{!o.locked}}}, nil
+	})
+
+	fake.on("ListPendingSearchOutbox", func(args []driver.Value) (fakeResult, error) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		var order []uuid.UUID
+		groups := map[uuid.UUID][]*testOutboxRow{}
+		for _, row := range o.rows {
+			if row.delivered {
+				continue
+			}
+			if _, ok := groups[row.eventID]; !ok {
+				order = append(order, row.eventID)
+			}
+			groups[row.eventID] = append(groups[row.eventID], row)
+		}
+
+		var result fakeResult
+		for _, eventID := range order {
+			group := groups[eventID]
+			eligible := true
+			var attempts int32
+			for _, row := range group {
+				eligible = eligible && !row.leased && !row.deferred
+				attempts = max(attempts, row.attempts)
+			}
+			if !eligible || int64(len(result.rows)) == args[0].(int64) {
+				continue
+			}
+			last := group[len(group)-1]
+			result.rows = append(result.rows, fakeRow(events.ListPendingSearchOutboxRow{
+				EventID:   eventID,
+				OutboxID:  last.outboxID,
+				Operation: last.operation,
+				Attempts:  attempts,
+				Changes:   int64(len(group)),
+			}))
+		}
+		return result, nil
+	})
+
+	// update applies change to an event's undelivered rows up to an ID.
+	update := func(eventArg, outboxArg int, change func(*testOutboxRow)) fakeQuery {
+		return func(args []driver.Value) (fakeResult, error) {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			var result fakeResult
+			for _, row := range o.rows {
+				if row.eventID.String() == args[eventArg] && row.outboxID <= args[outboxArg].(int64) && !row.delivered {
+					change(row)
+					result.affected++
+				}
+			}
+			return result, nil
+		}
+	}
+	fake.on("LeaseSearchOutbox", update(1, 2, func(row *testOutboxRow) { row.leased = true }))
+	fake.on("MarkSearchOutboxDelivered", update(0, 1, func(row *testOutboxRow) {
+		row.delivered, row.leased = true, false
+	}))
+	fake.on("DeferSearchOutbox", func(args []driver.Value) (fakeResult, error) {
+		return update(2, 3, func(row *testOutboxRow) {
+			row.attempts++
+			row.deferred, row.leased = true, false
+			row.lastError = args[1].(string)
+		})(args)
+	})
+}
+
+func (o *testOutbox) state(eventID uuid.UUID) (delivered, leased bool, attempts int32, lastError string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delivered = true
+	for _, row := range o.rows {
+		if row.eventID == eventID {
+			delivered = delivered && row.delivered
+			leased = leased || row.leased
+			attempts = max(attempts, row.attempts)
+			lastError = row.lastError
+		}
+	}
+	return delivered, leased, attempts, lastError
+}
+
+// testSearchService records what the relay sends to search-service.
+type testSearchService struct {
+	mu       sync.Mutex
+	bulk     [][]uuid.UUID
+	deleted  []uuid.UUID
+	failBulk bool
+}
+
+func (s *testSearchService) start(t *testing.T) *SearchServiceClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/internal/search/events/bulk":
+			var request SearchBulkIndexRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("bulk request body: %v", err)
+			}
+			var ids []uuid.UUID
+			for _, doc := range request.Events {
+				ids = append(ids, doc.EventID)
+			}
+			s.bulk = append(s.bulk, ids)
+			if s.failBulk {
+				http.Error(w, `{"error":"1 of 1 events failed to index"}`, http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"indexed":1,"failed":0}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/internal/search/events/"):
+			s.deleted = append(s.deleted, uuid.MustParse(strings.TrimPrefix(r.URL.Path, "/internal/search/events/")))
+			w.Write([]byte(`{"deleted":true}`))
+		default:
+			t.Errorf("unexpected search-service request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return &SearchServiceClient{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Logger:     logger.New("error"),
+	}
+}
+
+// testRelay sets up the relay with event 1 live, event 2 gone from the
+// database and event 3 deleted, each with changes queued.
+func testRelay(t *testing.T) (*APIConfig, *fakeDB, *testOutbox, *testSearchService) {
+	t.Helper()
+	cfg, fake := testEventConfig(t)
+	search := &testSearchService{}
+	cfg.SearchClient = search.start(t)
+
+	outbox := &testOutbox{}
+	outbox.serve(fake)
+
+	live, gone, deleted := testEventIDs[0], testEventIDs[1], testEventIDs[2]
+	outbox.add(live, SearchSyncUpsert)
+	outbox.add(gone, SearchSyncUpsert)
+	outbox.add(live, SearchSyncUpsert)
+	outbox.add(deleted, SearchSyncDelete)
+
+	start := time.Date(2026, 11, 6, 14, 0, 0, 0, time.UTC)
+	fake.on("GetEventByID", func(args []driver.Value) (fakeResult, error) {
+		if args[0] != live.String() {
+			return fakeResult{}, nil
+		}
+		return fakeResult{rows: [][]driver.Value{fakeRow(events.GetEventByIDRow{
+			EventID:        live,
+			Name:           "Jazz Night",
+			VenueID:        testVenue,
+			EventType:      "music",
+			StartDatetime:  start,
+			EndDatetime:    start.Add(2 * time.Hour),
+			TotalCapacity:  100,
+			AvailableSeats: 100,
+			BasePriceMinor: 50000,
+			Status:         sql.NullString{String: "published", Valid: true},
+			Version:        3,
+			CreatedBy:      testAdmin,
+			Currency:       "INR",
+			Tags:           []string{"live"},
+			VenueName:      "Blue Frog",
+			City:           "Pune",
+			Country:        "India",
+			VenueTimezone:  "Asia/Kolkata",
+		})}}, nil
+	})
+	fake.returns("GetVenueByID", events.Venue{
+		VenueID:  testVenue,
+		Name:     "Blue Frog",
+		Address:  "1 Main St",
+		City:     "Pune",
+		Country:  "India",
+		Capacity: 100,
+		Timezone: "Asia/Kolkata",
+	})
+	fake.returns("ListCategories")
+	fake.returns("ListEventMedia")
+
+	return cfg, fake, outbox, search
+}
+
+var testEventIDs = []uuid.UUID{
+	uuid.MustParse("00000000-0000-0000-0000-00000000e001"),
+	uuid.MustParse("00000000-0000-0000-0000-00000000e002"),
+	uuid.MustParse("00000000-0000-0000-0000-00000000e003"),
+}
+
+func TestRelaySearchSync(t *testing.T) {
+	cfg, fake, outbox, search := testRelay(t)
+	live, gone, deleted := testEventIDs[0], testEventIDs[1], testEventIDs[2]
+
+	if claimed := cfg.relaySearchSync(context.Background(), 10); claimed != 3 {
+		t.Fatalf("relaySearchSync claimed %d events, want 3", claimed)
+	}
+
+	if want := [][]uuid.UUID{{live}}; !reflect.DeepEqual(search.bulk, want) {
+		t.Errorf("bulk requests = %v, want %v", search.bulk, want)
+	}
+	if want := []uuid.UUID{gone, deleted}; !reflect.DeepEqual(search.deleted, want) {
+		t.Errorf("deleted = %v, want %v", search.deleted, want)
+	}
+	for _, eventID := range testEventIDs {
+		if delivered, leased, _, _ := outbox.state(eventID); !delivered || leased {
+			t.Errorf("event %s delivered %v, leased %v, want delivered and released", eventID, delivered, leased)
+		}
+	}
+
+	// The claim and the outcomes commit in their own transactions, with no
+	// transaction open while search-service is called.
+	log := fake.ran()
+	claimEnd := slices.Index(log, "commit")
+	recordStart := slices.Index(log[claimEnd:], "MarkSearchOutboxDelivered") + claimEnd
+	for _, entry := range log[claimEnd:recordStart] {
+		if entry == "LeaseSearchOutbox" || entry == "TryLockSearchOutboxRelay" {
+			t.Errorf("%s ran outside the claim transaction: %v", entry, log)
+		}
+	}
+	if got := fake.count("LeaseSearchOutbox"); got != 3 {
+		t.Errorf("leased %d events, want 3", got)
+	}
+	if got := fake.count("commit"); got != 2 {
+		t.Errorf("committed %d transactions, want 2: %v", got, log)
+	}
+
+	if claimed := cfg.relaySearchSync(context.Background(), 10); claimed != 0 {
+		t.Errorf("second pass claimed %d events, want 0", claimed)
+	}
+}
+
+func TestRelaySearchSyncDefersFailedBulk(t *testing.T) {
+	cfg, _, outbox, search := testRelay(t)
+	search.failBulk = true
+	live := testEventIDs[0]
+
+	cfg.relaySearchSync(context.Background(), 10)
+
+	delivered, leased, attempts, lastError := outbox.state(live)
+	if delivered || leased || attempts != 1 || !strings.Contains(lastError, "500") {
+		t.Errorf("failed event delivered %v, leased %v, attempts %d, error %q; want deferred after one attempt", delivered, leased, attempts, lastError)
+	}
+	for _, eventID := range testEventIDs[1:] {
+		if delivered, _, _, _ := outbox.state(eventID); !delivered {
+			t.Errorf("event %s not delivered after another event's bulk request failed", eventID)
+		}
+	}
+
+	// Deferred events wait out their backoff.
+	if claimed := cfg.relaySearchSync(context.Background(), 10); claimed != 0 {
+		t.Errorf("pass during backoff claimed %d events, want 0", claimed)
+	}
+}
+
+func TestRelaySearchSyncLeases(t *testing.T) {
+	cfg, fake, outbox, search := testRelay(t)
+
+	claimed, err := cfg.claimSearchSync(context.Background(), 2)
+	if err != nil || len(claimed) != 2 {
+		t.Fatalf("claimSearchSync = %d events, %v; want 2", len(claimed), err)
+	}
+
+	// A pass while the first batch is leased only takes what is left.
+	if got := cfg.relaySearchSync(context.Background(), 10); got != 1 {
+		t.Errorf("relaySearchSync during a lease claimed %d events, want 1", got)
+	}
+	if len(search.bulk) != 0 || !reflect.DeepEqual(search.deleted, []uuid.UUID{testEventIDs[2]}) {
+		t.Errorf("delivered bulk %v and deletes %v while leased, want only the unleased delete", search.bulk, search.deleted)
+	}
+	for _, eventID := range testEventIDs[:2] {
+		if delivered, leased, _, _ := outbox.state(eventID); delivered || !leased {
+			t.Errorf("leased event %s delivered %v, leased %v", eventID, delivered, leased)
+		}
+	}
+
+	// Nothing is claimed while another replica holds the relay lock.
+	outbox.locked = true
+	leases := fake.count("LeaseSearchOutbox")
+	if got := cfg.relaySearchSync(context.Background(), 10); got != 0 || fake.count("LeaseSearchOutbox") != leases {
+		t.Errorf("relaySearchSync without the lock claimed %d events", got)
+	}
+}
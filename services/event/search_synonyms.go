@@ -0,0 +1,79 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+)
+
+// Search synonyms live in search-service; these handlers put them behind
+// admin authentication.
+
+func (cfg *APIConfig) GetSearchSynonyms(w http.ResponseWriter, r *http.Request) {
+	if cfg.SearchClient == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Search service is not configured")
+		return
+	}
+
+	rules, err := cfg.SearchClient.GetSynonyms(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to get search synonyms", "error", err)
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to get search synonyms")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SearchSynonymsResponse{
+		Synonyms: rules,
+		Count:    len(rules),
+	})
+}
+
+// UpdateSearchSynonyms replaces the whole synonym set; super_admin only,
+// since it changes results for every search.
+func (cfg *APIConfig) UpdateSearchSynonyms(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin not authenticated")
+		return
+	}
+	if !cfg.requireSuperAdmin(w, r, adminID) {
+		return
+	}
+
+	if cfg.SearchClient == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Search service is not configured")
+		return
+	}
+
+	var requestBody SearchSynonymsRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if requestBody.Synonyms == nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "synonyms is required")
+		return
+	}
+
+	rules, err := cfg.SearchClient.PutSynonyms(r.Context(), requestBody.Synonyms)
+	if err != nil {
+		var searchErr *SearchServiceError
+		if errors.As(err, &searchErr) && searchErr.StatusCode == http.StatusBadRequest {
+			utils.RespondWithError(w, http.StatusBadRequest, searchErr.Message)
+			return
+		}
+		cfg.Logger.Error("Failed to update search synonyms", "error", err)
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to update search synonyms")
+		return
+	}
+
+	cfg.Logger.Info("Search synonyms updated", "admin_id", adminID, "rules", len(rules))
+
+	utils.RespondWithJSON(w, http.StatusOK, SearchSynonymsResponse{
+		Synonyms: rules,
+		Count:    len(rules),
+	})
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/database"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/middleware"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/money"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/storage"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
 )
 
@@ -25,11 +29,18 @@ func SetupRoutes(config *APIConfig) *http.ServeMux {
 	mux.HandleFunc("POST /api/v1/auth/admin/login", config.AdminLogin)
 	mux.HandleFunc("POST /api/v1/auth/admin/refresh", config.AdminRefreshToken)
 	mux.HandleFunc("POST /api/v1/auth/admin/logout", config.AdminLogout)
+	mux.HandleFunc("POST /api/v1/auth/admin/login/2fa", config.VerifyAdminLogin)
+	mux.HandleFunc("POST /api/v1/auth/admin/login/2fa/setup", config.SetupAdminLoginTwoFactor)
 
 	//Never to be used by the client, added only for testing purposed and a fallback from using elastisearch
 	mux.HandleFunc("GET /api/v1/events", config.ListPublishedEvents)
 	mux.HandleFunc("GET /api/v1/events/{id}", config.GetEventByID)
 	mux.HandleFunc("GET /api/v1/events/{id}/availability", config.GetEventAvailability)
+	mux.HandleFunc("GET /api/v1/categories", config.ListCategories)
+
+	if local, ok := config.MediaStore.(*storage.LocalStore); ok {
+		mux.Handle("GET /api/v1/media/", http.StripPrefix("/api/v1/media/", mediaFileServer(local.Root())))
+	}
 
 	adminAuth := auth.RequireAdminAuth(config.Config.JWTSecret)
 	mux.HandleFunc("POST /api/v1/admin/events", adminAuth(config.CreateEvent))
@@ -38,20 +49,86 @@ func SetupRoutes(config *APIConfig) *http.ServeMux {
 	mux.HandleFunc("GET /api/v1/admin/events", adminAuth(config.ListAdminEvents))
 	//Analytics KEY DO NOT FORGET TO ADD INC CLIENT
 	mux.HandleFunc("GET /api/v1/admin/events/{id}/analytics", adminAuth(config.GetEventAnalytics))
+	mux.HandleFunc("GET /api/v1/admin/events/{id}/analytics/sales", adminAuth(config.GetEventSalesAnalytics))
+	mux.HandleFunc("GET /api/v1/admin/events/{id}/attendees", adminAuth(config.ExportEventAttendees))
+	mux.HandleFunc("POST /api/v1/admin/events/{id}/check-in", adminAuth(config.CheckInAttendee))
+	mux.HandleFunc("POST /api/v1/admin/events/{id}/transfer", adminAuth(config.TransferEvent))
+	mux.HandleFunc("GET /api/v1/admin/events/{id}/media", adminAuth(config.ListEventMedia))
+	mux.HandleFunc("POST /api/v1/admin/events/{id}/media", adminAuth(config.UploadEventMedia))
+	mux.HandleFunc("DELETE /api/v1/admin/events/{id}/media/{mediaId}", adminAuth(config.DeleteEventMedia))
+	mux.HandleFunc("GET /api/v1/admin/events/{id}/history", adminAuth(config.ListEventHistory))
+	mux.HandleFunc("GET /api/v1/admin/events/{id}/history/{version}", adminAuth(config.GetEventHistoryVersion))
+	mux.HandleFunc("POST /api/v1/admin/events/{id}/revert", adminAuth(config.RevertEvent))
+	mux.HandleFunc("POST /api/v1/admin/events/{id}/restore", adminAuth(config.RestoreEvent))
+
+	mux.HandleFunc("GET /api/v1/admin/2fa", adminAuth(config.GetTwoFactorStatus))
+	mux.HandleFunc("POST /api/v1/admin/2fa", adminAuth(config.EnrollTwoFactor))
+	mux.HandleFunc("POST /api/v1/admin/2fa/confirm", adminAuth(config.ConfirmTwoFactor))
+	mux.HandleFunc("POST /api/v1/admin/2fa/disable", adminAuth(config.DisableTwoFactor))
+	mux.HandleFunc("POST /api/v1/admin/2fa/recovery-codes", adminAuth(config.RegenerateRecoveryCodes))
+	mux.HandleFunc("DELETE /api/v1/admin/admins/{id}/2fa", adminAuth(config.ResetAdminTwoFactor))
+
+	mux.HandleFunc("POST /api/v1/admin/organizations", adminAuth(config.CreateOrganization))
+	mux.HandleFunc("GET /api/v1/admin/organizations", adminAuth(config.ListOrganizations))
+	mux.HandleFunc("GET /api/v1/admin/organizations/{id}", adminAuth(config.GetOrganization))
+	mux.HandleFunc("PUT /api/v1/admin/organizations/{id}", adminAuth(config.UpdateOrganization))
+	mux.HandleFunc("GET /api/v1/admin/organizations/{id}/analytics", adminAuth(config.GetOrganizationAnalytics))
+	mux.HandleFunc("GET /api/v1/admin/organizations/{id}/audit", adminAuth(config.GetOrganizationAudit))
+	mux.HandleFunc("GET /api/v1/admin/organizations/{id}/members", adminAuth(config.ListOrganizationMembers))
+	mux.HandleFunc("POST /api/v1/admin/organizations/{id}/members", adminAuth(config.AddOrganizationMember))
+	mux.HandleFunc("PUT /api/v1/admin/organizations/{id}/members/{adminId}", adminAuth(config.UpdateOrganizationMember))
+	mux.HandleFunc("DELETE /api/v1/admin/organizations/{id}/members/{adminId}", adminAuth(config.RemoveOrganizationMember))
 
 	mux.HandleFunc("POST /api/v1/admin/venues", adminAuth(config.CreateVenue))
 	mux.HandleFunc("GET /api/v1/admin/venues", adminAuth(config.ListVenues))
 	mux.HandleFunc("PUT /api/v1/admin/venues/{id}", adminAuth(config.UpdateVenue))
 	mux.HandleFunc("DELETE /api/v1/admin/venues/{id}", adminAuth(config.DeleteVenue))
+	mux.HandleFunc("GET /api/v1/admin/venues/{id}/calendar", adminAuth(config.GetVenueCalendar))
+	mux.HandleFunc("GET /api/v1/admin/venues/{id}/history", adminAuth(config.ListVenueHistory))
+	mux.HandleFunc("GET /api/v1/admin/venues/{id}/history/{version}", adminAuth(config.GetVenueHistoryVersion))
+	mux.HandleFunc("POST /api/v1/admin/venues/{id}/revert", adminAuth(config.RevertVenue))
+	mux.HandleFunc("POST /api/v1/admin/venues/{id}/restore", adminAuth(config.RestoreVenue))
+	mux.HandleFunc("GET /api/v1/admin/venues/{id}/layout", adminAuth(config.GetVenueLayout))
+	mux.HandleFunc("PUT /api/v1/admin/venues/{id}/layout/sections/{sectionId}", adminAuth(config.PutLayoutSection))
+	mux.HandleFunc("DELETE /api/v1/admin/venues/{id}/layout/sections/{sectionId}", adminAuth(config.DeleteLayoutSection))
+	mux.HandleFunc("PUT /api/v1/admin/venues/{id}/layout/sections/{sectionId}/rows/{row}", adminAuth(config.PutLayoutRow))
+	mux.HandleFunc("DELETE /api/v1/admin/venues/{id}/layout/sections/{sectionId}/rows/{row}", adminAuth(config.DeleteLayoutRow))
+
+	mux.HandleFunc("POST /api/v1/admin/import", adminAuth(config.ImportCatalog))
+	mux.HandleFunc("GET /api/v1/admin/deleted", adminAuth(config.ListDeletedItems))
+	mux.HandleFunc("GET /api/v1/admin/exchange-rates", adminAuth(config.ListExchangeRates))
+	mux.HandleFunc("POST /api/v1/admin/categories", adminAuth(config.CreateCategory))
+	mux.HandleFunc("PUT /api/v1/admin/categories/{slug}", adminAuth(config.UpdateCategory))
+	mux.HandleFunc("DELETE /api/v1/admin/categories/{slug}", adminAuth(config.DeleteCategory))
+	mux.HandleFunc("GET /api/v1/admin/search/synonyms", adminAuth(config.GetSearchSynonyms))
+	mux.HandleFunc("PUT /api/v1/admin/search/synonyms", adminAuth(config.UpdateSearchSynonyms))
 
 	internalAuth := auth.RequireInternalAuth(config.Config.InternalAPIKey)
 	mux.HandleFunc("POST /internal/events/{id}/update-availability", internalAuth(config.UpdateEventAvailability))
 	mux.HandleFunc("GET /internal/events/{id}", internalAuth(config.GetEventForBooking))
 	mux.HandleFunc("POST /internal/events/{id}/return-seats", internalAuth(config.ReturnEventSeats))
+	mux.HandleFunc("PUT /internal/exchange-rates", internalAuth(config.PutExchangeRates))
+	mux.HandleFunc("GET /internal/search-sync", internalAuth(config.GetSearchSyncStatus))
+	mux.HandleFunc("GET /internal/events/changes", internalAuth(config.GetEventChanges))
 
 	return mux
 }
 
+// mediaFileServer serves locally stored media. Directory listings are not
+// exposed, and objects are immutable so they can be cached indefinitely.
+func mediaFileServer(root string) http.Handler {
+	files := http.FileServer(http.Dir(root))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
+
 func StartServer(config *APIConfig) {
 	mux := SetupRoutes(config)
 
@@ -63,6 +140,9 @@ func StartServer(config *APIConfig) {
 		Addr:    ":" + config.Config.Port,
 	}
 
+	go config.startPurgeWorker()
+	go config.startSearchSyncRelay()
+
 	config.Logger.Info("Starting Event Service", "port", config.Config.Port)
 	if err := server.ListenAndServe(); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
@@ -91,13 +171,85 @@ func InitEventService() (*APIConfig, *sql.DB) {
 		fmt.Printf("DEBUG: SearchServiceURL is empty, no SearchClient created\n")
 	}
 
+	var bookingClient *BookingServiceClient
+	if cfg.BookingServiceURL != "" {
+		bookingClient = NewBookingServiceClient(cfg.BookingServiceURL, cfg.InternalAPIKey, logger)
+		logger.Info("Booking service client initialized", "booking_service_url", cfg.BookingServiceURL)
+	} else {
+		logger.Info("Booking service URL not configured, sales analytics disabled")
+	}
+
+	userClient := NewUserServiceClient(cfg.UserServiceURL, cfg.InternalAPIKey, logger)
+
+	mediaStore, err := newMediaStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize media storage: %v", err)
+	}
+	logger.Info("Media storage initialized", "backend", cfg.MediaStorage)
+
+	geocoder, err := newGeocoder(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize geocoder: %v", err)
+	}
+	logger.Info("Geocoder initialized", "backend", cfg.Geocoder)
+
+	defaultCurrency, err := money.Lookup(cfg.DefaultCurrency)
+	if err != nil {
+		log.Fatalf("Invalid DEFAULT_CURRENCY: %v", err)
+	}
+	cfg.DefaultCurrency = defaultCurrency.Code
+
 	apiConfig := &APIConfig{
-		DB:           dbQueries,
-		DB_Conn:      db,
-		Config:       cfg,
-		Logger:       logger,
-		SearchClient: searchClient,
+		DB:            dbQueries,
+		DB_Conn:       db,
+		Config:        cfg,
+		Logger:        logger,
+		SearchClient:  searchClient,
+		BookingClient: bookingClient,
+		UserClient:    userClient,
+		MediaStore:    mediaStore,
+		Geocoder:      geocoder,
+
+		searchSyncWake: make(chan struct{}, 1),
 	}
 
 	return apiConfig, db
 }
+
+func newMediaStore(cfg *config.EventServiceConfig) (storage.ObjectStore, error) {
+	switch cfg.MediaStorage {
+	case "local":
+		baseURL := cfg.MediaPublicBaseURL
+		if baseURL == "" {
+			baseURL = "http://localhost:" + cfg.Port + "/api/v1/media"
+		}
+		return storage.NewLocalStore(cfg.MediaLocalDir, baseURL)
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			UsePathStyle:    cfg.S3UsePathStyle,
+			PublicBaseURL:   cfg.MediaPublicBaseURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown MEDIA_STORAGE %q, expected local or s3", cfg.MediaStorage)
+	}
+}
+
+// newGeocoder picks the geocoding backend. "none" leaves venues without
+// coordinates unless admins set them.
+func newGeocoder(cfg *config.EventServiceConfig) (geocode.Geocoder, error) {
+	switch cfg.Geocoder {
+	case "none":
+		return nil, nil
+	case "stub":
+		return geocode.NewStubGeocoder(), nil
+	case "nominatim":
+		return geocode.NewNominatimGeocoder(cfg.GeocoderURL, cfg.GeocoderUserAgent)
+	default:
+		return nil, fmt.Errorf("unknown GEOCODER %q, expected stub, nominatim or none", cfg.Geocoder)
+	}
+}
@@ -0,0 +1,82 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // venue zones must resolve in images without a zoneinfo database
+
+	"github.com/google/uuid"
+)
+
+const defaultVenueTimezone = "UTC"
+
+var venueLocations sync.Map
+
+// validateTimezone accepts IANA zone names such as "Europe/London". "Local"
+// is rejected because it would mean whatever zone the server runs in.
+func validateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("timezone must be an IANA zone name such as \"Europe/London\"")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown timezone %q", name)
+	}
+	return nil
+}
+
+// venueLocation resolves a stored venue timezone. Zones are validated on
+// write, so the UTC fallback only covers rows written outside the API.
+func venueLocation(name string) *time.Location {
+	if loc, ok := venueLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "" {
+		return time.UTC
+	}
+	venueLocations.Store(name, loc)
+	return loc
+}
+
+// localDate is the calendar date of t at the venue, which is what date
+// filters match against.
+func localDate(t time.Time, timezone string) string {
+	return t.In(venueLocation(timezone)).Format("2006-01-02")
+}
+
+// inVenueZone renders the event's times with the UTC offset of its venue,
+// so "19:00" in a response is the local start time.
+func (e *EventResponse) inVenueZone(timezone string) {
+	loc := venueLocation(timezone)
+	e.StartDatetime = e.StartDatetime.In(loc)
+	e.EndDatetime = e.EndDatetime.In(loc)
+	e.Timezone = loc.String()
+}
+
+// venueTimezone looks up a venue's zone for handlers that render an event
+// without having loaded its venue. Deleted venues still resolve.
+func (cfg *APIConfig) venueTimezone(ctx context.Context, venueID uuid.UUID) string {
+	timezone, err := cfg.DB.GetVenueTimezone(ctx, venueID)
+	if err != nil {
+		cfg.Logger.Warn("Failed to get venue timezone", "error", err, "venue_id", venueID)
+		return defaultVenueTimezone
+	}
+	return timezone
+}
+
+// localizeEvents renders a batch of events in their venues' zones, looking
+// each venue up once.
+func (cfg *APIConfig) localizeEvents(ctx context.Context, responses []EventResponse) {
+	zones := map[uuid.UUID]string{}
+	for i := range responses {
+		venueID := responses[i].VenueID
+		timezone, ok := zones[venueID]
+		if !ok {
+			timezone = cfg.venueTimezone(ctx, venueID)
+			zones[venueID] = timezone
+		}
+		responses[i].inVenueZone(timezone)
+	}
+}
@@ -0,0 +1,615 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	loginChallengeDuration    = 5 * time.Minute
+	maxLoginChallengeAttempts = 5
+	recoveryCodeCount         = 10
+)
+
+var (
+	errInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	errTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+)
+
+// twoFactorRequired reports whether admins with a global role must use a
+// second factor, as configured by ADMIN_2FA_REQUIRED_ROLES.
+func (cfg *APIConfig) twoFactorRequired(role string) bool {
+	return slices.Contains(cfg.Config.TwoFactorRoles, role)
+}
+
+func (cfg *APIConfig) twoFactorEnabled(ctx context.Context, adminID uuid.UUID) (bool, error) {
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return twoFactor.EnabledAt.Valid, nil
+}
+
+// challengeSecondFactor answers a successful password check with a login
+// challenge instead of a session when the admin has 2FA enabled or their
+// role requires it. It reports whether it wrote a response.
+func (cfg *APIConfig) challengeSecondFactor(w http.ResponseWriter, r *http.Request, response AdminAuthResponse, status int) bool {
+	enabled, err := cfg.twoFactorEnabled(r.Context(), response.AdminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to load admin two-factor status", "error", err, "admin_id", response.AdminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify two-factor status")
+		return true
+	}
+	if !enabled && !cfg.twoFactorRequired(response.Role) {
+		return false
+	}
+
+	token, err := auth.MakeRefreshToken()
+	if err != nil {
+		cfg.Logger.Error("Failed to create login challenge", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create login challenge")
+		return true
+	}
+
+	// One outstanding challenge per admin; expired ones are swept here too.
+	if err := cfg.DB.DeleteAdminLoginChallenges(r.Context(), response.AdminID); err != nil {
+		cfg.Logger.Warn("Failed to clear old login challenges", "error", err, "admin_id", response.AdminID)
+	}
+	challenge, err := cfg.DB.CreateAdminLoginChallenge(r.Context(), events.CreateAdminLoginChallengeParams{
+		Token:     token,
+		AdminID:   response.AdminID,
+		ExpiresAt: time.Now().UTC().Add(loginChallengeDuration),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to store login challenge", "error", err, "admin_id", response.AdminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create login challenge")
+		return true
+	}
+
+	response.TwoFactorRequired = enabled
+	response.TwoFactorSetupRequired = !enabled
+	response.ChallengeToken = challenge.Token
+	response.ChallengeExpiresAt = &challenge.ExpiresAt
+	utils.RespondWithJSON(w, status, response)
+	return true
+}
+
+// beginTwoFactorEnrollment stores a new pending secret, replacing any
+// earlier enrollment that was never confirmed.
+func (cfg *APIConfig) beginTwoFactorEnrollment(ctx context.Context, adminID uuid.UUID, email string) (TwoFactorSetupResponse, error) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return TwoFactorSetupResponse{}, err
+	}
+	_, err = cfg.DB.UpsertPendingAdminTwoFactor(ctx, events.UpsertPendingAdminTwoFactorParams{
+		AdminID: adminID,
+		Secret:  secret,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TwoFactorSetupResponse{}, errTwoFactorEnabled
+		}
+		return TwoFactorSetupResponse{}, err
+	}
+	return TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(cfg.Config.TwoFactorIssuer, email, secret),
+	}, nil
+}
+
+// activateTwoFactor confirms a pending enrollment with a code from the
+// authenticator and returns a fresh set of recovery codes.
+func (cfg *APIConfig) activateTwoFactor(ctx context.Context, twoFactor events.AdminTwoFactor, code string) ([]string, error) {
+	step, ok := auth.ValidateTOTP(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return nil, errInvalidTwoFactorCode
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	enabled, err := qtx.EnableAdminTwoFactor(ctx, events.EnableAdminTwoFactorParams{
+		Step:    step,
+		AdminID: twoFactor.AdminID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if enabled == 0 {
+		return nil, errInvalidTwoFactorCode
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, qtx, twoFactor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifySecondFactor checks a TOTP code or an unused recovery code against
+// an enabled enrollment. Each TOTP code and recovery code works only once.
+func (cfg *APIConfig) verifySecondFactor(ctx context.Context, twoFactor events.AdminTwoFactor, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		used, err := cfg.DB.UseAdminRecoveryCode(ctx, events.UseAdminRecoveryCodeParams{
+			AdminID:  twoFactor.AdminID,
+			CodeHash: auth.HashRecoveryCode(recoveryCode),
+		})
+		if err != nil {
+			return err
+		}
+		if used == 0 {
+			return errInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	step, ok := auth.ValidateTOTP(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return errInvalidTwoFactorCode
+	}
+	used, err := cfg.DB.UseAdminTwoFactorStep(ctx, events.UseAdminTwoFactorStepParams{
+		Step:    step,
+		AdminID: twoFactor.AdminID,
+	})
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return errInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, q events.Querier, adminID uuid.UUID) ([]string, error) {
+	codes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.DeleteAdminRecoveryCodes(ctx, adminID); err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		err := q.CreateAdminRecoveryCode(ctx, events.CreateAdminRecoveryCodeParams{
+			AdminID:  adminID,
+			CodeHash: auth.HashRecoveryCode(code),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to store recovery code: %w", err)
+		}
+	}
+	return codes, nil
+}
+
+func (cfg *APIConfig) respondTwoFactorError(w http.ResponseWriter, err error, adminID uuid.UUID) {
+	switch {
+	case errors.Is(err, errInvalidTwoFactorCode):
+		utils.RespondWithError(w, http.StatusUnauthorized, "Invalid two-factor code")
+	case errors.Is(err, errTwoFactorEnabled):
+		utils.RespondWithError(w, http.StatusConflict, "Two-factor authentication is already enabled")
+	default:
+		cfg.Logger.Error("Two-factor operation failed", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Two-factor operation failed")
+	}
+}
+
+// decodeTwoFactorCode reads a body holding exactly one of a TOTP code or a
+// recovery code.
+func decodeTwoFactorCode(w http.ResponseWriter, r *http.Request, allowRecovery bool) (TwoFactorCodeRequest, bool) {
+	var requestBody TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return requestBody, false
+	}
+	requestBody.Code = strings.TrimSpace(requestBody.Code)
+	requestBody.RecoveryCode = strings.TrimSpace(requestBody.RecoveryCode)
+	if !allowRecovery && requestBody.RecoveryCode != "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "A code from the authenticator app is required")
+		return requestBody, false
+	}
+	if (requestBody.Code == "") == (requestBody.RecoveryCode == "") {
+		utils.RespondWithError(w, http.StatusBadRequest, "Provide either code or recovery_code")
+		return requestBody, false
+	}
+	return requestBody, true
+}
+
+// VerifyAdminLogin is the second login step. It exchanges a challenge token
+// and a TOTP or recovery code for a session. Admins who were required to
+// set up 2FA confirm their enrollment here and get their recovery codes.
+func (cfg *APIConfig) VerifyAdminLogin(w http.ResponseWriter, r *http.Request) {
+	var requestBody AdminTwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	code := strings.TrimSpace(requestBody.Code)
+	recoveryCode := strings.TrimSpace(requestBody.RecoveryCode)
+	if requestBody.ChallengeToken == "" || (code == "") == (recoveryCode == "") {
+		utils.RespondWithError(w, http.StatusBadRequest, "challenge_token and either code or recovery_code are required")
+		return
+	}
+
+	challenge, err := cfg.DB.AttemptAdminLoginChallenge(r.Context(), events.AttemptAdminLoginChallengeParams{
+		Token:       requestBody.ChallengeToken,
+		MaxAttempts: maxLoginChallengeAttempts,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusUnauthorized, "Invalid or expired login challenge")
+			return
+		}
+		cfg.Logger.Error("Failed to load login challenge", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify login")
+		return
+	}
+
+	admin, err := cfg.DB.GetAdminByID(r.Context(), challenge.AdminID)
+	if err != nil || !admin.IsActive.Bool {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Invalid or expired login challenge")
+		return
+	}
+
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(r.Context(), admin.AdminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Two-factor setup has not been started")
+			return
+		}
+		cfg.Logger.Error("Failed to load admin two-factor", "error", err, "admin_id", admin.AdminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to verify login")
+		return
+	}
+
+	var recoveryCodes []string
+	if twoFactor.EnabledAt.Valid {
+		err = cfg.verifySecondFactor(r.Context(), twoFactor, code, recoveryCode)
+	} else if recoveryCode != "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "A code from the authenticator app is required")
+		return
+	} else {
+		recoveryCodes, err = cfg.activateTwoFactor(r.Context(), twoFactor, code)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidTwoFactorCode) {
+			cfg.Logger.Warn("Admin login with invalid two-factor code", "admin_id", admin.AdminID, "attempt", challenge.Attempts)
+		}
+		cfg.respondTwoFactorError(w, err, admin.AdminID)
+		return
+	}
+
+	if err := cfg.DB.DeleteAdminLoginChallenge(r.Context(), challenge.Token); err != nil {
+		cfg.Logger.Warn("Failed to delete login challenge", "error", err, "admin_id", admin.AdminID)
+	}
+	if recoveryCode != "" {
+		cfg.Logger.Info("Admin logged in with a recovery code", "admin_id", admin.AdminID)
+	}
+
+	permissions := "{}"
+	if admin.Permissions.Valid {
+		permissions = string(admin.Permissions.RawMessage)
+	}
+	cfg.completeAdminLogin(w, r, AdminAuthResponse{
+		AdminID:       admin.AdminID,
+		Email:         admin.Email,
+		Name:          admin.Name,
+		Role:          admin.Role.String,
+		RecoveryCodes: recoveryCodes,
+	}, permissions)
+}
+
+// SetupAdminLoginTwoFactor starts enrollment for an admin whose login was
+// held back because their role requires 2FA.
+func (cfg *APIConfig) SetupAdminLoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var requestBody TwoFactorSetupRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if requestBody.ChallengeToken == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "challenge_token is required")
+		return
+	}
+
+	challenge, err := cfg.DB.GetAdminLoginChallenge(r.Context(), requestBody.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusUnauthorized, "Invalid or expired login challenge")
+			return
+		}
+		cfg.Logger.Error("Failed to load login challenge", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to start two-factor setup")
+		return
+	}
+
+	admin, err := cfg.DB.GetAdminByID(r.Context(), challenge.AdminID)
+	if err != nil || !admin.IsActive.Bool {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Invalid or expired login challenge")
+		return
+	}
+
+	setup, err := cfg.beginTwoFactorEnrollment(r.Context(), admin.AdminID, admin.Email)
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, admin.AdminID)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, setup)
+}
+
+func (cfg *APIConfig) GetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	admin, err := cfg.DB.GetAdminByID(r.Context(), adminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to load admin", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch two-factor status")
+		return
+	}
+
+	response := TwoFactorStatusResponse{Required: cfg.twoFactorRequired(admin.Role.String)}
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(r.Context(), adminID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		cfg.Logger.Error("Failed to load admin two-factor", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch two-factor status")
+		return
+	}
+	if err == nil {
+		response.Enabled = twoFactor.EnabledAt.Valid
+		response.Pending = !twoFactor.EnabledAt.Valid
+		if twoFactor.EnabledAt.Valid {
+			response.EnabledAt = &twoFactor.EnabledAt.Time
+		}
+	}
+	if response.Enabled {
+		remaining, err := cfg.DB.CountUnusedAdminRecoveryCodes(r.Context(), adminID)
+		if err != nil {
+			cfg.Logger.Error("Failed to count recovery codes", "error", err, "admin_id", adminID)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch two-factor status")
+			return
+		}
+		response.RecoveryCodesRemaining = remaining
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// EnrollTwoFactor returns a new secret and otpauth URI to render as a QR
+// code. 2FA is not active until ConfirmTwoFactor succeeds.
+func (cfg *APIConfig) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	admin, err := cfg.DB.GetAdminByID(r.Context(), adminID)
+	if err != nil {
+		cfg.Logger.Error("Failed to load admin", "error", err, "admin_id", adminID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to start two-factor setup")
+		return
+	}
+
+	setup, err := cfg.beginTwoFactorEnrollment(r.Context(), adminID, admin.Email)
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, setup)
+}
+
+func (cfg *APIConfig) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	requestBody, ok := decodeTwoFactorCode(w, r, false)
+	if !ok {
+		return
+	}
+
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(r.Context(), adminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Two-factor setup has not been started")
+			return
+		}
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	if twoFactor.EnabledAt.Valid {
+		cfg.respondTwoFactorError(w, errTwoFactorEnabled, adminID)
+		return
+	}
+
+	codes, err := cfg.activateTwoFactor(r.Context(), twoFactor, requestBody.Code)
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+
+	cfg.Logger.Info("Admin enabled two-factor authentication", "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, used or not. It
+// takes an authenticator code so a stolen session cannot mint new ones.
+func (cfg *APIConfig) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	requestBody, ok := decodeTwoFactorCode(w, r, false)
+	if !ok {
+		return
+	}
+
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(r.Context(), adminID)
+	if err != nil || !twoFactor.EnabledAt.Valid {
+		if err == nil || errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Two-factor authentication is not enabled")
+			return
+		}
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	if err := cfg.verifySecondFactor(r.Context(), twoFactor, requestBody.Code, ""); err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	defer tx.Rollback()
+
+	codes, err := replaceRecoveryCodes(r.Context(), events.New(tx), adminID)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+
+	cfg.Logger.Info("Admin regenerated recovery codes", "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor turns 2FA off for the calling admin after checking a
+// current code. Admins whose role requires 2FA cannot turn it off.
+func (cfg *APIConfig) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	requestBody, ok := decodeTwoFactorCode(w, r, true)
+	if !ok {
+		return
+	}
+
+	admin, err := cfg.DB.GetAdminByID(r.Context(), adminID)
+	if err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	if cfg.twoFactorRequired(admin.Role.String) {
+		utils.RespondWithError(w, http.StatusForbidden, "Two-factor authentication is required for your role")
+		return
+	}
+
+	twoFactor, err := cfg.DB.GetAdminTwoFactor(r.Context(), adminID)
+	if err != nil || !twoFactor.EnabledAt.Valid {
+		if err == nil || errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Two-factor authentication is not enabled")
+			return
+		}
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+	if err := cfg.verifySecondFactor(r.Context(), twoFactor, requestBody.Code, requestBody.RecoveryCode); err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+
+	if err := cfg.removeTwoFactor(r.Context(), adminID); err != nil {
+		cfg.respondTwoFactorError(w, err, adminID)
+		return
+	}
+
+	cfg.Logger.Info("Admin disabled two-factor authentication", "admin_id", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, SuccessResponse{Message: "Two-factor authentication disabled"})
+}
+
+// ResetAdminTwoFactor lets a super admin clear another admin's 2FA, e.g.
+// after a lost device. The admin's sessions are revoked so they have to log
+// in again, enrolling anew if their role requires it.
+func (cfg *APIConfig) ResetAdminTwoFactor(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Admin ID not found in context")
+		return
+	}
+	if !cfg.requireSuperAdmin(w, r, adminID) {
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid admin ID")
+		return
+	}
+	if targetID == adminID {
+		utils.RespondWithError(w, http.StatusBadRequest, "Use the disable endpoint for your own account")
+		return
+	}
+	if _, err := cfg.DB.GetAdminByID(r.Context(), targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "Admin not found")
+			return
+		}
+		cfg.respondTwoFactorError(w, err, targetID)
+		return
+	}
+
+	if err := cfg.removeTwoFactor(r.Context(), targetID); err != nil {
+		cfg.respondTwoFactorError(w, err, targetID)
+		return
+	}
+	if err := cfg.DB.RevokeAllAdminTokens(r.Context(), targetID); err != nil {
+		cfg.Logger.Error("Failed to revoke admin sessions", "error", err, "admin_id", targetID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to revoke admin sessions")
+		return
+	}
+
+	cfg.Logger.Info("Super admin reset two-factor authentication", "admin_id", targetID, "reset_by", adminID)
+	utils.RespondWithJSON(w, http.StatusOK, SuccessResponse{Message: "Two-factor authentication reset"})
+}
+
+// removeTwoFactor deletes an admin's enrollment, recovery codes and any
+// login challenge in flight.
+func (cfg *APIConfig) removeTwoFactor(ctx context.Context, adminID uuid.UUID) error {
+	tx, err := cfg.DB_Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := events.New(tx)
+
+	if _, err := qtx.DeleteAdminTwoFactor(ctx, adminID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteAdminRecoveryCodes(ctx, adminID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteAdminLoginChallenges(ctx, adminID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
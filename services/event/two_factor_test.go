@@ -0,0 +1,331 @@
+package event
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/events"
+)
+
+// testTwoFactorStore keeps one admin's login challenges, 2FA enrollment
+// and recovery codes in memory, answering the login queries the way the
+// SQL does.
+type testTwoFactorStore struct {
+	mu            sync.Mutex
+	admin         events.Admin
+	twoFactor     *events.AdminTwoFactor
+	challenges    map[string]*events.AdminLoginChallenge
+	recoveryCodes map[string]bool // hash to used
+	sessions      int
+}
+
+func newTestTwoFactorStore(t *testing.T, fake *fakeDB, role string) *testTwoFactorStore {
+	t.Helper()
+	hash, err := auth.HashedPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashedPassword error = %v", err)
+	}
+	s := &testTwoFactorStore{
+		admin: events.Admin{
+			AdminID:      testAdmin,
+			Email:        "admin@example.com",
+			Name:         "Admin",
+			PasswordHash: hash,
+			Role:         sql.NullString{String: role, Valid: true},
+			IsActive:     sql.NullBool{Bool: true, Valid: true},
+		},
+		challenges:    map[string]*events.AdminLoginChallenge{},
+		recoveryCodes: map[string]bool{},
+	}
+
+	answer := func(query func(args []driver.Value) (any, int64)) fakeQuery {
+		return func(args []driver.Value) (fakeResult, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			row, affected := query(args)
+			if row == nil {
+				return fakeResult{affected: affected}, nil
+			}
+			return fakeResult{rows: [][]driver.Value{fakeRow(row)}, affected: 1}, nil
+		}
+	}
+
+	fake.on("GetAdminByEmail", answer(func(args []driver.Value) (any, int64) {
+		if args[0] != s.admin.Email {
+			return nil, 0
+		}
+		return s.admin, 1
+	}))
+	fake.on("GetAdminByID", answer(func(args []driver.Value) (any, int64) {
+		return events.GetAdminByIDRow{
+			AdminID:  s.admin.AdminID,
+			Email:    s.admin.Email,
+			Name:     s.admin.Name,
+			Role:     s.admin.Role,
+			IsActive: s.admin.IsActive,
+		}, 1
+	}))
+	fake.on("GetAdminTwoFactor", answer(func([]driver.Value) (any, int64) {
+		if s.twoFactor == nil {
+			return nil, 0
+		}
+		return *s.twoFactor, 1
+	}))
+	fake.on("UpsertPendingAdminTwoFactor", answer(func(args []driver.Value) (any, int64) {
+		if s.twoFactor != nil && s.twoFactor.EnabledAt.Valid {
+			return nil, 0
+		}
+		s.twoFactor = &events.AdminTwoFactor{AdminID: testAdmin, Secret: args[1].(string)}
+		return *s.twoFactor, 1
+	}))
+	fake.on("EnableAdminTwoFactor", answer(func(args []driver.Value) (any, int64) {
+		step := args[0].(int64)
+		if s.twoFactor == nil || s.twoFactor.EnabledAt.Valid || s.twoFactor.LastUsedStep >= step {
+			return nil, 0
+		}
+		s.twoFactor.EnabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+		s.twoFactor.LastUsedStep = step
+		return nil, 1
+	}))
+	fake.on("UseAdminTwoFactorStep", answer(func(args []driver.Value) (any, int64) {
+		step := args[0].(int64)
+		if s.twoFactor == nil || !s.twoFactor.EnabledAt.Valid || s.twoFactor.LastUsedStep >= step {
+			return nil, 0
+		}
+		s.twoFactor.LastUsedStep = step
+		return nil, 1
+	}))
+
+	fake.on("DeleteAdminLoginChallenges", answer(func([]driver.Value) (any, int64) {
+		clear(s.challenges)
+		return nil, 0
+	}))
+	fake.on("CreateAdminLoginChallenge", answer(func(args []driver.Value) (any, int64) {
+		challenge := &events.AdminLoginChallenge{Token: args[0].(string), AdminID: testAdmin, ExpiresAt: args[2].(time.Time)}
+		s.challenges[challenge.Token] = challenge
+		return *challenge, 1
+	}))
+	fake.on("GetAdminLoginChallenge", answer(func(args []driver.Value) (any, int64) {
+		challenge, ok := s.challenges[args[0].(string)]
+		if !ok || !challenge.ExpiresAt.After(time.Now()) {
+			return nil, 0
+		}
+		return *challenge, 1
+	}))
+	fake.on("AttemptAdminLoginChallenge", answer(func(args []driver.Value) (any, int64) {
+		challenge, ok := s.challenges[args[0].(string)]
+		if !ok || !challenge.ExpiresAt.After(time.Now()) || int64(challenge.Attempts) >= args[1].(int64) {
+			return nil, 0
+		}
+		challenge.Attempts++
+		return *challenge, 1
+	}))
+	fake.on("DeleteAdminLoginChallenge", answer(func(args []driver.Value) (any, int64) {
+		delete(s.challenges, args[0].(string))
+		return nil, 1
+	}))
+
+	fake.on("DeleteAdminRecoveryCodes", answer(func([]driver.Value) (any, int64) {
+		clear(s.recoveryCodes)
+		return nil, 0
+	}))
+	fake.on("CreateAdminRecoveryCode", answer(func(args []driver.Value) (any, int64) {
+		s.recoveryCodes[args[1].(string)] = false
+		return nil, 1
+	}))
+	fake.on("UseAdminRecoveryCode", answer(func(args []driver.Value) (any, int64) {
+		used, ok := s.recoveryCodes[args[1].(string)]
+		if !ok || used {
+			return nil, 0
+		}
+		s.recoveryCodes[args[1].(string)] = true
+		return nil, 1
+	}))
+
+	fake.on("CreateAdminRefreshToken", answer(func(args []driver.Value) (any, int64) {
+		s.sessions++
+		return events.AdminRefreshToken{Token: args[0].(string), AdminID: testAdmin, ExpiresAt: args[2].(time.Time)}, 1
+	}))
+	fake.returns("ListAdminOrganizations")
+	return s
+}
+
+// enable gives the admin an active enrollment with the given recovery
+// codes.
+func (s *testTwoFactorStore) enable(t *testing.T, recoveryCodes ...string) string {
+	t.Helper()
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret error = %v", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.twoFactor = &events.AdminTwoFactor{AdminID: testAdmin, Secret: secret, EnabledAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	for _, code := range recoveryCodes {
+		s.recoveryCodes[auth.HashRecoveryCode(code)] = false
+	}
+	return secret
+}
+
+func testTwoFactorConfig(t *testing.T, role string) (*APIConfig, *testTwoFactorStore) {
+	t.Helper()
+	cfg, fake := testEventConfig(t)
+	cfg.Config.JWTSecret = "test-secret"
+	cfg.Config.JWTAccessDuration = 15 * time.Minute
+	cfg.Config.JWTRefreshDuration = time.Hour
+	cfg.Config.TwoFactorIssuer = "Evently"
+	cfg.Config.TwoFactorRoles = []string{"super_admin"}
+	return cfg, newTestTwoFactorStore(t, fake, role)
+}
+
+func testCall(t *testing.T, handler http.HandlerFunc, body any) (int, AdminAuthResponse) {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data)))
+
+	var response AdminAuthResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w.Code, response
+}
+
+func testCode(t *testing.T, secret string) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatalf("TOTPCode error = %v", err)
+	}
+	return code
+}
+
+var testLogin = AdminLoginRequest{Email: "admin@example.com", Password: "correct horse"}
+
+func TestAdminLoginWithoutTwoFactor(t *testing.T) {
+	cfg, store := testTwoFactorConfig(t, "event_manager")
+
+	code, response := testCall(t, cfg.AdminLogin, testLogin)
+	if code != http.StatusOK || response.AccessToken == "" || response.ChallengeToken != "" {
+		t.Fatalf("AdminLogin = %d %+v, want a session", code, response)
+	}
+	if store.sessions != 1 {
+		t.Errorf("sessions = %d, want 1", store.sessions)
+	}
+}
+
+func TestAdminLoginWithTwoFactor(t *testing.T) {
+	cfg, store := testTwoFactorConfig(t, "event_manager")
+	secret := store.enable(t)
+
+	code, challenge := testCall(t, cfg.AdminLogin, testLogin)
+	if code != http.StatusOK || !challenge.TwoFactorRequired || challenge.ChallengeToken == "" || challenge.AccessToken != "" {
+		t.Fatalf("AdminLogin = %d %+v, want a challenge and no session", code, challenge)
+	}
+	if store.sessions != 0 {
+		t.Fatalf("password alone opened %d sessions", store.sessions)
+	}
+
+	totp := testCode(t, secret)
+	code, session := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, Code: totp})
+	if code != http.StatusOK || session.AccessToken == "" || session.RefreshToken == "" {
+		t.Fatalf("VerifyAdminLogin = %d %+v, want a session", code, session)
+	}
+
+	// The challenge is spent, and the code cannot be replayed on a new one.
+	if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, Code: totp}); code != http.StatusUnauthorized {
+		t.Errorf("reused challenge = %d, want %d", code, http.StatusUnauthorized)
+	}
+	_, again := testCall(t, cfg.AdminLogin, testLogin)
+	if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: again.ChallengeToken, Code: totp}); code != http.StatusUnauthorized {
+		t.Errorf("replayed code = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if store.sessions != 1 {
+		t.Errorf("sessions = %d, want 1", store.sessions)
+	}
+}
+
+func TestAdminLoginChallengeAttempts(t *testing.T) {
+	cfg, store := testTwoFactorConfig(t, "event_manager")
+	secret := store.enable(t)
+
+	_, challenge := testCall(t, cfg.AdminLogin, testLogin)
+	for range maxLoginChallengeAttempts {
+		if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, Code: "000000"}); code != http.StatusUnauthorized {
+			t.Fatalf("wrong code = %d, want %d", code, http.StatusUnauthorized)
+		}
+	}
+
+	// Once the attempts run out, even the right code fails.
+	code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, Code: testCode(t, secret)})
+	if code != http.StatusUnauthorized || store.sessions != 0 {
+		t.Errorf("right code after %d wrong ones = %d with %d sessions, want %d and none", maxLoginChallengeAttempts, code, store.sessions, http.StatusUnauthorized)
+	}
+}
+
+func TestAdminLoginRecoveryCode(t *testing.T) {
+	cfg, store := testTwoFactorConfig(t, "event_manager")
+	store.enable(t, "abcd-efgh")
+
+	_, challenge := testCall(t, cfg.AdminLogin, testLogin)
+	if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, RecoveryCode: "abcd-efgh"}); code != http.StatusOK {
+		t.Fatalf("recovery code = %d, want %d", code, http.StatusOK)
+	}
+
+	_, challenge = testCall(t, cfg.AdminLogin, testLogin)
+	if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, RecoveryCode: "abcd-efgh"}); code != http.StatusUnauthorized {
+		t.Errorf("used recovery code = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestAdminLoginRequiredEnrollment(t *testing.T) {
+	cfg, store := testTwoFactorConfig(t, "super_admin")
+
+	code, challenge := testCall(t, cfg.AdminLogin, testLogin)
+	if code != http.StatusOK || !challenge.TwoFactorSetupRequired || challenge.AccessToken != "" {
+		t.Fatalf("AdminLogin = %d %+v, want a setup challenge and no session", code, challenge)
+	}
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(TwoFactorSetupRequest{ChallengeToken: challenge.ChallengeToken})
+	cfg.SetupAdminLoginTwoFactor(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	var setup TwoFactorSetupResponse
+	json.Unmarshal(w.Body.Bytes(), &setup)
+	if w.Code != http.StatusOK || setup.Secret == "" {
+		t.Fatalf("SetupAdminLoginTwoFactor = %d %+v", w.Code, setup)
+	}
+
+	// Recovery codes cannot confirm an enrollment.
+	if code, _ := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, RecoveryCode: "abcd-efgh"}); code != http.StatusBadRequest {
+		t.Errorf("recovery code for enrollment = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	code, session := testCall(t, cfg.VerifyAdminLogin, AdminTwoFactorLoginRequest{ChallengeToken: challenge.ChallengeToken, Code: testCode(t, setup.Secret)})
+	if code != http.StatusOK || session.AccessToken == "" || len(session.RecoveryCodes) != recoveryCodeCount {
+		t.Fatalf("VerifyAdminLogin = %d %+v, want a session and %d recovery codes", code, session, recoveryCodeCount)
+	}
+	if !store.twoFactor.EnabledAt.Valid || len(store.recoveryCodes) != recoveryCodeCount {
+		t.Errorf("enrollment enabled %v with %d recovery codes stored", store.twoFactor.EnabledAt.Valid, len(store.recoveryCodes))
+	}
+
+	// Once enabled, setting up again is refused.
+	_, challenge = testCall(t, cfg.AdminLogin, testLogin)
+	if !challenge.TwoFactorRequired {
+		t.Errorf("login after enrollment = %+v, want a code challenge", challenge)
+	}
+	w = httptest.NewRecorder()
+	body, _ = json.Marshal(TwoFactorSetupRequest{ChallengeToken: challenge.ChallengeToken})
+	cfg.SetupAdminLoginTwoFactor(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("setup after enrollment = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
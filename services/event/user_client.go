@@ -0,0 +1,78 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+type UserServiceClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	Logger     *logger.Logger
+}
+
+func NewUserServiceClient(baseURL, apiKey string, logger *logger.Logger) *UserServiceClient {
+	return &UserServiceClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		APIKey: apiKey,
+		Logger: logger,
+	}
+}
+
+// GetUsersByIDs resolves up to 1000 user IDs in one call. Users that no longer
+// exist are simply absent from the returned map.
+func (c *UserServiceClient) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserSummary, error) {
+	users := make(map[uuid.UUID]UserSummary, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	jsonBody, err := json.Marshal(map[string][]uuid.UUID{"user_ids": userIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/internal/users/batch", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.Logger.Error("Failed to fetch users from user service", "error", err, "count", len(userIDs))
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.Logger.Error("User service returned error", "status", resp.StatusCode, "count", len(userIDs))
+		return nil, fmt.Errorf("user service returned status %d", resp.StatusCode)
+	}
+
+	var batch struct {
+		Users []UserSummary `json:"users"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, user := range batch.Users {
+		users[user.UserID] = user
+	}
+
+	return users, nil
+}
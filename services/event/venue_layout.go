@@ -0,0 +1,424 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/sqlc-dev/pqtype"
+)
+
+const (
+	maxLayoutSections    = 200
+	maxLayoutRows        = 200
+	maxLayoutSeatsPerRow = 500
+	maxLayoutSeats       = 100000
+	maxPriceMultiplier   = 10
+
+	NumberingSequential = "sequential"
+	NumberingOdd        = "odd"
+	NumberingEven       = "even"
+)
+
+var layoutIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,31}$`)
+
+var errLayoutElementNotFound = errors.New("not found in layout")
+
+// VenueLayout is the typed shape of venues.layout_config. A venue with an
+// empty layout ({}) has no seat map and keeps its manually entered capacity.
+type VenueLayout struct {
+	Sections   []LayoutSection   `json:"sections"`
+	PriceZones []LayoutPriceZone `json:"price_zones,omitempty"`
+}
+
+// LayoutPriceZone scales an event's base price for the seats assigned to it.
+type LayoutPriceZone struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type LayoutSection struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	PriceZone string      `json:"price_zone,omitempty"`
+	Rows      []LayoutRow `json:"rows"`
+}
+
+// LayoutRow describes one row of seats. Numbering is sequential by default;
+// odd/even numbering steps by two, as on either side of a centre aisle.
+type LayoutRow struct {
+	Label           string `json:"label"`
+	Seats           int    `json:"seats"`
+	StartNumber     int    `json:"start_number"`
+	Numbering       string `json:"numbering"`
+	PriceZone       string `json:"price_zone,omitempty"`
+	AccessibleSeats []int  `json:"accessible_seats,omitempty"`
+	CompanionSeats  []int  `json:"companion_seats,omitempty"`
+}
+
+// decodeVenueLayout parses a layout_config submitted by an admin. It returns
+// nil for an empty object, and an error describing the first problem found
+// for anything that doesn't match the layout schema.
+func decodeVenueLayout(raw json.RawMessage) (*VenueLayout, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return nil, fmt.Errorf("layout_config must be a JSON object")
+	}
+	if len(probe) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.DisallowUnknownFields()
+	var layout VenueLayout
+	if err := decoder.Decode(&layout); err != nil {
+		return nil, fmt.Errorf("layout_config: %v", err)
+	}
+
+	layout.normalize()
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// storedVenueLayout decodes a venue's saved layout. Venues created before the
+// schema existed may hold free-form JSON, which is reported as an error.
+func storedVenueLayout(layoutConfig pqtype.NullRawMessage) (*VenueLayout, error) {
+	if !layoutConfig.Valid {
+		return nil, nil
+	}
+	return decodeVenueLayout(layoutConfig.RawMessage)
+}
+
+func encodeVenueLayout(layout *VenueLayout) (pqtype.NullRawMessage, error) {
+	if layout == nil {
+		return pqtype.NullRawMessage{RawMessage: json.RawMessage("{}"), Valid: true}, nil
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	return pqtype.NullRawMessage{RawMessage: data, Valid: true}, nil
+}
+
+// normalize fills in numbering defaults so the stored layout is explicit
+// about every seat number it implies.
+func (l *VenueLayout) normalize() {
+	for i := range l.Sections {
+		for j := range l.Sections[i].Rows {
+			l.Sections[i].Rows[j].normalize()
+		}
+	}
+}
+
+func (row *LayoutRow) normalize() {
+	if row.Numbering == "" {
+		row.Numbering = NumberingSequential
+	}
+	if row.StartNumber == 0 {
+		row.StartNumber = 1
+		if row.Numbering == NumberingEven {
+			row.StartNumber = 2
+		}
+	}
+	sort.Ints(row.AccessibleSeats)
+	sort.Ints(row.CompanionSeats)
+}
+
+func (l *VenueLayout) Validate() error {
+	if len(l.Sections) == 0 {
+		return fmt.Errorf("layout must have at least one section")
+	}
+	if len(l.Sections) > maxLayoutSections {
+		return fmt.Errorf("layout can have at most %d sections", maxLayoutSections)
+	}
+
+	zones := make(map[string]bool, len(l.PriceZones))
+	for _, zone := range l.PriceZones {
+		if !layoutIDPattern.MatchString(zone.ID) {
+			return fmt.Errorf("price zone %q: id must be 1-32 letters, digits, '-' or '_'", zone.ID)
+		}
+		if zones[zone.ID] {
+			return fmt.Errorf("price zone %q is defined more than once", zone.ID)
+		}
+		if zone.Name == "" {
+			return fmt.Errorf("price zone %q: name is required", zone.ID)
+		}
+		if zone.Multiplier <= 0 || zone.Multiplier > maxPriceMultiplier {
+			return fmt.Errorf("price zone %q: multiplier must be greater than 0 and at most %d", zone.ID, maxPriceMultiplier)
+		}
+		zones[zone.ID] = true
+	}
+
+	sections := make(map[string]bool, len(l.Sections))
+	for _, section := range l.Sections {
+		if !layoutIDPattern.MatchString(section.ID) {
+			return fmt.Errorf("section %q: id must be 1-32 letters, digits, '-' or '_'", section.ID)
+		}
+		if sections[section.ID] {
+			return fmt.Errorf("section %q is defined more than once", section.ID)
+		}
+		sections[section.ID] = true
+		if err := section.validate(zones); err != nil {
+			return err
+		}
+	}
+
+	if l.Capacity() > maxLayoutSeats {
+		return fmt.Errorf("layout can have at most %d seats", maxLayoutSeats)
+	}
+	return nil
+}
+
+func (s LayoutSection) validate(zones map[string]bool) error {
+	if s.Name == "" {
+		return fmt.Errorf("section %q: name is required", s.ID)
+	}
+	if s.PriceZone != "" && !zones[s.PriceZone] {
+		return fmt.Errorf("section %q: unknown price zone %q", s.ID, s.PriceZone)
+	}
+	if len(s.Rows) == 0 {
+		return fmt.Errorf("section %q: at least one row is required", s.ID)
+	}
+	if len(s.Rows) > maxLayoutRows {
+		return fmt.Errorf("section %q: at most %d rows are allowed", s.ID, maxLayoutRows)
+	}
+
+	labels := make(map[string]bool, len(s.Rows))
+	for _, row := range s.Rows {
+		if !layoutIDPattern.MatchString(row.Label) {
+			return fmt.Errorf("section %q row %q: label must be 1-32 letters, digits, '-' or '_'", s.ID, row.Label)
+		}
+		if labels[row.Label] {
+			return fmt.Errorf("section %q: row %q is defined more than once", s.ID, row.Label)
+		}
+		labels[row.Label] = true
+		if err := row.validate(zones); err != nil {
+			return fmt.Errorf("section %q row %q: %v", s.ID, row.Label, err)
+		}
+	}
+	return nil
+}
+
+func (row LayoutRow) validate(zones map[string]bool) error {
+	if row.Seats < 1 || row.Seats > maxLayoutSeatsPerRow {
+		return fmt.Errorf("seats must be between 1 and %d", maxLayoutSeatsPerRow)
+	}
+	if row.StartNumber < 1 {
+		return fmt.Errorf("start_number must be positive")
+	}
+	switch row.Numbering {
+	case NumberingSequential:
+	case NumberingOdd:
+		if row.StartNumber%2 == 0 {
+			return fmt.Errorf("odd numbering must start on an odd seat number")
+		}
+	case NumberingEven:
+		if row.StartNumber%2 != 0 {
+			return fmt.Errorf("even numbering must start on an even seat number")
+		}
+	default:
+		return fmt.Errorf("numbering must be one of %s, %s or %s", NumberingSequential, NumberingOdd, NumberingEven)
+	}
+	if row.PriceZone != "" && !zones[row.PriceZone] {
+		return fmt.Errorf("unknown price zone %q", row.PriceZone)
+	}
+
+	valid := make(map[int]bool, row.Seats)
+	for _, number := range row.SeatNumbers() {
+		valid[number] = true
+	}
+	accessible := make(map[int]bool, len(row.AccessibleSeats))
+	for _, number := range row.AccessibleSeats {
+		if !valid[number] {
+			return fmt.Errorf("accessible seat %d is not in this row", number)
+		}
+		if accessible[number] {
+			return fmt.Errorf("accessible seat %d is listed more than once", number)
+		}
+		accessible[number] = true
+	}
+	companion := make(map[int]bool, len(row.CompanionSeats))
+	for _, number := range row.CompanionSeats {
+		if !valid[number] {
+			return fmt.Errorf("companion seat %d is not in this row", number)
+		}
+		if accessible[number] {
+			return fmt.Errorf("seat %d cannot be both accessible and companion", number)
+		}
+		if companion[number] {
+			return fmt.Errorf("companion seat %d is listed more than once", number)
+		}
+		companion[number] = true
+	}
+	return nil
+}
+
+// SeatNumbers lists the seat numbers printed on the row, in order.
+func (row LayoutRow) SeatNumbers() []int {
+	step := 1
+	if row.Numbering == NumberingOdd || row.Numbering == NumberingEven {
+		step = 2
+	}
+	numbers := make([]int, row.Seats)
+	for i := range numbers {
+		numbers[i] = row.StartNumber + i*step
+	}
+	return numbers
+}
+
+// Capacity is the number of seats the layout describes; it replaces the
+// manually entered venue capacity whenever a layout is present.
+func (l *VenueLayout) Capacity() int32 {
+	var total int32
+	for _, section := range l.Sections {
+		for _, row := range section.Rows {
+			total += int32(row.Seats)
+		}
+	}
+	return total
+}
+
+func (l *VenueLayout) section(id string) (int, bool) {
+	for i, section := range l.Sections {
+		if section.ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// UpsertSection replaces the section with the same ID or appends it.
+func (l *VenueLayout) UpsertSection(section LayoutSection) {
+	for i := range section.Rows {
+		section.Rows[i].normalize()
+	}
+	if i, ok := l.section(section.ID); ok {
+		l.Sections[i] = section
+		return
+	}
+	l.Sections = append(l.Sections, section)
+}
+
+func (l *VenueLayout) RemoveSection(id string) error {
+	i, ok := l.section(id)
+	if !ok {
+		return fmt.Errorf("section %q %w", id, errLayoutElementNotFound)
+	}
+	l.Sections = append(l.Sections[:i], l.Sections[i+1:]...)
+	return nil
+}
+
+// UpsertRow replaces the row with the same label in the section or appends it.
+func (l *VenueLayout) UpsertRow(sectionID string, row LayoutRow) error {
+	i, ok := l.section(sectionID)
+	if !ok {
+		return fmt.Errorf("section %q %w", sectionID, errLayoutElementNotFound)
+	}
+	row.normalize()
+	rows := l.Sections[i].Rows
+	for j := range rows {
+		if rows[j].Label == row.Label {
+			rows[j] = row
+			return nil
+		}
+	}
+	l.Sections[i].Rows = append(rows, row)
+	return nil
+}
+
+func (l *VenueLayout) RemoveRow(sectionID, label string) error {
+	i, ok := l.section(sectionID)
+	if !ok {
+		return fmt.Errorf("section %q %w", sectionID, errLayoutElementNotFound)
+	}
+	rows := l.Sections[i].Rows
+	for j := range rows {
+		if rows[j].Label == label {
+			l.Sections[i].Rows = append(rows[:j], rows[j+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("row %q in section %q %w", label, sectionID, errLayoutElementNotFound)
+}
+
+// SeatMap expands the layout into individual seats with their resolved price
+// zone; a row's zone overrides its section's.
+func (l *VenueLayout) SeatMap() []SeatMapSeat {
+	multipliers := make(map[string]float64, len(l.PriceZones))
+	for _, zone := range l.PriceZones {
+		multipliers[zone.ID] = zone.Multiplier
+	}
+
+	seats := make([]SeatMapSeat, 0, l.Capacity())
+	for _, section := range l.Sections {
+		for _, row := range section.Rows {
+			zone := section.PriceZone
+			if row.PriceZone != "" {
+				zone = row.PriceZone
+			}
+			multiplier := 1.0
+			if m, ok := multipliers[zone]; ok {
+				multiplier = m
+			}
+
+			accessible := make(map[int]bool, len(row.AccessibleSeats))
+			for _, number := range row.AccessibleSeats {
+				accessible[number] = true
+			}
+			companion := make(map[int]bool, len(row.CompanionSeats))
+			for _, number := range row.CompanionSeats {
+				companion[number] = true
+			}
+
+			for _, number := range row.SeatNumbers() {
+				seats = append(seats, SeatMapSeat{
+					Section:         section.ID,
+					Row:             row.Label,
+					Number:          number,
+					PriceZone:       zone,
+					PriceMultiplier: multiplier,
+					Accessible:      accessible[number],
+					Companion:       companion[number],
+				})
+			}
+		}
+	}
+	return seats
+}
+
+// Summary counts seats per price zone and accessibility flag.
+func (l *VenueLayout) Summary() VenueLayoutSummary {
+	summary := VenueLayoutSummary{
+		Sections: len(l.Sections),
+		Seats:    l.Capacity(),
+		Zones:    map[string]int32{},
+	}
+	for _, seat := range l.SeatMap() {
+		if seat.PriceZone == "" {
+			summary.UnzonedSeats++
+		} else {
+			summary.Zones[seat.PriceZone]++
+		}
+		if seat.Accessible {
+			summary.AccessibleSeats++
+		}
+		if seat.Companion {
+			summary.CompanionSeats++
+		}
+	}
+	for _, section := range l.Sections {
+		summary.Rows += len(section.Rows)
+	}
+	return summary
+}
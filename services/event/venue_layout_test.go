@@ -0,0 +1,288 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeVenueLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantNil bool
+		wantErr string
+	}{
+		{name: "missing", raw: "", wantNil: true},
+		{name: "null", raw: "null", wantNil: true},
+		{name: "empty object", raw: " {} ", wantNil: true},
+		{name: "not an object", raw: "[]", wantErr: "layout_config must be a JSON object"},
+		{name: "unknown field", raw: `{"sections":[],"stage":"north"}`, wantErr: `unknown field "stage"`},
+		{name: "wrong type", raw: `{"sections":[{"id":"A","name":"A","rows":[{"label":"1","seats":"ten"}]}]}`, wantErr: "layout_config:"},
+		{name: "no sections", raw: `{"sections":[]}`, wantErr: "at least one section"},
+		{name: "valid", raw: `{"sections":[{"id":"A","name":"Stalls","rows":[{"label":"1","seats":10}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := decodeVenueLayout(json.RawMessage(tt.raw))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeVenueLayout error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeVenueLayout error = %v", err)
+			}
+			if (layout == nil) != tt.wantNil {
+				t.Errorf("decodeVenueLayout = %+v, want nil %v", layout, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestDecodeVenueLayoutNormalizesRows(t *testing.T) {
+	raw := `{"sections":[{"id":"A","name":"Stalls","rows":[
+		{"label":"1","seats":3},
+		{"label":"2","seats":3,"numbering":"even"},
+		{"label":"3","seats":3,"numbering":"odd","accessible_seats":[5,1]}
+	]}]}`
+	layout, err := decodeVenueLayout(json.RawMessage(raw))
+	if err != nil {
+		t.Fatalf("decodeVenueLayout error = %v", err)
+	}
+
+	want := []LayoutRow{
+		{Label: "1", Seats: 3, StartNumber: 1, Numbering: NumberingSequential},
+		{Label: "2", Seats: 3, StartNumber: 2, Numbering: NumberingEven},
+		{Label: "3", Seats: 3, StartNumber: 1, Numbering: NumberingOdd, AccessibleSeats: []int{1, 5}},
+	}
+	if got := layout.Sections[0].Rows; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %+v, want %+v", got, want)
+	}
+}
+
+// testLayout returns a small valid layout for the validation cases to break.
+func testLayout() VenueLayout {
+	return VenueLayout{
+		PriceZones: []LayoutPriceZone{{ID: "vip", Name: "VIP", Multiplier: 2}},
+		Sections: []LayoutSection{
+			{ID: "A", Name: "Stalls", PriceZone: "vip", Rows: []LayoutRow{
+				{Label: "1", Seats: 10, StartNumber: 1, Numbering: NumberingSequential},
+			}},
+		},
+	}
+}
+
+func TestVenueLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(l *VenueLayout)
+		wantErr string
+	}{
+		{name: "valid", edit: func(l *VenueLayout) {}},
+		{name: "no sections", edit: func(l *VenueLayout) { l.Sections = nil }, wantErr: "at least one section"},
+		{name: "too many sections", edit: func(l *VenueLayout) {
+			for len(l.Sections) <= maxLayoutSections {
+				l.Sections = append(l.Sections, l.Sections[0])
+			}
+		}, wantErr: "at most 200 sections"},
+		{name: "bad zone id", edit: func(l *VenueLayout) { l.PriceZones[0].ID = "v i p" }, wantErr: "id must be 1-32"},
+		{name: "duplicate zone", edit: func(l *VenueLayout) { l.PriceZones = append(l.PriceZones, l.PriceZones[0]) }, wantErr: "defined more than once"},
+		{name: "zone without name", edit: func(l *VenueLayout) { l.PriceZones[0].Name = "" }, wantErr: "name is required"},
+		{name: "zero multiplier", edit: func(l *VenueLayout) { l.PriceZones[0].Multiplier = 0 }, wantErr: "multiplier must be"},
+		{name: "multiplier too large", edit: func(l *VenueLayout) { l.PriceZones[0].Multiplier = 10.5 }, wantErr: "multiplier must be"},
+		{name: "duplicate section", edit: func(l *VenueLayout) { l.Sections = append(l.Sections, l.Sections[0]) }, wantErr: `section "A" is defined more than once`},
+		{name: "section without name", edit: func(l *VenueLayout) { l.Sections[0].Name = "" }, wantErr: "name is required"},
+		{name: "unknown section zone", edit: func(l *VenueLayout) { l.Sections[0].PriceZone = "balcony" }, wantErr: `unknown price zone "balcony"`},
+		{name: "section without rows", edit: func(l *VenueLayout) { l.Sections[0].Rows = nil }, wantErr: "at least one row"},
+		{name: "bad row label", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].Label = "" }, wantErr: "label must be"},
+		{name: "duplicate row", edit: func(l *VenueLayout) {
+			l.Sections[0].Rows = append(l.Sections[0].Rows, l.Sections[0].Rows[0])
+		}, wantErr: `row "1" is defined more than once`},
+		{name: "no seats", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].Seats = 0 }, wantErr: "seats must be between"},
+		{name: "too many seats in a row", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].Seats = maxLayoutSeatsPerRow + 1 }, wantErr: "seats must be between"},
+		{name: "odd numbering from even", edit: func(l *VenueLayout) {
+			l.Sections[0].Rows[0].Numbering, l.Sections[0].Rows[0].StartNumber = NumberingOdd, 2
+		}, wantErr: "odd numbering must start"},
+		{name: "even numbering from odd", edit: func(l *VenueLayout) {
+			l.Sections[0].Rows[0].Numbering, l.Sections[0].Rows[0].StartNumber = NumberingEven, 3
+		}, wantErr: "even numbering must start"},
+		{name: "unknown numbering", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].Numbering = "reverse" }, wantErr: "numbering must be one of"},
+		{name: "unknown row zone", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].PriceZone = "balcony" }, wantErr: `unknown price zone "balcony"`},
+		{name: "accessible seat outside the row", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].AccessibleSeats = []int{11} }, wantErr: "accessible seat 11 is not in this row"},
+		{name: "odd row has no even seats", edit: func(l *VenueLayout) {
+			l.Sections[0].Rows[0].Numbering = NumberingOdd
+			l.Sections[0].Rows[0].AccessibleSeats = []int{4}
+		}, wantErr: "accessible seat 4 is not in this row"},
+		{name: "accessible seat twice", edit: func(l *VenueLayout) { l.Sections[0].Rows[0].AccessibleSeats = []int{2, 2} }, wantErr: "listed more than once"},
+		{name: "accessible and companion", edit: func(l *VenueLayout) {
+			l.Sections[0].Rows[0].AccessibleSeats = []int{2}
+			l.Sections[0].Rows[0].CompanionSeats = []int{2}
+		}, wantErr: "both accessible and companion"},
+		{name: "too many seats in the layout", edit: func(l *VenueLayout) {
+			rows := make([]LayoutRow, maxLayoutRows)
+			for i := range rows {
+				rows[i] = LayoutRow{Label: fmt.Sprintf("r%d", i), Seats: maxLayoutSeatsPerRow, StartNumber: 1, Numbering: NumberingSequential}
+			}
+			l.Sections = []LayoutSection{
+				{ID: "A", Name: "A", Rows: rows},
+				{ID: "B", Name: "B", Rows: rows},
+			}
+		}, wantErr: "at most 100000 seats"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := testLayout()
+			tt.edit(&layout)
+			err := layout.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLayoutRowSeatNumbers(t *testing.T) {
+	tests := []struct {
+		row  LayoutRow
+		want []int
+	}{
+		{row: LayoutRow{Seats: 4, StartNumber: 1, Numbering: NumberingSequential}, want: []int{1, 2, 3, 4}},
+		{row: LayoutRow{Seats: 3, StartNumber: 101, Numbering: NumberingSequential}, want: []int{101, 102, 103}},
+		{row: LayoutRow{Seats: 3, StartNumber: 1, Numbering: NumberingOdd}, want: []int{1, 3, 5}},
+		{row: LayoutRow{Seats: 3, StartNumber: 2, Numbering: NumberingEven}, want: []int{2, 4, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.row.SeatNumbers(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SeatNumbers(%+v) = %v, want %v", tt.row, got, tt.want)
+		}
+	}
+}
+
+func TestVenueLayoutCapacity(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []LayoutSection
+		want     int32
+	}{
+		{name: "empty", want: 0},
+		{name: "one row", sections: []LayoutSection{{Rows: []LayoutRow{{Seats: 12}}}}, want: 12},
+		{name: "rows across sections", sections: []LayoutSection{
+			{Rows: []LayoutRow{{Seats: 10}, {Seats: 12}}},
+			{Rows: []LayoutRow{{Seats: 8}}},
+		}, want: 30},
+		{name: "largest allowed", sections: []LayoutSection{
+			{Rows: []LayoutRow{{Seats: maxLayoutSeats / 2}}},
+			{Rows: []LayoutRow{{Seats: maxLayoutSeats / 2}}},
+		}, want: maxLayoutSeats},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := VenueLayout{Sections: tt.sections}
+			if got := layout.Capacity(); got != tt.want {
+				t.Errorf("Capacity = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVenueLayoutSeatMapAndSummary(t *testing.T) {
+	layout := VenueLayout{
+		PriceZones: []LayoutPriceZone{
+			{ID: "vip", Name: "VIP", Multiplier: 2},
+			{ID: "front", Name: "Front", Multiplier: 1.5},
+		},
+		Sections: []LayoutSection{
+			{ID: "A", Name: "Stalls", PriceZone: "vip", Rows: []LayoutRow{
+				{Label: "1", Seats: 2, StartNumber: 1, Numbering: NumberingSequential, PriceZone: "front", AccessibleSeats: []int{1}, CompanionSeats: []int{2}},
+				{Label: "2", Seats: 1, StartNumber: 2, Numbering: NumberingEven},
+			}},
+			{ID: "B", Name: "Balcony", Rows: []LayoutRow{
+				{Label: "1", Seats: 1, StartNumber: 1, Numbering: NumberingSequential},
+			}},
+		},
+	}
+	if err := layout.Validate(); err != nil {
+		t.Fatalf("Validate error = %v", err)
+	}
+
+	want := []SeatMapSeat{
+		{Section: "A", Row: "1", Number: 1, PriceZone: "front", PriceMultiplier: 1.5, Accessible: true},
+		{Section: "A", Row: "1", Number: 2, PriceZone: "front", PriceMultiplier: 1.5, Companion: true},
+		{Section: "A", Row: "2", Number: 2, PriceZone: "vip", PriceMultiplier: 2},
+		{Section: "B", Row: "1", Number: 1, PriceMultiplier: 1},
+	}
+	if got := layout.SeatMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SeatMap = %+v, want %+v", got, want)
+	}
+
+	summary := layout.Summary()
+	wantSummary := VenueLayoutSummary{
+		Sections:        2,
+		Rows:            3,
+		Seats:           4,
+		Zones:           map[string]int32{"front": 2, "vip": 1},
+		UnzonedSeats:    1,
+		AccessibleSeats: 1,
+		CompanionSeats:  1,
+	}
+	if !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("Summary = %+v, want %+v", summary, wantSummary)
+	}
+}
+
+func TestVenueLayoutEdits(t *testing.T) {
+	layout := testLayout()
+
+	layout.UpsertSection(LayoutSection{ID: "B", Name: "Balcony", Rows: []LayoutRow{{Label: "1", Seats: 5}}})
+	if got := layout.Capacity(); got != 15 {
+		t.Fatalf("Capacity after adding a section = %d, want 15", got)
+	}
+	if row := layout.Sections[1].Rows[0]; row.Numbering != NumberingSequential || row.StartNumber != 1 {
+		t.Errorf("upserted row was not normalized: %+v", row)
+	}
+
+	if err := layout.UpsertRow("B", LayoutRow{Label: "1", Seats: 8}); err != nil {
+		t.Fatalf("UpsertRow error = %v", err)
+	}
+	if err := layout.UpsertRow("B", LayoutRow{Label: "2", Seats: 4}); err != nil {
+		t.Fatalf("UpsertRow error = %v", err)
+	}
+	if got := layout.Capacity(); got != 22 {
+		t.Errorf("Capacity after replacing and adding rows = %d, want 22", got)
+	}
+
+	if err := layout.RemoveRow("B", "1"); err != nil {
+		t.Fatalf("RemoveRow error = %v", err)
+	}
+	if err := layout.RemoveSection("A"); err != nil {
+		t.Fatalf("RemoveSection error = %v", err)
+	}
+	if got := layout.Capacity(); got != 4 {
+		t.Errorf("Capacity after removals = %d, want 4", got)
+	}
+
+	for name, err := range map[string]error{
+		"UpsertRow":     layout.UpsertRow("Z", LayoutRow{Label: "1", Seats: 1}),
+		"RemoveRow":     layout.RemoveRow("B", "9"),
+		"RemoveSection": layout.RemoveSection("A"),
+	} {
+		if !errors.Is(err, errLayoutElementNotFound) {
+			t.Errorf("%s error = %v, want errLayoutElementNotFound", name, err)
+		}
+	}
+}
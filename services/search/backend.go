@@ -0,0 +1,228 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+// SearchBackend is an index the search endpoints can be served from:
+// writes from event-service, searches with their facets, and suggestions.
+// Features only Elasticsearch has, such as cursors, synonyms, index
+// versions and recommendations, stay on ElasticsearchClient.
+type SearchBackend interface {
+	Name() string
+	HealthCheck(ctx context.Context) error
+	IndexEvents(ctx context.Context, docs []EventDocument) (int, error)
+	DeleteEvents(ctx context.Context, eventIDs []uuid.UUID) (int, error)
+	UpdateTrendingScores(ctx context.Context, scores map[uuid.UUID]float64) error
+	Search(ctx context.Context, req SearchRequest) (*SearchResponse, error)
+	GetSuggestions(ctx context.Context, prefix string, limit int) ([]string, error)
+	GetFacets(ctx context.Context) (*SearchFacets, error)
+	// GetDocuments returns the indexed events among eventIDs, in the order
+	// asked for.
+	GetDocuments(ctx context.Context, eventIDs []uuid.UUID) ([]EventDocument, error)
+}
+
+const (
+	BackendElasticsearch = "elasticsearch"
+	BackendMemory        = "memory"
+	BackendNone          = "none"
+)
+
+// newSearchBackends picks the backend searches are served from and the one
+// reads fall back to while it is unhealthy. The Elasticsearch client is
+// returned separately for the features only it has; it is nil when
+// Elasticsearch is not the backend.
+func newSearchBackends(cfg *config.SearchServiceConfig, logger *logger.Logger) (SearchBackend, SearchBackend, *ElasticsearchClient, error) {
+	var primary SearchBackend
+	var esClient *ElasticsearchClient
+	switch cfg.SearchBackend {
+	case BackendElasticsearch:
+		client, err := NewElasticsearchClient(cfg.ElasticsearchURL, cfg.IndexName, logger)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		primary, esClient = client, client
+	case BackendMemory:
+		primary = NewMemoryBackend(logger)
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown SEARCH_BACKEND %q, expected elasticsearch or memory", cfg.SearchBackend)
+	}
+
+	switch cfg.SearchFallback {
+	case BackendNone, "":
+		return primary, nil, esClient, nil
+	case BackendMemory:
+		// An in-memory backend has nothing to fall back from.
+		if cfg.SearchBackend == BackendMemory {
+			return primary, nil, esClient, nil
+		}
+		return primary, NewMemoryBackend(logger), esClient, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown SEARCH_FALLBACK %q, expected memory or none", cfg.SearchFallback)
+	}
+}
+
+// memoryBackend returns the in-memory index, whether it is the backend or
+// the fallback, or nil if there is none.
+func (cfg *APIConfig) memoryBackend() *MemoryBackend {
+	for _, backend := range []SearchBackend{cfg.Backend, cfg.Fallback} {
+		if memory, ok := backend.(*MemoryBackend); ok {
+			return memory
+		}
+	}
+	return nil
+}
+
+func (cfg *APIConfig) fallbackReady(ctx context.Context) bool {
+	return cfg.Fallback != nil && cfg.Fallback.HealthCheck(ctx) == nil
+}
+
+// readBackend is where reads go: the fallback while the backend is
+// unhealthy and the fallback has loaded, the backend otherwise.
+func (cfg *APIConfig) readBackend(ctx context.Context) SearchBackend {
+	if cfg.degraded.Load() && cfg.fallbackReady(ctx) {
+		return cfg.Fallback
+	}
+	return cfg.Backend
+}
+
+// withReadBackend runs a read on the read backend, and again on the
+// fallback if the backend fails before the health monitor notices.
+func (cfg *APIConfig) withReadBackend(ctx context.Context, read func(SearchBackend) error) error {
+	backend := cfg.readBackend(ctx)
+	err := read(backend)
+	if err != nil && backend == cfg.Backend && cfg.fallbackReady(ctx) {
+		cfg.Logger.Warn("Search backend failed, retrying on fallback", "error", err, "backend", cfg.Backend.Name(), "fallback", cfg.Fallback.Name())
+		return read(cfg.Fallback)
+	}
+	return err
+}
+
+func (cfg *APIConfig) search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	var result *SearchResponse
+	err := cfg.withReadBackend(ctx, func(backend SearchBackend) error {
+		var err error
+		result, err = backend.Search(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// searchCached serves a search through the result cache. Results from the
+// fallback skip the cache, so none are left once the backend recovers.
+// So do seat-filtered results: a booking only updates the seat overlay and
+// never retires cached results, so a cached page would keep sold-out events
+// and miss restocked ones.
+func (cfg *APIConfig) searchCached(ctx context.Context, params string, req SearchRequest) (*SearchResponse, bool, error) {
+	if minimumSeats(req) > 0 {
+		result, err := cfg.search(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+		cfg.applySeatOverlay(ctx, result)
+		return result, false, nil
+	}
+
+	if cfg.readBackend(ctx) == cfg.Backend {
+		result, cached, err := cfg.cachedSearch(ctx, params, func() (*SearchResponse, error) {
+			return cfg.Backend.Search(ctx, req)
+		})
+		if err == nil || !cfg.fallbackReady(ctx) {
+			return result, cached, err
+		}
+		cfg.Logger.Warn("Search backend failed, retrying on fallback", "error", err, "backend", cfg.Backend.Name(), "fallback", cfg.Fallback.Name())
+	}
+
+	result, err := cfg.Fallback.Search(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	cfg.applySeatOverlay(ctx, result)
+	return result, false, nil
+}
+
+// indexDocuments writes documents to the backend, and to the fallback so
+// it is current when reads switch to it. Only the backend's outcome is
+// reported; the fallback also catches up from the change feed.
+func (cfg *APIConfig) indexDocuments(ctx context.Context, docs []EventDocument) (int, error) {
+	if cfg.Fallback != nil {
+		if _, err := cfg.Fallback.IndexEvents(ctx, docs); err != nil {
+			cfg.Logger.Error("Failed to index events in fallback", "error", err, "count", len(docs))
+		}
+	}
+	return cfg.Backend.IndexEvents(ctx, docs)
+}
+
+func (cfg *APIConfig) deleteDocuments(ctx context.Context, eventIDs []uuid.UUID) (int, error) {
+	if cfg.Fallback != nil {
+		if _, err := cfg.Fallback.DeleteEvents(ctx, eventIDs); err != nil {
+			cfg.Logger.Error("Failed to delete events from fallback", "error", err, "count", len(eventIDs))
+		}
+	}
+	return cfg.Backend.DeleteEvents(ctx, eventIDs)
+}
+
+func (cfg *APIConfig) updateTrendingScores(ctx context.Context, scores map[uuid.UUID]float64) error {
+	if cfg.Fallback != nil {
+		if err := cfg.Fallback.UpdateTrendingScores(ctx, scores); err != nil {
+			cfg.Logger.Error("Failed to update trending scores in fallback", "error", err, "count", len(scores))
+		}
+	}
+	return cfg.Backend.UpdateTrendingScores(ctx, scores)
+}
+
+// checkBackendHealth checks the backend and moves reads to or from the
+// fallback to match. It returns the backend's error, if any.
+func (cfg *APIConfig) checkBackendHealth(ctx context.Context) error {
+	err := cfg.Backend.HealthCheck(ctx)
+	if cfg.Fallback == nil {
+		return err
+	}
+
+	degraded := err != nil
+	if cfg.degraded.Swap(degraded) != degraded {
+		if degraded {
+			cfg.Logger.Warn("Search backend is unhealthy, serving reads from fallback", "error", err, "backend", cfg.Backend.Name(), "fallback", cfg.Fallback.Name())
+		} else {
+			cfg.Logger.Info("Search backend recovered, serving reads from it again", "backend", cfg.Backend.Name())
+		}
+	}
+	return err
+}
+
+// startHealthMonitor checks the backend on every tick until the process
+// exits.
+func (cfg *APIConfig) startHealthMonitor() {
+	interval := cfg.Config.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		cfg.checkBackendHealth(ctx)
+		cancel()
+	}
+}
+
+// requireElasticsearch guards endpoints only Elasticsearch can serve.
+func (cfg *APIConfig) requireElasticsearch(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cfg.ESClient == nil {
+			utils.RespondWithError(w, http.StatusServiceUnavailable, "Not available without Elasticsearch")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+type BookingServiceClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	Logger     *logger.Logger
+}
+
+type BookingServiceBookedEvent struct {
+	EventID      uuid.UUID `json:"event_id"`
+	Tickets      int64     `json:"tickets"`
+	LastBookedAt time.Time `json:"last_booked_at"`
+}
+
+type BookingServiceBookedEventsResponse struct {
+	UserID uuid.UUID                   `json:"user_id"`
+	Events []BookingServiceBookedEvent `json:"events"`
+}
+
+func NewBookingServiceClient(baseURL, apiKey string, logger *logger.Logger) *BookingServiceClient {
+	return &BookingServiceClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		APIKey: apiKey,
+		Logger: logger,
+	}
+}
+
+// GetBookedEvents lists the events a user has confirmed bookings for, most
+// recently booked first.
+func (c *BookingServiceClient) GetBookedEvents(ctx context.Context, userID uuid.UUID, limit int) ([]BookingServiceBookedEvent, error) {
+	url := c.BaseURL + "/internal/bookings/users/" + userID.String() + "/events?limit=" + strconv.Itoa(limit)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("booking service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response BookingServiceBookedEventsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Events, nil
+}
@@ -0,0 +1,335 @@
+package search
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cached search results are keyed by a generation counter. Changes that can
+// move an event in or out of a result set, or change how it ranks, bump the
+// generation so every older entry stops being read and ages out on its own
+// TTL; nothing is ever scanned or deleted in bulk. Seat counts change far more
+// often and never affect which events match, so they are kept per event
+// beside the cache and patched into results as they are served.
+const (
+	searchCacheGenerationKey = "search:generation"
+	searchCacheResultPrefix  = "search:result:"
+	searchCacheLockPrefix    = "search:lock:"
+	searchCacheSeatsPrefix   = "search:seats:"
+	searchCacheDocPrefix     = "search:doc:"
+
+	// searchCacheLockWait bounds how long a request waits for another
+	// replica to fill the entry it needs before querying Elasticsearch
+	// itself.
+	searchCacheLockWait = 2 * time.Second
+	searchCachePollStep = 50 * time.Millisecond
+	// searchSeatsTTL keeps an event's last seat count well past any cached
+	// result that needs it, so the next change can also tell how many seats
+	// were sold in between.
+	searchSeatsTTL = 7 * 24 * time.Hour
+)
+
+// releaseSearchLock deletes a fill lock only if it still holds our token,
+// so a fill that outlived its lock cannot release someone else's.
+var releaseSearchLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// searchFlights collapses concurrent cache misses for the same key inside
+// one process into a single Elasticsearch query.
+type searchFlights struct {
+	mu    sync.Mutex
+	calls map[string]*searchFlight
+}
+
+type searchFlight struct {
+	done   chan struct{}
+	result *SearchResponse
+	err    error
+}
+
+func (f *searchFlights) do(key string, fn func() (*SearchResponse, error)) (*SearchResponse, error) {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]*searchFlight)
+	}
+	if call, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	call := &searchFlight{done: make(chan struct{})}
+	f.calls[key] = call
+	f.mu.Unlock()
+
+	call.result, call.err = fn()
+	close(call.done)
+
+	f.mu.Lock()
+	delete(f.calls, key)
+	f.mu.Unlock()
+
+	return call.result, call.err
+}
+
+// cachedSearch serves a search from the cache, or runs it once across all
+// replicas and caches the result. The bool reports whether the result came
+// from the cache. Redis errors only cost the cache; the search still runs.
+func (cfg *APIConfig) cachedSearch(ctx context.Context, params string, search func() (*SearchResponse, error)) (*SearchResponse, bool, error) {
+	generation, err := cfg.searchCacheGeneration(ctx)
+	if err != nil {
+		cfg.Logger.Error("Failed to read search cache generation", "error", err)
+		result, err := search()
+		return result, false, err
+	}
+	key := searchCacheResultPrefix + strconv.FormatInt(generation, 10) + ":" + params
+
+	if cached := cfg.getCachedSearchResult(ctx, key); cached != nil {
+		cfg.applySeatOverlay(ctx, cached)
+		return cached, true, nil
+	}
+
+	cached := false
+	result, err := cfg.searchFlights.do(key, func() (*SearchResponse, error) {
+		result, fromCache, err := cfg.fillSearchCache(ctx, key, search)
+		if err != nil {
+			return nil, err
+		}
+		cached = fromCache
+		cfg.applySeatOverlay(ctx, result)
+		return result, nil
+	})
+	return result, cached, err
+}
+
+// fillSearchCache runs a missed search under a short Redis lock, so only
+// one replica queries Elasticsearch for a key while the others wait for its
+// result to land in the cache.
+func (cfg *APIConfig) fillSearchCache(ctx context.Context, key string, search func() (*SearchResponse, error)) (*SearchResponse, bool, error) {
+	lockKey := searchCacheLockPrefix + key
+	token := newSearchLockToken()
+
+	acquired, err := cfg.RedisClient.SetNX(ctx, lockKey, token, cfg.Config.SearchTimeout).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to take search cache lock", "error", err)
+	}
+
+	if err == nil && !acquired {
+		deadline := time.Now().Add(searchCacheLockWait)
+		for time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			case <-time.After(searchCachePollStep):
+			}
+			if cached := cfg.getCachedSearchResult(ctx, key); cached != nil {
+				return cached, true, nil
+			}
+			// The holder gave up without caching anything, so there is
+			// nothing to wait for.
+			if exists, err := cfg.RedisClient.Exists(ctx, lockKey).Result(); err != nil || exists == 0 {
+				break
+			}
+		}
+		cfg.Logger.Debug("Search cache fill did not finish in time, querying directly", "cache_key", key)
+	}
+
+	if acquired {
+		defer func() {
+			if err := releaseSearchLock.Run(context.Background(), cfg.RedisClient, []string{lockKey}, token).Err(); err != nil {
+				cfg.Logger.Error("Failed to release search cache lock", "error", err)
+			}
+		}()
+	}
+
+	result, err := search()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg.cacheSearchResult(ctx, key, result)
+	return result, false, nil
+}
+
+func newSearchLockToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return uuid.NewString()
+	}
+	return hex.EncodeToString(b)
+}
+
+func (cfg *APIConfig) searchCacheGeneration(ctx context.Context) (int64, error) {
+	generation, err := cfg.RedisClient.Get(ctx, searchCacheGenerationKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return generation, err
+}
+
+func (cfg *APIConfig) getCachedSearchResult(ctx context.Context, key string) *SearchResponse {
+	val, err := cfg.RedisClient.Get(ctx, key).Result()
+	if err != nil {
+		return nil
+	}
+
+	var result SearchResponse
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		cfg.Logger.Error("Failed to unmarshal cached search result", "error", err)
+		return nil
+	}
+
+	return &result
+}
+
+func (cfg *APIConfig) cacheSearchResult(ctx context.Context, key string, result *SearchResponse) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		cfg.Logger.Error("Failed to marshal search result for caching", "error", err)
+		return
+	}
+
+	if err := cfg.RedisClient.Set(ctx, key, data, cfg.Config.CacheExpiry).Err(); err != nil {
+		cfg.Logger.Error("Failed to cache search result", "error", err)
+	}
+}
+
+// applySeatOverlay replaces each result's seat count with the latest one
+// recorded for its event, in a single round trip.
+func (cfg *APIConfig) applySeatOverlay(ctx context.Context, result *SearchResponse) {
+	if result == nil || len(result.Results) == 0 {
+		return
+	}
+
+	keys := make([]string, len(result.Results))
+	for i, event := range result.Results {
+		keys[i] = searchCacheSeatsPrefix + event.EventID.String()
+	}
+
+	values, err := cfg.RedisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to read seat overlay", "error", err)
+		return
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		seats, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			continue
+		}
+		result.Results[i].AvailableSeats = int32(seats)
+	}
+}
+
+// searchDocumentFingerprint hashes the parts of a document that decide
+// whether and where it shows up in results, leaving out the seat count and
+// the bookkeeping fields that change with it.
+func searchDocumentFingerprint(doc EventDocument) string {
+	doc.AvailableSeats = 0
+	doc.Version = 0
+	doc.UpdatedAt = time.Time{}
+	doc.TrendingScore = nil
+
+	data, _ := json.Marshal(doc)
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordIndexedEvents updates the seat overlay for freshly indexed
+// documents and bumps the cache generation only if one of them changed in
+// a way cached results could depend on. A booking that only moves the seat
+// count leaves every cached entry in place. Seats sold since the previous
+// update count towards trending. Events new to the index, including ones
+// published again after leaving it, are matched against saved searches.
+func (cfg *APIConfig) recordIndexedEvents(ctx context.Context, docs ...EventDocument) {
+	if len(docs) == 0 {
+		return
+	}
+
+	pipe := cfg.RedisClient.Pipeline()
+	previous := make([]*redis.StringCmd, len(docs))
+	previousSeats := make([]*redis.StatusCmd, len(docs))
+	fingerprints := make([]string, len(docs))
+	for i, doc := range docs {
+		id := doc.EventID.String()
+		fingerprints[i] = searchDocumentFingerprint(doc)
+		previous[i] = pipe.GetSet(ctx, searchCacheDocPrefix+id, fingerprints[i])
+		previousSeats[i] = pipe.SetArgs(ctx, searchCacheSeatsPrefix+id, doc.AvailableSeats, redis.SetArgs{Get: true, TTL: searchSeatsTTL})
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		cfg.Logger.Error("Failed to record indexed events in search cache", "error", err)
+		cfg.bumpSearchCacheGeneration(ctx)
+		return
+	}
+
+	sold := make(map[uuid.UUID]float64)
+	for i, cmd := range previousSeats {
+		old, err := strconv.ParseInt(cmd.Val(), 10, 32)
+		if err == nil && int32(old) > docs[i].AvailableSeats {
+			sold[docs[i].EventID] += float64(int32(old)-docs[i].AvailableSeats) * trendingSeatWeight
+		}
+	}
+	cfg.recordTrendingSignals(ctx, sold)
+
+	var added []EventDocument
+	for i, cmd := range previous {
+		if errors.Is(cmd.Err(), redis.Nil) {
+			added = append(added, docs[i])
+		}
+	}
+	cfg.matchSavedSearches(ctx, added)
+
+	for i, cmd := range previous {
+		if old, err := cmd.Result(); err != nil || old != fingerprints[i] {
+			cfg.bumpSearchCacheGeneration(ctx)
+			return
+		}
+	}
+	cfg.Logger.Debug("Indexed events changed seats only, search cache kept", "count", len(docs))
+}
+
+// forgetIndexedEvents drops events' cache bookkeeping and trending scores
+// after they leave the index, and retires cached results that may still
+// list them.
+func (cfg *APIConfig) forgetIndexedEvents(ctx context.Context, eventIDs ...uuid.UUID) {
+	if len(eventIDs) == 0 {
+		return
+	}
+	keys := make([]string, 0, 2*len(eventIDs))
+	for _, eventID := range eventIDs {
+		id := eventID.String()
+		keys = append(keys, searchCacheDocPrefix+id, searchCacheSeatsPrefix+id)
+	}
+	if err := cfg.RedisClient.Del(ctx, keys...).Err(); err != nil {
+		cfg.Logger.Error("Failed to clear search cache entries for events", "error", err, "count", len(eventIDs))
+	}
+	cfg.dropTrendingScores(ctx, eventIDs)
+	cfg.bumpSearchCacheGeneration(ctx)
+}
+
+// bumpSearchCacheGeneration retires every cached result at once. Old
+// entries are left to expire.
+func (cfg *APIConfig) bumpSearchCacheGeneration(ctx context.Context) {
+	generation, err := cfg.RedisClient.Incr(ctx, searchCacheGenerationKey).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to bump search cache generation", "error", err)
+		return
+	}
+	cfg.Logger.Info("Search cache generation bumped", "generation", generation)
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+func testCacheConfig(t *testing.T) (*APIConfig, *fakeRedis) {
+	t.Helper()
+	client, fake := newFakeRedis(t)
+	cfg := &APIConfig{
+		Config: &config.SearchServiceConfig{
+			CacheExpiry:      time.Minute,
+			SearchTimeout:    time.Second,
+			TrendingHalfLife: 24 * time.Hour,
+		},
+		Logger:      logger.New("error"),
+		RedisClient: client,
+	}
+	return cfg, fake
+}
+
+func testGeneration(t *testing.T, fake *fakeRedis) string {
+	t.Helper()
+	generation, _ := fake.get(searchCacheGenerationKey)
+	return generation
+}
+
+func TestRecordIndexedEvents(t *testing.T) {
+	cfg, fake := testCacheConfig(t)
+	ctx := context.Background()
+
+	jazz := testDocument(1, "Jazz Night", "Pune", "music", 500, 1)
+
+	booked := jazz
+	booked.AvailableSeats = 90
+	booked.Version = 2
+	booked.UpdatedAt = time.Now()
+
+	repriced := booked
+	repriced.BasePrice = 600
+	repriced.Version = 3
+
+	steps := []struct {
+		name           string
+		doc            EventDocument
+		wantGeneration string
+		wantSeats      string
+	}{
+		{name: "new event", doc: jazz, wantGeneration: "1", wantSeats: "100"},
+		{name: "seats only", doc: booked, wantGeneration: "1", wantSeats: "90"},
+		{name: "reindexed unchanged", doc: booked, wantGeneration: "1", wantSeats: "90"},
+		{name: "price change", doc: repriced, wantGeneration: "2", wantSeats: "90"},
+	}
+
+	for _, step := range steps {
+		cfg.recordIndexedEvents(ctx, step.doc)
+
+		if got := testGeneration(t, fake); got != step.wantGeneration {
+			t.Errorf("%s: generation = %q, want %q", step.name, got, step.wantGeneration)
+		}
+		if got, _ := fake.get(searchCacheSeatsPrefix + jazz.EventID.String()); got != step.wantSeats {
+			t.Errorf("%s: seats = %q, want %q", step.name, got, step.wantSeats)
+		}
+	}
+}
+
+func TestRecordIndexedEventsBumpsOnRedisError(t *testing.T) {
+	cfg, fake := testCacheConfig(t)
+	fake.failCommand("getset", errors.New("connection reset"))
+
+	cfg.recordIndexedEvents(context.Background(), testDocument(1, "Jazz Night", "Pune", "music", 500, 1))
+
+	if got := testGeneration(t, fake); got != "1" {
+		t.Errorf("generation = %q, want it bumped when the fingerprints cannot be compared", got)
+	}
+}
+
+func TestForgetIndexedEvents(t *testing.T) {
+	cfg, fake := testCacheConfig(t)
+	ctx := context.Background()
+
+	cfg.recordIndexedEvents(ctx, testDocument(1, "Jazz Night", "Pune", "music", 500, 1), testDocument(2, "Rock Concert", "Mumbai", "music", 1500, 2))
+	cfg.forgetIndexedEvents(ctx, testEventID(1))
+
+	want := []string{searchCacheSeatsPrefix + testEventID(2).String()}
+	if got := fake.keys(searchCacheSeatsPrefix); !reflect.DeepEqual(got, want) {
+		t.Errorf("seat keys = %v, want %v", got, want)
+	}
+	want = []string{searchCacheDocPrefix + testEventID(2).String()}
+	if got := fake.keys(searchCacheDocPrefix); !reflect.DeepEqual(got, want) {
+		t.Errorf("fingerprint keys = %v, want %v", got, want)
+	}
+	if got := testGeneration(t, fake); got != "2" {
+		t.Errorf("generation = %q, want %q", got, "2")
+	}
+}
+
+func TestCachedSearch(t *testing.T) {
+	cfg, fake := testCacheConfig(t)
+	ctx := context.Background()
+
+	searches := 0
+	search := func() (*SearchResponse, error) {
+		searches++
+		return &SearchResponse{Results: []EventSearchResult{{EventID: testEventID(1), AvailableSeats: 100}}, Total: 1}, nil
+	}
+	run := func(name string, wantCached bool, wantSearches int, wantSeats int32) {
+		t.Helper()
+		result, cached, err := cfg.cachedSearch(ctx, "city=pune", search)
+		if err != nil {
+			t.Fatalf("%s: cachedSearch error = %v", name, err)
+		}
+		if cached != wantCached || searches != wantSearches {
+			t.Errorf("%s: cached = %v after %d searches, want %v after %d", name, cached, searches, wantCached, wantSearches)
+		}
+		if got := result.Results[0].AvailableSeats; got != wantSeats {
+			t.Errorf("%s: seats = %d, want %d", name, got, wantSeats)
+		}
+	}
+
+	run("miss", false, 1, 100)
+	run("hit", true, 1, 100)
+
+	// A seat change is patched into the cached result without a new search.
+	booked := testDocument(1, "Jazz Night", "Pune", "music", 500, 1)
+	cfg.recordIndexedEvents(ctx, booked)
+	booked.AvailableSeats = 80
+	cfg.recordIndexedEvents(ctx, booked)
+	// The first record bumped the generation for the new event.
+	run("after new event", false, 2, 80)
+	run("after seats change", true, 2, 80)
+
+	cfg.bumpSearchCacheGeneration(ctx)
+	run("after bump", false, 3, 80)
+
+	want := []string{searchCacheResultPrefix + "0:city=pune", searchCacheResultPrefix + "1:city=pune", searchCacheResultPrefix + "2:city=pune"}
+	if got := fake.keys(searchCacheResultPrefix); !reflect.DeepEqual(got, want) {
+		t.Errorf("cached results = %v, want %v", got, want)
+	}
+}
+
+func TestCachedSearchWithoutRedis(t *testing.T) {
+	cfg, fake := testCacheConfig(t)
+	fake.failCommand("get", errors.New("connection refused"))
+
+	searches := 0
+	for range 2 {
+		_, cached, err := cfg.cachedSearch(context.Background(), "city=pune", func() (*SearchResponse, error) {
+			searches++
+			return &SearchResponse{}, nil
+		})
+		if err != nil || cached {
+			t.Fatalf("cachedSearch = cached %v, error %v; want a direct search", cached, err)
+		}
+	}
+	if searches != 2 {
+		t.Errorf("searches = %d, want 2", searches)
+	}
+}
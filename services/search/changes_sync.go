@@ -0,0 +1,254 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// The changes sync repairs the index from event-service's change feed
+// instead of reloading every event. The watermark is the time and event ID
+// of the newest change applied. Each pass starts a little before it: a
+// transaction stamps updated_at when it starts, not when it commits, so a
+// slow one can show up behind changes already read. Applying a change twice
+// is harmless, and an older state that overtakes a newer push from
+// event-service is corrected on the next pass, since the newer change sorts
+// after the watermark. Without a watermark a pass reads the whole feed,
+// which also fills an empty index.
+const (
+	changesSyncWatermarkKey = "search:changes:watermark"
+	changesSyncLockKey      = "search:changes:lock"
+	changesSyncLockTTL      = 10 * time.Minute
+	changesSyncPageSize     = 200
+)
+
+var errChangesSyncRunning = errors.New("a changes sync is already running")
+
+type changesWatermark struct {
+	ChangedAt time.Time `json:"changed_at"`
+	EventID   uuid.UUID `json:"event_id"`
+}
+
+// changesSyncResult describes a finished changes sync pass.
+type changesSyncResult struct {
+	Upserted  int
+	Deleted   int
+	Watermark *changesWatermark
+}
+
+func (cfg *APIConfig) loadChangesWatermark(ctx context.Context) (*changesWatermark, error) {
+	val, err := cfg.RedisClient.Get(ctx, changesSyncWatermarkKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var watermark changesWatermark
+	if err := json.Unmarshal([]byte(val), &watermark); err != nil {
+		return nil, err
+	}
+	return &watermark, nil
+}
+
+func (cfg *APIConfig) saveChangesWatermark(ctx context.Context, watermark changesWatermark) error {
+	data, err := json.Marshal(watermark)
+	if err != nil {
+		return err
+	}
+	return cfg.RedisClient.Set(ctx, changesSyncWatermarkKey, data, 0).Err()
+}
+
+// syncChanges applies every change since the watermark, one page at a time,
+// moving the watermark after each page so a failed pass resumes where it
+// stopped. Only one replica runs a pass at a time.
+func (cfg *APIConfig) syncChanges(ctx context.Context) (*changesSyncResult, error) {
+	token := newSearchLockToken()
+	acquired, err := cfg.RedisClient.SetNX(ctx, changesSyncLockKey, token, changesSyncLockTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take changes sync lock: %w", err)
+	}
+	if !acquired {
+		return nil, errChangesSyncRunning
+	}
+	defer func() {
+		if err := releaseSearchLock.Run(context.Background(), cfg.RedisClient, []string{changesSyncLockKey}, token).Err(); err != nil {
+			cfg.Logger.Error("Failed to release changes sync lock", "error", err)
+		}
+	}()
+
+	watermark, err := cfg.loadChangesWatermark(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load changes watermark: %w", err)
+	}
+
+	result := &changesSyncResult{Watermark: watermark}
+	var since time.Time
+	after := uuid.Nil
+	if watermark != nil {
+		since = watermark.ChangedAt.Add(-cfg.Config.ChangesSyncOverlap)
+	}
+
+	for {
+		page, err := cfg.EventServiceClient.GetEventChanges(ctx, since, after, changesSyncPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch changes: %w", err)
+		}
+		if len(page.Changes) == 0 {
+			break
+		}
+
+		upserted, deleted, err := cfg.applyEventChanges(ctx, page.Changes)
+		if err != nil {
+			return nil, err
+		}
+		result.Upserted += upserted
+		result.Deleted += deleted
+
+		last := page.Changes[len(page.Changes)-1]
+		since, after = last.ChangedAt, last.EventID
+
+		// Pages inside the overlap are behind the watermark already.
+		if result.Watermark == nil || last.ChangedAt.After(result.Watermark.ChangedAt) {
+			next := changesWatermark{ChangedAt: last.ChangedAt, EventID: last.EventID}
+			if err := cfg.saveChangesWatermark(ctx, next); err != nil {
+				return nil, fmt.Errorf("failed to save changes watermark: %w", err)
+			}
+			result.Watermark = &next
+		}
+
+		if !page.HasMore {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// applyEventChanges writes one page of changes to the index, and to the
+// index being built if there is one.
+func (cfg *APIConfig) applyEventChanges(ctx context.Context, changes []EventServiceChange) (int, int, error) {
+	var documents []EventDocument
+	var deleted []uuid.UUID
+	for _, change := range changes {
+		if change.Operation == "upsert" && change.Event != nil {
+			documents = append(documents, cfg.convertEventToDocument(*change.Event))
+		} else {
+			deleted = append(deleted, change.EventID)
+		}
+	}
+
+	if len(documents) > 0 {
+		cfg.applyTrendingScores(ctx, documents)
+		failed, err := cfg.indexDocuments(ctx, documents)
+		if err != nil {
+			return 0, 0, err
+		}
+		if failed > 0 {
+			return 0, 0, fmt.Errorf("%d changed events failed to index", failed)
+		}
+		cfg.mirrorIndexedEvents(ctx, documents...)
+		cfg.recordIndexedEvents(ctx, documents...)
+	}
+
+	if len(deleted) > 0 {
+		failed, err := cfg.deleteDocuments(ctx, deleted)
+		if err != nil {
+			return 0, 0, err
+		}
+		if failed > 0 {
+			return 0, 0, fmt.Errorf("%d removed events failed to delete", failed)
+		}
+		cfg.mirrorDeletedEvents(ctx, deleted...)
+		cfg.forgetIndexedEvents(ctx, deleted...)
+	}
+
+	return len(documents), len(deleted), nil
+}
+
+// startChangesSync runs a changes sync pass at startup, to catch up on
+// anything missed while the service was down, and then on every tick until
+// the process exits.
+func (cfg *APIConfig) startChangesSync() {
+	interval := cfg.Config.ChangesSyncInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := cfg.syncChanges(context.Background())
+		switch {
+		case errors.Is(err, errChangesSyncRunning):
+		case err != nil:
+			cfg.Logger.Warn("Changes sync failed, retrying next tick", "error", err)
+		case result.Upserted > 0 || result.Deleted > 0:
+			cfg.Logger.Info("Synced event changes", "upserted", result.Upserted, "deleted", result.Deleted)
+		}
+		<-ticker.C
+	}
+}
+
+// SyncChanges runs a changes sync pass now instead of waiting for the next
+// tick.
+func (cfg *APIConfig) SyncChanges(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	result, err := cfg.syncChanges(r.Context())
+	if errors.Is(err, errChangesSyncRunning) {
+		utils.RespondWithError(w, http.StatusConflict, "A changes sync is already running")
+		return
+	}
+	if err != nil {
+		cfg.Logger.Error("Changes sync failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Changes sync failed")
+		return
+	}
+
+	response := ChangesSyncResponse{
+		Upserted:  result.Upserted,
+		Deleted:   result.Deleted,
+		TimeTaken: time.Since(start).String(),
+	}
+	if result.Watermark != nil {
+		response.Watermark = &result.Watermark.ChangedAt
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (cfg *APIConfig) GetChangesSyncStatus(w http.ResponseWriter, r *http.Request) {
+	watermark, err := cfg.loadChangesWatermark(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to load changes watermark", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to load changes sync status")
+		return
+	}
+
+	running, err := cfg.RedisClient.Exists(r.Context(), changesSyncLockKey).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to check changes sync lock", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to load changes sync status")
+		return
+	}
+
+	response := ChangesSyncStatusResponse{
+		Interval: cfg.Config.ChangesSyncInterval.String(),
+		Running:  running > 0,
+	}
+	if watermark != nil {
+		response.Watermark = &watermark.ChangedAt
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,270 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+// testChangeFeed serves event-service's change feed from memory, ordered
+// and paged the way event-service pages it.
+type testChangeFeed struct {
+	mu      sync.Mutex
+	changes []EventServiceChange
+	// requests lists the since and after of every page asked for.
+	requests [][2]string
+	// failFrom, when positive, fails that request, counting from one, and
+	// every later one.
+	failFrom int
+}
+
+func newTestChangeFeed(t *testing.T, cfg *APIConfig) *testChangeFeed {
+	t.Helper()
+	feed := &testChangeFeed{}
+	server := httptest.NewServer(http.HandlerFunc(feed.serve))
+	t.Cleanup(server.Close)
+	cfg.EventServiceClient = NewEventServiceClient(server.URL, "test-key", logger.New("error"))
+	return feed
+}
+
+func (f *testChangeFeed) add(changes ...EventServiceChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changes = append(f.changes, changes...)
+}
+
+// failFromRequest fails the nth request from now on and every later one,
+// or none if n is zero.
+func (f *testChangeFeed) failFromRequest(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failFrom = 0
+	if n > 0 {
+		f.failFrom = len(f.requests) + n
+	}
+}
+
+func (f *testChangeFeed) seen() [][2]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][2]string(nil), f.requests...)
+}
+
+func (f *testChangeFeed) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	f.requests = append(f.requests, [2]string{query.Get("since"), query.Get("after")})
+	if f.failFrom > 0 && len(f.requests) >= f.failFrom {
+		http.Error(w, "database unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	var since time.Time
+	if s := query.Get("since"); s != "" {
+		since, _ = time.Parse(time.RFC3339Nano, s)
+	}
+	after := query.Get("after")
+	if after == "" {
+		after = uuid.Nil.String()
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	ordered := slices.Clone(f.changes)
+	slices.SortFunc(ordered, func(a, b EventServiceChange) int {
+		if c := a.ChangedAt.Compare(b.ChangedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.EventID.String(), b.EventID.String())
+	})
+
+	response := EventServiceChangesResponse{Changes: []EventServiceChange{}}
+	for _, change := range ordered {
+		if change.ChangedAt.Before(since) || (change.ChangedAt.Equal(since) && change.EventID.String() <= after) {
+			continue
+		}
+		if len(response.Changes) == limit {
+			break
+		}
+		response.Changes = append(response.Changes, change)
+	}
+	response.HasMore = len(response.Changes) == limit
+	json.NewEncoder(w).Encode(response)
+}
+
+var testChangesStart = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testUpsert(n int, at time.Duration) EventServiceChange {
+	event := testPublishedEvents(n)[0]
+	return EventServiceChange{EventID: event.EventID, Operation: "upsert", ChangedAt: testChangesStart.Add(at), Event: &event}
+}
+
+func testRemoval(n int, at time.Duration) EventServiceChange {
+	return EventServiceChange{EventID: testEventID(n), Operation: "delete", ChangedAt: testChangesStart.Add(at)}
+}
+
+func testChangesConfig(t *testing.T) (*APIConfig, *MemoryBackend, *fakeRedis) {
+	t.Helper()
+	cfg, fake := testCacheConfig(t)
+	cfg.Config.ChangesSyncOverlap = time.Minute
+	backend := NewMemoryBackend(logger.New("error"))
+	backend.MarkLoaded()
+	cfg.Backend = backend
+	return cfg, backend, fake
+}
+
+// indexedIDs returns which of the given events the backend holds.
+func indexedIDs(t *testing.T, backend *MemoryBackend, ns ...int) []string {
+	t.Helper()
+	ids := make([]uuid.UUID, len(ns))
+	for i, n := range ns {
+		ids[i] = testEventID(n)
+	}
+	docs, err := backend.GetDocuments(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetDocuments error = %v", err)
+	}
+	found := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		found = append(found, doc.EventID.String())
+	}
+	slices.Sort(found)
+	return found
+}
+
+// syncPass runs one pass and then drops the pass lock, which the fake
+// Redis cannot release since it does not run scripts.
+func syncPass(t *testing.T, cfg *APIConfig) (*changesSyncResult, error) {
+	t.Helper()
+	result, err := cfg.syncChanges(context.Background())
+	cfg.RedisClient.Del(context.Background(), changesSyncLockKey)
+	return result, err
+}
+
+func TestSyncChanges(t *testing.T) {
+	cfg, backend, _ := testChangesConfig(t)
+	backend.IndexEvents(context.Background(), []EventDocument{testDocument(3, "Gone", "Pune", "music", 100, 1)})
+	feed := newTestChangeFeed(t, cfg)
+	feed.add(testUpsert(1, 0), testUpsert(2, time.Second), testRemoval(3, 2*time.Second))
+
+	// Without a watermark the whole feed is read.
+	result, err := syncPass(t, cfg)
+	if err != nil {
+		t.Fatalf("syncChanges error = %v", err)
+	}
+	if result.Upserted != 2 || result.Deleted != 1 {
+		t.Errorf("first pass = %d upserted, %d deleted, want 2 and 1", result.Upserted, result.Deleted)
+	}
+	if got, want := indexedIDs(t, backend, 1, 2, 3), testIDs(1, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("indexed = %v, want %v", got, want)
+	}
+	wantWatermark := &changesWatermark{ChangedAt: testChangesStart.Add(2 * time.Second), EventID: testEventID(3)}
+	if watermark, _ := cfg.loadChangesWatermark(context.Background()); !reflect.DeepEqual(watermark, wantWatermark) {
+		t.Errorf("watermark = %+v, want %+v", watermark, wantWatermark)
+	}
+
+	// A change stamped before the watermark but committed after the first
+	// pass read the feed is still picked up, thanks to the overlap.
+	feed.add(testUpsert(4, 3*time.Second), testUpsert(5, -30*time.Second+2*time.Second))
+	result, err = syncPass(t, cfg)
+	if err != nil {
+		t.Fatalf("syncChanges error = %v", err)
+	}
+	if got, want := indexedIDs(t, backend, 1, 2, 3, 4, 5), testIDs(1, 2, 4, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("indexed = %v, want %v", got, want)
+	}
+	if result.Upserted != 4 || result.Deleted != 1 {
+		t.Errorf("second pass = %d upserted, %d deleted, want the overlap applied again", result.Upserted, result.Deleted)
+	}
+
+	requests := feed.seen()
+	wantSince := testChangesStart.Add(2*time.Second - time.Minute).Format(time.RFC3339Nano)
+	if got := requests[len(requests)-1]; got != [2]string{wantSince, ""} {
+		t.Errorf("second pass asked for %v, want changes since %s", got, wantSince)
+	}
+	wantWatermark = &changesWatermark{ChangedAt: testChangesStart.Add(3 * time.Second), EventID: testEventID(4)}
+	if watermark, _ := cfg.loadChangesWatermark(context.Background()); !reflect.DeepEqual(watermark, wantWatermark) {
+		t.Errorf("watermark = %+v, want %+v", watermark, wantWatermark)
+	}
+}
+
+func TestSyncChangesKeepsWatermarkInsideOverlap(t *testing.T) {
+	cfg, _, _ := testChangesConfig(t)
+	watermark := changesWatermark{ChangedAt: testChangesStart.Add(time.Hour), EventID: testEventID(9)}
+	if err := cfg.saveChangesWatermark(context.Background(), watermark); err != nil {
+		t.Fatalf("saveChangesWatermark error = %v", err)
+	}
+	feed := newTestChangeFeed(t, cfg)
+	// A late change behind the watermark, inside the overlap.
+	feed.add(testUpsert(1, time.Hour-10*time.Second))
+
+	result, err := syncPass(t, cfg)
+	if err != nil {
+		t.Fatalf("syncChanges error = %v", err)
+	}
+	if result.Upserted != 1 {
+		t.Errorf("upserted = %d, want 1", result.Upserted)
+	}
+	if got, _ := cfg.loadChangesWatermark(context.Background()); !reflect.DeepEqual(got, &watermark) {
+		t.Errorf("watermark = %+v, want it kept at %+v", got, watermark)
+	}
+}
+
+func TestSyncChangesResumesAfterFailedPage(t *testing.T) {
+	cfg, backend, _ := testChangesConfig(t)
+	feed := newTestChangeFeed(t, cfg)
+	for i := range changesSyncPageSize + 50 {
+		feed.add(testUpsert(i+1, time.Duration(i)*time.Minute))
+	}
+	feed.failFromRequest(2)
+
+	if _, err := syncPass(t, cfg); err == nil {
+		t.Fatal("syncChanges succeeded with the second page failing")
+	}
+	// The watermark moved past the page that was applied.
+	firstPageEnd := testChangesStart.Add(time.Duration(changesSyncPageSize-1) * time.Minute)
+	watermark, _ := cfg.loadChangesWatermark(context.Background())
+	if watermark == nil || !watermark.ChangedAt.Equal(firstPageEnd) {
+		t.Fatalf("watermark = %+v, want it at the end of the first page, %v", watermark, firstPageEnd)
+	}
+
+	feed.failFromRequest(0)
+	result, err := syncPass(t, cfg)
+	if err != nil {
+		t.Fatalf("syncChanges error = %v", err)
+	}
+	// The retry starts an overlap before the watermark, not from scratch:
+	// it reads the minute before it again, then the 50 changes after it.
+	if result.Upserted != 52 {
+		t.Errorf("upserted = %d, want 52", result.Upserted)
+	}
+	if got, want := indexedIDs(t, backend, 1, changesSyncPageSize+50), testIDs(1, changesSyncPageSize+50); !reflect.DeepEqual(got, want) {
+		t.Errorf("indexed = %v, want %v", got, want)
+	}
+}
+
+func TestSyncChangesRunning(t *testing.T) {
+	cfg, _, _ := testChangesConfig(t)
+	feed := newTestChangeFeed(t, cfg)
+	cfg.RedisClient.Set(context.Background(), changesSyncLockKey, "another-replica", 0)
+
+	if _, err := cfg.syncChanges(context.Background()); !errors.Is(err, errChangesSyncRunning) {
+		t.Fatalf("syncChanges error = %v, want %v", err, errChangesSyncRunning)
+	}
+	if requests := feed.seen(); len(requests) != 0 {
+		t.Errorf("fetched %d pages while another pass held the lock", len(requests))
+	}
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+)
+
+// searchCursor is what a next_cursor token carries: the point in time the
+// walk reads from, where the last page ended, and the search itself, so
+// follow-up requests only need the token. Clients treat it as opaque.
+type searchCursor struct {
+	PitID   string        `json:"pit"`
+	After   []any         `json:"after"`
+	Request SearchRequest `json:"request"`
+}
+
+func encodeSearchCursor(cursor searchCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSearchCursor(token string) (searchCursor, error) {
+	var cursor searchCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.PitID == "" || len(cursor.After) == 0 || cursor.Request.Limit <= 0 || cursor.Request.Limit > 100 {
+		return cursor, errors.New("incomplete cursor")
+	}
+	return cursor, nil
+}
+
+// searchWithCursor serves one page of a cursor walk, opening a point in time
+// for the first one. Every page of the walk sees the index as it was when
+// the walk started. Cursor pages are not cached, since they belong to one
+// client's walk.
+func (cfg *APIConfig) searchWithCursor(w http.ResponseWriter, r *http.Request, cursor searchCursor) {
+	if cfg.ESClient == nil || cfg.readBackend(r.Context()) != cfg.Backend {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Cursor paging is not available right now, use page and limit")
+		return
+	}
+
+	if cursor.PitID == "" {
+		pitID, err := cfg.ESClient.OpenPointInTime(r.Context())
+		if err != nil {
+			cfg.Logger.Error("Failed to open point in time", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Search failed")
+			return
+		}
+		cursor.PitID = pitID
+	}
+
+	result, page, err := cfg.ESClient.SearchAfter(r.Context(), cursor.Request, cursor.PitID, cursor.After)
+	if errors.Is(err, ErrCursorExpired) {
+		utils.RespondWithError(w, http.StatusGone, "Cursor has expired, start the search again")
+		return
+	}
+	if err != nil {
+		cfg.Logger.Error("Cursor search failed", "error", err, "query", cursor.Request.Query)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Search failed")
+		return
+	}
+
+	cfg.applySeatOverlay(r.Context(), result)
+	cfg.recordSearchSignals(r.Context(), cursor.Request, result)
+	result = echoSearch(result, cursor.Request)
+
+	if len(result.Results) == cursor.Request.Limit && len(page.After) > 0 {
+		next := searchCursor{
+			PitID:   page.PitID,
+			After:   page.After,
+			Request: cursor.Request,
+		}
+		next.Request.Page++
+		token, err := encodeSearchCursor(next)
+		if err != nil {
+			cfg.Logger.Error("Failed to encode search cursor", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Search failed")
+			return
+		}
+		result.NextCursor = token
+	} else if err := cfg.ESClient.ClosePointInTime(context.Background(), page.PitID); err != nil {
+		cfg.Logger.Warn("Failed to close point in time", "error", err)
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
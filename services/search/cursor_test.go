@@ -0,0 +1,88 @@
+package search
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	cursor := searchCursor{
+		PitID: "46ToAwMDaWR5BXV1aWQy",
+		After: []any{float64(1792137600000), "b7c1c2a4-3f0e-4d8a-9a51-1f2e3d4c5b6a", float64(42)},
+		Request: SearchRequest{
+			Query:    "jazz",
+			City:     "Pune",
+			Tags:     []string{"live"},
+			MinPrice: 100,
+			Currency: "INR",
+			Near:     &geocode.Point{Lat: 18.52, Lon: 73.85},
+			RadiusKm: 25,
+			Days:     []int{5, 6},
+			TimeFrom: "18:00",
+			Sort:     SortDistance,
+			Page:     3,
+			Limit:    20,
+		},
+	}
+
+	token, err := encodeSearchCursor(cursor)
+	if err != nil {
+		t.Fatalf("encodeSearchCursor error = %v", err)
+	}
+	if strings.ContainsAny(token, "+/=") {
+		t.Errorf("token %q is not URL-safe", token)
+	}
+
+	got, err := decodeSearchCursor(token)
+	if err != nil {
+		t.Fatalf("decodeSearchCursor error = %v", err)
+	}
+	if !reflect.DeepEqual(got, cursor) {
+		t.Errorf("decodeSearchCursor = %+v, want %+v", got, cursor)
+	}
+}
+
+func TestDecodeSearchCursorRejects(t *testing.T) {
+	encode := func(json string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(json))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "not base64", token: "not a cursor!"},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte(`{"pit":"p","after":[1],"request":{"limit":10}}`))},
+		{name: "not JSON", token: encode("pit=p")},
+		{name: "no point in time", token: encode(`{"after":[1],"request":{"limit":10}}`)},
+		{name: "no sort values", token: encode(`{"pit":"p","after":[],"request":{"limit":10}}`)},
+		{name: "no limit", token: encode(`{"pit":"p","after":[1],"request":{}}`)},
+		{name: "limit too large", token: encode(`{"pit":"p","after":[1],"request":{"limit":101}}`)},
+		{name: "negative limit", token: encode(`{"pit":"p","after":[1],"request":{"limit":-5}}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := decodeSearchCursor(tt.token); err == nil {
+				t.Errorf("decodeSearchCursor(%q) = %+v, want an error", tt.token, cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeSearchCursorLimits(t *testing.T) {
+	for _, limit := range []int{1, 100} {
+		token, err := encodeSearchCursor(searchCursor{PitID: "p", After: []any{"x"}, Request: SearchRequest{Limit: limit}})
+		if err != nil {
+			t.Fatalf("encodeSearchCursor error = %v", err)
+		}
+		if _, err := decodeSearchCursor(token); err != nil {
+			t.Errorf("decodeSearchCursor with limit %d error = %v", limit, err)
+		}
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,14 +42,94 @@ func NewElasticsearchClient(url, indexName string, logger *logger.Logger) (*Elas
 	}, nil
 }
 
+// indexSchemaVersion is recorded in the index mapping's _meta. Bump it when
+// the mapping or analysis settings change in a way existing indices need a
+// reindex to pick up.
+const indexSchemaVersion = 3
+
+// searchTextFields are the fields free-text queries match, with their
+// boosts.
+var searchTextFields = []string{
+	"name^3",
+	"description^2",
+	"venue_name^2",
+	"venue_city",
+	"event_type",
+	"tags",
+}
+
+// searchPhraseFields are the analyzed fields the phrase tier matches.
+var searchPhraseFields = []string{
+	"name^3",
+	"description",
+	"venue_name",
+}
+
+// SynonymsSet is the Elasticsearch synonyms set the search analyzer reads.
+// Updating it reloads the analyzer in place, so synonym changes need no
+// reindex.
+func (e *ElasticsearchClient) SynonymsSet() string {
+	return e.indexName + "-synonyms"
+}
+
+// The configured index name is an alias. The index behind it is one of a
+// series of versions named {alias}-v{schema}-{timestamp}, so a new version
+// can be built and swapped in while searches keep using the old one.
+
+// IndexVersion is one physical index behind the alias.
+type IndexVersion struct {
+	Name      string    `json:"name"`
+	Documents int64     `json:"documents"`
+	CreatedAt time.Time `json:"created_at"`
+	Active    bool      `json:"active"`
+}
+
+func (e *ElasticsearchClient) versionPrefix() string {
+	return e.indexName + "-v"
+}
+
+// NewIndexName names a new index version for the current schema.
+func (e *ElasticsearchClient) NewIndexName(now time.Time) string {
+	return fmt.Sprintf("%s%d-%s", e.versionPrefix(), indexSchemaVersion, now.UTC().Format("20060102150405"))
+}
+
+// CreateIndex makes sure the alias resolves to an index, creating an empty
+// first version if nothing exists yet. An index from before versioning,
+// which has the alias's own name, is left serving until a rebuild
+// replaces it.
 func (e *ElasticsearchClient) CreateIndex(ctx context.Context) error {
+	target, _, err := e.AliasTarget(ctx)
+	if err != nil {
+		return err
+	}
+	if target != "" {
+		e.logger.Info("Index already exists", "index", e.indexName, "target", target)
+		return nil
+	}
+
+	name := e.NewIndexName(time.Now())
+	if err := e.CreateIndexVersion(ctx, name); err != nil {
+		return err
+	}
+	return e.SwapAlias(ctx, name, "")
+}
+
+// CreateIndexVersion creates a physical index with the current mapping and
+// analysis settings.
+func (e *ElasticsearchClient) CreateIndexVersion(ctx context.Context, name string) error {
+	// Documents are indexed with event_text: lowercased, ASCII-folded and
+	// stemmed. Queries go through event_search, which adds the synonyms.
 	mapping := map[string]any{
 		"mappings": map[string]any{
+			"_meta": map[string]any{
+				"schema_version": indexSchemaVersion,
+			},
 			"properties": map[string]any{
 				"event_id":    map[string]any{"type": "keyword"},
 				"name": map[string]any{
-					"type":     "text",
-					"analyzer": "standard",
+					"type":            "text",
+					"analyzer":        "event_text",
+					"search_analyzer": "event_search",
 					"fields": map[string]any{
 						"keyword": map[string]any{"type": "keyword"},
 						"suggest": map[string]any{
@@ -58,23 +142,36 @@ func (e *ElasticsearchClient) CreateIndex(ctx context.Context) error {
 					},
 				},
 				"description": map[string]any{
-					"type":     "text",
-					"analyzer": "standard",
+					"type":            "text",
+					"analyzer":        "event_text",
+					"search_analyzer": "event_search",
 				},
 				"venue_id":      map[string]any{"type": "keyword"},
-				"venue_name":    map[string]any{"type": "text", "fields": map[string]any{"keyword": map[string]any{"type": "keyword"}}},
-				"venue_address": map[string]any{"type": "text"},
+				"venue_name":    map[string]any{"type": "text", "analyzer": "event_text", "search_analyzer": "event_search", "fields": map[string]any{"keyword": map[string]any{"type": "keyword"}}},
+				"venue_address": map[string]any{"type": "text", "analyzer": "event_text", "search_analyzer": "event_search"},
 				"venue_city":    map[string]any{"type": "keyword"},
 				"venue_state":   map[string]any{"type": "keyword"},
 				"venue_country": map[string]any{"type": "keyword"},
+				"location":      map[string]any{"type": "geo_point"},
 				"event_type":    map[string]any{"type": "keyword"},
+				"categories":    map[string]any{"type": "keyword"},
+				"tags":          map[string]any{"type": "keyword"},
 				"start_datetime": map[string]any{"type": "date"},
 				"end_datetime":   map[string]any{"type": "date"},
+				"timezone":       map[string]any{"type": "keyword"},
+				"start_date":     map[string]any{"type": "date", "format": "yyyy-MM-dd"},
+				"start_weekday":  map[string]any{"type": "byte"},
+				"start_minute":   map[string]any{"type": "short"},
 				"base_price":     map[string]any{"type": "float"},
+				"currency":       map[string]any{"type": "keyword"},
 				"available_seats": map[string]any{"type": "integer"},
 				"total_capacity":  map[string]any{"type": "integer"},
 				"status":         map[string]any{"type": "keyword"},
 				"version":        map[string]any{"type": "long"},
+				"trending_score": map[string]any{"type": "double"},
+				"image_url":      map[string]any{"type": "keyword", "index": false},
+				"thumbnail_url":  map[string]any{"type": "keyword", "index": false},
+				"banner_url":     map[string]any{"type": "keyword", "index": false},
 				"created_at":     map[string]any{"type": "date"},
 				"updated_at":     map[string]any{"type": "date"},
 			},
@@ -83,11 +180,31 @@ func (e *ElasticsearchClient) CreateIndex(ctx context.Context) error {
 			"number_of_shards":   1,
 			"number_of_replicas": 0,
 			"analysis": map[string]any{
+				"filter": map[string]any{
+					"english_possessive": map[string]any{
+						"type":     "stemmer",
+						"language": "possessive_english",
+					},
+					"english_stemmer": map[string]any{
+						"type":     "stemmer",
+						"language": "english",
+					},
+					"event_synonyms": map[string]any{
+						"type":         "synonym_graph",
+						"synonyms_set": e.SynonymsSet(),
+						"updateable":   true,
+					},
+				},
 				"analyzer": map[string]any{
+					"event_text": map[string]any{
+						"type":      "custom",
+						"tokenizer": "standard",
+						"filter":    []string{"lowercase", "asciifolding", "english_possessive", "english_stemmer"},
+					},
 					"event_search": map[string]any{
 						"type":      "custom",
 						"tokenizer": "standard",
-						"filter":    []string{"lowercase", "stop"},
+						"filter":    []string{"lowercase", "asciifolding", "event_synonyms", "english_possessive", "english_stemmer"},
 					},
 				},
 			},
@@ -100,7 +217,7 @@ func (e *ElasticsearchClient) CreateIndex(ctx context.Context) error {
 	}
 
 	req := esapi.IndicesCreateRequest{
-		Index: e.indexName,
+		Index: name,
 		Body:  &buf,
 	}
 
@@ -111,17 +228,181 @@ func (e *ElasticsearchClient) CreateIndex(ctx context.Context) error {
 	defer res.Body.Close()
 
 	if res.IsError() {
-		if strings.Contains(res.String(), "resource_already_exists_exception") {
-			e.logger.Info("Index already exists", "index", e.indexName)
-			return nil
-		}
 		return fmt.Errorf("failed to create index: %s", res.String())
 	}
 
-	e.logger.Info("Created Elasticsearch index", "index", e.indexName)
+	e.logger.Info("Created Elasticsearch index", "index", name)
+	return nil
+}
+
+// AliasTarget returns the index the alias points to. legacy is true when
+// the name belongs to a plain index from before versioning; target is
+// empty when neither exists.
+func (e *ElasticsearchClient) AliasTarget(ctx context.Context) (target string, legacy bool, err error) {
+	req := esapi.IndicesGetAliasRequest{
+		Name: []string{e.indexName},
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		exists := esapi.IndicesExistsRequest{Index: []string{e.indexName}}
+		existsRes, err := exists.Do(ctx, e.client)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to check index: %w", err)
+		}
+		defer existsRes.Body.Close()
+		if existsRes.StatusCode == 200 {
+			return e.indexName, true, nil
+		}
+		return "", false, nil
+	}
+	if res.IsError() {
+		return "", false, fmt.Errorf("failed to get alias: %s", res.String())
+	}
+
+	var result map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", false, fmt.Errorf("failed to decode alias: %w", err)
+	}
+	for name := range result {
+		return name, false, nil
+	}
+	return "", false, nil
+}
+
+// SwapAlias points the alias at target in one atomic step. dropIndex, if
+// set, is deleted in the same step; that is how a pre-versioning index
+// with the alias's name makes way for it.
+func (e *ElasticsearchClient) SwapAlias(ctx context.Context, target, dropIndex string) error {
+	actions := []any{
+		map[string]any{
+			"remove": map[string]any{
+				"index":      e.versionPrefix() + "*",
+				"alias":      e.indexName,
+				"must_exist": false,
+			},
+		},
+	}
+	if dropIndex != "" {
+		actions = append(actions, map[string]any{
+			"remove_index": map[string]any{"index": dropIndex},
+		})
+	}
+	actions = append(actions, map[string]any{
+		"add": map[string]any{
+			"index":          target,
+			"alias":          e.indexName,
+			"is_write_index": true,
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]any{"actions": actions}); err != nil {
+		return fmt.Errorf("failed to encode alias actions: %w", err)
+	}
+
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: &buf,
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return fmt.Errorf("failed to update alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to update alias: %s", res.String())
+	}
+
+	e.logger.Info("Pointed index alias at new version", "alias", e.indexName, "index", target)
 	return nil
 }
 
+// ListIndexVersions returns every index version, newest first.
+func (e *ElasticsearchClient) ListIndexVersions(ctx context.Context) ([]IndexVersion, error) {
+	target, _, err := e.AliasTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := esapi.CatIndicesRequest{
+		Index:  []string{e.versionPrefix() + "*"},
+		Format: "json",
+		H:      []string{"index", "docs.count", "creation.date"},
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indices: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to list indices: %s", res.String())
+	}
+
+	var rows []map[string]string
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to decode indices: %w", err)
+	}
+
+	versions := make([]IndexVersion, 0, len(rows))
+	for _, row := range rows {
+		documents, _ := strconv.ParseInt(row["docs.count"], 10, 64)
+		created, _ := strconv.ParseInt(row["creation.date"], 10, 64)
+		versions = append(versions, IndexVersion{
+			Name:      row["index"],
+			Documents: documents,
+			CreatedAt: time.UnixMilli(created).UTC(),
+			Active:    row["index"] == target,
+		})
+	}
+	// The timestamp suffix makes names sort by age within a schema version,
+	// and creation time orders across them.
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+	return versions, nil
+}
+
+// CountDocuments refreshes an index and counts its documents.
+func (e *ElasticsearchClient) CountDocuments(ctx context.Context, index string) (int64, error) {
+	refresh := esapi.IndicesRefreshRequest{Index: []string{index}}
+	refreshRes, err := refresh.Do(ctx, e.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh index: %w", err)
+	}
+	refreshRes.Body.Close()
+
+	req := esapi.CountRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("failed to count documents: %s", res.String())
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode count: %w", err)
+	}
+	return result.Count, nil
+}
+
 func (e *ElasticsearchClient) IndexEvent(ctx context.Context, eventID uuid.UUID, doc EventDocument) error {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(doc); err != nil {
@@ -170,34 +451,149 @@ func (e *ElasticsearchClient) DeleteEvent(ctx context.Context, eventID uuid.UUID
 
 func (e *ElasticsearchClient) Search(ctx context.Context, searchReq SearchRequest) (*SearchResponse, error) {
 	query := e.buildSearchQuery(searchReq)
-	
+
+	searchResult, queryTime, err := e.runSearch(ctx, []string{e.indexName}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.parseSearchResponse(searchResult, searchReq, queryTime)
+}
+
+// ErrCursorExpired means the point in time a cursor refers to has been
+// closed or has timed out.
+var ErrCursorExpired = errors.New("search cursor has expired")
+
+// SearchPage is one page of a cursor walk: the point in time to keep
+// reading from and the sort values of its last hit.
+type SearchPage struct {
+	PitID string
+	After []any
+}
+
+// SearchAfter runs a search against a point in time, starting after the
+// given sort values, or from the top if there are none. Facets are left
+// out; they belong to the first page of a search, not to every page.
+func (e *ElasticsearchClient) SearchAfter(ctx context.Context, searchReq SearchRequest, pitID string, after []any) (*SearchResponse, *SearchPage, error) {
+	query := e.buildSearchQuery(searchReq)
+	delete(query, "from")
+	delete(query, "aggs")
+	query["track_total_hits"] = true
+	query["pit"] = map[string]any{
+		"id":         pitID,
+		"keep_alive": pointInTimeKeepAlive,
+	}
+	if len(after) > 0 {
+		query["search_after"] = after
+	}
+
+	// A point in time already names its index.
+	searchResult, queryTime, err := e.runSearch(ctx, nil, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := e.parseSearchResponse(searchResult, searchReq, queryTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &SearchPage{PitID: pitID}
+	if id, ok := searchResult["pit_id"].(string); ok && id != "" {
+		page.PitID = id
+	}
+	if hits, ok := searchResult["hits"].(map[string]any); ok {
+		if hitsArray, ok := hits["hits"].([]any); ok && len(hitsArray) > 0 {
+			if last, ok := hitsArray[len(hitsArray)-1].(map[string]any); ok {
+				page.After, _ = last["sort"].([]any)
+			}
+		}
+	}
+
+	return result, page, nil
+}
+
+func (e *ElasticsearchClient) runSearch(ctx context.Context, indices []string, query map[string]any) (map[string]any, time.Duration, error) {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
-		return nil, fmt.Errorf("failed to encode search query: %w", err)
+		return nil, 0, fmt.Errorf("failed to encode search query: %w", err)
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{e.indexName},
+		Index: indices,
 		Body:  &buf,
 	}
 
 	start := time.Now()
 	res, err := req.Do(ctx, e.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute search: %w", err)
+		return nil, 0, fmt.Errorf("failed to execute search: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("search failed: %s", res.String())
+		if res.StatusCode == 404 && strings.Contains(res.String(), "search_context_missing_exception") {
+			return nil, 0, ErrCursorExpired
+		}
+		return nil, 0, fmt.Errorf("search failed: %s", res.String())
 	}
 
 	var searchResult map[string]any
 	if err := json.NewDecoder(res.Body).Decode(&searchResult); err != nil {
-		return nil, fmt.Errorf("failed to decode search result: %w", err)
+		return nil, 0, fmt.Errorf("failed to decode search result: %w", err)
+	}
+
+	return searchResult, time.Since(start), nil
+}
+
+// OpenPointInTime pins the current state of the index for a cursor walk.
+func (e *ElasticsearchClient) OpenPointInTime(ctx context.Context) (string, error) {
+	req := esapi.OpenPointInTimeRequest{
+		Index:     []string{e.indexName},
+		KeepAlive: pointInTimeKeepAlive,
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to open point in time: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("failed to open point in time: %s", res.String())
 	}
 
-	return e.parseSearchResponse(searchResult, searchReq, time.Since(start))
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode point in time: %w", err)
+	}
+	return result.ID, nil
+}
+
+// ClosePointInTime releases a point in time once a walk reaches its end.
+// Abandoned walks are released by Elasticsearch when keep-alive runs out.
+func (e *ElasticsearchClient) ClosePointInTime(ctx context.Context, pitID string) error {
+	body, err := json.Marshal(map[string]string{"id": pitID})
+	if err != nil {
+		return fmt.Errorf("failed to encode point in time: %w", err)
+	}
+
+	req := esapi.ClosePointInTimeRequest{
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return fmt.Errorf("failed to close point in time: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("failed to close point in time: %s", res.String())
+	}
+	return nil
 }
 
 func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any {
@@ -225,13 +621,7 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 				"filter": []any{},
 			},
 		},
-		"sort": []any{
-			map[string]any{
-				"start_datetime": map[string]any{
-					"order": "asc",
-				},
-			},
-		},
+		"sort": searchSort(req),
 		"aggs": map[string]any{
 			"cities": map[string]any{
 				"terms": map[string]any{
@@ -245,6 +635,18 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 					"size":  20,
 				},
 			},
+			"categories": map[string]any{
+				"terms": map[string]any{
+					"field": "categories",
+					"size":  50,
+				},
+			},
+			"tags": map[string]any{
+				"terms": map[string]any{
+					"field": "tags",
+					"size":  30,
+				},
+			},
 			"price_stats": map[string]any{
 				"stats": map[string]any{
 					"field": "base_price",
@@ -255,21 +657,48 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 
 	boolQuery := query["query"].(map[string]any)["bool"].(map[string]any)
 
+	// A query has to match exactly (after stemming and synonyms) or within
+	// a typo or two, with exact matches scoring higher. Matching it as a
+	// phrase scores higher still.
 	if req.Query != "" {
 		boolQuery["must"] = append(boolQuery["must"].([]any), map[string]any{
-			"multi_match": map[string]any{
-				"query": req.Query,
-				"fields": []string{
-					"name^3",
-					"description^2",
-					"venue_name^2",
-					"venue_city",
-					"event_type",
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{
+						"multi_match": map[string]any{
+							"query":                req.Query,
+							"fields":               searchTextFields,
+							"type":                 "best_fields",
+							"minimum_should_match": "75%",
+							"boost":                2,
+						},
+					},
+					map[string]any{
+						"multi_match": map[string]any{
+							"query":                req.Query,
+							"fields":               searchTextFields,
+							"type":                 "best_fields",
+							"fuzziness":            "AUTO",
+							"prefix_length":        1,
+							"max_expansions":       50,
+							"minimum_should_match": "75%",
+						},
+					},
 				},
-				"type":                "best_fields",
-				"minimum_should_match": "75%",
+				"minimum_should_match": 1,
 			},
 		})
+		boolQuery["should"] = []any{
+			map[string]any{
+				"multi_match": map[string]any{
+					"query":  req.Query,
+					"fields": searchPhraseFields,
+					"type":   "phrase",
+					"slop":   1,
+					"boost":  3,
+				},
+			},
+		}
 	}
 
 	filters := boolQuery["filter"].([]any)
@@ -277,20 +706,41 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 	if req.City != "" {
 		filters = append(filters, map[string]any{
 			"term": map[string]any{
-				"venue_city": req.City,
+				"venue_city": map[string]any{"value": req.City, "case_insensitive": true},
 			},
 		})
 	}
 
+	// A type matches events of that category and, for a top-level
+	// category, of its subcategories. Documents indexed before categories
+	// existed only have event_type.
 	if req.EventType != "" {
+		filters = append(filters, map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"term": map[string]any{"event_type": req.EventType}},
+					map[string]any{"term": map[string]any{"categories": req.EventType}},
+				},
+				"minimum_should_match": 1,
+			},
+		})
+	}
+
+	for _, tag := range req.Tags {
 		filters = append(filters, map[string]any{
 			"term": map[string]any{
-				"event_type": req.EventType,
+				"tags": tag,
 			},
 		})
 	}
 
 	if req.DateFrom != "" || req.DateTo != "" {
+		// Plain dates are days at each event's venue and match the local
+		// start date; full timestamps are instants.
+		dateField := "start_datetime"
+		if isCalendarDate(req.DateFrom) && isCalendarDate(req.DateTo) {
+			dateField = "start_date"
+		}
 		dateRange := map[string]any{}
 		if req.DateFrom != "" {
 			dateRange["gte"] = req.DateFrom
@@ -300,7 +750,15 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 		}
 		filters = append(filters, map[string]any{
 			"range": map[string]any{
-				"start_datetime": dateRange,
+				dateField: dateRange,
+			},
+		})
+	}
+
+	if req.Currency != "" {
+		filters = append(filters, map[string]any{
+			"term": map[string]any{
+				"currency": req.Currency,
 			},
 		})
 	}
@@ -320,11 +778,71 @@ func (e *ElasticsearchClient) buildSearchQuery(req SearchRequest) map[string]any
 		})
 	}
 
+	if req.Near != nil && req.RadiusKm > 0 {
+		filters = append(filters, map[string]any{
+			"geo_distance": map[string]any{
+				"distance": fmt.Sprintf("%gkm", req.RadiusKm),
+				"location": req.Near,
+			},
+		})
+	}
+
+	if seats := minimumSeats(req); seats > 0 {
+		filters = append(filters, map[string]any{
+			"range": map[string]any{
+				"available_seats": map[string]any{"gte": seats},
+			},
+		})
+	}
+
+	if req.VenueID != "" {
+		filters = append(filters, map[string]any{
+			"term": map[string]any{
+				"venue_id": req.VenueID,
+			},
+		})
+	}
+
+	// Weekday and time of day are the venue's, worked out at indexing.
+	if len(req.Days) > 0 {
+		filters = append(filters, map[string]any{
+			"terms": map[string]any{
+				"start_weekday": req.Days,
+			},
+		})
+	}
+
+	if req.TimeFrom != "" || req.TimeTo != "" {
+		filters = append(filters, minuteFilter(req.TimeFrom, req.TimeTo))
+	}
+
 	boolQuery["filter"] = filters
 
 	return query
 }
 
+// distanceSortIndex is the position of the distance in a hit's sort
+// values: first for sort=distance, last otherwise.
+func distanceSortIndex(req SearchRequest) int {
+	if req.Near == nil {
+		return -1
+	}
+	if req.Sort == SortDistance {
+		return 0
+	}
+	return len(searchSort(req)) - 1
+}
+
+// isCalendarDate reports whether a date filter is a bare YYYY-MM-DD date.
+// An empty bound does not force either field.
+func isCalendarDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
+}
+
 func (e *ElasticsearchClient) parseSearchResponse(result map[string]any, req SearchRequest, queryTime time.Duration) (*SearchResponse, error) {
 	hits, ok := result["hits"].(map[string]any)
 	if !ok {
@@ -374,14 +892,36 @@ func (e *ElasticsearchClient) parseSearchResponse(result map[string]any, req Sea
 				VenueCity:     utils.GetStringFromInterface(source["venue_city"]),
 				VenueAddress:  utils.GetStringFromInterface(source["venue_address"]),
 				EventType:     utils.GetStringFromInterface(source["event_type"]),
+				Categories:    utils.GetStringsFromInterface(source["categories"]),
+				Tags:          utils.GetStringsFromInterface(source["tags"]),
 				StartDateTime: startDateTime,
 				EndDateTime:   endDateTime,
+				Timezone:      utils.GetStringFromInterface(source["timezone"]),
 				BasePrice:     utils.GetFloatFromInterface(source["base_price"]),
+				Currency:      utils.GetStringFromInterface(source["currency"]),
 				AvailableSeats: int32(utils.GetFloatFromInterface(source["available_seats"])),
 				Status:        utils.GetStringFromInterface(source["status"]),
+				ImageURL:      utils.GetStringFromInterface(source["image_url"]),
+				ThumbnailURL:  utils.GetStringFromInterface(source["thumbnail_url"]),
 				Score:         score,
 			}
 
+			// Venues without coordinates get an infinite sort distance,
+			// which is left out rather than reported.
+			if i := distanceSortIndex(req); i >= 0 {
+				if sortValues, ok := hitMap["sort"].([]any); ok && len(sortValues) > i {
+					if distance, ok := sortValues[i].(float64); ok && !math.IsInf(distance, 0) && distance < maxSortDistanceKm {
+						event.DistanceKm = &distance
+					}
+				}
+			}
+
+			if req.Sort == SortTrending || req.Sort == SortPopularity {
+				if trending, ok := source["trending_score"].(float64); ok {
+					event.TrendingScore = &trending
+				}
+			}
+
 			if desc, ok := source["description"]; ok && desc != nil {
 				event.Description = utils.GetStringFromInterface(desc)
 			}
@@ -392,31 +932,10 @@ func (e *ElasticsearchClient) parseSearchResponse(result map[string]any, req Sea
 
 	facets := SearchFacets{}
 	if aggs, ok := result["aggregations"].(map[string]any); ok {
-		if cities, ok := aggs["cities"].(map[string]any); ok {
-			if buckets, ok := cities["buckets"].([]any); ok {
-				for _, bucket := range buckets {
-					if b, ok := bucket.(map[string]any); ok {
-						facets.Cities = append(facets.Cities, FacetItem{
-							Value: utils.GetStringFromInterface(b["key"]),
-							Count: int64(utils.GetFloatFromInterface(b["doc_count"])),
-						})
-					}
-				}
-			}
-		}
-
-		if eventTypes, ok := aggs["event_types"].(map[string]any); ok {
-			if buckets, ok := eventTypes["buckets"].([]any); ok {
-				for _, bucket := range buckets {
-					if b, ok := bucket.(map[string]any); ok {
-						facets.EventTypes = append(facets.EventTypes, FacetItem{
-							Value: utils.GetStringFromInterface(b["key"]),
-							Count: int64(utils.GetFloatFromInterface(b["doc_count"])),
-						})
-					}
-				}
-			}
-		}
+		facets.Cities = termsFacet(aggs, "cities")
+		facets.EventTypes = termsFacet(aggs, "event_types")
+		facets.Categories = termsFacet(aggs, "categories")
+		facets.Tags = termsFacet(aggs, "tags")
 
 		if priceStats, ok := aggs["price_stats"].(map[string]any); ok {
 			if min, ok := priceStats["min"]; ok && min != nil {
@@ -438,6 +957,28 @@ func (e *ElasticsearchClient) parseSearchResponse(result map[string]any, req Sea
 	}, nil
 }
 
+// termsFacet reads the buckets of a terms aggregation.
+func termsFacet(aggs map[string]any, name string) []FacetItem {
+	agg, ok := aggs[name].(map[string]any)
+	if !ok {
+		return nil
+	}
+	buckets, ok := agg["buckets"].([]any)
+	if !ok {
+		return nil
+	}
+	var items []FacetItem
+	for _, bucket := range buckets {
+		if b, ok := bucket.(map[string]any); ok {
+			items = append(items, FacetItem{
+				Value: utils.GetStringFromInterface(b["key"]),
+				Count: int64(utils.GetFloatFromInterface(b["doc_count"])),
+			})
+		}
+	}
+	return items
+}
+
 func (e *ElasticsearchClient) GetSuggestions(ctx context.Context, query string, limit int) ([]string, error) {
 	searchQuery := map[string]any{
 		"suggest": map[string]any{
@@ -494,18 +1035,216 @@ func (e *ElasticsearchClient) GetSuggestions(ctx context.Context, query string,
 	return suggestions, nil
 }
 
+// GetDocuments fetches indexed events by ID, in the order asked for.
+// Events that are not in the index are left out.
+func (e *ElasticsearchClient) GetDocuments(ctx context.Context, eventIDs []uuid.UUID) ([]EventDocument, error) {
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(eventIDs))
+	for i, eventID := range eventIDs {
+		ids[i] = eventID.String()
+	}
+	body, err := json.Marshal(map[string]any{"ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document IDs: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Index: e.indexName,
+		Body:  bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get documents: %s", res.String())
+	}
+
+	var result struct {
+		Docs []struct {
+			Found  bool          `json:"found"`
+			Source EventDocument `json:"_source"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	var documents []EventDocument
+	for _, doc := range result.Docs {
+		if doc.Found {
+			documents = append(documents, doc.Source)
+		}
+	}
+	return documents, nil
+}
+
+// Recommend finds upcoming published events that resemble the liked events
+// in wording, or share their type, venue, categories, tags or cities. An
+// event has to match at least one of those; the best matches come first,
+// then the most trending.
+func (e *ElasticsearchClient) Recommend(ctx context.Context, rq RecommendationQuery) (*SearchResponse, error) {
+	if rq.Limit <= 0 {
+		rq.Limit = 10
+	}
+
+	var should []any
+	if len(rq.Like) > 0 {
+		like := make([]any, len(rq.Like))
+		for i, eventID := range rq.Like {
+			like[i] = map[string]any{"_id": eventID.String()}
+		}
+		should = append(should, map[string]any{
+			"more_like_this": map[string]any{
+				"fields":               []string{"name", "description", "tags"},
+				"like":                 like,
+				"min_term_freq":        1,
+				"min_doc_freq":         1,
+				"max_query_terms":      25,
+				"minimum_should_match": "20%",
+				"boost":                3,
+			},
+		})
+	}
+
+	// Tags and cities are typed by people, so they match regardless of
+	// case; terms queries cannot, hence one term query per value.
+	termsClause := func(field string, values []string, boost float64, anyCase bool) {
+		if len(values) == 0 {
+			return
+		}
+		if !anyCase {
+			should = append(should, map[string]any{
+				"terms": map[string]any{
+					field:   values,
+					"boost": boost,
+				},
+			})
+			return
+		}
+		terms := make([]any, len(values))
+		for i, value := range values {
+			terms[i] = map[string]any{
+				"term": map[string]any{
+					field: map[string]any{"value": value, "case_insensitive": true},
+				},
+			}
+		}
+		should = append(should, map[string]any{
+			"bool": map[string]any{
+				"should": terms,
+				"boost":  boost,
+			},
+		})
+	}
+	termsClause("event_type", rq.EventTypes, 2, false)
+	termsClause("categories", rq.Categories, 1.5, false)
+	termsClause("tags", rq.Tags, 1, true)
+	termsClause("venue_city", rq.Cities, 0.5, true)
+	if len(rq.VenueIDs) > 0 {
+		venues := make([]string, len(rq.VenueIDs))
+		for i, venueID := range rq.VenueIDs {
+			venues[i] = venueID.String()
+		}
+		termsClause("venue_id", venues, 1.5, false)
+	}
+
+	if len(should) == 0 {
+		return &SearchResponse{Results: []EventSearchResult{}, Page: 1, Limit: rq.Limit}, nil
+	}
+
+	boolQuery := map[string]any{
+		"filter": []any{
+			map[string]any{"term": map[string]any{"status": "published"}},
+			map[string]any{"range": map[string]any{"start_datetime": map[string]any{"gte": "now"}}},
+		},
+		"should":               should,
+		"minimum_should_match": 1,
+	}
+	if len(rq.Exclude) > 0 {
+		excluded := make([]string, len(rq.Exclude))
+		for i, eventID := range rq.Exclude {
+			excluded[i] = eventID.String()
+		}
+		boolQuery["must_not"] = []any{
+			map[string]any{"ids": map[string]any{"values": excluded}},
+		}
+	}
+
+	query := map[string]any{
+		"size":  rq.Limit,
+		"query": map[string]any{"bool": boolQuery},
+		"sort": []any{
+			"_score",
+			map[string]any{"trending_score": map[string]any{"order": "desc", "missing": "_last"}},
+			map[string]any{"start_datetime": map[string]any{"order": "asc"}},
+		},
+	}
+
+	searchResult, queryTime, err := e.runSearch(ctx, []string{e.indexName}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.parseSearchResponse(searchResult, SearchRequest{Page: 1, Limit: rq.Limit}, queryTime)
+}
+
+func (e *ElasticsearchClient) Name() string {
+	return BackendElasticsearch
+}
+
+// IndexEvents indexes documents into the live index and returns how many
+// failed.
+func (e *ElasticsearchClient) IndexEvents(ctx context.Context, events []EventDocument) (int, error) {
+	return e.BulkIndexInto(ctx, e.indexName, events, false)
+}
+
+func (e *ElasticsearchClient) DeleteEvents(ctx context.Context, eventIDs []uuid.UUID) (int, error) {
+	return e.BulkDelete(ctx, eventIDs)
+}
+
+// GetFacets aggregates the filter values across all published events.
+func (e *ElasticsearchClient) GetFacets(ctx context.Context) (*SearchFacets, error) {
+	result, err := e.Search(ctx, SearchRequest{Page: 1, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	return &result.Facets, nil
+}
+
 func (e *ElasticsearchClient) BulkIndex(ctx context.Context, events []EventDocument) error {
+	_, err := e.BulkIndexInto(ctx, e.indexName, events, false)
+	return err
+}
+
+// BulkIndexInto indexes documents into a given index and returns how many
+// of them failed. versioned writes use each event's version as an external
+// version, so when a document arrives twice while an index is being built,
+// the newer one wins whichever lands first; such writes are not refreshed
+// and the stale side of a race is not counted as a failure.
+func (e *ElasticsearchClient) BulkIndexInto(ctx context.Context, index string, events []EventDocument, versioned bool) (int, error) {
 	if len(events) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	var buf bytes.Buffer
 	for _, event := range events {
+		action := map[string]any{
+			"_index": index,
+			"_id":    event.EventID.String(),
+		}
+		if versioned {
+			action["version"] = event.Version
+			action["version_type"] = "external_gte"
+		}
 		meta := map[string]any{
-			"index": map[string]any{
-				"_index": e.indexName,
-				"_id":    event.EventID.String(),
-			},
+			"index": action,
 		}
 
 		metaBytes, _ := json.Marshal(meta)
@@ -521,26 +1260,296 @@ func (e *ElasticsearchClient) BulkIndex(ctx context.Context, events []EventDocum
 		Body:    &buf,
 		Refresh: "true",
 	}
+	if versioned {
+		req.Refresh = ""
+	}
 
 	res, err := req.Do(ctx, e.client)
 	if err != nil {
-		return fmt.Errorf("failed to bulk index: %w", err)
+		return 0, fmt.Errorf("failed to bulk index: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("bulk index failed: %s", res.String())
+		return 0, fmt.Errorf("bulk index failed: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+
+	failed := 0
+	if result.Errors {
+		for _, item := range result.Items {
+			for _, outcome := range item {
+				if outcome.Status >= 300 && outcome.Status != 409 {
+					failed++
+				}
+			}
+		}
+	}
+
+	e.logger.Info("Bulk indexed events", "index", index, "count", len(events), "failed", failed)
+	return failed, nil
+}
+
+// DeleteEventFrom removes a document from a given index.
+func (e *ElasticsearchClient) DeleteEventFrom(ctx context.Context, index string, eventID uuid.UUID) error {
+	req := esapi.DeleteRequest{
+		Index:      index,
+		DocumentID: eventID.String(),
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("failed to delete document: %s", res.String())
 	}
 
-	e.logger.Info("Bulk indexed events", "count", len(events))
 	return nil
 }
 
-func (e *ElasticsearchClient) DeleteIndex(ctx context.Context) error {
-	req := esapi.IndicesDeleteRequest{
+// BulkDelete removes documents from the index and returns how many of them
+// failed. Documents that are already gone do not count as failures.
+func (e *ElasticsearchClient) BulkDelete(ctx context.Context, eventIDs []uuid.UUID) (int, error) {
+	if len(eventIDs) == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	for _, eventID := range eventIDs {
+		meta := map[string]any{
+			"delete": map[string]any{
+				"_index": e.indexName,
+				"_id":    eventID.String(),
+			},
+		}
+		metaBytes, _ := json.Marshal(meta)
+		buf.Write(metaBytes)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{
+		Body:    &buf,
+		Refresh: "true",
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk delete: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("bulk delete failed: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+
+	failed := 0
+	if result.Errors {
+		for _, item := range result.Items {
+			for _, outcome := range item {
+				if outcome.Status >= 300 && outcome.Status != 404 {
+					failed++
+				}
+			}
+		}
+	}
+
+	return failed, nil
+}
+
+// UpdateTrendingScores sets the trending score on existing documents.
+// Documents that have left the index are skipped.
+func (e *ElasticsearchClient) UpdateTrendingScores(ctx context.Context, scores map[uuid.UUID]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for eventID, score := range scores {
+		meta := map[string]any{
+			"update": map[string]any{
+				"_index": e.indexName,
+				"_id":    eventID.String(),
+			},
+		}
+		metaBytes, _ := json.Marshal(meta)
+		buf.Write(metaBytes)
+		buf.WriteByte('\n')
+
+		docBytes, _ := json.Marshal(map[string]any{
+			"doc": map[string]any{"trending_score": score},
+		})
+		buf.Write(docBytes)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{
+		Body: &buf,
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return fmt.Errorf("failed to update trending scores: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("trending score update failed: %s", res.String())
+	}
+
+	return nil
+}
+
+// IndexSchemaVersion reads the schema version an existing index was
+// created with; indices from before versioning report 1.
+func (e *ElasticsearchClient) IndexSchemaVersion(ctx context.Context) (int, error) {
+	req := esapi.IndicesGetMappingRequest{
 		Index: []string{e.indexName},
 	}
 
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("failed to get mapping: %s", res.String())
+	}
+
+	var result map[string]struct {
+		Mappings struct {
+			Meta struct {
+				SchemaVersion int `json:"schema_version"`
+			} `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode mapping: %w", err)
+	}
+
+	version := 1
+	for _, index := range result {
+		if index.Mappings.Meta.SchemaVersion > version {
+			version = index.Mappings.Meta.SchemaVersion
+		}
+	}
+	return version, nil
+}
+
+// GetSynonyms returns the rules in the synonyms set, in Solr format. The
+// bool is false if the set does not exist yet.
+func (e *ElasticsearchClient) GetSynonyms(ctx context.Context) ([]string, bool, error) {
+	size := maxSynonymRules
+	req := esapi.SynonymsGetSynonymRequest{
+		DocumentID: e.SynonymsSet(),
+		Size:       &size,
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, false, nil
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("failed to get synonyms: %s", res.String())
+	}
+
+	var result struct {
+		SynonymsSet []struct {
+			Synonyms string `json:"synonyms"`
+		} `json:"synonyms_set"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, false, fmt.Errorf("failed to decode synonyms: %w", err)
+	}
+
+	rules := make([]string, len(result.SynonymsSet))
+	for i, rule := range result.SynonymsSet {
+		rules[i] = rule.Synonyms
+	}
+	return rules, true, nil
+}
+
+// PutSynonyms replaces every rule in the synonyms set. Elasticsearch reloads
+// the search analyzers that use it before returning.
+func (e *ElasticsearchClient) PutSynonyms(ctx context.Context, rules []string) error {
+	set := make([]map[string]string, len(rules))
+	for i, rule := range rules {
+		set[i] = map[string]string{"synonyms": rule}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]any{"synonyms_set": set}); err != nil {
+		return fmt.Errorf("failed to encode synonyms: %w", err)
+	}
+
+	req := esapi.SynonymsPutSynonymRequest{
+		DocumentID: e.SynonymsSet(),
+		Body:       &buf,
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return fmt.Errorf("failed to put synonyms: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to put synonyms: %s", res.String())
+	}
+
+	return nil
+}
+
+// EnsureSynonyms creates the synonyms set with the default rules if it does
+// not exist. The index cannot be created without it.
+func (e *ElasticsearchClient) EnsureSynonyms(ctx context.Context) error {
+	_, exists, err := e.GetSynonyms(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if err := e.PutSynonyms(ctx, defaultSynonyms); err != nil {
+		return err
+	}
+	e.logger.Info("Created synonyms set with default rules", "set", e.SynonymsSet(), "rules", len(defaultSynonyms))
+	return nil
+}
+
+func (e *ElasticsearchClient) DeleteIndex(ctx context.Context, name string) error {
+	req := esapi.IndicesDeleteRequest{
+		Index: []string{name},
+	}
+
 	res, err := req.Do(ctx, e.client)
 	if err != nil {
 		return fmt.Errorf("failed to delete index: %w", err)
@@ -551,7 +1560,7 @@ func (e *ElasticsearchClient) DeleteIndex(ctx context.Context) error {
 		return fmt.Errorf("failed to delete index: %s", res.String())
 	}
 
-	e.logger.Info("Deleted Elasticsearch index", "index", e.indexName)
+	e.logger.Info("Deleted Elasticsearch index", "index", name)
 	return nil
 }
 
@@ -0,0 +1,300 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+// fakeElasticsearch answers the index management, bulk, delete and count
+// requests an index rebuild makes, keeping indices in memory. Searches are
+// not supported.
+type fakeElasticsearch struct {
+	mu      sync.Mutex
+	alias   string
+	target  string
+	indices map[string]*fakeIndex
+	created int64
+}
+
+type fakeIndex struct {
+	created  int64
+	versions map[string]int64 // document ID to version
+}
+
+// newFakeElasticsearch returns a client for a fresh fakeElasticsearch
+// whose alias is named alias.
+func newFakeElasticsearch(t *testing.T, alias string) (*ElasticsearchClient, *fakeElasticsearch) {
+	t.Helper()
+	fake := &fakeElasticsearch{alias: alias, indices: map[string]*fakeIndex{}, created: 1_700_000_000_000}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /_alias/{name}", fake.getAlias)
+	mux.HandleFunc("HEAD /{index}", fake.existsIndex)
+	mux.HandleFunc("PUT /{index}", fake.createIndex)
+	mux.HandleFunc("DELETE /{index}", fake.deleteIndex)
+	mux.HandleFunc("POST /_aliases", fake.updateAliases)
+	mux.HandleFunc("GET /_cat/indices/{pattern}", fake.catIndices)
+	mux.HandleFunc("POST /{index}/_refresh", func(w http.ResponseWriter, r *http.Request) { fake.respond(w, http.StatusOK, map[string]any{}) })
+	mux.HandleFunc("POST /{index}/_count", fake.count)
+	mux.HandleFunc("POST /_bulk", fake.bulk)
+	mux.HandleFunc("DELETE /{index}/_doc/{id}", fake.deleteDocument)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewElasticsearchClient(server.URL, alias, logger.New("error"))
+	if err != nil {
+		t.Fatalf("NewElasticsearchClient error = %v", err)
+	}
+	return client, fake
+}
+
+// addIndex creates an index holding the given document IDs at version 1.
+func (f *fakeElasticsearch) addIndex(name string, ids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index := f.newIndex(name)
+	for _, id := range ids {
+		index.versions[id] = 1
+	}
+}
+
+// pointAlias points the alias at an existing index.
+func (f *fakeElasticsearch) pointAlias(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.target = name
+}
+
+func (f *fakeElasticsearch) aliasTarget() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.target
+}
+
+// indexNames returns the names of every index, sorted.
+func (f *fakeElasticsearch) indexNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.indices))
+	for name := range f.indices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// documents returns the sorted document IDs of an index.
+func (f *fakeElasticsearch) documents(name string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, ok := f.indices[name]
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0, len(index.versions))
+	for id := range index.versions {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (f *fakeElasticsearch) newIndex(name string) *fakeIndex {
+	f.created += 1000
+	index := &fakeIndex{created: f.created, versions: map[string]int64{}}
+	f.indices[name] = index
+	return index
+}
+
+func (f *fakeElasticsearch) respond(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeElasticsearch) getAlias(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.PathValue("name") != f.alias || f.target == "" {
+		f.respond(w, http.StatusNotFound, map[string]any{"error": "alias missing"})
+		return
+	}
+	f.respond(w, http.StatusOK, map[string]any{f.target: map[string]any{"aliases": map[string]any{f.alias: map[string]any{}}}})
+}
+
+func (f *fakeElasticsearch) existsIndex(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	if _, ok := f.indices[r.PathValue("index")]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeElasticsearch) createIndex(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := r.PathValue("index")
+	if _, ok := f.indices[name]; ok {
+		f.respond(w, http.StatusBadRequest, map[string]any{"error": "resource_already_exists_exception"})
+		return
+	}
+	f.newIndex(name)
+	f.respond(w, http.StatusOK, map[string]any{"acknowledged": true, "index": name})
+}
+
+func (f *fakeElasticsearch) deleteIndex(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := r.PathValue("index")
+	if _, ok := f.indices[name]; !ok {
+		f.respond(w, http.StatusNotFound, map[string]any{"error": "index_not_found_exception"})
+		return
+	}
+	delete(f.indices, name)
+	if f.target == name {
+		f.target = ""
+	}
+	f.respond(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+// updateAliases applies the actions as one step, as Elasticsearch does:
+// nothing changes if any of them fails.
+func (f *fakeElasticsearch) updateAliases(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Actions []map[string]struct {
+			Index string `json:"index"`
+			Alias string `json:"alias"`
+		} `json:"actions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.respond(w, http.StatusBadRequest, map[string]any{"error": err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	target, drop := f.target, ""
+	for _, action := range body.Actions {
+		for kind, args := range action {
+			switch kind {
+			case "remove":
+				if matched, _ := path.Match(args.Index, target); matched {
+					target = ""
+				}
+			case "remove_index":
+				drop = args.Index
+			case "add":
+				if _, ok := f.indices[args.Index]; !ok || args.Alias != f.alias {
+					f.respond(w, http.StatusNotFound, map[string]any{"error": "index_not_found_exception"})
+					return
+				}
+				target = args.Index
+			}
+		}
+	}
+	if drop != "" {
+		delete(f.indices, drop)
+	}
+	f.target = target
+	f.respond(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+func (f *fakeElasticsearch) catIndices(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rows := []map[string]string{}
+	for name, index := range f.indices {
+		if matched, _ := path.Match(r.PathValue("pattern"), name); matched {
+			rows = append(rows, map[string]string{
+				"index":         name,
+				"docs.count":    strconv.Itoa(len(index.versions)),
+				"creation.date": strconv.FormatInt(index.created, 10),
+			})
+		}
+	}
+	f.respond(w, http.StatusOK, rows)
+}
+
+func (f *fakeElasticsearch) count(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, ok := f.indices[r.PathValue("index")]
+	if !ok {
+		f.respond(w, http.StatusNotFound, map[string]any{"error": "index_not_found_exception"})
+		return
+	}
+	f.respond(w, http.StatusOK, map[string]any{"count": len(index.versions)})
+}
+
+// bulk handles index actions, honouring external_gte versions, and rejects
+// writes to missing indices the way Elasticsearch rejects items.
+func (f *fakeElasticsearch) bulk(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var items []map[string]map[string]int
+	failed := false
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var meta map[string]struct {
+			Index   string `json:"_index"`
+			ID      string `json:"_id"`
+			Version int64  `json:"version"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &meta); err != nil {
+			f.respond(w, http.StatusBadRequest, map[string]any{"error": err.Error()})
+			return
+		}
+		action, ok := meta["index"]
+		if !ok || !scanner.Scan() {
+			f.respond(w, http.StatusBadRequest, map[string]any{"error": "only index actions are supported"})
+			return
+		}
+
+		status := http.StatusCreated
+		index, exists := f.indices[action.Index]
+		switch {
+		case !exists:
+			status = http.StatusNotFound
+		case index.versions[action.ID] > action.Version:
+			status = http.StatusConflict
+		default:
+			index.versions[action.ID] = action.Version
+		}
+		if status >= 300 {
+			failed = true
+		}
+		items = append(items, map[string]map[string]int{"index": {"status": status}})
+	}
+	f.respond(w, http.StatusOK, map[string]any{"errors": failed, "items": items})
+}
+
+func (f *fakeElasticsearch) deleteDocument(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, ok := f.indices[r.PathValue("index")]
+	if ok {
+		_, ok = index.versions[r.PathValue("id")]
+	}
+	if !ok {
+		f.respond(w, http.StatusNotFound, map[string]any{"result": "not_found"})
+		return
+	}
+	delete(index.versions, r.PathValue("id"))
+	f.respond(w, http.StatusOK, map[string]any{"result": "deleted"})
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
@@ -29,19 +31,48 @@ type EventServiceEvent struct {
 	VenueCity     *string   `json:"venue_city,omitempty"`
 	VenueState    *string   `json:"venue_state,omitempty"`
 	VenueCountry  *string   `json:"venue_country,omitempty"`
+	VenueLatitude  *float64 `json:"venue_latitude,omitempty"`
+	VenueLongitude *float64 `json:"venue_longitude,omitempty"`
 	EventType     string    `json:"event_type"`
+	Categories    []string  `json:"categories,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
 	StartDatetime time.Time `json:"start_datetime"`
 	EndDatetime   time.Time `json:"end_datetime"`
+	Timezone      string    `json:"timezone,omitempty"`
 	TotalCapacity int32     `json:"total_capacity"`
 	AvailableSeats int32    `json:"available_seats"`
 	BasePrice     float64   `json:"base_price"`
+	Currency      string    `json:"currency"`
 	Status        string    `json:"status"`
 	Version       int32     `json:"version"`
 	CreatedBy     uuid.UUID `json:"created_by"`
+	Images        *EventServiceImages `json:"images,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+type EventServiceImages struct {
+	Poster  *EventServiceImage  `json:"poster,omitempty"`
+	Banner  *EventServiceImage  `json:"banner,omitempty"`
+	Gallery []EventServiceImage `json:"gallery,omitempty"`
+}
+
+type EventServiceImage struct {
+	URL      string `json:"url"`
+	Variants map[string]struct {
+		URL string `json:"url"`
+	} `json:"variants"`
+}
+
+// VariantURL returns the URL of a resized variant, or the original if the
+// variant is missing.
+func (i EventServiceImage) VariantURL(name string) string {
+	if variant, ok := i.Variants[name]; ok && variant.URL != "" {
+		return variant.URL
+	}
+	return i.URL
+}
+
 type EventServiceListResponse struct {
 	Events  []EventServiceEvent `json:"events"`
 	Total   int64               `json:"total"`
@@ -50,6 +81,20 @@ type EventServiceListResponse struct {
 	HasMore bool                `json:"has_more"`
 }
 
+// EventServiceChange is one entry of event-service's change feed. Event is
+// set when Operation is "upsert".
+type EventServiceChange struct {
+	EventID   uuid.UUID          `json:"event_id"`
+	Operation string             `json:"operation"`
+	ChangedAt time.Time          `json:"changed_at"`
+	Event     *EventServiceEvent `json:"event,omitempty"`
+}
+
+type EventServiceChangesResponse struct {
+	Changes []EventServiceChange `json:"changes"`
+	HasMore bool                 `json:"has_more"`
+}
+
 func NewEventServiceClient(baseURL, apiKey string, logger *logger.Logger) *EventServiceClient {
 	return &EventServiceClient{
 		BaseURL: baseURL,
@@ -113,6 +158,33 @@ func (c *EventServiceClient) GetAllPublishedEvents(ctx context.Context) ([]Event
 	return allEvents, nil
 }
 
+// CountPublishedEvents asks event-service how many events it lists, counted
+// by its own query rather than by paging through them.
+func (c *EventServiceClient) CountPublishedEvents(ctx context.Context) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/v1/events?limit=1", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("event service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response EventServiceListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Total, nil
+}
+
 func (c *EventServiceClient) GetEvent(ctx context.Context, eventID uuid.UUID) (*EventServiceEvent, error) {
 	url := fmt.Sprintf("%s/api/v1/events/%s", c.BaseURL, eventID.String())
 	
@@ -143,3 +215,40 @@ func (c *EventServiceClient) GetEvent(ctx context.Context, eventID uuid.UUID) (*
 
 	return &event, nil
 }
+
+// GetEventChanges fetches one page of events changed at or after since,
+// continuing after the given event ID among changes at that same time.
+func (c *EventServiceClient) GetEventChanges(ctx context.Context, since time.Time, after uuid.UUID, limit int) (*EventServiceChangesResponse, error) {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if after != uuid.Nil {
+		query.Set("after", after.String())
+	}
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/internal/events/changes?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("event service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response EventServiceChangesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &response, nil
+}
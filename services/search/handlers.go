@@ -3,17 +3,20 @@ package search
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
 	"github.com/google/uuid"
 )
 
 func (e *ElasticsearchClient) HealthCheck(ctx context.Context) error {
-	res, err := e.client.Ping()
+	res, err := e.client.Ping(e.client.Ping.WithContext(ctx))
 	if err != nil {
 		return fmt.Errorf("elasticsearch ping failed: %w", err)
 	}
@@ -27,11 +30,19 @@ func (e *ElasticsearchClient) HealthCheck(ctx context.Context) error {
 }
 
 
+// HandleReadiness reports ready while the search backend is healthy, and
+// degraded while it is down but reads are served from the fallback.
 func (cfg *APIConfig) HandleReadiness(w http.ResponseWriter, r *http.Request) {
-	esStatus := "connected"
-	if err := cfg.ESClient.HealthCheck(r.Context()); err != nil {
-		cfg.Logger.Error("Elasticsearch health check failed", "error", err)
-		esStatus = "disconnected"
+	esStatus := "disabled"
+	if cfg.ESClient != nil {
+		esStatus = "connected"
+	}
+	backendErr := cfg.checkBackendHealth(r.Context())
+	if backendErr != nil {
+		cfg.Logger.Error("Search backend health check failed", "error", backendErr, "backend", cfg.Backend.Name())
+		if cfg.ESClient != nil {
+			esStatus = "disconnected"
+		}
 	}
 
 	redisStatus := "connected"
@@ -41,12 +52,16 @@ func (cfg *APIConfig) HandleReadiness(w http.ResponseWriter, r *http.Request) {
 	}
 
 	status := "ready"
-	if esStatus == "disconnected" || redisStatus == "disconnected" {
+	if backendErr != nil && cfg.fallbackReady(r.Context()) {
+		status = "degraded"
+	}
+	if redisStatus == "disconnected" || (backendErr != nil && status != "degraded") {
 		status = "not ready"
 		utils.RespondWithJSON(w, http.StatusServiceUnavailable, HealthResponse{
 			Status:        status,
 			Elasticsearch: esStatus,
 			Redis:         redisStatus,
+			Backend:       cfg.readBackend(r.Context()).Name(),
 			Service:       "search-service",
 		})
 		return
@@ -56,19 +71,42 @@ func (cfg *APIConfig) HandleReadiness(w http.ResponseWriter, r *http.Request) {
 		Status:        status,
 		Elasticsearch: esStatus,
 		Redis:         redisStatus,
+		Backend:       cfg.readBackend(r.Context()).Name(),
 		Service:       "search-service",
 	})
 }
 
+// SearchEvents pages with page and limit for shallow pages, or with a
+// cursor: paging=cursor starts a walk and each response's next_cursor,
+// passed back as cursor, fetches the page after it.
 func (cfg *APIConfig) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	if token := r.URL.Query().Get("cursor"); token != "" {
+		cursor, err := decodeSearchCursor(token)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		cfg.searchWithCursor(w, r, cursor)
+		return
+	}
+
 	query := r.URL.Query().Get("q")
 	city := r.URL.Query().Get("city")
 	eventType := r.URL.Query().Get("type")
+	// Every tag given must match.
+	var tags []string
+	for _, tag := range r.URL.Query()["tag"] {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
 	dateFrom := r.URL.Query().Get("date_from")
 	dateTo := r.URL.Query().Get("date_to")
 
 	minPrice, _ := strconv.ParseFloat(r.URL.Query().Get("min_price"), 64)
 	maxPrice, _ := strconv.ParseFloat(r.URL.Query().Get("max_price"), 64)
+	// Prices are only comparable within one currency.
+	currency := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("currency")))
 
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	if page <= 0 {
@@ -80,35 +118,92 @@ func (cfg *APIConfig) SearchEvents(w http.ResponseWriter, r *http.Request) {
 		limit = 20
 	}
 
+	sort, err := parseSortParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	near, radiusKm, err := parseLocationParams(r, sort)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	available, minSeats, venueID, err := parseAvailabilityParams(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	days, timeFrom, timeTo, err := parseScheduleParams(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	searchReq := SearchRequest{
 		Query:     query,
 		City:      city,
 		EventType: eventType,
+		Tags:      tags,
 		DateFrom:  dateFrom,
 		DateTo:    dateTo,
 		MinPrice:  minPrice,
 		MaxPrice:  maxPrice,
+		Currency:  currency,
+		Near:      near,
+		RadiusKm:  radiusKm,
+		Available: available,
+		MinSeats:  minSeats,
+		VenueID:   venueID,
+		Days:      days,
+		TimeFrom:  timeFrom,
+		TimeTo:    timeTo,
+		Sort:      sort,
 		Page:      page,
 		Limit:     limit,
 	}
 
-	cacheKey := fmt.Sprintf("search:%s:%s:%s:%s:%s:%.2f:%.2f:%d:%d", 
-		query, city, eventType, dateFrom, dateTo, minPrice, maxPrice, page, limit)
+	if r.URL.Query().Get("paging") == "cursor" {
+		searchReq.Page = 1
+		cfg.searchWithCursor(w, r, searchCursor{Request: searchReq})
+		return
+	}
 
-	if cached := cfg.getCachedSearchResult(r.Context(), cacheKey); cached != nil {
-		cfg.Logger.Info("Returning cached search result", "cache_key", cacheKey)
-		utils.RespondWithJSON(w, http.StatusOK, cached)
+	if page > maxOffsetResults/limit {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("page and limit reach past result %d; use paging=cursor for deeper pages", maxOffsetResults))
 		return
 	}
 
-	result, err := cfg.ESClient.Search(r.Context(), searchReq)
+	location := ""
+	if near != nil {
+		location = fmt.Sprintf("%.5f,%.5f", near.Lat, near.Lon)
+	}
+	dayParams := make([]string, len(days))
+	for i, day := range days {
+		dayParams[i] = strconv.Itoa(day)
+	}
+	// Seat-filtered searches skip the cache, so seats are not part of the key.
+	cacheParams := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%.2f:%.2f:%s:%s:%.3f:%s:%s:%s-%s:%s:%d:%d", 
+		query, city, eventType, strings.Join(tags, ","), dateFrom, dateTo, minPrice, maxPrice, currency, location, radiusKm,
+		venueID, strings.Join(dayParams, ","), timeFrom, timeTo, sort, page, limit)
+
+	result, cached, err := cfg.searchCached(r.Context(), cacheParams, searchReq)
 	if err != nil {
 		cfg.Logger.Error("Search failed", "error", err, "query", query)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Search failed")
 		return
 	}
 
-	cfg.cacheSearchResult(r.Context(), cacheKey, result)
+	cfg.recordSearchSignals(r.Context(), searchReq, result)
+	result = echoSearch(result, searchReq)
+
+	if cached {
+		cfg.Logger.Info("Returning cached search result", "cache_params", cacheParams)
+		utils.RespondWithJSON(w, http.StatusOK, result)
+		return
+	}
 
 	cfg.Logger.Info("Search completed", 
 		"query", query,
@@ -119,6 +214,43 @@ func (cfg *APIConfig) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	utils.RespondWithJSON(w, http.StatusOK, result)
 }
 
+// parseLocationParams reads the "near me" parameters: lat and lon together
+// and an optional radius_km. Both radius_km and sort=distance need a
+// position.
+func parseLocationParams(r *http.Request, sort string) (*geocode.Point, float64, error) {
+	latStr := r.URL.Query().Get("lat")
+	lonStr := r.URL.Query().Get("lon")
+	radiusStr := r.URL.Query().Get("radius_km")
+
+	if latStr == "" && lonStr == "" {
+		if radiusStr != "" || sort == SortDistance {
+			return nil, 0, fmt.Errorf("lat and lon are required for radius_km and sort=distance")
+		}
+		return nil, 0, nil
+	}
+
+	lat, latErr := strconv.ParseFloat(latStr, 64)
+	lon, lonErr := strconv.ParseFloat(lonStr, 64)
+	if latErr != nil || lonErr != nil {
+		return nil, 0, fmt.Errorf("lat and lon must both be numbers")
+	}
+	near := &geocode.Point{Lat: lat, Lon: lon}
+	if err := near.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	var radiusKm float64
+	if radiusStr != "" {
+		var err error
+		radiusKm, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radiusKm <= 0 || radiusKm > maxRadiusKm {
+			return nil, 0, fmt.Errorf("radius_km must be greater than 0 and at most %d", maxRadiusKm)
+		}
+	}
+
+	return near, radiusKm, nil
+}
+
 func (cfg *APIConfig) GetSuggestions(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if query == "" {
@@ -131,7 +263,12 @@ func (cfg *APIConfig) GetSuggestions(w http.ResponseWriter, r *http.Request) {
 		limit = 10
 	}
 
-	suggestions, err := cfg.ESClient.GetSuggestions(r.Context(), query, limit)
+	var suggestions []string
+	err := cfg.withReadBackend(r.Context(), func(backend SearchBackend) error {
+		var err error
+		suggestions, err = backend.GetSuggestions(r.Context(), query, limit)
+		return err
+	})
 	if err != nil {
 		cfg.Logger.Error("Failed to get suggestions", "error", err, "query", query)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get suggestions")
@@ -146,12 +283,12 @@ func (cfg *APIConfig) GetSuggestions(w http.ResponseWriter, r *http.Request) {
 }
 
 func (cfg *APIConfig) GetFilters(w http.ResponseWriter, r *http.Request) {
-	emptySearch := SearchRequest{
-		Page:  1,
-		Limit: 1,
-	}
-
-	result, err := cfg.ESClient.Search(r.Context(), emptySearch)
+	var facets *SearchFacets
+	err := cfg.withReadBackend(r.Context(), func(backend SearchBackend) error {
+		var err error
+		facets, err = backend.GetFacets(r.Context())
+		return err
+	})
 	if err != nil {
 		cfg.Logger.Error("Failed to get filters", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get filters")
@@ -159,24 +296,39 @@ func (cfg *APIConfig) GetFilters(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var cities []string
-	for _, city := range result.Facets.Cities {
+	for _, city := range facets.Cities {
 		cities = append(cities, city.Value)
 	}
 
 	var eventTypes []string
-	for _, eventType := range result.Facets.EventTypes {
+	for _, eventType := range facets.EventTypes {
 		eventTypes = append(eventTypes, eventType.Value)
 	}
 
+	var categories []string
+	for _, category := range facets.Categories {
+		categories = append(categories, category.Value)
+	}
+
+	var tags []string
+	for _, tag := range facets.Tags {
+		tags = append(tags, tag.Value)
+	}
+
 	response := FiltersResponse{
 		Cities:     cities,
 		EventTypes: eventTypes,
-		PriceRange: result.Facets.PriceRange,
+		Categories: categories,
+		Tags:       tags,
+		PriceRange: facets.PriceRange,
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+// GetTrendingEvents ranks upcoming events by their trending score: seats
+// sold, page views and search appearances, each counting less as it ages.
+// Events with no recent activity follow, soonest first.
 func (cfg *APIConfig) GetTrendingEvents(w http.ResponseWriter, r *http.Request) {
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	if limit <= 0 || limit > 50 {
@@ -184,17 +336,29 @@ func (cfg *APIConfig) GetTrendingEvents(w http.ResponseWriter, r *http.Request)
 	}
 
 	searchReq := SearchRequest{
-		Page:  1,
-		Limit: limit,
+		City:      r.URL.Query().Get("city"),
+		EventType: r.URL.Query().Get("type"),
+		DateFrom:  time.Now().UTC().Format(time.RFC3339),
+		Sort:      SortTrending,
+		Page:      1,
+		Limit:     limit,
 	}
 
-	result, err := cfg.ESClient.Search(r.Context(), searchReq)
+	result, err := cfg.search(r.Context(), searchReq)
 	if err != nil {
 		cfg.Logger.Error("Failed to get trending events", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get trending events")
 		return
 	}
 
+	cfg.applySeatOverlay(r.Context(), result)
+	for i := range result.Results {
+		if stored := result.Results[i].TrendingScore; stored != nil {
+			current := cfg.currentTrendingScore(*stored)
+			result.Results[i].TrendingScore = &current
+		}
+	}
+
 	response := TrendingEventsResponse{
 		Events: result.Results,
 	}
@@ -216,13 +380,19 @@ func (cfg *APIConfig) IndexEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := cfg.ESClient.IndexEvent(r.Context(), req.Event.EventID, req.Event); err != nil {
+	req.Event.setLocalStart()
+	docs := []EventDocument{req.Event}
+	cfg.applyTrendingScores(r.Context(), docs)
+	req.Event = docs[0]
+
+	if failed, err := cfg.indexDocuments(r.Context(), docs); err != nil || failed > 0 {
 		cfg.Logger.Error("Failed to index event", "error", err, "event_id", req.Event.EventID)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to index event")
 		return
 	}
 
-	cfg.invalidateSearchCache(r.Context())
+	cfg.mirrorIndexedEvents(r.Context(), req.Event)
+	cfg.recordIndexedEvents(r.Context(), req.Event)
 
 	cfg.Logger.WithFields(map[string]any{"event_id": req.Event.EventID, "event_name": req.Event.Name}).Info("Event indexed successfully")
 
@@ -234,6 +404,53 @@ func (cfg *APIConfig) IndexEvent(w http.ResponseWriter, r *http.Request) {
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+func (cfg *APIConfig) BulkIndexEvents(w http.ResponseWriter, r *http.Request) {
+	var req BulkIndexEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		cfg.Logger.WithFields(map[string]any{"error": err.Error()}).Warn("Invalid JSON in bulk event indexing")
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if len(req.Events) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "At least one event is required")
+		return
+	}
+
+	if len(req.Events) > 1000 {
+		utils.RespondWithError(w, http.StatusBadRequest, "At most 1000 events can be indexed per request")
+		return
+	}
+
+	for i, event := range req.Events {
+		if event.EventID == uuid.Nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Event ID is required for every event")
+			return
+		}
+		req.Events[i].setLocalStart()
+	}
+
+	cfg.applyTrendingScores(r.Context(), req.Events)
+
+	if failed, err := cfg.indexDocuments(r.Context(), req.Events); err != nil || failed > 0 {
+		cfg.Logger.Error("Failed to bulk index events", "error", err, "count", len(req.Events), "failed", failed)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to index events")
+		return
+	}
+
+	cfg.mirrorIndexedEvents(r.Context(), req.Events...)
+	cfg.recordIndexedEvents(r.Context(), req.Events...)
+
+	cfg.Logger.WithFields(map[string]any{"count": len(req.Events)}).Info("Events bulk indexed successfully")
+
+	response := BulkIndexEventsResponse{
+		Status:  "indexed",
+		Indexed: len(req.Events),
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
 func (cfg *APIConfig) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	eventIDStr := r.PathValue("id")
 	eventID, err := uuid.Parse(eventIDStr)
@@ -243,13 +460,14 @@ func (cfg *APIConfig) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := cfg.ESClient.DeleteEvent(r.Context(), eventID); err != nil {
+	if failed, err := cfg.deleteDocuments(r.Context(), []uuid.UUID{eventID}); err != nil || failed > 0 {
 		cfg.Logger.Error("Failed to delete event", "error", err, "event_id", eventID)
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete event")
 		return
 	}
 
-	cfg.invalidateSearchCache(r.Context())
+	cfg.mirrorDeletedEvents(r.Context(), eventID)
+	cfg.forgetIndexedEvents(r.Context(), eventID)
 
 	cfg.Logger.WithFields(map[string]any{"event_id": eventID}).Info("Event deleted from search index")
 
@@ -271,18 +489,44 @@ func (cfg *APIConfig) FullResync(w http.ResponseWriter, r *http.Request) {
 
 	cfg.Logger.Info("Starting full resync", "force_reindex", req.ForceReindex)
 
+	// A forced reindex builds a new index version and swaps it in, so
+	// searches keep working from the old one until it is ready.
 	if req.ForceReindex {
-		if err := cfg.ESClient.DeleteIndex(r.Context()); err != nil {
-			cfg.Logger.Error("Failed to delete index during resync", "error", err)
+		if cfg.ESClient == nil {
+			utils.RespondWithError(w, http.StatusServiceUnavailable, "Reindexing is not available without Elasticsearch")
+			return
 		}
-	}
 
-	if err := cfg.ESClient.CreateIndex(r.Context()); err != nil {
-		cfg.Logger.Error("Failed to create index during resync", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create index")
+		result, err := cfg.rebuildIndex(r.Context())
+		if errors.Is(err, errReindexRunning) {
+			utils.RespondWithError(w, http.StatusConflict, "A reindex is already running")
+			return
+		}
+		if err != nil {
+			cfg.Logger.Error("Reindex failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Reindex failed, the current index is still in use")
+			return
+		}
+
+		cfg.Logger.Info("Reindex completed", "index", result.Index, "events_indexed", result.EventsIndexed, "documents", result.Documents, "time_taken", time.Since(start))
+
+		utils.RespondWithJSON(w, http.StatusOK, FullResyncResponse{
+			Message:       "Reindex completed successfully",
+			EventsIndexed: result.EventsIndexed,
+			TimeTaken:     time.Since(start).String(),
+			Index:         result.Index,
+		})
 		return
 	}
 
+	if cfg.ESClient != nil {
+		if err := cfg.ESClient.CreateIndex(r.Context()); err != nil {
+			cfg.Logger.Error("Failed to create index during resync", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create index")
+			return
+		}
+	}
+
 	events, err := cfg.EventServiceClient.GetAllPublishedEvents(r.Context())
 	if err != nil {
 		cfg.Logger.Error("Failed to fetch events from Event Service", "error", err)
@@ -306,6 +550,8 @@ func (cfg *APIConfig) FullResync(w http.ResponseWriter, r *http.Request) {
 		documents[i] = cfg.convertEventToDocument(event)
 	}
 
+	cfg.applyTrendingScores(r.Context(), documents)
+
 	batchSize := 100
 	totalIndexed := 0
 
@@ -316,7 +562,7 @@ func (cfg *APIConfig) FullResync(w http.ResponseWriter, r *http.Request) {
 		}
 
 		batch := documents[i:end]
-		if err := cfg.ESClient.BulkIndex(r.Context(), batch); err != nil {
+		if _, err := cfg.indexDocuments(r.Context(), batch); err != nil {
 			cfg.Logger.Error("Failed to bulk index batch", "error", err, "batch_start", i, "batch_size", len(batch))
 			continue
 		}
@@ -327,7 +573,7 @@ func (cfg *APIConfig) FullResync(w http.ResponseWriter, r *http.Request) {
 
 	timeTaken := time.Since(start)
 
-	cfg.invalidateSearchCache(r.Context())
+	cfg.bumpSearchCacheGeneration(r.Context())
 
 	cfg.Logger.Info("Full resync completed", 
 		"events_indexed", totalIndexed,
@@ -349,9 +595,16 @@ func (cfg *APIConfig) convertEventToDocument(event EventServiceEvent) EventDocum
 		Name:          event.Name,
 		VenueID:       event.VenueID,
 		EventType:     event.EventType,
+		Categories:    event.Categories,
+		Tags:          event.Tags,
 		StartDateTime: event.StartDatetime,
 		EndDateTime:   event.EndDatetime,
+		Timezone:      event.Timezone,
+		// event-service renders times with the venue's offset, so the
+		// date as written is the local date.
+		StartDate:     event.StartDatetime.Format("2006-01-02"),
 		BasePrice:     event.BasePrice,
+		Currency:      event.Currency,
 		AvailableSeats: event.AvailableSeats,
 		TotalCapacity: event.TotalCapacity,
 		Status:        event.Status,
@@ -384,55 +637,24 @@ func (cfg *APIConfig) convertEventToDocument(event EventServiceEvent) EventDocum
 		doc.VenueCountry = *event.VenueCountry
 	}
 
-	return doc
-}
-
-func (cfg *APIConfig) getCachedSearchResult(ctx context.Context, key string) *SearchResponse {
-	val, err := cfg.RedisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil
-	}
-
-	var result SearchResponse
-	if err := json.Unmarshal([]byte(val), &result); err != nil {
-		cfg.Logger.Error("Failed to unmarshal cached search result", "error", err)
-		return nil
-	}
-
-	return &result
-}
-
-func (cfg *APIConfig) cacheSearchResult(ctx context.Context, key string, result *SearchResponse) {
-	data, err := json.Marshal(result)
-	if err != nil {
-		cfg.Logger.Error("Failed to marshal search result for caching", "error", err)
-		return
-	}
-
-	if err := cfg.RedisClient.Set(ctx, key, data, cfg.Config.CacheExpiry).Err(); err != nil {
-		cfg.Logger.Error("Failed to cache search result", "error", err)
-	}
-}
-
-func (cfg *APIConfig) invalidateSearchCache(ctx context.Context) {
-	pattern := "search:*"
-	
-	keys, err := cfg.RedisClient.Keys(ctx, pattern).Result()
-	if err != nil {
-		cfg.Logger.Error("Failed to get cache keys for invalidation", "error", err, "pattern", pattern)
-		return
+	if event.VenueLatitude != nil && event.VenueLongitude != nil {
+		doc.Location = &geocode.Point{Lat: *event.VenueLatitude, Lon: *event.VenueLongitude}
 	}
 
-	if len(keys) == 0 {
-		cfg.Logger.Debug("No cache keys found to invalidate", "pattern", pattern)
-		return
-	}
-
-	deletedCount, err := cfg.RedisClient.Del(ctx, keys...).Result()
-	if err != nil {
-		cfg.Logger.Error("Failed to delete cache keys", "error", err, "keys_count", len(keys))
-		return
+	if images := event.Images; images != nil {
+		cover := images.Poster
+		if cover == nil && len(images.Gallery) > 0 {
+			cover = &images.Gallery[0]
+		}
+		if cover != nil {
+			doc.ImageURL = cover.VariantURL("medium")
+			doc.ThumbnailURL = cover.VariantURL("thumbnail")
+		}
+		if images.Banner != nil {
+			doc.BannerURL = images.Banner.VariantURL("large")
+		}
 	}
 
-	cfg.Logger.Info("Search cache invalidated", "keys_deleted", deletedCount, "pattern", pattern)
+	doc.setLocalStart()
+	return doc
 }
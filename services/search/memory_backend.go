@@ -0,0 +1,614 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+// MemoryBackend keeps published events in process and answers the same
+// searches as Elasticsearch with simpler matching: query words are matched
+// against words in the name, description, venue, type and tags, allowing
+// the same typos as the fuzzy query but without stemming or synonyms. It
+// suits development and tests, and serves as a fallback while
+// Elasticsearch is down. Every replica keeps its own copy, filled and kept
+// current from event-service's change feed.
+type MemoryBackend struct {
+	mu     sync.RWMutex
+	events map[uuid.UUID]memoryEvent
+	loaded atomic.Bool
+	logger *logger.Logger
+}
+
+// memoryEvent is an indexed document with its searchable words, split once
+// when it is indexed.
+type memoryEvent struct {
+	doc   EventDocument
+	words []string
+}
+
+var errMemoryIndexLoading = errors.New("in-memory search index is still loading")
+
+func NewMemoryBackend(logger *logger.Logger) *MemoryBackend {
+	return &MemoryBackend{
+		events: make(map[uuid.UUID]memoryEvent),
+		logger: logger,
+	}
+}
+
+func (m *MemoryBackend) Name() string {
+	return BackendMemory
+}
+
+// HealthCheck fails until the index has been loaded once, so reads are not
+// switched to an empty index.
+func (m *MemoryBackend) HealthCheck(ctx context.Context) error {
+	if !m.loaded.Load() {
+		return errMemoryIndexLoading
+	}
+	return nil
+}
+
+// MarkLoaded records that the index has caught up with the change feed.
+func (m *MemoryBackend) MarkLoaded() {
+	if !m.loaded.Swap(true) {
+		m.mu.RLock()
+		count := len(m.events)
+		m.mu.RUnlock()
+		m.logger.Info("In-memory search index loaded", "events", count)
+	}
+}
+
+// IndexEvents stores documents, keeping the stored one when it has a newer
+// version, so an old state read from the change feed cannot replace a newer
+// push.
+func (m *MemoryBackend) IndexEvents(ctx context.Context, docs []EventDocument) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, doc := range docs {
+		if existing, ok := m.events[doc.EventID]; ok {
+			if existing.doc.Version > doc.Version {
+				continue
+			}
+			if doc.TrendingScore == nil {
+				doc.TrendingScore = existing.doc.TrendingScore
+			}
+		}
+		m.events[doc.EventID] = memoryEvent{doc: doc, words: documentWords(doc)}
+	}
+	return 0, nil
+}
+
+func (m *MemoryBackend) DeleteEvents(ctx context.Context, eventIDs []uuid.UUID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, eventID := range eventIDs {
+		delete(m.events, eventID)
+	}
+	return 0, nil
+}
+
+func (m *MemoryBackend) UpdateTrendingScores(ctx context.Context, scores map[uuid.UUID]float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for eventID, score := range scores {
+		event, ok := m.events[eventID]
+		if !ok {
+			continue
+		}
+		score := score
+		event.doc.TrendingScore = &score
+		m.events[eventID] = event
+	}
+	return nil
+}
+
+func (m *MemoryBackend) GetDocuments(ctx context.Context, eventIDs []uuid.UUID) ([]EventDocument, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var docs []EventDocument
+	for _, eventID := range eventIDs {
+		if event, ok := m.events[eventID]; ok {
+			docs = append(docs, event.doc)
+		}
+	}
+	return docs, nil
+}
+
+// memoryHit is a matching event and its distance from the searcher, if
+// known.
+type memoryHit struct {
+	doc        EventDocument
+	distanceKm *float64
+}
+
+// Search applies the same filters, sort orders, paging and facets as the
+// Elasticsearch query.
+func (m *MemoryBackend) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	start := time.Now()
+
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	queryWords := splitWords(req.Query)
+
+	m.mu.RLock()
+	var hits []memoryHit
+	for _, event := range m.events {
+		if !memoryMatches(event, req, queryWords) {
+			continue
+		}
+		hit := memoryHit{doc: event.doc}
+		if req.Near != nil && event.doc.Location != nil {
+			distance := req.Near.DistanceKm(*event.doc.Location)
+			if req.RadiusKm > 0 && distance > req.RadiusKm {
+				continue
+			}
+			hit.distanceKm = &distance
+		} else if req.Near != nil && req.RadiusKm > 0 {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+	m.mu.RUnlock()
+
+	sortMemoryHits(hits, req)
+
+	results := []EventSearchResult{}
+	from := (req.Page - 1) * req.Limit
+	for i := from; i < len(hits) && i < from+req.Limit; i++ {
+		result := searchResultFromDocument(hits[i].doc)
+		result.DistanceKm = hits[i].distanceKm
+		if req.Sort == SortTrending || req.Sort == SortPopularity {
+			result.TrendingScore = hits[i].doc.TrendingScore
+		}
+		results = append(results, result)
+	}
+
+	return &SearchResponse{
+		Results:   results,
+		Total:     int64(len(hits)),
+		Page:      req.Page,
+		Limit:     req.Limit,
+		QueryTime: time.Since(start).String(),
+		Facets:    memoryFacets(hits),
+	}, nil
+}
+
+// GetSuggestions completes the start of event names, like the completion
+// suggester.
+func (m *MemoryBackend) GetSuggestions(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	m.mu.RLock()
+	seen := make(map[string]bool)
+	var names []string
+	for _, event := range m.events {
+		name := event.doc.Name
+		if event.doc.Status != "published" || seen[name] || !strings.HasPrefix(strings.ToLower(name), prefix) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(names)
+	if len(names) > limit {
+		names = names[:limit]
+	}
+	return names, nil
+}
+
+func (m *MemoryBackend) GetFacets(ctx context.Context) (*SearchFacets, error) {
+	result, err := m.Search(ctx, SearchRequest{Page: 1, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	return &result.Facets, nil
+}
+
+func memoryMatches(event memoryEvent, req SearchRequest, queryWords []string) bool {
+	doc := event.doc
+	if doc.Status != "published" {
+		return false
+	}
+	if req.City != "" && !strings.EqualFold(doc.VenueCity, req.City) {
+		return false
+	}
+	if req.EventType != "" && doc.EventType != req.EventType && !containsString(doc.Categories, req.EventType) {
+		return false
+	}
+	for _, tag := range req.Tags {
+		if !containsString(doc.Tags, tag) {
+			return false
+		}
+	}
+	if req.Currency != "" && doc.Currency != req.Currency {
+		return false
+	}
+	if req.MinPrice > 0 && doc.BasePrice < req.MinPrice {
+		return false
+	}
+	if req.MaxPrice > 0 && doc.BasePrice > req.MaxPrice {
+		return false
+	}
+	if seats := minimumSeats(req); seats > 0 && int(doc.AvailableSeats) < seats {
+		return false
+	}
+	if req.VenueID != "" && doc.VenueID.String() != req.VenueID {
+		return false
+	}
+	if len(req.Days) > 0 && !slices.Contains(req.Days, doc.StartWeekday) {
+		return false
+	}
+	if !minuteMatches(doc.StartMinute, req.TimeFrom, req.TimeTo) {
+		return false
+	}
+	if !memoryDateMatches(doc, req.DateFrom, req.DateTo) {
+		return false
+	}
+	return memoryTextMatches(event.words, queryWords)
+}
+
+// memoryDateMatches compares plain dates with the local start date and
+// timestamps with the start instant, like the Elasticsearch filter.
+func memoryDateMatches(doc EventDocument, from, to string) bool {
+	if from == "" && to == "" {
+		return true
+	}
+	if isCalendarDate(from) && isCalendarDate(to) {
+		return (from == "" || doc.StartDate >= from) && (to == "" || doc.StartDate <= to)
+	}
+	if t, ok := parseDateBound(from); ok && doc.StartDateTime.Before(t) {
+		return false
+	}
+	if t, ok := parseDateBound(to); ok && doc.StartDateTime.After(t) {
+		return false
+	}
+	return true
+}
+
+func parseDateBound(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// memoryTextMatches requires three quarters of the query words, rounded
+// down, to appear in the event, as the query's minimum_should_match does.
+func memoryTextMatches(words, queryWords []string) bool {
+	if len(queryWords) == 0 {
+		return true
+	}
+	required := max(len(queryWords)*3/4, 1)
+
+	matched := 0
+	for _, queryWord := range queryWords {
+		for _, word := range words {
+			if fuzzyWordMatch(queryWord, word) {
+				matched++
+				break
+			}
+		}
+	}
+	return matched >= required
+}
+
+// fuzzyWordMatch allows the edits fuzziness AUTO does, with the first
+// letter fixed: none for words of up to two letters, one up to five, two
+// beyond.
+func fuzzyWordMatch(query, word string) bool {
+	if query == word {
+		return true
+	}
+	q, w := []rune(query), []rune(word)
+	if len(q) <= 2 || len(w) == 0 || q[0] != w[0] {
+		return false
+	}
+	allowed := 1
+	if len(q) > 5 {
+		allowed = 2
+	}
+	if diff := len(q) - len(w); diff > allowed || -diff > allowed {
+		return false
+	}
+	return editDistance(q, w) <= allowed
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment)
+// distance, which counts a swap of neighbouring letters as one edit like
+// Elasticsearch does.
+func editDistance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+// documentWords lists the distinct words of the fields a text query
+// searches.
+func documentWords(doc EventDocument) []string {
+	fields := []string{doc.Name, doc.Description, doc.VenueName, doc.VenueCity, doc.EventType}
+	fields = append(fields, doc.Tags...)
+
+	seen := make(map[string]bool)
+	var words []string
+	for _, field := range fields {
+		for _, word := range splitWords(field) {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// sortMemoryHits orders hits like the Elasticsearch sort, with distance
+// added when a position is given. Events without a trending score or a
+// location sort last on that key. Hits have no relevance score, so
+// sort=relevance orders them by date.
+func sortMemoryHits(hits []memoryHit, req SearchRequest) {
+	byDate := func(a, b memoryHit) int {
+		return a.doc.StartDateTime.Compare(b.doc.StartDateTime)
+	}
+	byPrice := func(a, b memoryHit) int {
+		return cmp.Compare(a.doc.BasePrice, b.doc.BasePrice)
+	}
+	byTrending := func(a, b memoryHit) int {
+		return compareMissingLast(a.doc.TrendingScore, b.doc.TrendingScore, true)
+	}
+	byDistance := func(a, b memoryHit) int {
+		return compareMissingLast(a.distanceKm, b.distanceKm, false)
+	}
+
+	keys := []func(a, b memoryHit) int{byDate}
+	switch req.Sort {
+	case SortDateDesc:
+		keys = []func(a, b memoryHit) int{func(a, b memoryHit) int { return byDate(b, a) }}
+	case SortPriceAsc:
+		keys = []func(a, b memoryHit) int{byPrice, byDate}
+	case SortPriceDesc:
+		keys = []func(a, b memoryHit) int{func(a, b memoryHit) int { return byPrice(b, a) }, byDate}
+	case SortTrending, SortPopularity:
+		keys = []func(a, b memoryHit) int{byTrending, byDate}
+	}
+	if req.Near != nil {
+		if req.Sort == SortDistance {
+			keys = append([]func(a, b memoryHit) int{byDistance}, keys...)
+		} else {
+			keys = append(keys, byDistance)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		for _, key := range keys {
+			if c := key(hits[i], hits[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return hits[i].doc.EventID.String() < hits[j].doc.EventID.String()
+	})
+}
+
+func compareMissingLast(a, b *float64, descending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case *a == *b:
+		return 0
+	case (*a < *b) != descending:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// memoryFacets counts the matching events per city, type, category and tag,
+// with the same bucket limits as the aggregations.
+func memoryFacets(hits []memoryHit) SearchFacets {
+	cities := make(map[string]int64)
+	eventTypes := make(map[string]int64)
+	categories := make(map[string]int64)
+	tags := make(map[string]int64)
+	var prices PriceRange
+
+	for i, hit := range hits {
+		cities[hit.doc.VenueCity]++
+		eventTypes[hit.doc.EventType]++
+		for _, category := range hit.doc.Categories {
+			categories[category]++
+		}
+		for _, tag := range hit.doc.Tags {
+			tags[tag]++
+		}
+		if i == 0 || hit.doc.BasePrice < prices.Min {
+			prices.Min = hit.doc.BasePrice
+		}
+		if i == 0 || hit.doc.BasePrice > prices.Max {
+			prices.Max = hit.doc.BasePrice
+		}
+	}
+
+	return SearchFacets{
+		Cities:     topFacets(cities, 20),
+		EventTypes: topFacets(eventTypes, 20),
+		Categories: topFacets(categories, 50),
+		Tags:       topFacets(tags, 30),
+		PriceRange: prices,
+	}
+}
+
+// topFacets orders buckets by count and then value, as terms
+// aggregations do, and keeps the first size.
+func topFacets(counts map[string]int64, size int) []FacetItem {
+	var items []FacetItem
+	for value, count := range counts {
+		if value != "" {
+			items = append(items, FacetItem{Value: value, Count: count})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Value < items[j].Value
+	})
+	if len(items) > size {
+		items = items[:size]
+	}
+	return items
+}
+
+func searchResultFromDocument(doc EventDocument) EventSearchResult {
+	return EventSearchResult{
+		EventID:        doc.EventID,
+		Name:           doc.Name,
+		Description:    doc.Description,
+		VenueName:      doc.VenueName,
+		VenueCity:      doc.VenueCity,
+		VenueAddress:   doc.VenueAddress,
+		EventType:      doc.EventType,
+		Categories:     doc.Categories,
+		Tags:           doc.Tags,
+		StartDateTime:  doc.StartDateTime,
+		EndDateTime:    doc.EndDateTime,
+		Timezone:       doc.Timezone,
+		BasePrice:      doc.BasePrice,
+		Currency:       doc.Currency,
+		AvailableSeats: doc.AvailableSeats,
+		Status:         doc.Status,
+		ImageURL:       doc.ImageURL,
+		ThumbnailURL:   doc.ThumbnailURL,
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// followChanges fills a replica's in-memory index from event-service's
+// change feed and keeps it current. Unlike the shared changes sync, every
+// replica follows the feed itself, with the watermark kept in process,
+// since each has its own copy. Writes pushed to this replica are applied
+// straight away as well.
+func (cfg *APIConfig) followChanges(memory *MemoryBackend) {
+	interval := cfg.Config.FallbackSyncInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var since time.Time
+	after := uuid.Nil
+	for {
+		nextSince, nextAfter, err := cfg.followChangesOnce(context.Background(), memory, since, after)
+		if err != nil {
+			cfg.Logger.Warn("In-memory index failed to follow changes, retrying next tick", "error", err)
+		} else {
+			since, after = nextSince, nextAfter
+			memory.MarkLoaded()
+		}
+		<-ticker.C
+	}
+}
+
+// followChangesOnce applies every change after the given position and
+// returns the new position. Like the shared sync, it starts a little
+// before the last change seen to pick up transactions that committed late.
+func (cfg *APIConfig) followChangesOnce(ctx context.Context, memory *MemoryBackend, since time.Time, after uuid.UUID) (time.Time, uuid.UUID, error) {
+	watermark, watermarkID := since, after
+	if !since.IsZero() {
+		since, after = since.Add(-cfg.Config.ChangesSyncOverlap), uuid.Nil
+	}
+
+	for {
+		page, err := cfg.EventServiceClient.GetEventChanges(ctx, since, after, changesSyncPageSize)
+		if err != nil {
+			return time.Time{}, uuid.Nil, err
+		}
+
+		var documents []EventDocument
+		var deleted []uuid.UUID
+		for _, change := range page.Changes {
+			if change.Operation == "upsert" && change.Event != nil {
+				documents = append(documents, cfg.convertEventToDocument(*change.Event))
+			} else {
+				deleted = append(deleted, change.EventID)
+			}
+		}
+		cfg.applyTrendingScores(ctx, documents)
+		memory.IndexEvents(ctx, documents)
+		memory.DeleteEvents(ctx, deleted)
+
+		if len(page.Changes) > 0 {
+			last := page.Changes[len(page.Changes)-1]
+			since, after = last.ChangedAt, last.EventID
+			if last.ChangedAt.After(watermark) {
+				watermark, watermarkID = last.ChangedAt, last.EventID
+			}
+		}
+		if !page.HasMore {
+			return watermark, watermarkID, nil
+		}
+	}
+}
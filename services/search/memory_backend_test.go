@@ -0,0 +1,345 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+// testEventID returns a fixed event ID ending in n, so results can be
+// compared by number.
+func testEventID(n int) uuid.UUID {
+	return uuid.UUID{15: byte(n)}
+}
+
+func testScore(score float64) *float64 {
+	return &score
+}
+
+// testEventStart is a Friday afternoon in UTC.
+var testEventStart = time.Date(2026, 11, 6, 14, 0, 0, 0, time.UTC)
+
+// testDocument returns a published event starting days after
+// testEventStart.
+func testDocument(n int, name, city, eventType string, price float64, days int) EventDocument {
+	start := testEventStart.AddDate(0, 0, days)
+	doc := EventDocument{
+		EventID:        testEventID(n),
+		Name:           name,
+		VenueCity:      city,
+		EventType:      eventType,
+		Categories:     []string{eventType},
+		StartDateTime:  start,
+		StartDate:      start.Format("2006-01-02"),
+		BasePrice:      price,
+		Currency:       "INR",
+		AvailableSeats: 100,
+		Status:         "published",
+		Version:        1,
+	}
+	doc.setLocalStart()
+	return doc
+}
+
+// testMemoryBackend holds five events, one of them a draft:
+//
+//	5 Tech Meetup      Bengaluru conference  20 USD  day 0
+//	1 Jazz Night       Pune      music      500 INR  day 1, trending 5
+//	2 Rock Concert     Mumbai    music     1500 INR  day 2, trending 9
+//	3 Comedy Standup   Pune      comedy     300 INR  day 3
+//	4 Jazz Rehearsal   Pune      music      draft
+func testMemoryBackend(t *testing.T) *MemoryBackend {
+	t.Helper()
+
+	jazz := testDocument(1, "Jazz Night", "Pune", "music", 500, 1)
+	jazz.Categories = []string{"music", "jazz"}
+	jazz.Tags = []string{"live", "jazz"}
+	jazz.Location = &geocode.Point{Lat: 18.52, Lon: 73.85}
+	jazz.TrendingScore = testScore(5)
+
+	rock := testDocument(2, "Rock Concert", "Mumbai", "music", 1500, 2)
+	rock.Tags = []string{"live"}
+	rock.Location = &geocode.Point{Lat: 19.07, Lon: 72.87}
+	rock.TrendingScore = testScore(9)
+
+	comedy := testDocument(3, "Comedy Standup", "Pune", "comedy", 300, 3)
+	comedy.Tags = []string{"standup"}
+
+	draft := testDocument(4, "Jazz Rehearsal", "Pune", "music", 0, 1)
+	draft.Status = "draft"
+
+	meetup := testDocument(5, "Tech Meetup", "Bengaluru", "conference", 20, 0)
+	meetup.Currency = "USD"
+
+	backend := NewMemoryBackend(logger.New("error"))
+	if _, err := backend.IndexEvents(context.Background(), []EventDocument{jazz, rock, comedy, draft, meetup}); err != nil {
+		t.Fatalf("IndexEvents error = %v", err)
+	}
+	return backend
+}
+
+func resultNumbers(results []EventSearchResult) []int {
+	numbers := []int{}
+	for _, result := range results {
+		numbers = append(numbers, int(result.EventID[15]))
+	}
+	return numbers
+}
+
+func TestMemoryBackendSearch(t *testing.T) {
+	pune := &geocode.Point{Lat: 18.52, Lon: 73.85}
+
+	tests := []struct {
+		name  string
+		req   SearchRequest
+		want  []int
+		total int64
+	}{
+		{name: "everything published, soonest first", req: SearchRequest{}, want: []int{5, 1, 2, 3}},
+		{name: "query word", req: SearchRequest{Query: "jazz"}, want: []int{1}},
+		{name: "query with a typo", req: SearchRequest{Query: "jaz"}, want: []int{1}},
+		{name: "long word with two typos", req: SearchRequest{Query: "concrt"}, want: []int{2}},
+		{name: "query matches the venue city", req: SearchRequest{Query: "jazz pune"}, want: []int{1, 3}},
+		{name: "query without a match", req: SearchRequest{Query: "opera"}, want: []int{}},
+		{name: "city", req: SearchRequest{City: "Pune"}, want: []int{1, 3}},
+		{name: "city in another case", req: SearchRequest{City: "pune"}, want: []int{1, 3}},
+		{name: "event type", req: SearchRequest{EventType: "music"}, want: []int{1, 2}},
+		{name: "subcategory", req: SearchRequest{EventType: "jazz"}, want: []int{1}},
+		{name: "one tag", req: SearchRequest{Tags: []string{"live"}}, want: []int{1, 2}},
+		{name: "every tag", req: SearchRequest{Tags: []string{"live", "jazz"}}, want: []int{1}},
+		{name: "currency", req: SearchRequest{Currency: "USD"}, want: []int{5}},
+		{name: "price range", req: SearchRequest{Currency: "INR", MinPrice: 400, MaxPrice: 1000}, want: []int{1}},
+		{name: "from a date", req: SearchRequest{DateFrom: "2026-11-08"}, want: []int{2, 3}},
+		{name: "up to a date", req: SearchRequest{DateTo: "2026-11-07"}, want: []int{5, 1}},
+		{name: "from an instant", req: SearchRequest{DateFrom: "2026-11-07T15:00:00Z"}, want: []int{2, 3}},
+		{name: "latest first", req: SearchRequest{Sort: SortDateDesc}, want: []int{3, 2, 1, 5}},
+		{name: "cheapest first", req: SearchRequest{Currency: "INR", Sort: SortPriceAsc}, want: []int{3, 1, 2}},
+		{name: "dearest first", req: SearchRequest{Currency: "INR", Sort: SortPriceDesc}, want: []int{2, 1, 3}},
+		{name: "popular first, unscored last", req: SearchRequest{Sort: SortPopularity}, want: []int{2, 1, 5, 3}},
+		{name: "within a radius", req: SearchRequest{Near: pune, RadiusKm: 10}, want: []int{1}},
+		{name: "nearest first, unlocated last", req: SearchRequest{Near: pune, Sort: SortDistance}, want: []int{1, 2, 5, 3}},
+		{name: "second page", req: SearchRequest{Page: 2, Limit: 2}, want: []int{2, 3}, total: 4},
+		{name: "past the last page", req: SearchRequest{Page: 3, Limit: 2}, want: []int{}, total: 4},
+	}
+
+	backend := testMemoryBackend(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := backend.Search(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Search error = %v", err)
+			}
+			if got := resultNumbers(result.Results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search = %v, want %v", got, tt.want)
+			}
+			total := tt.total
+			if total == 0 {
+				total = int64(len(tt.want))
+			}
+			if result.Total != total {
+				t.Errorf("Total = %d, want %d", result.Total, total)
+			}
+		})
+	}
+}
+
+func TestMemoryBackendSearchReportsDistance(t *testing.T) {
+	backend := testMemoryBackend(t)
+	result, err := backend.Search(context.Background(), SearchRequest{Near: &geocode.Point{Lat: 18.52, Lon: 73.85}, Sort: SortDistance})
+	if err != nil {
+		t.Fatalf("Search error = %v", err)
+	}
+
+	distances := make(map[int]*float64)
+	for i, n := range resultNumbers(result.Results) {
+		distances[n] = result.Results[i].DistanceKm
+	}
+	if d := distances[1]; d == nil || *d > 0.01 {
+		t.Errorf("distance to the Pune event = %v, want 0", d)
+	}
+	if d := distances[2]; d == nil || *d < 110 || *d > 130 {
+		t.Errorf("distance to the Mumbai event = %v, want about 120 km", d)
+	}
+	if d := distances[3]; d != nil {
+		t.Errorf("distance to an event without a location = %v, want none", *d)
+	}
+}
+
+func TestMemoryBackendFacets(t *testing.T) {
+	backend := testMemoryBackend(t)
+
+	tests := []struct {
+		name string
+		req  SearchRequest
+		want SearchFacets
+	}{
+		{
+			name: "every published event",
+			req:  SearchRequest{},
+			want: SearchFacets{
+				Cities:     []FacetItem{{Value: "Pune", Count: 2}, {Value: "Bengaluru", Count: 1}, {Value: "Mumbai", Count: 1}},
+				EventTypes: []FacetItem{{Value: "music", Count: 2}, {Value: "comedy", Count: 1}, {Value: "conference", Count: 1}},
+				Categories: []FacetItem{{Value: "music", Count: 2}, {Value: "comedy", Count: 1}, {Value: "conference", Count: 1}, {Value: "jazz", Count: 1}},
+				Tags:       []FacetItem{{Value: "live", Count: 2}, {Value: "jazz", Count: 1}, {Value: "standup", Count: 1}},
+				PriceRange: PriceRange{Min: 20, Max: 1500},
+			},
+		},
+		{
+			name: "facets follow the filters, not the page",
+			req:  SearchRequest{City: "Pune", Limit: 1},
+			want: SearchFacets{
+				Cities:     []FacetItem{{Value: "Pune", Count: 2}},
+				EventTypes: []FacetItem{{Value: "comedy", Count: 1}, {Value: "music", Count: 1}},
+				Categories: []FacetItem{{Value: "comedy", Count: 1}, {Value: "jazz", Count: 1}, {Value: "music", Count: 1}},
+				Tags:       []FacetItem{{Value: "jazz", Count: 1}, {Value: "live", Count: 1}, {Value: "standup", Count: 1}},
+				PriceRange: PriceRange{Min: 300, Max: 500},
+			},
+		},
+		{
+			name: "no matches",
+			req:  SearchRequest{City: "Delhi"},
+			want: SearchFacets{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := backend.Search(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Search error = %v", err)
+			}
+			if !reflect.DeepEqual(result.Facets, tt.want) {
+				t.Errorf("Facets = %+v, want %+v", result.Facets, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopFacetsLimit(t *testing.T) {
+	counts := map[string]int64{"a": 1, "b": 3, "c": 2, "d": 2, "": 9}
+	want := []FacetItem{{Value: "b", Count: 3}, {Value: "c", Count: 2}, {Value: "d", Count: 2}}
+	if got := topFacets(counts, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("topFacets = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryBackendIndexing(t *testing.T) {
+	ctx := context.Background()
+	backend := testMemoryBackend(t)
+
+	if err := backend.HealthCheck(ctx); err == nil {
+		t.Error("HealthCheck passed before the index was loaded")
+	}
+	backend.MarkLoaded()
+	if err := backend.HealthCheck(ctx); err != nil {
+		t.Errorf("HealthCheck error = %v after loading", err)
+	}
+
+	// An older version read from the change feed does not replace a newer
+	// push, and a newer one without a trending score keeps the stored one.
+	older := testDocument(1, "Jazz Night (old)", "Pune", "music", 500, 1)
+	older.Version = 0
+	newer := testDocument(1, "Jazz Night Live", "Pune", "music", 500, 1)
+	newer.Version = 2
+	backend.IndexEvents(ctx, []EventDocument{older})
+	backend.IndexEvents(ctx, []EventDocument{newer})
+	backend.IndexEvents(ctx, []EventDocument{older})
+
+	result, _ := backend.Search(ctx, SearchRequest{City: "Pune", Sort: SortPopularity})
+	if len(result.Results) == 0 || result.Results[0].Name != "Jazz Night Live" {
+		t.Fatalf("Search = %+v, want the newest version first", result.Results)
+	}
+	if score := result.Results[0].TrendingScore; score == nil || *score != 5 {
+		t.Errorf("TrendingScore = %v, want the stored 5", score)
+	}
+
+	backend.UpdateTrendingScores(ctx, map[uuid.UUID]float64{testEventID(3): 20, testEventID(99): 1})
+	result, _ = backend.Search(ctx, SearchRequest{Sort: SortPopularity})
+	if got := resultNumbers(result.Results); !reflect.DeepEqual(got, []int{3, 2, 1, 5}) {
+		t.Errorf("Search after rescoring = %v, want [3 2 1 5]", got)
+	}
+
+	backend.DeleteEvents(ctx, []uuid.UUID{testEventID(2), testEventID(5)})
+	result, _ = backend.Search(ctx, SearchRequest{})
+	if got := resultNumbers(result.Results); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Search after deleting = %v, want [1 3]", got)
+	}
+}
+
+func TestMemoryBackendSuggestions(t *testing.T) {
+	backend := testMemoryBackend(t)
+
+	tests := []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{prefix: "ja", limit: 10, want: []string{"Jazz Night"}},
+		{prefix: " JAZZ ", limit: 10, want: []string{"Jazz Night"}},
+		{prefix: "", limit: 2, want: []string{"Comedy Standup", "Jazz Night"}},
+		{prefix: "zz", limit: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		got, err := backend.GetSuggestions(context.Background(), tt.prefix, tt.limit)
+		if err != nil {
+			t.Fatalf("GetSuggestions error = %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetSuggestions(%q, %d) = %v, want %v", tt.prefix, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyWordMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		word  string
+		want  bool
+	}{
+		{query: "jazz", word: "jazz", want: true},
+		{query: "ja", word: "jo", want: false},
+		{query: "jaz", word: "jazz", want: true},
+		{query: "jazz", word: "jaz", want: true},
+		{query: "jzaz", word: "jazz", want: true},
+		{query: "azz", word: "jazz", want: false},
+		{query: "jaxx", word: "jazz", want: false},
+		{query: "festivl", word: "festival", want: true},
+		{query: "fstivl", word: "festival", want: true},
+		{query: "fstvl", word: "festival", want: false},
+		{query: "concret", word: "concert", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyWordMatch(tt.query, tt.word); got != tt.want {
+			t.Errorf("fuzzyWordMatch(%q, %q) = %v, want %v", tt.query, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryTextMatches(t *testing.T) {
+	words := splitWords("Jazz Night at the Blue Frog, Pune")
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: true},
+		{query: "blue frog", want: true},
+		{query: "jazz night mumbai", want: true},
+		{query: "jazz mumbai delhi", want: false},
+		{query: "rock jazz blues frog", want: true},
+		{query: "rock jazz soul funk", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := memoryTextMatches(words, splitWords(tt.query)); got != tt.want {
+			t.Errorf("memoryTextMatches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package search
 
 import (
+	"net/netip"
+	"sync/atomic"
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -15,20 +18,77 @@ type APIConfig struct {
 	ESClient      *ElasticsearchClient
 	RedisClient   *redis.Client
 	EventServiceClient *EventServiceClient
+	// UserServiceClient and BookingServiceClient are nil when their URLs are
+	// not configured.
+	UserServiceClient    *UserServiceClient
+	BookingServiceClient *BookingServiceClient
+	// Backend serves searches, and Fallback, if set, serves reads while
+	// Backend is unhealthy. ESClient is nil unless Elasticsearch is the
+	// backend.
+	Backend  SearchBackend
+	Fallback SearchBackend
+	degraded atomic.Bool
+	// savedSearches holds the saved searches with alerts on, for matching
+	// newly indexed events.
+	savedSearches savedSearchMatcher
+	searchFlights searchFlights
+	// trustedProxies are the gateway networks whose client address headers
+	// are believed.
+	trustedProxies []netip.Prefix
 }
 
 type SearchRequest struct {
 	Query     string  `json:"q"`
 	City      string  `json:"city"`
 	EventType string  `json:"type"`
+	Tags      []string `json:"tags,omitempty"`
 	DateFrom  string  `json:"date_from"`
 	DateTo    string  `json:"date_to"`
 	MinPrice  float64 `json:"min_price"`
 	MaxPrice  float64 `json:"max_price"`
+	Currency  string  `json:"currency"`
+	Near      *geocode.Point `json:"near,omitempty"`
+	RadiusKm  float64 `json:"radius_km"`
+	// Available keeps events with seats left, and MinSeats those with at
+	// least that many.
+	Available bool    `json:"available,omitempty"`
+	MinSeats  int     `json:"min_seats,omitempty"`
+	VenueID   string  `json:"venue_id,omitempty"`
+	// Days (ISO weekdays, 1 for Monday) and TimeFrom and TimeTo (HH:MM)
+	// match the start in the venue's local time.
+	Days      []int   `json:"days,omitempty"`
+	TimeFrom  string  `json:"time_from,omitempty"`
+	TimeTo    string  `json:"time_to,omitempty"`
+	Sort      string  `json:"sort"`
 	Page      int     `json:"page"`
 	Limit     int     `json:"limit"`
 }
 
+const (
+	SortRelevance  = "relevance"
+	SortDate       = "date_asc"
+	SortDateDesc   = "date_desc"
+	SortPriceAsc   = "price_asc"
+	SortPriceDesc  = "price_desc"
+	SortPopularity = "popularity"
+	SortDistance   = "distance"
+	// SortTrending ranks by trending score like SortPopularity, and also
+	// reports each event's stored score; it backs the trending endpoint
+	// rather than being a search option.
+	SortTrending = "trending"
+
+	maxRadiusKm = 20000
+	// maxOffsetResults is as deep as from/size paging can reach in
+	// Elasticsearch; deeper pages need a cursor.
+	maxOffsetResults = 10000
+	// pointInTimeKeepAlive is how long a cursor stays usable after each
+	// page.
+	pointInTimeKeepAlive = "2m"
+	// Hits without a location get a huge sort distance from Elasticsearch
+	// instead of an error; anything past half the globe is one of those.
+	maxSortDistanceKm = 20050
+)
+
 type SearchResponse struct {
 	Results   []EventSearchResult `json:"results"`
 	Total     int64               `json:"total"`
@@ -36,6 +96,34 @@ type SearchResponse struct {
 	Limit     int                 `json:"limit"`
 	QueryTime string              `json:"query_time"`
 	Facets    SearchFacets        `json:"facets"`
+	// NextCursor fetches the following page of a cursor walk; it is empty
+	// on the last page and for offset paging.
+	NextCursor string             `json:"next_cursor,omitempty"`
+	// Sort and Filters echo how the results were ordered and narrowed.
+	Sort       string             `json:"sort,omitempty"`
+	Filters    *AppliedFilters    `json:"filters,omitempty"`
+}
+
+// AppliedFilters are the filters of a search, named as its parameters.
+type AppliedFilters struct {
+	Query     string   `json:"q,omitempty"`
+	City      string   `json:"city,omitempty"`
+	EventType string   `json:"type,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	DateFrom  string   `json:"date_from,omitempty"`
+	DateTo    string   `json:"date_to,omitempty"`
+	MinPrice  float64  `json:"min_price,omitempty"`
+	MaxPrice  float64  `json:"max_price,omitempty"`
+	Currency  string   `json:"currency,omitempty"`
+	Lat       *float64 `json:"lat,omitempty"`
+	Lon       *float64 `json:"lon,omitempty"`
+	RadiusKm  float64  `json:"radius_km,omitempty"`
+	Available bool     `json:"available,omitempty"`
+	MinSeats  int      `json:"min_seats,omitempty"`
+	VenueID   string   `json:"venue_id,omitempty"`
+	Days      []string `json:"days,omitempty"`
+	TimeFrom  string   `json:"time_from,omitempty"`
+	TimeTo    string   `json:"time_to,omitempty"`
 }
 
 type EventSearchResult struct {
@@ -46,17 +134,27 @@ type EventSearchResult struct {
 	VenueCity     string    `json:"venue_city"`
 	VenueAddress  string    `json:"venue_address,omitempty"`
 	EventType     string    `json:"event_type"`
+	Categories    []string  `json:"categories,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
 	StartDateTime time.Time `json:"start_datetime"`
 	EndDateTime   time.Time `json:"end_datetime"`
+	Timezone      string    `json:"timezone,omitempty"`
 	BasePrice     float64   `json:"base_price"`
+	Currency      string    `json:"currency,omitempty"`
 	AvailableSeats int32    `json:"available_seats"`
 	Status        string    `json:"status"`
+	ImageURL      string    `json:"image_url,omitempty"`
+	ThumbnailURL  string    `json:"thumbnail_url,omitempty"`
+	DistanceKm    *float64  `json:"distance_km,omitempty"`
+	TrendingScore *float64  `json:"trending_score,omitempty"`
 	Score         float64   `json:"score"`
 }
 
 type SearchFacets struct {
 	Cities     []FacetItem `json:"cities"`
 	EventTypes []FacetItem `json:"event_types"`
+	Categories []FacetItem `json:"categories"`
+	Tags       []FacetItem `json:"tags"`
 	PriceRange PriceRange  `json:"price_range"`
 }
 
@@ -82,6 +180,8 @@ type SuggestionsResponse struct {
 type FiltersResponse struct {
 	Cities     []string   `json:"cities"`
 	EventTypes []string   `json:"event_types"`
+	Categories []string   `json:"categories"`
+	Tags       []string   `json:"tags"`
 	PriceRange PriceRange `json:"price_range"`
 }
 
@@ -89,6 +189,108 @@ type TrendingEventsResponse struct {
 	Events []EventSearchResult `json:"events"`
 }
 
+// RecommendationQuery describes what recommended events should resemble.
+// Like lists indexed events to compare wording with; the other lists are
+// matched exactly.
+type RecommendationQuery struct {
+	Like       []uuid.UUID
+	Exclude    []uuid.UUID
+	EventTypes []string
+	Categories []string
+	Tags       []string
+	Cities     []string
+	VenueIDs   []uuid.UUID
+	Limit      int
+}
+
+type RecommendationsResponse struct {
+	Events []EventSearchResult `json:"events"`
+	// Strategy is "similar" for similar events, and "personalized" or
+	// "trending" for the for-you feed, depending on what it was built from.
+	Strategy string `json:"strategy"`
+}
+
+// SavedSearchCriteria are the search parameters a saved search keeps, named
+// as in GET /api/v1/search.
+type SavedSearchCriteria struct {
+	Query     string   `json:"q,omitempty"`
+	City      string   `json:"city,omitempty"`
+	EventType string   `json:"type,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	DateFrom  string   `json:"date_from,omitempty"`
+	DateTo    string   `json:"date_to,omitempty"`
+	MinPrice  float64  `json:"min_price,omitempty"`
+	MaxPrice  float64  `json:"max_price,omitempty"`
+	Currency  string   `json:"currency,omitempty"`
+	Lat       *float64 `json:"lat,omitempty"`
+	Lon       *float64 `json:"lon,omitempty"`
+	RadiusKm  float64  `json:"radius_km,omitempty"`
+}
+
+type SavedSearch struct {
+	ID            uuid.UUID           `json:"id"`
+	UserID        uuid.UUID           `json:"user_id"`
+	Name          string              `json:"name"`
+	Search        SavedSearchCriteria `json:"search"`
+	Frequency     string              `json:"frequency"`
+	AlertsEnabled bool                `json:"alerts_enabled"`
+	LastAlertedAt *time.Time          `json:"last_alerted_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type CreateSavedSearchRequest struct {
+	Name          string              `json:"name"`
+	Search        SavedSearchCriteria `json:"search"`
+	Frequency     string              `json:"frequency"`
+	AlertsEnabled *bool               `json:"alerts_enabled"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name          *string              `json:"name"`
+	Search        *SavedSearchCriteria `json:"search"`
+	Frequency     *string              `json:"frequency"`
+	AlertsEnabled *bool                `json:"alerts_enabled"`
+}
+
+type SavedSearchesResponse struct {
+	SavedSearches []SavedSearch `json:"saved_searches"`
+	Count         int           `json:"count"`
+}
+
+// SearchAlert tells a user about events that newly match one of their
+// saved searches.
+type SearchAlert struct {
+	ID              uuid.UUID    `json:"id"`
+	SavedSearchID   uuid.UUID    `json:"saved_search_id"`
+	SavedSearchName string       `json:"saved_search_name"`
+	Events          []AlertEvent `json:"events"`
+	// MatchCount counts every match since the previous alert; Events lists
+	// the soonest of them.
+	MatchCount int       `json:"match_count"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AlertEvent struct {
+	EventID       uuid.UUID `json:"event_id"`
+	Name          string    `json:"name"`
+	VenueName     string    `json:"venue_name"`
+	VenueCity     string    `json:"venue_city"`
+	StartDateTime time.Time `json:"start_datetime"`
+	BasePrice     float64   `json:"base_price"`
+	Currency      string    `json:"currency"`
+}
+
+type SearchAlertsResponse struct {
+	Alerts []SearchAlert `json:"alerts"`
+	Count  int           `json:"count"`
+}
+
+type UnsubscribeAlertsResponse struct {
+	Status       string `json:"status"`
+	Unsubscribed int    `json:"unsubscribed"`
+}
+
 type EventDocument struct {
 	EventID       uuid.UUID `json:"event_id"`
 	Name          string    `json:"name"`
@@ -99,14 +301,35 @@ type EventDocument struct {
 	VenueCity     string    `json:"venue_city"`
 	VenueState    string    `json:"venue_state,omitempty"`
 	VenueCountry  string    `json:"venue_country"`
+	Location      *geocode.Point `json:"location,omitempty"`
 	EventType     string    `json:"event_type"`
+	// Categories is the event's category with its parent first, so a
+	// filter on the parent also finds subcategory events.
+	Categories    []string  `json:"categories,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
 	StartDateTime time.Time `json:"start_datetime"`
 	EndDateTime   time.Time `json:"end_datetime"`
+	Timezone      string    `json:"timezone"`
+	// StartDate is the calendar date the event starts on at its venue,
+	// which is what date-only filters match.
+	StartDate     string    `json:"start_date"`
+	// StartWeekday (ISO, 1 for Monday) and StartMinute (minutes after
+	// midnight) are when the event starts in its venue's week and day.
+	// search-service fills them in; see setLocalStart.
+	StartWeekday  int       `json:"start_weekday"`
+	StartMinute   int       `json:"start_minute"`
 	BasePrice     float64   `json:"base_price"`
+	Currency      string    `json:"currency,omitempty"`
 	AvailableSeats int32    `json:"available_seats"`
 	TotalCapacity int32     `json:"total_capacity"`
 	Status        string    `json:"status"`
 	Version       int32     `json:"version"`
+	ImageURL      string    `json:"image_url,omitempty"`
+	ThumbnailURL  string    `json:"thumbnail_url,omitempty"`
+	BannerURL     string    `json:"banner_url,omitempty"`
+	// TrendingScore is maintained by search-service, not sent by
+	// event-service; see trending.go.
+	TrendingScore *float64  `json:"trending_score,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
@@ -119,6 +342,36 @@ type FullResyncResponse struct {
 	Message       string `json:"message"`
 	EventsIndexed int    `json:"events_indexed"`
 	TimeTaken     string `json:"time_taken"`
+	// Index is the new index version, for resyncs that built one.
+	Index         string `json:"index,omitempty"`
+}
+
+type ChangesSyncResponse struct {
+	Upserted  int        `json:"upserted"`
+	Deleted   int        `json:"deleted"`
+	Watermark *time.Time `json:"watermark,omitempty"`
+	TimeTaken string     `json:"time_taken"`
+}
+
+type ChangesSyncStatusResponse struct {
+	Watermark *time.Time `json:"watermark,omitempty"`
+	Interval  string     `json:"interval"`
+	Running   bool       `json:"running"`
+}
+
+type IndexVersionsResponse struct {
+	Alias    string         `json:"alias"`
+	Building string         `json:"building,omitempty"`
+	Versions []IndexVersion `json:"versions"`
+}
+
+type RollbackIndexRequest struct {
+	Index string `json:"index"`
+}
+
+type RollbackIndexResponse struct {
+	Status string `json:"status"`
+	Index  string `json:"index"`
 }
 
 type IndexEventRequest struct {
@@ -130,6 +383,29 @@ type IndexEventResponse struct {
 	EventID string `json:"event_id"`
 }
 
+type BulkIndexEventsRequest struct {
+	Events []EventDocument `json:"events"`
+}
+
+type BulkIndexEventsResponse struct {
+	Status  string `json:"status"`
+	Indexed int    `json:"indexed"`
+}
+
+type RecordViewResponse struct {
+	Status  string `json:"status"`
+	EventID string `json:"event_id"`
+}
+
+type SynonymsRequest struct {
+	Synonyms []string `json:"synonyms"`
+}
+
+type SynonymsResponse struct {
+	Synonyms []string `json:"synonyms"`
+	Count    int      `json:"count"`
+}
+
 type DeleteEventRequest struct {
 	EventID uuid.UUID `json:"event_id"`
 }
@@ -143,5 +419,6 @@ type HealthResponse struct {
 	Status        string `json:"status"`
 	Elasticsearch string `json:"elasticsearch"`
 	Redis         string `json:"redis"`
+	Backend       string `json:"backend"`
 	Service       string `json:"service"`
 }
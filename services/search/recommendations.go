@@ -0,0 +1,247 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRecommendations = 10
+	maxRecommendations     = 50
+	// forYouBookedEvents is how many of a user's most recent bookings the
+	// for-you feed is built from.
+	forYouBookedEvents = 20
+
+	StrategySimilar      = "similar"
+	StrategyPersonalized = "personalized"
+	StrategyTrending     = "trending"
+)
+
+var errEventNotIndexed = errors.New("event is not indexed")
+
+func recommendationLimit(r *http.Request) int {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 || limit > maxRecommendations {
+		return defaultRecommendations
+	}
+	return limit
+}
+
+// GetSimilarEvents lists upcoming events like the given one: the same type,
+// venue or category, or a similar name, description and tags.
+func (cfg *APIConfig) GetSimilarEvents(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	limit := recommendationLimit(r)
+
+	cacheParams := fmt.Sprintf("similar:%s:%d", eventID, limit)
+	result, _, err := cfg.cachedSearch(r.Context(), cacheParams, func() (*SearchResponse, error) {
+		docs, err := cfg.ESClient.GetDocuments(r.Context(), []uuid.UUID{eventID})
+		if err != nil {
+			return nil, err
+		}
+		if len(docs) == 0 {
+			return nil, errEventNotIndexed
+		}
+		source := docs[0]
+
+		return cfg.ESClient.Recommend(r.Context(), RecommendationQuery{
+			Like:       []uuid.UUID{eventID},
+			Exclude:    []uuid.UUID{eventID},
+			EventTypes: []string{source.EventType},
+			Categories: source.Categories,
+			VenueIDs:   []uuid.UUID{source.VenueID},
+			Limit:      limit,
+		})
+	})
+	if errors.Is(err, errEventNotIndexed) {
+		utils.RespondWithError(w, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err != nil {
+		cfg.Logger.Error("Failed to find similar events", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to find similar events")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, RecommendationsResponse{
+		Events:   nonNilResults(result.Results),
+		Strategy: StrategySimilar,
+	})
+}
+
+// GetForYouEvents recommends upcoming events from what the user booked and
+// the preferences they saved. Users with neither, anyone whose
+// recommendations run short, and everyone while Elasticsearch is
+// unavailable, get trending events instead.
+func (cfg *APIConfig) GetForYouEvents(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	limit := recommendationLimit(r)
+
+	var query RecommendationQuery
+	var events []EventSearchResult
+	if cfg.ESClient != nil && cfg.readBackend(r.Context()) == cfg.Backend {
+		query = cfg.userRecommendationQuery(r.Context(), userID)
+		query.Limit = limit
+
+		result, err := cfg.ESClient.Recommend(r.Context(), query)
+		if err != nil {
+			cfg.Logger.Warn("Personalized recommendations failed, falling back to trending", "error", err, "user_id", userID)
+		} else {
+			events = result.Results
+		}
+	}
+
+	strategy := StrategyPersonalized
+	if len(events) == 0 {
+		strategy = StrategyTrending
+	}
+
+	if len(events) < limit {
+		trending, err := cfg.trendingExcept(r.Context(), limit-len(events), query.Exclude, events)
+		if err != nil {
+			cfg.Logger.Error("Failed to get trending events for recommendations", "error", err, "user_id", userID)
+			if len(events) == 0 {
+				utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get recommendations")
+				return
+			}
+		}
+		events = append(events, trending...)
+	}
+
+	response := &SearchResponse{Results: events}
+	cfg.applySeatOverlay(r.Context(), response)
+
+	utils.RespondWithJSON(w, http.StatusOK, RecommendationsResponse{
+		Events:   nonNilResults(response.Results),
+		Strategy: strategy,
+	})
+}
+
+// userRecommendationQuery gathers what a user's recommendations should
+// resemble. Either source failing only leaves its signals out.
+func (cfg *APIConfig) userRecommendationQuery(ctx context.Context, userID uuid.UUID) RecommendationQuery {
+	var query RecommendationQuery
+
+	if cfg.BookingServiceClient != nil {
+		booked, err := cfg.BookingServiceClient.GetBookedEvents(ctx, userID, forYouBookedEvents)
+		if err != nil {
+			cfg.Logger.Warn("Failed to load booking history for recommendations", "error", err, "user_id", userID)
+		}
+
+		bookedIDs := make([]uuid.UUID, len(booked))
+		for i, event := range booked {
+			bookedIDs[i] = event.EventID
+		}
+		query.Exclude = bookedIDs
+
+		docs, err := cfg.ESClient.GetDocuments(ctx, bookedIDs)
+		if err != nil {
+			cfg.Logger.Warn("Failed to load booked events for recommendations", "error", err, "user_id", userID)
+		}
+		for _, doc := range docs {
+			query.Like = append(query.Like, doc.EventID)
+			query.EventTypes = appendUnique(query.EventTypes, doc.EventType)
+			query.VenueIDs = appendUniqueID(query.VenueIDs, doc.VenueID)
+			if doc.VenueCity != "" {
+				query.Cities = appendUnique(query.Cities, doc.VenueCity)
+			}
+		}
+	}
+
+	if cfg.UserServiceClient != nil {
+		prefs, err := cfg.UserServiceClient.GetPreferences(ctx, userID)
+		if err != nil {
+			cfg.Logger.Warn("Failed to load preferences for recommendations", "error", err, "user_id", userID)
+		} else {
+			query.Categories = appendUnique(query.Categories, prefs.Categories...)
+			query.Tags = appendUnique(query.Tags, prefs.Tags...)
+			query.Cities = appendUnique(query.Cities, prefs.Cities...)
+		}
+	}
+
+	return query
+}
+
+// trendingExcept returns up to limit trending events that are neither
+// excluded nor already listed.
+func (cfg *APIConfig) trendingExcept(ctx context.Context, limit int, exclude []uuid.UUID, listed []EventSearchResult) ([]EventSearchResult, error) {
+	skip := make(map[uuid.UUID]bool, len(exclude)+len(listed))
+	for _, eventID := range exclude {
+		skip[eventID] = true
+	}
+	for _, event := range listed {
+		skip[event.EventID] = true
+	}
+
+	result, err := cfg.search(ctx, SearchRequest{
+		DateFrom: time.Now().UTC().Format(time.RFC3339),
+		Sort:     SortTrending,
+		Page:     1,
+		Limit:    limit + len(skip),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []EventSearchResult
+	for _, event := range result.Results {
+		if skip[event.EventID] {
+			continue
+		}
+		// Only the trending endpoint reports scores, decayed to now.
+		event.TrendingScore = nil
+		events = append(events, event)
+		if len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+func appendUnique(values []string, more ...string) []string {
+	for _, value := range more {
+		seen := false
+		for _, existing := range values {
+			if existing == value {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func appendUniqueID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+func nonNilResults(results []EventSearchResult) []EventSearchResult {
+	if results == nil {
+		return []EventSearchResult{}
+	}
+	return results
+}
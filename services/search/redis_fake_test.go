@@ -0,0 +1,505 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis answers the Redis commands this service uses from memory, by
+// hooking a go-redis client so no server is needed. Keys never expire and
+// Lua scripts fail, so code paths that rely on either are out of reach.
+type fakeRedis struct {
+	mu   sync.Mutex
+	data map[string]any // string, hash, set or sorted set
+	// fail, when set, is returned by every command whose name it maps.
+	fail map[string]error
+}
+
+// newFakeRedis returns a client backed by a fresh fakeRedis.
+func newFakeRedis(t *testing.T) (*redis.Client, *fakeRedis) {
+	t.Helper()
+	fake := &fakeRedis{data: map[string]any{}, fail: map[string]error{}}
+	client := redis.NewClient(&redis.Options{Addr: "fake-redis:6379"})
+	client.AddHook(fake)
+	t.Cleanup(func() { client.Close() })
+	return client, fake
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("fake redis does not dial")
+	}
+}
+
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.process(cmd)
+		return cmd.Err()
+	}
+}
+
+// ProcessPipelineHook runs a pipeline's commands in order. MULTI and EXEC
+// around a transaction are acknowledged and otherwise ignored, since every
+// command already runs under the lock.
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var firstErr error
+		for _, cmd := range cmds {
+			switch cmd.Name() {
+			case "multi":
+				cmd.(*redis.StatusCmd).SetVal("OK")
+				continue
+			case "exec":
+				cmd.(*redis.SliceCmd).SetVal(nil)
+				continue
+			}
+			f.process(cmd)
+			if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+// failCommand makes every later command of that name fail with err.
+func (f *fakeRedis) failCommand(name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fail[name] = err
+}
+
+func (f *fakeRedis) get(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key].(string)
+	return value, ok
+}
+
+func (f *fakeRedis) hash(key string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return maps(f.data[key])
+}
+
+func (f *fakeRedis) members(key string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	set, _ := f.data[key].(map[string]bool)
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	slices.Sort(members)
+	return members
+}
+
+func (f *fakeRedis) keys(prefix string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func maps(value any) map[string]string {
+	hash, _ := value.(map[string]string)
+	copied := make(map[string]string, len(hash))
+	for field, value := range hash {
+		copied[field] = value
+	}
+	return copied
+}
+
+func (f *fakeRedis) process(cmd redis.Cmder) {
+	name := cmd.Name()
+	if err, ok := f.fail[name]; ok {
+		cmd.SetErr(err)
+		return
+	}
+
+	args := make([]string, len(cmd.Args()))
+	for i, arg := range cmd.Args() {
+		args[i] = fakeRedisArg(arg)
+	}
+	args = args[1:]
+
+	switch name {
+	case "ping":
+		cmd.(*redis.StatusCmd).SetVal("PONG")
+	case "get":
+		f.getCmd(cmd, args[0])
+	case "set":
+		f.setCmd(cmd, args)
+	case "getset":
+		f.getCmd(cmd, args[0])
+		f.data[args[0]] = args[1]
+	case "incr":
+		value, _ := strconv.ParseInt(fakeString(f.data[args[0]]), 10, 64)
+		value++
+		f.data[args[0]] = strconv.FormatInt(value, 10)
+		cmd.(*redis.IntCmd).SetVal(value)
+	case "del", "exists":
+		count := int64(0)
+		for _, key := range args {
+			if _, ok := f.data[key]; ok {
+				count++
+				if name == "del" {
+					delete(f.data, key)
+				}
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(count)
+	case "mget":
+		values := make([]any, len(args))
+		for i, key := range args {
+			if value, ok := f.data[key].(string); ok {
+				values[i] = value
+			}
+		}
+		cmd.(*redis.SliceCmd).SetVal(values)
+	case "hset":
+		hash := f.hashFor(args[0])
+		added := int64(0)
+		for i := 1; i+1 < len(args); i += 2 {
+			if _, ok := hash[args[i]]; !ok {
+				added++
+			}
+			hash[args[i]] = args[i+1]
+		}
+		cmd.(*redis.IntCmd).SetVal(added)
+	case "hget":
+		if value, ok := f.hashFor(args[0])[args[1]]; ok {
+			cmd.(*redis.StringCmd).SetVal(value)
+		} else {
+			cmd.SetErr(redis.Nil)
+		}
+	case "hmget":
+		hash := f.hashFor(args[0])
+		values := make([]any, len(args)-1)
+		for i, field := range args[1:] {
+			if value, ok := hash[field]; ok {
+				values[i] = value
+			}
+		}
+		cmd.(*redis.SliceCmd).SetVal(values)
+	case "hgetall":
+		cmd.(*redis.MapStringStringCmd).SetVal(maps(f.data[args[0]]))
+	case "hdel":
+		hash := f.hashFor(args[0])
+		removed := int64(0)
+		for _, field := range args[1:] {
+			if _, ok := hash[field]; ok {
+				delete(hash, field)
+				removed++
+			}
+		}
+		f.dropIfEmpty(args[0], len(hash))
+		cmd.(*redis.IntCmd).SetVal(removed)
+	case "hscan":
+		f.hscanCmd(cmd, args)
+	case "sadd", "srem":
+		set := f.setFor(args[0])
+		changed := int64(0)
+		for _, member := range args[1:] {
+			if set[member] != (name == "sadd") {
+				changed++
+			}
+			if name == "sadd" {
+				set[member] = true
+			} else {
+				delete(set, member)
+			}
+		}
+		f.dropIfEmpty(args[0], len(set))
+		cmd.(*redis.IntCmd).SetVal(changed)
+	case "smembers":
+		set := f.setFor(args[0])
+		members := make([]string, 0, len(set))
+		for member := range set {
+			members = append(members, member)
+		}
+		f.dropIfEmpty(args[0], len(set))
+		cmd.(*redis.StringSliceCmd).SetVal(members)
+	case "spop":
+		set := f.setFor(args[0])
+		count := 1
+		if len(args) > 1 {
+			count, _ = strconv.Atoi(args[1])
+		}
+		var popped []string
+		for member := range set {
+			if len(popped) == count {
+				break
+			}
+			popped = append(popped, member)
+			delete(set, member)
+		}
+		f.dropIfEmpty(args[0], len(set))
+		cmd.(*redis.StringSliceCmd).SetVal(popped)
+	case "zadd":
+		f.zaddCmd(cmd, args)
+	case "zrem":
+		set := f.zsetFor(args[0])
+		removed := int64(0)
+		for _, member := range args[1:] {
+			if _, ok := set[member]; ok {
+				delete(set, member)
+				removed++
+			}
+		}
+		f.dropIfEmpty(args[0], len(set))
+		cmd.(*redis.IntCmd).SetVal(removed)
+	case "zrangebyscore":
+		f.zrangeByScoreCmd(cmd, args)
+	default:
+		cmd.SetErr(fmt.Errorf("fake redis does not support %s", strings.ToUpper(name)))
+	}
+}
+
+func (f *fakeRedis) getCmd(cmd redis.Cmder, key string) {
+	value, ok := f.data[key].(string)
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return
+	}
+	cmd.(*redis.StringCmd).SetVal(value)
+}
+
+// setCmd handles SET with the EX, PX, NX, XX and GET options.
+func (f *fakeRedis) setCmd(cmd redis.Cmder, args []string) {
+	key, value := args[0], args[1]
+	nx, xx, get := false, false, false
+	for _, option := range args[2:] {
+		switch strings.ToLower(option) {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "get":
+			get = true
+		}
+	}
+	old, exists := f.data[key]
+	if (nx && exists) || (xx && !exists) {
+		switch cmd := cmd.(type) {
+		case *redis.BoolCmd:
+			cmd.SetVal(false)
+		default:
+			cmd.SetErr(redis.Nil)
+		}
+		return
+	}
+	f.data[key] = value
+	if get {
+		if exists {
+			cmd.(*redis.StatusCmd).SetVal(fakeString(old))
+		} else {
+			cmd.SetErr(redis.Nil)
+		}
+		return
+	}
+	switch cmd := cmd.(type) {
+	case *redis.BoolCmd:
+		cmd.SetVal(true)
+	case *redis.StatusCmd:
+		cmd.SetVal("OK")
+	}
+}
+
+// hscanCmd returns a hash's fields in order, COUNT at a time, with the
+// cursor being the offset of the next page.
+func (f *fakeRedis) hscanCmd(cmd redis.Cmder, args []string) {
+	hash := f.hashFor(args[0])
+	cursor, _ := strconv.Atoi(args[1])
+	count := 10
+	for i := 2; i+1 < len(args); i += 2 {
+		if strings.EqualFold(args[i], "count") {
+			count, _ = strconv.Atoi(args[i+1])
+		}
+	}
+	f.dropIfEmpty(args[0], len(hash))
+
+	fields := make([]string, 0, len(hash))
+	for field := range hash {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	end := min(cursor+count, len(fields))
+	var page []string
+	for _, field := range fields[min(cursor, end):end] {
+		page = append(page, field, hash[field])
+	}
+	next := uint64(end)
+	if end >= len(fields) {
+		next = 0
+	}
+	cmd.(*redis.ScanCmd).SetVal(page, next)
+}
+
+func (f *fakeRedis) zaddCmd(cmd redis.Cmder, args []string) {
+	set := f.zsetFor(args[0])
+	rest := args[1:]
+	nx := false
+	if len(rest) > 0 && strings.EqualFold(rest[0], "nx") {
+		nx, rest = true, rest[1:]
+	}
+	added := int64(0)
+	for i := 0; i+1 < len(rest); i += 2 {
+		score, _ := strconv.ParseFloat(rest[i], 64)
+		member := rest[i+1]
+		if _, ok := set[member]; ok {
+			if nx {
+				continue
+			}
+		} else {
+			added++
+		}
+		set[member] = score
+	}
+	cmd.(*redis.IntCmd).SetVal(added)
+}
+
+func (f *fakeRedis) zrangeByScoreCmd(cmd redis.Cmder, args []string) {
+	set := f.zsetFor(args[0])
+	low, lowOpen := fakeScoreBound(args[1])
+	high, highOpen := fakeScoreBound(args[2])
+	offset, count := 0, -1
+	for i := 3; i+2 < len(args); i++ {
+		if strings.EqualFold(args[i], "limit") {
+			offset, _ = strconv.Atoi(args[i+1])
+			count, _ = strconv.Atoi(args[i+2])
+		}
+	}
+	f.dropIfEmpty(args[0], len(set))
+
+	var members []string
+	for member, score := range set {
+		if score < low || (lowOpen && score == low) || score > high || (highOpen && score == high) {
+			continue
+		}
+		members = append(members, member)
+	}
+	slices.SortFunc(members, func(a, b string) int {
+		if set[a] != set[b] {
+			if set[a] < set[b] {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	if offset > len(members) {
+		offset = len(members)
+	}
+	members = members[offset:]
+	if count >= 0 && count < len(members) {
+		members = members[:count]
+	}
+	cmd.(*redis.StringSliceCmd).SetVal(members)
+}
+
+func fakeScoreBound(value string) (float64, bool) {
+	open := strings.HasPrefix(value, "(")
+	value = strings.TrimPrefix(value, "(")
+	switch value {
+	case "-inf":
+		return math.Inf(-1), open
+	case "+inf", "inf":
+		return math.Inf(1), open
+	}
+	score, _ := strconv.ParseFloat(value, 64)
+	return score, open
+}
+
+func (f *fakeRedis) hashFor(key string) map[string]string {
+	hash, ok := f.data[key].(map[string]string)
+	if !ok {
+		hash = map[string]string{}
+		f.data[key] = hash
+	}
+	return hash
+}
+
+func (f *fakeRedis) setFor(key string) map[string]bool {
+	set, ok := f.data[key].(map[string]bool)
+	if !ok {
+		set = map[string]bool{}
+		f.data[key] = set
+	}
+	return set
+}
+
+func (f *fakeRedis) zsetFor(key string) map[string]float64 {
+	set, ok := f.data[key].(map[string]float64)
+	if !ok {
+		set = map[string]float64{}
+		f.data[key] = set
+	}
+	return set
+}
+
+// dropIfEmpty removes a collection left empty, as Redis does.
+func (f *fakeRedis) dropIfEmpty(key string, size int) {
+	if size == 0 {
+		delete(f.data, key)
+	}
+}
+
+func fakeString(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+// fakeRedisArg formats a command argument the way go-redis writes it.
+func fakeRedisArg(arg any) string {
+	switch arg := arg.(type) {
+	case string:
+		return arg
+	case []byte:
+		return string(arg)
+	case int:
+		return strconv.Itoa(arg)
+	case int64:
+		return strconv.FormatInt(arg, 10)
+	case int32:
+		return strconv.FormatInt(int64(arg), 10)
+	case uint64:
+		return strconv.FormatUint(arg, 10)
+	case float64:
+		return strconv.FormatFloat(arg, 'f', -1, 64)
+	case bool:
+		if arg {
+			return "1"
+		}
+		return "0"
+	case time.Duration:
+		return strconv.FormatInt(int64(arg), 10)
+	case time.Time:
+		return arg.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return arg.String()
+	}
+	return fmt.Sprint(arg)
+}
@@ -0,0 +1,324 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+// A rebuild loads a new index version from event-service while the alias
+// keeps serving the old one. Writes that arrive meanwhile go to both, so the
+// new version does not miss them: indexed documents are versioned by the
+// event's version and deletions are remembered and replayed after loading,
+// so the snapshot cannot bring back an older state. The building index is
+// kept in Redis so every replica mirrors its writes, and doubles as the lock
+// that keeps two rebuilds from running at once.
+const (
+	buildingIndexKey        = "search:index:building"
+	buildingIndexDeletedKey = "search:index:building:deleted"
+	buildingIndexTTL        = time.Hour
+
+	reindexBatchSize = 500
+
+	// A new version may differ from event-service's own count by this
+	// fraction of it, or by reindexCountSlack events if that is more.
+	// Events that start or change while the build runs move the two
+	// counts apart a little; a missing page of events does not.
+	reindexCountTolerance = 0.005
+	reindexCountSlack     = 5
+)
+
+var errReindexRunning = errors.New("a reindex is already running")
+
+// rebuildResult describes a finished rebuild.
+type rebuildResult struct {
+	Index         string
+	EventsIndexed int
+	Documents     int64
+}
+
+// buildingIndex returns the index a running rebuild is loading, if any.
+func (cfg *APIConfig) buildingIndex(ctx context.Context) string {
+	if cfg.ESClient == nil {
+		return ""
+	}
+	name, err := cfg.RedisClient.Get(ctx, buildingIndexKey).Result()
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// mirrorIndexedEvents repeats a write on the index being built.
+func (cfg *APIConfig) mirrorIndexedEvents(ctx context.Context, docs ...EventDocument) {
+	building := cfg.buildingIndex(ctx)
+	if building == "" {
+		return
+	}
+	if failed, err := cfg.ESClient.BulkIndexInto(ctx, building, docs, true); err != nil || failed > 0 {
+		cfg.Logger.Error("Failed to mirror write to building index", "error", err, "index", building, "failed", failed)
+	}
+}
+
+// mirrorDeletedEvents repeats deletions on the index being built and
+// remembers them, in case the snapshot being loaded still has the events.
+func (cfg *APIConfig) mirrorDeletedEvents(ctx context.Context, eventIDs ...uuid.UUID) {
+	building := cfg.buildingIndex(ctx)
+	if building == "" || len(eventIDs) == 0 {
+		return
+	}
+	members := make([]any, len(eventIDs))
+	for i, eventID := range eventIDs {
+		members[i] = eventID.String()
+	}
+	if err := cfg.RedisClient.SAdd(ctx, buildingIndexDeletedKey, members...).Err(); err != nil {
+		cfg.Logger.Error("Failed to remember deletions for building index", "error", err, "count", len(eventIDs))
+	}
+	for _, eventID := range eventIDs {
+		if err := cfg.ESClient.DeleteEventFrom(ctx, building, eventID); err != nil {
+			cfg.Logger.Error("Failed to mirror deletion to building index", "error", err, "index", building, "event_id", eventID)
+		}
+	}
+}
+
+// rebuildIndex builds a new index version from event-service, checks it
+// holds every published event, and swaps the alias over to it. On any
+// failure the new version is dropped and the alias is left alone.
+//
+// Two checks guard the swap: the load itself must have landed every
+// fetched document, and the new version must agree with a count that
+// event-service takes separately, which catches events the fetch missed.
+func (cfg *APIConfig) rebuildIndex(ctx context.Context) (*rebuildResult, error) {
+	name := cfg.ESClient.NewIndexName(time.Now())
+
+	acquired, err := cfg.RedisClient.SetNX(ctx, buildingIndexKey, name, buildingIndexTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take reindex lock: %w", err)
+	}
+	if !acquired {
+		return nil, errReindexRunning
+	}
+	defer cfg.RedisClient.Del(context.Background(), buildingIndexKey, buildingIndexDeletedKey)
+
+	previous, legacy, err := cfg.ESClient.AliasTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.ESClient.CreateIndexVersion(ctx, name); err != nil {
+		return nil, err
+	}
+	swapped := false
+	defer func() {
+		if swapped {
+			return
+		}
+		if err := cfg.ESClient.DeleteIndex(context.Background(), name); err != nil {
+			cfg.Logger.Error("Failed to drop unfinished index version", "error", err, "index", name)
+		}
+	}()
+
+	events, err := cfg.EventServiceClient.GetAllPublishedEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+
+	documents := make([]EventDocument, len(events))
+	for i, event := range events {
+		documents[i] = cfg.convertEventToDocument(event)
+	}
+	cfg.applyTrendingScores(ctx, documents)
+
+	for i := 0; i < len(documents); i += reindexBatchSize {
+		end := min(i+reindexBatchSize, len(documents))
+		failed, err := cfg.ESClient.BulkIndexInto(ctx, name, documents[i:end], true)
+		if err != nil {
+			return nil, err
+		}
+		if failed > 0 {
+			return nil, fmt.Errorf("%d documents failed to index in batch starting at %d", failed, i)
+		}
+	}
+
+	deleted, err := cfg.RedisClient.SMembers(ctx, buildingIndexDeletedKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deletions during reindex: %w", err)
+	}
+	for _, id := range deleted {
+		eventID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		if err := cfg.ESClient.DeleteEventFrom(ctx, name, eventID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Events created during the build can only add to the count, and
+	// deleted ones were removed from both sides.
+	count, err := cfg.ESClient.CountDocuments(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if expected := int64(len(documents) - len(deleted)); count < expected {
+		return nil, fmt.Errorf("new index holds %d documents, expected at least %d", count, expected)
+	}
+
+	published, err := cfg.EventServiceClient.CountPublishedEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count published events: %w", err)
+	}
+	tolerance := max(int64(float64(published)*reindexCountTolerance), reindexCountSlack)
+	if diff := count - published; diff > tolerance || diff < -tolerance {
+		return nil, fmt.Errorf("new index holds %d documents but event-service lists %d published events", count, published)
+	}
+
+	dropIndex := ""
+	if legacy {
+		dropIndex = previous
+	}
+	if err := cfg.ESClient.SwapAlias(ctx, name, dropIndex); err != nil {
+		return nil, err
+	}
+	swapped = true
+
+	cfg.bumpSearchCacheGeneration(ctx)
+	cfg.pruneIndexVersions(ctx)
+
+	return &rebuildResult{
+		Index:         name,
+		EventsIndexed: len(documents),
+		Documents:     count,
+	}, nil
+}
+
+// pruneIndexVersions deletes old index versions beyond the number kept for
+// rollback.
+func (cfg *APIConfig) pruneIndexVersions(ctx context.Context) {
+	versions, err := cfg.ESClient.ListIndexVersions(ctx)
+	if err != nil {
+		cfg.Logger.Error("Failed to list index versions for pruning", "error", err)
+		return
+	}
+
+	kept := 0
+	for _, version := range versions {
+		if version.Active {
+			continue
+		}
+		if kept < cfg.Config.IndexRetainVersions {
+			kept++
+			continue
+		}
+		if err := cfg.ESClient.DeleteIndex(ctx, version.Name); err != nil {
+			cfg.Logger.Error("Failed to delete old index version", "error", err, "index", version.Name)
+		}
+	}
+}
+
+// upgradeIndexInBackground rebuilds an index that predates versioning or
+// the current schema. event-service may still be starting, so it retries
+// for a while.
+func (cfg *APIConfig) upgradeIndexInBackground() {
+	for attempt := 1; attempt <= 10; attempt++ {
+		result, err := cfg.rebuildIndex(context.Background())
+		if err == nil {
+			cfg.Logger.Info("Upgraded search index", "index", result.Index, "events_indexed", result.EventsIndexed)
+			return
+		}
+		if errors.Is(err, errReindexRunning) {
+			return
+		}
+		cfg.Logger.Warn("Search index upgrade failed, retrying", "error", err, "attempt", attempt)
+		time.Sleep(time.Duration(attempt) * 15 * time.Second)
+	}
+	cfg.Logger.Error("Gave up upgrading search index; run POST /internal/search/resync with force_reindex")
+}
+
+func (cfg *APIConfig) ListIndexVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := cfg.ESClient.ListIndexVersions(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to list index versions", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list index versions")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, IndexVersionsResponse{
+		Alias:    cfg.Config.IndexName,
+		Building: cfg.buildingIndex(r.Context()),
+		Versions: versions,
+	})
+}
+
+// RollbackIndex points the alias back at an older index version, by
+// default the newest one before the active one. That version does not
+// have changes made since it was replaced.
+func (cfg *APIConfig) RollbackIndex(w http.ResponseWriter, r *http.Request) {
+	var req RollbackIndexRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+	}
+
+	if cfg.buildingIndex(r.Context()) != "" {
+		utils.RespondWithError(w, http.StatusConflict, errReindexRunning.Error())
+		return
+	}
+
+	versions, err := cfg.ESClient.ListIndexVersions(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to list index versions", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to roll back index")
+		return
+	}
+
+	target := ""
+	activeSeen := false
+	for _, version := range versions {
+		if req.Index != "" {
+			if version.Name == req.Index {
+				if version.Active {
+					utils.RespondWithError(w, http.StatusBadRequest, "That index version is already active")
+					return
+				}
+				target = version.Name
+				break
+			}
+			continue
+		}
+		if version.Active {
+			activeSeen = true
+			continue
+		}
+		if activeSeen {
+			target = version.Name
+			break
+		}
+	}
+	if target == "" {
+		utils.RespondWithError(w, http.StatusNotFound, "No index version to roll back to")
+		return
+	}
+
+	if err := cfg.ESClient.SwapAlias(r.Context(), target, ""); err != nil {
+		cfg.Logger.Error("Failed to roll back index", "error", err, "index", target)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to roll back index")
+		return
+	}
+	cfg.bumpSearchCacheGeneration(r.Context())
+
+	cfg.Logger.Warn("Search index rolled back", "index", target)
+
+	utils.RespondWithJSON(w, http.StatusOK, RollbackIndexResponse{
+		Status: "rolled_back",
+		Index:  target,
+	})
+}
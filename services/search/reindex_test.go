@@ -0,0 +1,243 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+const (
+	testOlderVersion = "events-v3-20260101000000"
+	testOldVersion   = "events-v3-20260201000000"
+)
+
+// testEventService serves the published event listing: every event on one
+// page, and total as the count. during, if set, runs while the listing is
+// being fetched.
+func testEventService(t *testing.T, events []EventServiceEvent, total int64, during func()) *EventServiceClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := EventServiceListResponse{Total: total}
+		if r.URL.Query().Has("page") {
+			if during != nil {
+				during()
+			}
+			response.Events = events
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return NewEventServiceClient(server.URL, "test-key", logger.New("error"))
+}
+
+func testPublishedEvents(ns ...int) []EventServiceEvent {
+	events := make([]EventServiceEvent, len(ns))
+	for i, n := range ns {
+		events[i] = EventServiceEvent{
+			EventID:       testEventID(n),
+			Name:          "Event",
+			EventType:     "music",
+			StartDatetime: testEventStart,
+			EndDatetime:   testEventStart.Add(2 * time.Hour),
+			Status:        "published",
+			Version:       1,
+		}
+	}
+	return events
+}
+
+func testIDs(ns ...int) []string {
+	ids := make([]string, len(ns))
+	for i, n := range ns {
+		ids[i] = testEventID(n).String()
+	}
+	return ids
+}
+
+func testReindexConfig(t *testing.T) (*APIConfig, *fakeElasticsearch, *fakeRedis) {
+	t.Helper()
+	client, redisFake := newFakeRedis(t)
+	es, esFake := newFakeElasticsearch(t, "events")
+	cfg := &APIConfig{
+		Config:      &config.SearchServiceConfig{IndexName: "events", IndexRetainVersions: 1},
+		Logger:      logger.New("error"),
+		RedisClient: client,
+		ESClient:    es,
+	}
+	return cfg, esFake, redisFake
+}
+
+func TestRebuildIndex(t *testing.T) {
+	cfg, es, redisFake := testReindexConfig(t)
+	es.addIndex(testOlderVersion, testIDs(1)...)
+	es.addIndex(testOldVersion, testIDs(1, 2)...)
+	es.pointAlias(testOldVersion)
+	cfg.EventServiceClient = testEventService(t, testPublishedEvents(1, 2, 3), 3, nil)
+
+	result, err := cfg.rebuildIndex(context.Background())
+	if err != nil {
+		t.Fatalf("rebuildIndex error = %v", err)
+	}
+
+	if result.EventsIndexed != 3 || result.Documents != 3 {
+		t.Errorf("result = %+v, want 3 events indexed and 3 documents", result)
+	}
+	if got := es.aliasTarget(); got != result.Index {
+		t.Errorf("alias target = %q, want %q", got, result.Index)
+	}
+	if got, want := es.documents(result.Index), testIDs(1, 2, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("new version documents = %v, want %v", got, want)
+	}
+	// One replaced version is kept for rollback; older ones are pruned.
+	if got, want := es.indexNames(), []string{testOldVersion, result.Index}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indices = %v, want %v", got, want)
+	}
+	if got := testGeneration(t, redisFake); got != "1" {
+		t.Errorf("generation = %q, want it bumped after the swap", got)
+	}
+	if got := redisFake.keys(buildingIndexKey); len(got) != 0 {
+		t.Errorf("building keys left behind: %v", got)
+	}
+}
+
+func TestRebuildIndexReplacesLegacyIndex(t *testing.T) {
+	cfg, es, _ := testReindexConfig(t)
+	es.addIndex("events", testIDs(1)...)
+	cfg.EventServiceClient = testEventService(t, testPublishedEvents(1, 2), 2, nil)
+
+	result, err := cfg.rebuildIndex(context.Background())
+	if err != nil {
+		t.Fatalf("rebuildIndex error = %v", err)
+	}
+	if got := es.aliasTarget(); got != result.Index {
+		t.Errorf("alias target = %q, want %q", got, result.Index)
+	}
+	if got, want := es.indexNames(), []string{result.Index}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indices = %v, want the legacy index dropped in the swap", got)
+	}
+}
+
+func TestRebuildIndexMirrorsWritesDuringBuild(t *testing.T) {
+	cfg, es, redisFake := testReindexConfig(t)
+	es.addIndex(testOldVersion, testIDs(1, 2, 3)...)
+	es.pointAlias(testOldVersion)
+
+	// While the snapshot is read, event 2 is deleted and event 4 created.
+	// The snapshot still holds 2 and misses 4.
+	created := testDocument(4, "Late Show", "Pune", "music", 100, 5)
+	cfg.EventServiceClient = testEventService(t, testPublishedEvents(1, 2, 3), 3, func() {
+		ctx := context.Background()
+		cfg.mirrorDeletedEvents(ctx, testEventID(2))
+		cfg.mirrorIndexedEvents(ctx, created)
+	})
+
+	result, err := cfg.rebuildIndex(context.Background())
+	if err != nil {
+		t.Fatalf("rebuildIndex error = %v", err)
+	}
+	if got, want := es.documents(result.Index), testIDs(1, 3, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("new version documents = %v, want %v", got, want)
+	}
+	if got := redisFake.members(buildingIndexDeletedKey); len(got) != 0 {
+		t.Errorf("remembered deletions left behind: %v", got)
+	}
+	// Once the build is over, writes are no longer mirrored.
+	if building := cfg.buildingIndex(context.Background()); building != "" {
+		t.Errorf("building index = %q after the rebuild", building)
+	}
+}
+
+func TestRebuildIndexKeepsAliasOnCountMismatch(t *testing.T) {
+	cfg, es, redisFake := testReindexConfig(t)
+	es.addIndex(testOldVersion, testIDs(1, 2, 3)...)
+	es.pointAlias(testOldVersion)
+	// event-service counts far more events than its listing returned.
+	cfg.EventServiceClient = testEventService(t, testPublishedEvents(1, 2, 3), 100, nil)
+
+	_, err := cfg.rebuildIndex(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "100 published events") {
+		t.Fatalf("rebuildIndex error = %v, want a count mismatch", err)
+	}
+	if got := es.aliasTarget(); got != testOldVersion {
+		t.Errorf("alias target = %q, want it left at %q", got, testOldVersion)
+	}
+	if got, want := es.indexNames(), []string{testOldVersion}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indices = %v, want the unfinished version dropped", got)
+	}
+	if got := testGeneration(t, redisFake); got != "" {
+		t.Errorf("generation = %q, want it untouched", got)
+	}
+}
+
+func TestRebuildIndexRunning(t *testing.T) {
+	cfg, es, _ := testReindexConfig(t)
+	es.addIndex(testOldVersion)
+	es.pointAlias(testOldVersion)
+	cfg.RedisClient.Set(context.Background(), buildingIndexKey, "events-v3-20260301000000", 0)
+
+	if _, err := cfg.rebuildIndex(context.Background()); !errors.Is(err, errReindexRunning) {
+		t.Fatalf("rebuildIndex error = %v, want %v", err, errReindexRunning)
+	}
+	if got, want := es.indexNames(), []string{testOldVersion}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indices = %v, want %v", got, want)
+	}
+}
+
+func TestRollbackIndex(t *testing.T) {
+	const active = "events-v3-20260301000000"
+
+	tests := []struct {
+		name       string
+		versions   []string
+		body       string
+		building   bool
+		wantStatus int
+		wantTarget string
+	}{
+		{name: "previous version", versions: []string{testOlderVersion, testOldVersion, active}, wantStatus: http.StatusOK, wantTarget: testOldVersion},
+		{name: "named version", versions: []string{testOlderVersion, testOldVersion, active}, body: `{"index": "` + testOlderVersion + `"}`, wantStatus: http.StatusOK, wantTarget: testOlderVersion},
+		{name: "active version", versions: []string{testOldVersion, active}, body: `{"index": "` + active + `"}`, wantStatus: http.StatusBadRequest, wantTarget: active},
+		{name: "unknown version", versions: []string{testOldVersion, active}, body: `{"index": "events-v3-19990101000000"}`, wantStatus: http.StatusNotFound, wantTarget: active},
+		{name: "nothing older", versions: []string{active}, wantStatus: http.StatusNotFound, wantTarget: active},
+		{name: "during a rebuild", versions: []string{testOldVersion, active}, building: true, wantStatus: http.StatusConflict, wantTarget: active},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, es, redisFake := testReindexConfig(t)
+			for _, version := range tt.versions {
+				es.addIndex(version)
+			}
+			es.pointAlias(active)
+			if tt.building {
+				cfg.RedisClient.Set(context.Background(), buildingIndexKey, "events-v3-20260401000000", 0)
+			}
+
+			w := httptest.NewRecorder()
+			cfg.RollbackIndex(w, httptest.NewRequest(http.MethodPost, "/internal/search/indices/rollback", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := es.aliasTarget(); got != tt.wantTarget {
+				t.Errorf("alias target = %q, want %q", got, tt.wantTarget)
+			}
+			wantGeneration := ""
+			if tt.wantStatus == http.StatusOK {
+				wantGeneration = "1"
+			}
+			if got := testGeneration(t, redisFake); got != wantGeneration {
+				t.Errorf("generation = %q, want %q", got, wantGeneration)
+			}
+		})
+	}
+}
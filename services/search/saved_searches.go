@@ -0,0 +1,520 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Saved searches and their alerts are stored by the user service. Redis
+// only holds an index of the searches with alerts on, which newly indexed
+// events are matched against: one hash of their records, rebuilt from the
+// user service at startup and whenever it has been evicted. The version
+// counter moves whenever the index changes, so replicas know to reload it.
+const (
+	savedSearchIndexKey   = "search:saved:index"
+	savedSearchVersionKey = "search:saved:version"
+	// savedSearchIndexBuilt is the index field marking it complete; an
+	// index without it was evicted, or written to since, and is rebuilt.
+	savedSearchIndexBuilt = "built"
+	savedSearchIndexPage  = 500
+
+	maxSavedSearchName  = 100
+	maxSavedSearchQuery = 200
+	maxSavedSearchTags  = 10
+	defaultAlertsListed = 20
+	maxAlertsListed     = 100
+
+	AlertFrequencyInstant = "instant"
+	AlertFrequencyDaily   = "daily"
+	AlertFrequencyWeekly  = "weekly"
+	defaultAlertFrequency = AlertFrequencyDaily
+)
+
+// alertPeriods is the least time between two alerts for one saved search.
+// Instant alerts go out on the next delivery pass.
+var alertPeriods = map[string]time.Duration{
+	AlertFrequencyInstant: 0,
+	AlertFrequencyDaily:   24 * time.Hour,
+	AlertFrequencyWeekly:  7 * 24 * time.Hour,
+}
+
+var errSavedSearchNotFound = errors.New("saved search not found")
+
+// normalizeCriteria tidies criteria the way the search endpoint reads its
+// parameters, and rejects criteria that would match every event or that
+// the search endpoint would refuse.
+func normalizeCriteria(c SavedSearchCriteria) (SavedSearchCriteria, error) {
+	c.Query = strings.TrimSpace(c.Query)
+	c.City = strings.ToLower(strings.TrimSpace(c.City))
+	c.EventType = strings.TrimSpace(c.EventType)
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+
+	var tags []string
+	for _, tag := range c.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	c.Tags = tags
+
+	if len(c.Query) > maxSavedSearchQuery {
+		return c, fmt.Errorf("q must be at most %d characters", maxSavedSearchQuery)
+	}
+	if len(c.Tags) > maxSavedSearchTags {
+		return c, fmt.Errorf("at most %d tags are allowed", maxSavedSearchTags)
+	}
+	for _, date := range []string{c.DateFrom, c.DateTo} {
+		if _, ok := parseDateBound(date); date != "" && !ok {
+			return c, fmt.Errorf("date_from and date_to must be dates (YYYY-MM-DD) or RFC 3339 timestamps")
+		}
+	}
+	if c.MinPrice < 0 || c.MaxPrice < 0 || (c.MaxPrice > 0 && c.MinPrice > c.MaxPrice) {
+		return c, fmt.Errorf("min_price and max_price must be positive, with min_price at most max_price")
+	}
+
+	if (c.Lat == nil) != (c.Lon == nil) {
+		return c, fmt.Errorf("lat and lon must be given together")
+	}
+	if c.Lat != nil {
+		if err := (geocode.Point{Lat: *c.Lat, Lon: *c.Lon}).Validate(); err != nil {
+			return c, err
+		}
+		if c.RadiusKm <= 0 || c.RadiusKm > maxRadiusKm {
+			return c, fmt.Errorf("radius_km must be greater than 0 and at most %d with lat and lon", maxRadiusKm)
+		}
+	} else if c.RadiusKm != 0 {
+		return c, fmt.Errorf("lat and lon are required for radius_km")
+	}
+
+	if c.Query == "" && c.City == "" && c.EventType == "" && len(c.Tags) == 0 &&
+		c.MinPrice == 0 && c.MaxPrice == 0 && c.Lat == nil && c.DateFrom == "" && c.DateTo == "" {
+		return c, fmt.Errorf("a saved search needs at least one search criterion")
+	}
+	return c, nil
+}
+
+// searchRequest turns criteria into the search they stand for.
+func (c SavedSearchCriteria) searchRequest() SearchRequest {
+	req := SearchRequest{
+		Query:     c.Query,
+		City:      c.City,
+		EventType: c.EventType,
+		Tags:      c.Tags,
+		DateFrom:  c.DateFrom,
+		DateTo:    c.DateTo,
+		MinPrice:  c.MinPrice,
+		MaxPrice:  c.MaxPrice,
+		Currency:  c.Currency,
+		RadiusKm:  c.RadiusKm,
+	}
+	if c.Lat != nil && c.Lon != nil {
+		req.Near = &geocode.Point{Lat: *c.Lat, Lon: *c.Lon}
+	}
+	return req
+}
+
+func validateSavedSearchName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxSavedSearchName {
+		return "", fmt.Errorf("name is required and must be at most %d characters", maxSavedSearchName)
+	}
+	return name, nil
+}
+
+func validateAlertFrequency(frequency string) (string, error) {
+	if frequency == "" {
+		return defaultAlertFrequency, nil
+	}
+	if _, ok := alertPeriods[frequency]; !ok {
+		return "", fmt.Errorf("frequency must be %s, %s or %s", AlertFrequencyInstant, AlertFrequencyDaily, AlertFrequencyWeekly)
+	}
+	return frequency, nil
+}
+
+// requireUserService guards the saved search endpoints, which need the
+// user service to store them.
+func (cfg *APIConfig) requireUserService(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cfg.UserServiceClient == nil {
+			utils.RespondWithError(w, http.StatusServiceUnavailable, "Saved searches are not available without the user service")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// indexSavedSearch brings the alerting index in line with a saved search
+// just written to the user service.
+func (cfg *APIConfig) indexSavedSearch(ctx context.Context, search SavedSearch) {
+	if !search.AlertsEnabled {
+		cfg.unindexSavedSearch(ctx, search.ID)
+		return
+	}
+
+	search.LastAlertedAt = nil
+	data, err := json.Marshal(search)
+	if err != nil {
+		cfg.Logger.Error("Failed to encode saved search for the alert index", "error", err, "saved_search_id", search.ID)
+		return
+	}
+	cfg.writeSavedSearchIndex(ctx, search.ID, func(pipe redis.Pipeliner) {
+		pipe.HSet(ctx, savedSearchIndexKey, search.ID.String(), data)
+	})
+}
+
+// unindexSavedSearch takes a saved search out of the alerting index and
+// drops its matches not yet sent.
+func (cfg *APIConfig) unindexSavedSearch(ctx context.Context, id uuid.UUID) {
+	cfg.writeSavedSearchIndex(ctx, id, func(pipe redis.Pipeliner) {
+		pipe.HDel(ctx, savedSearchIndexKey, id.String())
+		pipe.Del(ctx, alertsPendingPrefix+id.String())
+		pipe.ZRem(ctx, alertsDueKey, id.String())
+	})
+}
+
+// writeSavedSearchIndex applies a change to the alerting index. The user
+// service already has the change, so a failed write is not the request's
+// failure; the index is dropped instead, to be rebuilt rather than left
+// stale.
+func (cfg *APIConfig) writeSavedSearchIndex(ctx context.Context, id uuid.UUID, write func(pipe redis.Pipeliner)) {
+	pipe := cfg.RedisClient.TxPipeline()
+	write(pipe)
+	pipe.Incr(ctx, savedSearchVersionKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		cfg.Logger.Error("Failed to update saved search alert index, dropping it", "error", err, "saved_search_id", id)
+		cfg.RedisClient.Del(ctx, savedSearchIndexKey)
+	}
+}
+
+// loadSavedSearchIndex returns the saved searches with alerts on from the
+// index, rebuilding it first if it is incomplete.
+func (cfg *APIConfig) loadSavedSearchIndex(ctx context.Context) ([]SavedSearch, error) {
+	values, err := cfg.RedisClient.HGetAll(ctx, savedSearchIndexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if _, built := values[savedSearchIndexBuilt]; !built {
+		return cfg.rebuildSavedSearchIndex(ctx)
+	}
+
+	searches := make([]SavedSearch, 0, len(values))
+	for field, value := range values {
+		if field == savedSearchIndexBuilt {
+			continue
+		}
+		var search SavedSearch
+		if err := json.Unmarshal([]byte(value), &search); err != nil {
+			cfg.Logger.Warn("Skipping unreadable saved search", "error", err, "saved_search_id", field)
+			continue
+		}
+		searches = append(searches, search)
+	}
+	return searches, nil
+}
+
+// rebuildSavedSearchIndex replaces the alerting index with the saved
+// searches the user service has alerts on for. A change written while it
+// runs can be undone by it; alert delivery checks each search with the
+// user service, so at worst matches are queued for a search that no
+// longer wants them.
+func (cfg *APIConfig) rebuildSavedSearchIndex(ctx context.Context) ([]SavedSearch, error) {
+	var searches []SavedSearch
+	after := uuid.Nil
+	for {
+		page, more, err := cfg.UserServiceClient.ListAlertingSavedSearches(ctx, after, savedSearchIndexPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list saved searches with alerts on: %w", err)
+		}
+		searches = append(searches, page...)
+		if !more || len(page) == 0 {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+
+	fields := make([]any, 0, 2*len(searches)+2)
+	fields = append(fields, savedSearchIndexBuilt, time.Now().Unix())
+	for _, search := range searches {
+		search.LastAlertedAt = nil
+		data, err := json.Marshal(search)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, search.ID.String(), data)
+	}
+
+	pipe := cfg.RedisClient.TxPipeline()
+	pipe.Del(ctx, savedSearchIndexKey)
+	pipe.HSet(ctx, savedSearchIndexKey, fields...)
+	pipe.Incr(ctx, savedSearchVersionKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	cfg.Logger.Info("Rebuilt saved search alert index", "count", len(searches))
+	return searches, nil
+}
+
+// savedSearchID reads the {id} path value, answering the request itself
+// if it is not a UUID.
+func savedSearchID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid saved search ID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondSavedSearchError answers a failed saved search lookup or write.
+// The user service's refusals, such as the limit on saved searches, are
+// passed on as bad requests.
+func (cfg *APIConfig) respondSavedSearchError(w http.ResponseWriter, err error, action string, userID uuid.UUID) {
+	if errors.Is(err, errSavedSearchNotFound) {
+		utils.RespondWithError(w, http.StatusNotFound, "Saved search not found")
+		return
+	}
+	var refusal *userServiceError
+	if errors.As(err, &refusal) && refusal.StatusCode < http.StatusInternalServerError {
+		utils.RespondWithError(w, http.StatusBadRequest, refusal.Message)
+		return
+	}
+	cfg.Logger.Error("Failed to "+action+" saved search", "error", err, "user_id", userID)
+	utils.RespondWithError(w, http.StatusInternalServerError, "Failed to "+action+" saved search")
+}
+
+func (cfg *APIConfig) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	searches, err := cfg.UserServiceClient.ListSavedSearches(r.Context(), userID)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "list", userID)
+		return
+	}
+	if searches == nil {
+		searches = []SavedSearch{}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SavedSearchesResponse{
+		SavedSearches: searches,
+		Count:         len(searches),
+	})
+}
+
+// CreateSavedSearch saves a search under a name. With alerts on, which is
+// the default, the user is alerted to events that start matching it.
+func (cfg *APIConfig) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	name, err := validateSavedSearchName(req.Name)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	criteria, err := normalizeCriteria(req.Search)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	frequency, err := validateAlertFrequency(req.Frequency)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	search, err := cfg.UserServiceClient.CreateSavedSearch(r.Context(), userID, SaveSearchRequest{
+		Name:          name,
+		Search:        criteria,
+		Frequency:     frequency,
+		AlertsEnabled: req.AlertsEnabled == nil || *req.AlertsEnabled,
+	})
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "create", userID)
+		return
+	}
+	cfg.indexSavedSearch(r.Context(), *search)
+
+	cfg.Logger.Info("Search saved", "saved_search_id", search.ID, "user_id", userID, "alerts_enabled", search.AlertsEnabled)
+	utils.RespondWithJSON(w, http.StatusCreated, search)
+}
+
+func (cfg *APIConfig) GetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	id, ok := savedSearchID(w, r)
+	if !ok {
+		return
+	}
+
+	search, err := cfg.UserServiceClient.GetUserSavedSearch(r.Context(), userID, id)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "get", userID)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, search)
+}
+
+// UpdateSavedSearch changes the fields given. Setting alerts_enabled to
+// false unsubscribes from the search's alerts and drops matches not yet
+// sent; the search itself stays saved.
+func (cfg *APIConfig) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	id, ok := savedSearchID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	search, err := cfg.UserServiceClient.GetUserSavedSearch(r.Context(), userID, id)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "update", userID)
+		return
+	}
+
+	if req.Name != nil {
+		if search.Name, err = validateSavedSearchName(*req.Name); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.Search != nil {
+		if search.Search, err = normalizeCriteria(*req.Search); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.Frequency != nil {
+		if search.Frequency, err = validateAlertFrequency(*req.Frequency); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.AlertsEnabled != nil {
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+
+	updated, err := cfg.UserServiceClient.UpdateSavedSearch(r.Context(), userID, id, SaveSearchRequest{
+		Name:          search.Name,
+		Search:        search.Search,
+		Frequency:     search.Frequency,
+		AlertsEnabled: search.AlertsEnabled,
+	})
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "update", userID)
+		return
+	}
+	cfg.indexSavedSearch(r.Context(), *updated)
+
+	utils.RespondWithJSON(w, http.StatusOK, updated)
+}
+
+func (cfg *APIConfig) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	id, ok := savedSearchID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := cfg.UserServiceClient.DeleteSavedSearch(r.Context(), userID, id); err != nil {
+		cfg.respondSavedSearchError(w, err, "delete", userID)
+		return
+	}
+	cfg.unindexSavedSearch(r.Context(), id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSearchAlerts returns the user's most recent alerts, newest first.
+func (cfg *APIConfig) ListSearchAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > maxAlertsListed {
+		limit = defaultAlertsListed
+	}
+
+	alerts, err := cfg.UserServiceClient.ListSearchAlerts(r.Context(), userID, limit)
+	if err != nil {
+		cfg.Logger.Error("Failed to list search alerts", "error", err, "user_id", userID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list search alerts")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SearchAlertsResponse{
+		Alerts: alerts,
+		Count:  len(alerts),
+	})
+}
+
+// UnsubscribeAllAlerts turns alerts off for every one of the user's saved
+// searches.
+func (cfg *APIConfig) UnsubscribeAllAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	ids, err := cfg.UserServiceClient.DisableSearchAlerts(r.Context(), userID)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "unsubscribe", userID)
+		return
+	}
+	for _, id := range ids {
+		cfg.unindexSavedSearch(r.Context(), id)
+	}
+
+	cfg.Logger.Info("Unsubscribed from all search alerts", "user_id", userID, "unsubscribed", len(ids))
+	utils.RespondWithJSON(w, http.StatusOK, UnsubscribeAlertsResponse{
+		Status:       "unsubscribed",
+		Unsubscribed: len(ids),
+	})
+}
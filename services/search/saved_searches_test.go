@@ -0,0 +1,176 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+func testCoordinate(value float64) *float64 {
+	return &value
+}
+
+func TestNormalizeCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria SavedSearchCriteria
+		want     SavedSearchCriteria
+		wantErr  string
+	}{
+		{
+			name:     "values are tidied",
+			criteria: SavedSearchCriteria{Query: "  jazz ", City: " Pune ", Currency: " inr", Tags: []string{" Live", "live", "", "JAZZ "}},
+			want:     SavedSearchCriteria{Query: "jazz", City: "pune", Currency: "INR", Tags: []string{"live", "jazz"}},
+		},
+		{
+			name:     "position with a radius",
+			criteria: SavedSearchCriteria{Lat: testCoordinate(18.52), Lon: testCoordinate(73.85), RadiusKm: 10},
+			want:     SavedSearchCriteria{Lat: testCoordinate(18.52), Lon: testCoordinate(73.85), RadiusKm: 10},
+		},
+		{name: "no criteria", criteria: SavedSearchCriteria{Query: "  ", Tags: []string{" "}}, wantErr: "at least one search criterion"},
+		{name: "currency alone", criteria: SavedSearchCriteria{Currency: "INR"}, wantErr: "at least one search criterion"},
+		{name: "query too long", criteria: SavedSearchCriteria{Query: strings.Repeat("a", maxSavedSearchQuery+1)}, wantErr: "q must be at most"},
+		{name: "too many tags", criteria: SavedSearchCriteria{Tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k", ",")}, wantErr: "at most 10 tags"},
+		{name: "bad date", criteria: SavedSearchCriteria{DateFrom: "next friday"}, wantErr: "date_from and date_to must be dates"},
+		{name: "negative price", criteria: SavedSearchCriteria{MinPrice: -1}, wantErr: "min_price and max_price"},
+		{name: "inverted prices", criteria: SavedSearchCriteria{MinPrice: 500, MaxPrice: 100}, wantErr: "min_price and max_price"},
+		{name: "lat without lon", criteria: SavedSearchCriteria{Lat: testCoordinate(18.52), RadiusKm: 10}, wantErr: "lat and lon must be given together"},
+		{name: "position without radius", criteria: SavedSearchCriteria{Lat: testCoordinate(18.52), Lon: testCoordinate(73.85)}, wantErr: "radius_km must be greater than 0"},
+		{name: "radius without position", criteria: SavedSearchCriteria{City: "Pune", RadiusKm: 10}, wantErr: "lat and lon are required"},
+		{name: "latitude out of range", criteria: SavedSearchCriteria{Lat: testCoordinate(91), Lon: testCoordinate(0), RadiusKm: 10}, wantErr: "lat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCriteria(tt.criteria)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("normalizeCriteria error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeCriteria error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeCriteria = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAlertFrequency(t *testing.T) {
+	tests := []struct {
+		frequency string
+		want      string
+		wantErr   bool
+	}{
+		{frequency: "", want: AlertFrequencyDaily},
+		{frequency: AlertFrequencyInstant, want: AlertFrequencyInstant},
+		{frequency: AlertFrequencyWeekly, want: AlertFrequencyWeekly},
+		{frequency: "hourly", wantErr: true},
+		{frequency: "Daily", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := validateAlertFrequency(tt.frequency)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("validateAlertFrequency(%q) = %q, %v, want %q, error %v", tt.frequency, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAlertingSearchMatches(t *testing.T) {
+	jazz := testDocument(1, "Jazz Night at the Blue Frog", "Pune", "music", 500, 1)
+	jazz.Categories = []string{"music", "jazz"}
+	jazz.Tags = []string{"live"}
+	jazz.Location = &geocode.Point{Lat: 18.52, Lon: 73.85}
+
+	unlocated := testDocument(2, "Jazz Brunch", "Pune", "music", 500, 1)
+
+	tests := []struct {
+		name     string
+		criteria SavedSearchCriteria
+		doc      EventDocument
+		want     bool
+	}{
+		{name: "query", criteria: SavedSearchCriteria{Query: "jazz"}, doc: jazz, want: true},
+		{name: "query with a typo", criteria: SavedSearchCriteria{Query: "jaz frog"}, doc: jazz, want: true},
+		{name: "query that does not match", criteria: SavedSearchCriteria{Query: "opera"}, doc: jazz, want: false},
+		{name: "city", criteria: SavedSearchCriteria{City: "Pune"}, doc: jazz, want: true},
+		{name: "other city", criteria: SavedSearchCriteria{City: "Mumbai"}, doc: jazz, want: false},
+		{name: "city in another case", criteria: SavedSearchCriteria{City: "pune"}, doc: jazz, want: true},
+		{name: "subcategory", criteria: SavedSearchCriteria{EventType: "jazz"}, doc: jazz, want: true},
+		{name: "tag", criteria: SavedSearchCriteria{Tags: []string{"live"}}, doc: jazz, want: true},
+		{name: "missing tag", criteria: SavedSearchCriteria{Tags: []string{"live", "outdoor"}}, doc: jazz, want: false},
+		{name: "price cap", criteria: SavedSearchCriteria{MaxPrice: 600, Currency: "INR"}, doc: jazz, want: true},
+		{name: "price cap in another currency", criteria: SavedSearchCriteria{MaxPrice: 600, Currency: "USD"}, doc: jazz, want: false},
+		{name: "too dear", criteria: SavedSearchCriteria{MaxPrice: 400}, doc: jazz, want: false},
+		{name: "dates", criteria: SavedSearchCriteria{DateFrom: "2026-11-07", DateTo: "2026-11-07"}, doc: jazz, want: true},
+		{name: "dates before", criteria: SavedSearchCriteria{DateTo: "2026-11-06"}, doc: jazz, want: false},
+		{name: "within the radius", criteria: SavedSearchCriteria{Lat: testCoordinate(18.6), Lon: testCoordinate(73.8), RadiusKm: 15}, doc: jazz, want: true},
+		{name: "outside the radius", criteria: SavedSearchCriteria{Lat: testCoordinate(19.07), Lon: testCoordinate(72.87), RadiusKm: 50}, doc: jazz, want: false},
+		{name: "radius and no location", criteria: SavedSearchCriteria{Lat: testCoordinate(18.52), Lon: testCoordinate(73.85), RadiusKm: 50}, doc: unlocated, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := tt.criteria.searchRequest()
+			search := alertingSearch{
+				search:  SavedSearch{Search: tt.criteria},
+				request: request,
+				words:   splitWords(request.Query),
+			}
+			event := memoryEvent{doc: tt.doc, words: documentWords(tt.doc)}
+			if got := search.matches(event); got != tt.want {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlertingSearchSkipsUnpublished(t *testing.T) {
+	doc := testDocument(1, "Jazz Night", "Pune", "music", 500, 1)
+	doc.Status = "cancelled"
+	search := alertingSearch{request: SavedSearchCriteria{Query: "jazz"}.searchRequest(), words: []string{"jazz"}}
+	if search.matches(memoryEvent{doc: doc, words: documentWords(doc)}) {
+		t.Error("a cancelled event matched a saved search")
+	}
+}
+
+func TestMatchSavedSearchesFoldsCity(t *testing.T) {
+	client, fake := newFakeRedis(t)
+	cfg := &APIConfig{
+		Logger:            logger.New("error"),
+		RedisClient:       client,
+		UserServiceClient: &UserServiceClient{},
+	}
+	ctx := context.Background()
+
+	// Searches saved before cities were folded keep their original case.
+	searches := []SavedSearch{
+		{ID: testEventID(11), Search: SavedSearchCriteria{City: "pune"}, AlertsEnabled: true},
+		{ID: testEventID(12), Search: SavedSearchCriteria{City: "PUNE"}, AlertsEnabled: true},
+		{ID: testEventID(13), Search: SavedSearchCriteria{City: "mumbai"}, AlertsEnabled: true},
+	}
+	for _, search := range searches {
+		data, _ := json.Marshal(search)
+		client.HSet(ctx, savedSearchIndexKey, search.ID.String(), data)
+	}
+	client.HSet(ctx, savedSearchIndexKey, savedSearchIndexBuilt, 1)
+
+	doc := testDocument(1, "Jazz Night", "Pune", "music", 500, 1)
+	doc.StartDateTime = time.Now().Add(48 * time.Hour)
+	cfg.matchSavedSearches(ctx, []EventDocument{doc})
+
+	want := []string{alertsPendingPrefix + testEventID(11).String(), alertsPendingPrefix + testEventID(12).String()}
+	if got := fake.keys(alertsPendingPrefix); !reflect.DeepEqual(got, want) {
+		t.Errorf("pending alerts = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,309 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Events new to the index are matched against saved searches the other way
+// round from a search: each event is checked against every saved search
+// with alerts on, like a percolator. Matches wait in a per-search hash
+// until the search is due an alert, so a search alerts at most once per its
+// frequency with everything matched since. Alerts are stored by the user
+// service, which also keeps when each search last alerted.
+const (
+	alertsDueKey        = "search:alerts:due"
+	alertsPendingPrefix = "search:alerts:pending:"
+
+	// alertEvents is how many of the matched events an alert lists.
+	alertEvents         = 20
+	alertsDeliveryBatch = 100
+)
+
+// savedSearchMatcher is a replica's copy of the saved searches with alerts
+// on, grouped by lower-cased city so an event is only checked against
+// searches for its city and those for any city.
+type savedSearchMatcher struct {
+	mu      sync.Mutex
+	version string
+	loaded  bool
+	byCity  map[string][]alertingSearch
+}
+
+// alertingSearch is a saved search ready for matching, with its query split
+// into words once.
+type alertingSearch struct {
+	search  SavedSearch
+	request SearchRequest
+	words   []string
+}
+
+// alertingSearches returns the saved searches with alerts on, reloading
+// them from the index when another replica has changed them.
+func (cfg *APIConfig) alertingSearches(ctx context.Context) (map[string][]alertingSearch, error) {
+	version, err := cfg.RedisClient.Get(ctx, savedSearchVersionKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	matcher := &cfg.savedSearches
+	matcher.mu.Lock()
+	defer matcher.mu.Unlock()
+
+	if matcher.loaded && matcher.version == version {
+		return matcher.byCity, nil
+	}
+
+	searches, err := cfg.loadSavedSearchIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCity := make(map[string][]alertingSearch)
+	for _, search := range searches {
+		if !search.AlertsEnabled {
+			continue
+		}
+		request := search.Search.searchRequest()
+		city := strings.ToLower(request.City)
+		byCity[city] = append(byCity[city], alertingSearch{
+			search:  search,
+			request: request,
+			words:   splitWords(request.Query),
+		})
+	}
+
+	matcher.byCity, matcher.version, matcher.loaded = byCity, version, true
+	cfg.Logger.Debug("Loaded saved searches for alerts", "count", len(searches), "version", version)
+	return byCity, nil
+}
+
+// matches reports whether an event meets every criterion of the search,
+// matching text as the in-memory backend does.
+func (s alertingSearch) matches(event memoryEvent) bool {
+	if !memoryMatches(event, s.request, s.words) {
+		return false
+	}
+	if s.request.Near != nil && s.request.RadiusKm > 0 {
+		location := event.doc.Location
+		return location != nil && s.request.Near.DistanceKm(*location) <= s.request.RadiusKm
+	}
+	return true
+}
+
+// matchSavedSearches queues alerts for saved searches that events newly
+// added to the index, or published again, match. Events that have already
+// started are skipped, as are changes from before a search was saved.
+func (cfg *APIConfig) matchSavedSearches(ctx context.Context, docs []EventDocument) {
+	if len(docs) == 0 || cfg.UserServiceClient == nil {
+		return
+	}
+	searches, err := cfg.alertingSearches(ctx)
+	if err != nil {
+		cfg.Logger.Error("Failed to load saved searches for alerts", "error", err)
+		return
+	}
+	if len(searches) == 0 {
+		return
+	}
+
+	now := time.Now()
+	pipe := cfg.RedisClient.Pipeline()
+	matched := 0
+	for _, doc := range docs {
+		if doc.Status != "published" || !doc.StartDateTime.After(now) {
+			continue
+		}
+		event := memoryEvent{doc: doc, words: documentWords(doc)}
+		summary, err := json.Marshal(alertEventFromDocument(doc))
+		if err != nil {
+			continue
+		}
+
+		for _, candidates := range [][]alertingSearch{searches[strings.ToLower(doc.VenueCity)], searches[""]} {
+			for _, candidate := range candidates {
+				if !doc.UpdatedAt.IsZero() && doc.UpdatedAt.Before(candidate.search.CreatedAt) {
+					continue
+				}
+				if !candidate.matches(event) {
+					continue
+				}
+				id := candidate.search.ID.String()
+				pipe.HSet(ctx, alertsPendingPrefix+id, doc.EventID.String(), summary)
+				pipe.ZAddNX(ctx, alertsDueKey, redis.Z{Score: float64(now.Unix()), Member: id})
+				matched++
+			}
+		}
+	}
+	if matched == 0 {
+		return
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		cfg.Logger.Error("Failed to queue saved search matches", "error", err, "matches", matched)
+		return
+	}
+	cfg.Logger.Debug("Queued saved search matches", "events", len(docs), "matches", matched)
+}
+
+func alertEventFromDocument(doc EventDocument) AlertEvent {
+	return AlertEvent{
+		EventID:       doc.EventID,
+		Name:          doc.Name,
+		VenueName:     doc.VenueName,
+		VenueCity:     doc.VenueCity,
+		StartDateTime: doc.StartDateTime,
+		BasePrice:     doc.BasePrice,
+		Currency:      doc.Currency,
+	}
+}
+
+// startAlertDelivery rebuilds the saved search index, then sends due
+// alerts on every tick until the process exits. Every replica runs it;
+// each due search is taken by one.
+func (cfg *APIConfig) startAlertDelivery() {
+	if _, err := cfg.rebuildSavedSearchIndex(context.Background()); err != nil {
+		// Matching rebuilds it once the user service answers.
+		cfg.Logger.Error("Failed to rebuild saved search index at startup", "error", err)
+		cfg.RedisClient.Del(context.Background(), savedSearchIndexKey)
+	}
+
+	interval := cfg.Config.AlertDeliveryInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for cfg.deliverAlerts(context.Background()) == alertsDeliveryBatch {
+		}
+	}
+}
+
+// deliverAlerts sends one batch of due alerts and returns how many saved
+// searches it took.
+func (cfg *APIConfig) deliverAlerts(ctx context.Context) int {
+	now := time.Now()
+	ids, err := cfg.RedisClient.ZRangeByScore(ctx, alertsDueKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: alertsDeliveryBatch,
+	}).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to read due search alerts", "error", err)
+		return 0
+	}
+
+	for _, id := range ids {
+		// Removing the entry claims it; another replica that got there
+		// first removed it already.
+		if removed, err := cfg.RedisClient.ZRem(ctx, alertsDueKey, id).Result(); err != nil || removed == 0 {
+			continue
+		}
+		if err := cfg.deliverAlert(ctx, id, now); err != nil {
+			cfg.Logger.Error("Failed to deliver search alert, retrying next pass", "error", err, "saved_search_id", id)
+			cfg.RedisClient.ZAddNX(ctx, alertsDueKey, redis.Z{Score: float64(now.Unix()), Member: id})
+		}
+	}
+	return len(ids)
+}
+
+// deliverAlert gathers a saved search's pending matches into an alert, or
+// puts the search back for when its frequency next allows one.
+func (cfg *APIConfig) deliverAlert(ctx context.Context, id string, now time.Time) error {
+	searchID, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	search, err := cfg.UserServiceClient.GetSavedSearch(ctx, searchID)
+	if errors.Is(err, errSavedSearchNotFound) {
+		return cfg.RedisClient.Del(ctx, alertsPendingPrefix+id).Err()
+	}
+	if err != nil {
+		return err
+	}
+	if !search.AlertsEnabled {
+		return cfg.RedisClient.Del(ctx, alertsPendingPrefix+id).Err()
+	}
+
+	if period := alertPeriods[search.Frequency]; period > 0 && search.LastAlertedAt != nil {
+		if next := search.LastAlertedAt.Add(period); next.After(now) {
+			return cfg.RedisClient.ZAdd(ctx, alertsDueKey, redis.Z{Score: float64(next.Unix()), Member: id}).Err()
+		}
+	}
+
+	// Matches are only dropped once the alert is stored, so a failed
+	// delivery sends them on the retry.
+	pending, err := cfg.RedisClient.HGetAll(ctx, alertsPendingPrefix+id).Result()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	delivered := make([]string, 0, len(pending))
+	for eventID := range pending {
+		delivered = append(delivered, eventID)
+	}
+
+	events := cfg.stillListedEvents(ctx, pending, now)
+	if len(events) > 0 {
+		slices.SortFunc(events, func(a, b AlertEvent) int {
+			return a.StartDateTime.Compare(b.StartDateTime)
+		})
+
+		alert, err := cfg.UserServiceClient.RecordSearchAlert(ctx, search.ID, events[:min(len(events), alertEvents)], len(events), now.UTC())
+		if err != nil {
+			return err
+		}
+		cfg.Logger.Info("Search alert delivered", "saved_search_id", search.ID, "user_id", search.UserID, "events", alert.MatchCount)
+	}
+
+	return cfg.RedisClient.HDel(ctx, alertsPendingPrefix+id, delivered...).Err()
+}
+
+// stillListedEvents decodes pending matches, leaving out events that have
+// started or have left the index since they matched.
+func (cfg *APIConfig) stillListedEvents(ctx context.Context, pending map[string]string, now time.Time) []AlertEvent {
+	var events []AlertEvent
+	for _, data := range pending {
+		var event AlertEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil || !event.StartDateTime.After(now) {
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	pipe := cfg.RedisClient.Pipeline()
+	indexed := make([]*redis.IntCmd, len(events))
+	for i, event := range events {
+		indexed[i] = pipe.Exists(ctx, searchCacheDocPrefix+event.EventID.String())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		// Better an alert with a stale event than none.
+		cfg.Logger.Warn("Failed to check alerted events are still indexed", "error", err)
+		return events
+	}
+
+	listed := events[:0]
+	for i, event := range events {
+		if indexed[i].Val() > 0 {
+			listed = append(listed, event)
+		}
+	}
+	return listed
+}
@@ -0,0 +1,282 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// searchSorts are the orders a search can ask for. Each breaks ties by
+// start time, soonest first.
+var searchSorts = []string{SortRelevance, SortDate, SortDateDesc, SortPriceAsc, SortPriceDesc, SortPopularity, SortDistance}
+
+// weekdayNames index ISO weekdays: 1 is Monday, 7 is Sunday.
+var weekdayNames = []string{"", "mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+const maxMinSeats = 10000
+
+// effectiveSort is the order a search is actually given in.
+func effectiveSort(req SearchRequest) string {
+	if req.Sort == "" {
+		return SortDate
+	}
+	return req.Sort
+}
+
+// searchSort builds the Elasticsearch sort keys for a search. With a
+// position, distance is always one of the keys so each hit reports it;
+// sort=distance makes it the primary one. Events without a trending score
+// or whose venue has no coordinates sort last on that key.
+func searchSort(req SearchRequest) []any {
+	dateSort := func(order string) map[string]any {
+		return map[string]any{"start_datetime": map[string]any{"order": order}}
+	}
+
+	var keys []any
+	switch req.Sort {
+	case SortRelevance:
+		keys = []any{map[string]any{"_score": map[string]any{"order": "desc"}}, dateSort("asc")}
+	case SortDateDesc:
+		keys = []any{dateSort("desc")}
+	case SortPriceAsc, SortPriceDesc:
+		order := "asc"
+		if req.Sort == SortPriceDesc {
+			order = "desc"
+		}
+		keys = []any{map[string]any{"base_price": map[string]any{"order": order}}, dateSort("asc")}
+	case SortTrending, SortPopularity:
+		keys = []any{
+			map[string]any{
+				"trending_score": map[string]any{
+					"order":         "desc",
+					"missing":       "_last",
+					"unmapped_type": "double",
+				},
+			},
+			dateSort("asc"),
+		}
+	default:
+		keys = []any{dateSort("asc")}
+	}
+
+	if req.Near != nil {
+		distanceSort := map[string]any{
+			"_geo_distance": map[string]any{
+				"location":        req.Near,
+				"order":           "asc",
+				"unit":            "km",
+				"ignore_unmapped": true,
+			},
+		}
+		if req.Sort == SortDistance {
+			keys = append([]any{distanceSort}, keys...)
+		} else {
+			keys = append(keys, distanceSort)
+		}
+	}
+	return keys
+}
+
+// parseSortParam reads sort. Distance needs a position, which
+// parseLocationParams checks. Prices are in each event's own currency and
+// only order within one, so the price sorts need a currency filter.
+func parseSortParam(r *http.Request) (string, error) {
+	sort := r.URL.Query().Get("sort")
+	if sort != "" && !slices.Contains(searchSorts, sort) {
+		return "", fmt.Errorf("sort must be one of %s", strings.Join(searchSorts, ", "))
+	}
+	if (sort == SortPriceAsc || sort == SortPriceDesc) && strings.TrimSpace(r.URL.Query().Get("currency")) == "" {
+		return "", fmt.Errorf("currency is required for sort=%s", sort)
+	}
+	return sort, nil
+}
+
+// parseAvailabilityParams reads the seat and venue filters: available=true
+// keeps events with seats left, min_seats keeps events with at least that
+// many, and venue_id keeps one venue's events.
+func parseAvailabilityParams(r *http.Request) (available bool, minSeats int, venueID string, err error) {
+	if value := r.URL.Query().Get("available"); value != "" {
+		if available, err = strconv.ParseBool(value); err != nil {
+			return false, 0, "", fmt.Errorf("available must be true or false")
+		}
+	}
+	if value := r.URL.Query().Get("min_seats"); value != "" {
+		minSeats, err = strconv.Atoi(value)
+		if err != nil || minSeats <= 0 || minSeats > maxMinSeats {
+			return false, 0, "", fmt.Errorf("min_seats must be between 1 and %d", maxMinSeats)
+		}
+	}
+	if venueID = strings.TrimSpace(r.URL.Query().Get("venue_id")); venueID != "" {
+		parsed, err := uuid.Parse(venueID)
+		if err != nil {
+			return false, 0, "", fmt.Errorf("venue_id must be a UUID")
+		}
+		venueID = parsed.String()
+	}
+	return available, minSeats, venueID, nil
+}
+
+// parseScheduleParams reads the day-of-week and time-of-day filters, both
+// in each venue's local time. day takes weekday names (mon to sun), given
+// repeatedly or separated by commas. time_from and time_to take HH:MM; a
+// window that ends before it starts runs past midnight.
+func parseScheduleParams(r *http.Request) (days []int, timeFrom, timeTo string, err error) {
+	for _, value := range r.URL.Query()["day"] {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			day := weekdayNumber(name)
+			if day == 0 {
+				return nil, "", "", fmt.Errorf("day must be a weekday name: mon, tue, wed, thu, fri, sat or sun")
+			}
+			if !slices.Contains(days, day) {
+				days = append(days, day)
+			}
+		}
+	}
+	slices.Sort(days)
+
+	timeFrom = strings.TrimSpace(r.URL.Query().Get("time_from"))
+	timeTo = strings.TrimSpace(r.URL.Query().Get("time_to"))
+	for _, value := range []string{timeFrom, timeTo} {
+		if _, ok := clockMinute(value); value != "" && !ok {
+			return nil, "", "", fmt.Errorf("time_from and time_to must be times of day as HH:MM")
+		}
+	}
+	return days, timeFrom, timeTo, nil
+}
+
+// weekdayNumber turns a weekday name, short or full, into its ISO number,
+// or 0 if it is not one.
+func weekdayNumber(name string) int {
+	for day := 1; day < len(weekdayNames); day++ {
+		if name == weekdayNames[day] || name == strings.ToLower(time.Weekday(day%7).String()) {
+			return day
+		}
+	}
+	return 0
+}
+
+// clockMinute turns HH:MM into minutes after midnight.
+func clockMinute(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// minuteMatches reports whether a start time falls in a time-of-day window.
+// Either bound may be open.
+func minuteMatches(minute int, timeFrom, timeTo string) bool {
+	from, hasFrom := clockMinute(timeFrom)
+	to, hasTo := clockMinute(timeTo)
+	switch {
+	case hasFrom && hasTo && from > to:
+		return minute >= from || minute <= to
+	case hasFrom && minute < from:
+		return false
+	case hasTo && minute > to:
+		return false
+	}
+	return true
+}
+
+// minuteFilter is the Elasticsearch filter for a time-of-day window.
+func minuteFilter(timeFrom, timeTo string) map[string]any {
+	from, hasFrom := clockMinute(timeFrom)
+	to, hasTo := clockMinute(timeTo)
+	if hasFrom && hasTo && from > to {
+		return map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"range": map[string]any{"start_minute": map[string]any{"gte": from}}},
+					map[string]any{"range": map[string]any{"start_minute": map[string]any{"lte": to}}},
+				},
+				"minimum_should_match": 1,
+			},
+		}
+	}
+	bounds := map[string]any{}
+	if hasFrom {
+		bounds["gte"] = from
+	}
+	if hasTo {
+		bounds["lte"] = to
+	}
+	return map[string]any{"range": map[string]any{"start_minute": bounds}}
+}
+
+// minimumSeats is how many seats an event must have left to be listed, or
+// 0 if seats do not matter.
+func minimumSeats(req SearchRequest) int {
+	if req.MinSeats > 0 {
+		return req.MinSeats
+	}
+	if req.Available {
+		return 1
+	}
+	return 0
+}
+
+// setLocalStart records the weekday and time of day the event starts at
+// its venue, which the day and time filters match.
+func (doc *EventDocument) setLocalStart() {
+	start := doc.StartDateTime
+	if doc.Timezone != "" {
+		if loc, err := time.LoadLocation(doc.Timezone); err == nil {
+			start = start.In(loc)
+		}
+	}
+	doc.StartWeekday = int(start.Weekday())
+	if doc.StartWeekday == 0 {
+		doc.StartWeekday = 7
+	}
+	doc.StartMinute = start.Hour()*60 + start.Minute()
+}
+
+// appliedFilters echoes the filters a search was narrowed by.
+func appliedFilters(req SearchRequest) AppliedFilters {
+	filters := AppliedFilters{
+		Query:     req.Query,
+		City:      req.City,
+		EventType: req.EventType,
+		Tags:      req.Tags,
+		DateFrom:  req.DateFrom,
+		DateTo:    req.DateTo,
+		MinPrice:  req.MinPrice,
+		MaxPrice:  req.MaxPrice,
+		Currency:  req.Currency,
+		Available: req.Available,
+		MinSeats:  req.MinSeats,
+		VenueID:   req.VenueID,
+		TimeFrom:  req.TimeFrom,
+		TimeTo:    req.TimeTo,
+	}
+	if req.Near != nil {
+		filters.Lat, filters.Lon = &req.Near.Lat, &req.Near.Lon
+		filters.RadiusKm = req.RadiusKm
+	}
+	for _, day := range req.Days {
+		filters.Days = append(filters.Days, weekdayNames[day])
+	}
+	return filters
+}
+
+// echoSearch returns a copy of result that tells the client how its
+// results were ordered and filtered. result itself is left alone, since a
+// cache miss hands the same response to every request waiting on it.
+func echoSearch(result *SearchResponse, req SearchRequest) *SearchResponse {
+	filters := appliedFilters(req)
+	echoed := *result
+	echoed.Sort = effectiveSort(req)
+	echoed.Filters = &filters
+	return &echoed
+}
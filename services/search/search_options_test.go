@@ -0,0 +1,346 @@
+package search
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/geocode"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+// sortKeys describes Elasticsearch sort keys as field:order.
+func sortKeys(keys []any) []string {
+	described := []string{}
+	for _, key := range keys {
+		for field, options := range key.(map[string]any) {
+			described = append(described, field+":"+options.(map[string]any)["order"].(string))
+		}
+	}
+	return described
+}
+
+func TestSearchSort(t *testing.T) {
+	pune := &geocode.Point{Lat: 18.52, Lon: 73.85}
+
+	tests := []struct {
+		name string
+		req  SearchRequest
+		want []string
+	}{
+		{name: "default", req: SearchRequest{}, want: []string{"start_datetime:asc"}},
+		{name: "date", req: SearchRequest{Sort: SortDate}, want: []string{"start_datetime:asc"}},
+		{name: "latest first", req: SearchRequest{Sort: SortDateDesc}, want: []string{"start_datetime:desc"}},
+		{name: "relevance", req: SearchRequest{Sort: SortRelevance}, want: []string{"_score:desc", "start_datetime:asc"}},
+		{name: "cheapest first", req: SearchRequest{Sort: SortPriceAsc}, want: []string{"base_price:asc", "start_datetime:asc"}},
+		{name: "dearest first", req: SearchRequest{Sort: SortPriceDesc}, want: []string{"base_price:desc", "start_datetime:asc"}},
+		{name: "popularity", req: SearchRequest{Sort: SortPopularity}, want: []string{"trending_score:desc", "start_datetime:asc"}},
+		{name: "trending", req: SearchRequest{Sort: SortTrending}, want: []string{"trending_score:desc", "start_datetime:asc"}},
+		{name: "distance is reported", req: SearchRequest{Near: pune}, want: []string{"start_datetime:asc", "_geo_distance:asc"}},
+		{name: "nearest first", req: SearchRequest{Near: pune, Sort: SortDistance}, want: []string{"_geo_distance:asc", "start_datetime:asc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortKeys(searchSort(tt.req)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchSort = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortParam(t *testing.T) {
+	tests := []struct {
+		query   url.Values
+		want    string
+		wantErr bool
+	}{
+		{query: url.Values{}, want: ""},
+		{query: url.Values{"sort": {SortDateDesc}}, want: SortDateDesc},
+		{query: url.Values{"sort": {SortDistance}}, want: SortDistance},
+		{query: url.Values{"sort": {SortPriceAsc}, "currency": {"INR"}}, want: SortPriceAsc},
+		{query: url.Values{"sort": {SortPriceDesc}, "currency": {"USD"}}, want: SortPriceDesc},
+		{query: url.Values{"sort": {SortPriceAsc}}, wantErr: true},
+		{query: url.Values{"sort": {SortPriceDesc}, "currency": {" "}}, wantErr: true},
+		{query: url.Values{"sort": {"cheapest"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/api/v1/search?"+tt.query.Encode(), nil)
+		got, err := parseSortParam(r)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseSortParam(%q) = %q, %v, want %q, error %v", tt.query.Encode(), got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseAvailabilityParams(t *testing.T) {
+	venueID := uuid.MustParse("0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e")
+
+	tests := []struct {
+		query         string
+		wantAvailable bool
+		wantMinSeats  int
+		wantVenueID   string
+		wantErr       bool
+	}{
+		{query: ""},
+		{query: "available=true", wantAvailable: true},
+		{query: "available=0"},
+		{query: "min_seats=4", wantMinSeats: 4},
+		{query: "min_seats=10000", wantMinSeats: 10000},
+		{query: "venue_id=" + url.QueryEscape(" 0B9C8D7E-6F5A-4B3C-9D2E-1F0A9B8C7D6E "), wantVenueID: venueID.String()},
+		{query: "available=yes", wantErr: true},
+		{query: "min_seats=0", wantErr: true},
+		{query: "min_seats=10001", wantErr: true},
+		{query: "min_seats=two", wantErr: true},
+		{query: "venue_id=hall-a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/api/v1/search?"+tt.query, nil)
+		available, minSeats, venueID, err := parseAvailabilityParams(r)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAvailabilityParams(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if available != tt.wantAvailable || minSeats != tt.wantMinSeats || venueID != tt.wantVenueID {
+			t.Errorf("parseAvailabilityParams(%q) = %v, %d, %q, want %v, %d, %q",
+				tt.query, available, minSeats, venueID, tt.wantAvailable, tt.wantMinSeats, tt.wantVenueID)
+		}
+	}
+}
+
+func TestParseScheduleParams(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantDays []int
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{query: ""},
+		{query: "day=sat,sun", wantDays: []int{6, 7}},
+		{query: "day=Friday&day=mon&day=fri", wantDays: []int{1, 5}},
+		{query: "day=sun,,+tue+", wantDays: []int{2, 7}},
+		{query: "time_from=18:00&time_to=23:30", wantFrom: "18:00", wantTo: "23:30"},
+		{query: "time_from=22:00&time_to=02:00", wantFrom: "22:00", wantTo: "02:00"},
+		{query: "day=someday", wantErr: true},
+		{query: "time_from=6pm", wantErr: true},
+		{query: "time_to=24:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/api/v1/search?"+tt.query, nil)
+		days, timeFrom, timeTo, err := parseScheduleParams(r)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseScheduleParams(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(days, tt.wantDays) || timeFrom != tt.wantFrom || timeTo != tt.wantTo {
+			t.Errorf("parseScheduleParams(%q) = %v, %q, %q, want %v, %q, %q",
+				tt.query, days, timeFrom, timeTo, tt.wantDays, tt.wantFrom, tt.wantTo)
+		}
+	}
+}
+
+func TestWeekdayNumber(t *testing.T) {
+	tests := map[string]int{
+		"mon":      1,
+		"monday":   1,
+		"sat":      6,
+		"sun":      7,
+		"sunday":   7,
+		"Sun":      0,
+		"weekend":  0,
+		"":         0,
+		"thursday": 4,
+	}
+
+	for name, want := range tests {
+		if got := weekdayNumber(name); got != want {
+			t.Errorf("weekdayNumber(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestMinuteMatches(t *testing.T) {
+	tests := []struct {
+		minute   int
+		timeFrom string
+		timeTo   string
+		want     bool
+	}{
+		{minute: 600, want: true},
+		{minute: 1080, timeFrom: "18:00", want: true},
+		{minute: 1079, timeFrom: "18:00", want: false},
+		{minute: 720, timeTo: "12:00", want: true},
+		{minute: 721, timeTo: "12:00", want: false},
+		{minute: 1200, timeFrom: "18:00", timeTo: "22:00", want: true},
+		{minute: 1330, timeFrom: "18:00", timeTo: "22:00", want: false},
+		{minute: 1410, timeFrom: "22:00", timeTo: "02:00", want: true},
+		{minute: 60, timeFrom: "22:00", timeTo: "02:00", want: true},
+		{minute: 720, timeFrom: "22:00", timeTo: "02:00", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := minuteMatches(tt.minute, tt.timeFrom, tt.timeTo); got != tt.want {
+			t.Errorf("minuteMatches(%d, %q, %q) = %v, want %v", tt.minute, tt.timeFrom, tt.timeTo, got, tt.want)
+		}
+	}
+}
+
+func TestMinimumSeats(t *testing.T) {
+	tests := []struct {
+		req  SearchRequest
+		want int
+	}{
+		{req: SearchRequest{}, want: 0},
+		{req: SearchRequest{Available: true}, want: 1},
+		{req: SearchRequest{MinSeats: 4}, want: 4},
+		{req: SearchRequest{Available: true, MinSeats: 4}, want: 4},
+	}
+
+	for _, tt := range tests {
+		if got := minimumSeats(tt.req); got != tt.want {
+			t.Errorf("minimumSeats(%+v) = %d, want %d", tt.req, got, tt.want)
+		}
+	}
+}
+
+func TestSetLocalStart(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       time.Time
+		timezone    string
+		wantWeekday int
+		wantMinute  int
+	}{
+		{name: "UTC", start: time.Date(2026, 11, 6, 14, 0, 0, 0, time.UTC), wantWeekday: 5, wantMinute: 840},
+		{name: "ahead of UTC", start: time.Date(2026, 11, 6, 14, 0, 0, 0, time.UTC), timezone: "Asia/Kolkata", wantWeekday: 5, wantMinute: 1170},
+		{name: "into the next day", start: time.Date(2026, 11, 7, 20, 0, 0, 0, time.UTC), timezone: "Asia/Kolkata", wantWeekday: 7, wantMinute: 90},
+		{name: "into the previous day", start: time.Date(2026, 11, 9, 2, 0, 0, 0, time.UTC), timezone: "America/New_York", wantWeekday: 7, wantMinute: 1260},
+		{name: "unknown timezone", start: time.Date(2026, 11, 8, 9, 15, 0, 0, time.UTC), timezone: "Mars/Olympus", wantWeekday: 7, wantMinute: 555},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := EventDocument{StartDateTime: tt.start, Timezone: tt.timezone}
+			doc.setLocalStart()
+			if doc.StartWeekday != tt.wantWeekday || doc.StartMinute != tt.wantMinute {
+				t.Errorf("setLocalStart = day %d minute %d, want day %d minute %d",
+					doc.StartWeekday, doc.StartMinute, tt.wantWeekday, tt.wantMinute)
+			}
+		})
+	}
+}
+
+func TestMemoryBackendScheduleAndSeatFilters(t *testing.T) {
+	hallA := uuid.MustParse("0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e")
+	hallB := uuid.MustParse("7a6b5c4d-3e2f-4a1b-8c9d-0e1f2a3b4c5d")
+
+	// Friday 14:00 in UTC, sold out.
+	soldOut := testDocument(1, "Matinee", "Pune", "music", 500, 0)
+	soldOut.VenueID = hallA
+	soldOut.AvailableSeats = 0
+
+	// Saturday 19:30 in Kolkata.
+	evening := testDocument(2, "Evening Show", "Pune", "music", 500, 1)
+	evening.VenueID = hallB
+	evening.AvailableSeats = 5
+	evening.Timezone = "Asia/Kolkata"
+	evening.setLocalStart()
+
+	// Sunday 09:00 in New York.
+	morning := testDocument(3, "Morning Show", "Pune", "music", 500, 2)
+	morning.VenueID = hallA
+	morning.Timezone = "America/New_York"
+	morning.setLocalStart()
+
+	// Monday 23:30 in UTC.
+	late := testDocument(4, "Late Show", "Pune", "music", 500, 3)
+	late.VenueID = hallB
+	late.StartDateTime = late.StartDateTime.Add(9*time.Hour + 30*time.Minute)
+	late.setLocalStart()
+
+	backend := NewMemoryBackend(logger.New("error"))
+	if _, err := backend.IndexEvents(context.Background(), []EventDocument{soldOut, evening, morning, late}); err != nil {
+		t.Fatalf("IndexEvents error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  SearchRequest
+		want []int
+	}{
+		{name: "available", req: SearchRequest{Available: true}, want: []int{2, 3, 4}},
+		{name: "minimum seats", req: SearchRequest{MinSeats: 10}, want: []int{3, 4}},
+		{name: "venue", req: SearchRequest{VenueID: hallA.String()}, want: []int{1, 3}},
+		{name: "local weekend", req: SearchRequest{Days: []int{6, 7}}, want: []int{2, 3}},
+		{name: "local evening", req: SearchRequest{TimeFrom: "18:00"}, want: []int{2, 4}},
+		{name: "local morning", req: SearchRequest{TimeTo: "12:00"}, want: []int{3}},
+		{name: "window past midnight", req: SearchRequest{TimeFrom: "22:00", TimeTo: "10:00"}, want: []int{3, 4}},
+		{name: "combined", req: SearchRequest{Available: true, VenueID: hallB.String(), Days: []int{1}}, want: []int{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := backend.Search(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Search error = %v", err)
+			}
+			if got := resultNumbers(result.Results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEchoSearchCopiesResponse(t *testing.T) {
+	shared := &SearchResponse{Results: []EventSearchResult{{EventID: testEventID(1)}}, Total: 1}
+
+	dearest := echoSearch(shared, SearchRequest{Currency: "INR", Sort: SortPriceDesc})
+	soonest := echoSearch(shared, SearchRequest{City: "Pune"})
+
+	if shared.Sort != "" || shared.Filters != nil {
+		t.Errorf("echoSearch changed the shared response: sort %q, filters %+v", shared.Sort, shared.Filters)
+	}
+	if dearest.Sort != SortPriceDesc || dearest.Filters.Currency != "INR" {
+		t.Errorf("first echo = sort %q, filters %+v", dearest.Sort, dearest.Filters)
+	}
+	if soonest.Sort != SortDate || soonest.Filters.City != "Pune" || soonest.Filters.Currency != "" {
+		t.Errorf("second echo = sort %q, filters %+v", soonest.Sort, soonest.Filters)
+	}
+	if soonest.Total != 1 || len(soonest.Results) != 1 {
+		t.Errorf("echo lost the results: %+v", soonest)
+	}
+}
+
+func TestEchoSearchSharedFlight(t *testing.T) {
+	var flights searchFlights
+	release := make(chan struct{})
+	done := make(chan *SearchResponse)
+
+	for _, city := range []string{"Pune", "Mumbai", "Delhi"} {
+		go func() {
+			result, _ := flights.do("key", func() (*SearchResponse, error) {
+				<-release
+				return &SearchResponse{Total: 1}, nil
+			})
+			done <- echoSearch(result, SearchRequest{City: city})
+		}()
+	}
+	close(release)
+
+	cities := map[string]bool{}
+	for range 3 {
+		cities[(<-done).Filters.City] = true
+	}
+	if len(cities) != 3 {
+		t.Errorf("echoed cities = %v, want each request's own", cities)
+	}
+}
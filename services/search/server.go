@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
 	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
@@ -23,11 +24,30 @@ func SetupRoutes(config *APIConfig) *http.ServeMux {
 	mux.HandleFunc("GET /api/v1/search/suggestions", config.GetSuggestions)
 	mux.HandleFunc("GET /api/v1/search/filters", config.GetFilters)
 	mux.HandleFunc("GET /api/v1/search/trending", config.GetTrendingEvents)
+	mux.HandleFunc("POST /api/v1/search/events/{id}/view", config.RecordEventView)
+	mux.HandleFunc("GET /api/v1/search/events/{id}/similar", config.requireElasticsearch(config.GetSimilarEvents))
+
+	userAuth := auth.RequireAuth(config.Config.JWTSecret)
+	mux.HandleFunc("GET /api/v1/search/for-you", userAuth(config.GetForYouEvents))
+	mux.HandleFunc("GET /api/v1/search/saved", userAuth(config.requireUserService(config.ListSavedSearches)))
+	mux.HandleFunc("POST /api/v1/search/saved", userAuth(config.requireUserService(config.CreateSavedSearch)))
+	mux.HandleFunc("GET /api/v1/search/saved/{id}", userAuth(config.requireUserService(config.GetSavedSearch)))
+	mux.HandleFunc("PUT /api/v1/search/saved/{id}", userAuth(config.requireUserService(config.UpdateSavedSearch)))
+	mux.HandleFunc("DELETE /api/v1/search/saved/{id}", userAuth(config.requireUserService(config.DeleteSavedSearch)))
+	mux.HandleFunc("GET /api/v1/search/alerts", userAuth(config.requireUserService(config.ListSearchAlerts)))
+	mux.HandleFunc("POST /api/v1/search/alerts/unsubscribe", userAuth(config.requireUserService(config.UnsubscribeAllAlerts)))
 
 	internalAuth := auth.RequireInternalAuth(config.Config.InternalAPIKey)
 	mux.HandleFunc("POST /internal/search/events", internalAuth(config.IndexEvent))
+	mux.HandleFunc("POST /internal/search/events/bulk", internalAuth(config.BulkIndexEvents))
 	mux.HandleFunc("DELETE /internal/search/events/{id}", internalAuth(config.DeleteEvent))
 	mux.HandleFunc("POST /internal/search/resync", internalAuth(config.FullResync))
+	mux.HandleFunc("GET /internal/search/changes", internalAuth(config.requireElasticsearch(config.GetChangesSyncStatus)))
+	mux.HandleFunc("POST /internal/search/changes/sync", internalAuth(config.requireElasticsearch(config.SyncChanges)))
+	mux.HandleFunc("GET /internal/search/indices", internalAuth(config.requireElasticsearch(config.ListIndexVersions)))
+	mux.HandleFunc("POST /internal/search/indices/rollback", internalAuth(config.requireElasticsearch(config.RollbackIndex)))
+	mux.HandleFunc("GET /internal/search/synonyms", internalAuth(config.requireElasticsearch(config.GetSynonyms)))
+	mux.HandleFunc("PUT /internal/search/synonyms", internalAuth(config.requireElasticsearch(config.UpdateSynonyms)))
 
 	return mux
 }
@@ -43,6 +63,20 @@ func StartServer(config *APIConfig) {
 		Addr:    ":" + config.Config.Port,
 	}
 
+	go config.startTrendingFlusher()
+	if config.UserServiceClient != nil {
+		go config.startAlertDelivery()
+	}
+	if config.ESClient != nil {
+		go config.startChangesSync()
+	}
+	if memory := config.memoryBackend(); memory != nil {
+		go config.followChanges(memory)
+	}
+	if config.Fallback != nil {
+		go config.startHealthMonitor()
+	}
+
 	config.Logger.Info("Starting Search Service", "port", config.Config.Port)
 	if err := server.ListenAndServe(); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
@@ -53,10 +87,11 @@ func InitSearchService() (*APIConfig, error) {
 	cfg := config.LoadSearchServiceConfig()
 	logger := logger.New(cfg.LogLevel).WithService("search-service")
 
-	esClient, err := NewElasticsearchClient(cfg.ElasticsearchURL, cfg.IndexName, logger)
+	backend, fallback, esClient, err := newSearchBackends(cfg, logger)
 	if err != nil {
 		return nil, err
 	}
+	logger.Info("Search backend selected", "backend", backend.Name(), "fallback", cfg.SearchFallback)
 
 	redisOptions, err := redis.ParseURL(cfg.RedisURL)
 	if err != nil {
@@ -66,21 +101,89 @@ func InitSearchService() (*APIConfig, error) {
 
 	eventServiceClient := NewEventServiceClient(cfg.EventServiceURL, cfg.InternalAPIKey, logger)
 
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	apiConfig := &APIConfig{
 		Config:             cfg,
 		Logger:             logger,
 		ESClient:           esClient,
+		Backend:            backend,
+		Fallback:           fallback,
 		RedisClient:        redisClient,
 		EventServiceClient: eventServiceClient,
+		trustedProxies:     trustedProxies,
+	}
+	if cfg.UserServiceURL != "" {
+		apiConfig.UserServiceClient = NewUserServiceClient(cfg.UserServiceURL, cfg.InternalAPIKey, logger)
+	}
+	if cfg.BookingServiceURL != "" {
+		apiConfig.BookingServiceClient = NewBookingServiceClient(cfg.BookingServiceURL, cfg.InternalAPIKey, logger)
 	}
 
-	logger.Info("Initializing Elasticsearch index")
-	if err := esClient.CreateIndex(context.Background()); err != nil {
-		logger.Error("Failed to create Elasticsearch index", "error", err)
-		return nil, err
+	if esClient != nil {
+		if err := apiConfig.setUpElasticsearch(context.Background()); err != nil {
+			if fallback == nil {
+				return nil, err
+			}
+			// With a fallback the service can start while Elasticsearch is
+			// down and finish setting it up once it is back.
+			logger.Warn("Elasticsearch is unavailable, serving reads from fallback until it is set up", "error", err, "fallback", fallback.Name())
+			apiConfig.degraded.Store(true)
+			go apiConfig.retryElasticsearchSetup()
+		}
 	}
 
 	return apiConfig, nil
 }
 
+// setUpElasticsearch creates the synonyms and index if they are missing,
+// and starts rebuilding an index created from an older schema.
+func (cfg *APIConfig) setUpElasticsearch(ctx context.Context) error {
+	if err := cfg.ESClient.EnsureSynonyms(ctx); err != nil {
+		cfg.Logger.Error("Failed to set up synonyms", "error", err)
+		return err
+	}
 
+	cfg.Logger.Info("Initializing Elasticsearch index")
+	if err := cfg.ESClient.CreateIndex(ctx); err != nil {
+		cfg.Logger.Error("Failed to create Elasticsearch index", "error", err)
+		return err
+	}
+
+	// Mapping and analyzer changes only apply to an index created with
+	// them, so an older index is rebuilt behind the alias.
+	_, legacy, err := cfg.ESClient.AliasTarget(ctx)
+	if err != nil {
+		cfg.Logger.Warn("Failed to check index alias", "error", err)
+	} else if version, err := cfg.ESClient.IndexSchemaVersion(ctx); err != nil {
+		cfg.Logger.Warn("Failed to check index schema version", "error", err)
+	} else if legacy || version < indexSchemaVersion {
+		cfg.Logger.Warn("Search index is out of date, rebuilding it in the background",
+			"index", cfg.Config.IndexName, "legacy", legacy, "index_schema_version", version, "schema_version", indexSchemaVersion)
+		go cfg.upgradeIndexInBackground()
+	}
+
+	return nil
+}
+
+// retryElasticsearchSetup repeats setUpElasticsearch on every health check
+// tick until it succeeds.
+func (cfg *APIConfig) retryElasticsearchSetup() {
+	interval := cfg.Config.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := cfg.setUpElasticsearch(context.Background()); err == nil {
+			cfg.Logger.Info("Elasticsearch set up after starting without it")
+			return
+		}
+	}
+}
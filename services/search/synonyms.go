@@ -0,0 +1,105 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+)
+
+const (
+	maxSynonymRules      = 1000
+	maxSynonymRuleLength = 500
+)
+
+// defaultSynonyms seeds a new synonyms set. Rules use the Solr format:
+// "a, b, c" makes the terms interchangeable, "a => b" rewrites a to b.
+var defaultSynonyms = []string{
+	"standup, stand up, stand-up, comedy",
+	"gig, concert, live music",
+	"fest, festival",
+	"theatre, theater",
+	"workshop, masterclass",
+}
+
+// normalizeSynonymRules trims the rules, drops blank ones and checks the
+// rest are rules Elasticsearch will accept.
+func normalizeSynonymRules(rules []string) ([]string, error) {
+	normalized := make([]string, 0, len(rules))
+	for i, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if len(rule) > maxSynonymRuleLength {
+			return nil, fmt.Errorf("synonym rule %d is longer than %d characters", i+1, maxSynonymRuleLength)
+		}
+		if strings.ContainsAny(rule, "\r\n") {
+			return nil, fmt.Errorf("synonym rule %d must be on one line", i+1)
+		}
+
+		if left, right, ok := strings.Cut(rule, "=>"); ok {
+			if strings.TrimSpace(left) == "" || strings.TrimSpace(right) == "" || strings.Contains(right, "=>") {
+				return nil, fmt.Errorf("synonym rule %d must look like \"a, b => c\"", i+1)
+			}
+		} else if len(strings.Split(rule, ",")) < 2 {
+			return nil, fmt.Errorf("synonym rule %d needs at least two comma-separated terms", i+1)
+		}
+		normalized = append(normalized, rule)
+	}
+
+	if len(normalized) > maxSynonymRules {
+		return nil, fmt.Errorf("at most %d synonym rules are allowed", maxSynonymRules)
+	}
+	return normalized, nil
+}
+
+func (cfg *APIConfig) GetSynonyms(w http.ResponseWriter, r *http.Request) {
+	rules, _, err := cfg.ESClient.GetSynonyms(r.Context())
+	if err != nil {
+		cfg.Logger.Error("Failed to get synonyms", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get synonyms")
+		return
+	}
+	if rules == nil {
+		rules = []string{}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SynonymsResponse{
+		Synonyms: rules,
+		Count:    len(rules),
+	})
+}
+
+// UpdateSynonyms replaces the whole synonym set. It takes effect for new
+// searches straight away, and cached results from before are retired.
+func (cfg *APIConfig) UpdateSynonyms(w http.ResponseWriter, r *http.Request) {
+	var req SynonymsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	rules, err := normalizeSynonymRules(req.Synonyms)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := cfg.ESClient.PutSynonyms(r.Context(), rules); err != nil {
+		cfg.Logger.Error("Failed to update synonyms", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update synonyms")
+		return
+	}
+
+	cfg.bumpSearchCacheGeneration(r.Context())
+
+	cfg.Logger.Info("Synonyms updated", "rules", len(rules))
+
+	utils.RespondWithJSON(w, http.StatusOK, SynonymsResponse{
+		Synonyms: rules,
+		Count:    len(rules),
+	})
+}
@@ -0,0 +1,406 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Trending scores decay exponentially with a configurable half-life. Rather
+// than decaying every stored score as time passes, each signal is weighted
+// up by how far after trendingEpoch it arrived (forward decay), which keeps
+// the order of stored scores equal to the order of their decayed values at
+// any moment. Scores are kept as logarithms so the growing weights never
+// overflow. The scores live in Redis and are copied onto the event
+// documents in batches, where trending queries sort by them.
+const (
+	trendingScoresKey = "trending:scores"
+	trendingDirtyKey  = "trending:dirty"
+	trendingViewKey   = "trending:viewed:"
+
+	trendingSeatWeight   = 1.0
+	trendingViewWeight   = 0.2
+	trendingSearchWeight = 0.05
+	// trendingSearchResults is how many top results of a search count as
+	// having been searched for.
+	trendingSearchResults = 5
+	// trendingViewWindow is how long repeated views of an event from the
+	// same client count once.
+	trendingViewWindow = 30 * time.Minute
+
+	trendingFlushBatch = 500
+	// trendingPruneInterval is how often scores of events that are no
+	// longer indexed are swept out, for the ones that left without a
+	// deletion reaching this service, such as in an index rebuild.
+	trendingPruneInterval = time.Hour
+)
+
+var trendingEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// addTrendingSignals adds log-space weights to stored log-space scores,
+// ARGV holding event ID and weight pairs, and marks the events for copying
+// to the index.
+var addTrendingSignals = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	local id = ARGV[i]
+	local add = tonumber(ARGV[i + 1])
+	local old = tonumber(redis.call("HGET", KEYS[1], id))
+	local score = add
+	if old then
+		local high, low = math.max(old, add), math.min(old, add)
+		score = high + math.log(1 + math.exp(low - high))
+	end
+	redis.call("HSET", KEYS[1], id, string.format("%.17g", score))
+	redis.call("SADD", KEYS[2], id)
+end
+return #ARGV / 2
+`)
+
+// trendingOffset is the log-space weight a signal arriving at t gets.
+func (cfg *APIConfig) trendingOffset(t time.Time) float64 {
+	halfLife := cfg.Config.TrendingHalfLife
+	if halfLife <= 0 {
+		halfLife = 24 * time.Hour
+	}
+	return t.Sub(trendingEpoch).Seconds() * math.Ln2 / halfLife.Seconds()
+}
+
+// currentTrendingScore turns a stored score into the decayed sum of the
+// event's signal weights as of now.
+func (cfg *APIConfig) currentTrendingScore(stored float64) float64 {
+	score := math.Exp(stored - cfg.trendingOffset(time.Now()))
+	return math.Round(score*1000) / 1000
+}
+
+// recordTrendingSignals adds weighted popularity signals to events' scores.
+func (cfg *APIConfig) recordTrendingSignals(ctx context.Context, weights map[uuid.UUID]float64) {
+	offset := cfg.trendingOffset(time.Now())
+	args := make([]any, 0, 2*len(weights))
+	for eventID, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		args = append(args, eventID.String(), strconv.FormatFloat(math.Log(weight)+offset, 'g', -1, 64))
+	}
+	if len(args) == 0 {
+		return
+	}
+
+	if err := addTrendingSignals.Run(ctx, cfg.RedisClient, []string{trendingScoresKey, trendingDirtyKey}, args...).Err(); err != nil {
+		cfg.Logger.Error("Failed to record trending signals", "error", err, "events", len(args)/2)
+	}
+}
+
+// recordSearchSignals credits the top results of a first-page search that
+// asked for something in particular.
+func (cfg *APIConfig) recordSearchSignals(ctx context.Context, req SearchRequest, result *SearchResponse) {
+	if req.Page != 1 || (req.Query == "" && len(req.Tags) == 0) {
+		return
+	}
+	weights := make(map[uuid.UUID]float64)
+	for i, event := range result.Results {
+		if i == trendingSearchResults {
+			break
+		}
+		weights[event.EventID] += trendingSearchWeight
+	}
+	cfg.recordTrendingSignals(ctx, weights)
+}
+
+// applyTrendingScores copies stored scores onto documents about to be
+// indexed, since indexing replaces the whole document.
+func (cfg *APIConfig) applyTrendingScores(ctx context.Context, docs []EventDocument) {
+	if len(docs) == 0 {
+		return
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.EventID.String()
+	}
+
+	values, err := cfg.RedisClient.HMGet(ctx, trendingScoresKey, ids...).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to read trending scores", "error", err)
+		return
+	}
+	for i, value := range values {
+		if raw, ok := value.(string); ok {
+			if score, err := strconv.ParseFloat(raw, 64); err == nil {
+				docs[i].TrendingScore = &score
+			}
+		}
+	}
+}
+
+// startTrendingFlusher copies changed trending scores onto event documents
+// until the process exits.
+func (cfg *APIConfig) startTrendingFlusher() {
+	interval := cfg.Config.TrendingFlushInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastPrune := time.Now()
+	for range ticker.C {
+		for cfg.flushTrendingScores(context.Background()) == trendingFlushBatch {
+		}
+
+		if time.Since(lastPrune) >= trendingPruneInterval {
+			cfg.pruneTrendingScores(context.Background())
+			lastPrune = time.Now()
+		}
+	}
+}
+
+// flushTrendingScores copies one batch of changed scores to the index and
+// returns how many events it took. Events that fail to update are marked
+// again for the next flush.
+func (cfg *APIConfig) flushTrendingScores(ctx context.Context) int {
+	ids, err := cfg.RedisClient.SPopN(ctx, trendingDirtyKey, trendingFlushBatch).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to take changed trending scores", "error", err)
+		return 0
+	}
+	if len(ids) == 0 {
+		return 0
+	}
+
+	values, err := cfg.RedisClient.HMGet(ctx, trendingScoresKey, ids...).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to read trending scores", "error", err)
+		cfg.remarkTrendingScores(ctx, ids)
+		return 0
+	}
+
+	scores := make(map[uuid.UUID]float64, len(ids))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		eventID, err := uuid.Parse(ids[i])
+		if err != nil {
+			continue
+		}
+		if score, err := strconv.ParseFloat(raw, 64); err == nil {
+			scores[eventID] = score
+		}
+	}
+
+	if err := cfg.updateTrendingScores(ctx, scores); err != nil {
+		cfg.Logger.Error("Failed to update trending scores in index", "error", err, "count", len(scores))
+		cfg.remarkTrendingScores(ctx, ids)
+		return 0
+	}
+
+	cfg.Logger.Debug("Flushed trending scores", "count", len(scores))
+	return len(ids)
+}
+
+// pruneTrendingScores drops the scores of events the backend no longer
+// has. It is skipped while the backend is unhealthy, since then it cannot
+// tell a missing event from an unreachable one.
+func (cfg *APIConfig) pruneTrendingScores(ctx context.Context) {
+	if cfg.degraded.Load() || cfg.Backend.HealthCheck(ctx) != nil {
+		return
+	}
+
+	dropped := 0
+	var cursor uint64
+	for {
+		fields, next, err := cfg.RedisClient.HScan(ctx, trendingScoresKey, cursor, "", trendingFlushBatch).Result()
+		if err != nil {
+			cfg.Logger.Error("Failed to scan trending scores", "error", err)
+			return
+		}
+
+		var stale []string
+		var eventIDs []uuid.UUID
+		for i := 0; i < len(fields); i += 2 {
+			eventID, err := uuid.Parse(fields[i])
+			if err != nil {
+				stale = append(stale, fields[i])
+				continue
+			}
+			eventIDs = append(eventIDs, eventID)
+		}
+
+		docs, err := cfg.Backend.GetDocuments(ctx, eventIDs)
+		if err != nil {
+			cfg.Logger.Error("Failed to check trending events in index", "error", err)
+			return
+		}
+		indexed := make(map[uuid.UUID]bool, len(docs))
+		for _, doc := range docs {
+			indexed[doc.EventID] = true
+		}
+		for _, eventID := range eventIDs {
+			if !indexed[eventID] {
+				stale = append(stale, eventID.String())
+			}
+		}
+
+		if len(stale) > 0 {
+			if err := cfg.RedisClient.HDel(ctx, trendingScoresKey, stale...).Err(); err != nil {
+				cfg.Logger.Error("Failed to prune trending scores", "error", err)
+				return
+			}
+			dropped += len(stale)
+		}
+
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	if dropped > 0 {
+		cfg.Logger.Info("Pruned trending scores of events no longer indexed", "count", dropped)
+	}
+}
+
+// dropTrendingScores forgets the scores of events that left the index.
+func (cfg *APIConfig) dropTrendingScores(ctx context.Context, eventIDs []uuid.UUID) {
+	ids := make([]string, len(eventIDs))
+	members := make([]any, len(eventIDs))
+	for i, eventID := range eventIDs {
+		ids[i] = eventID.String()
+		members[i] = ids[i]
+	}
+
+	pipe := cfg.RedisClient.TxPipeline()
+	pipe.HDel(ctx, trendingScoresKey, ids...)
+	pipe.SRem(ctx, trendingDirtyKey, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		cfg.Logger.Error("Failed to drop trending scores", "error", err, "count", len(eventIDs))
+	}
+}
+
+func (cfg *APIConfig) remarkTrendingScores(ctx context.Context, ids []string) {
+	members := make([]any, len(ids))
+	for i, id := range ids {
+		members[i] = id
+	}
+	if err := cfg.RedisClient.SAdd(ctx, trendingDirtyKey, members...).Err(); err != nil {
+		cfg.Logger.Error("Failed to re-mark trending scores", "error", err, "count", len(ids))
+	}
+}
+
+// RecordEventView counts a view of a published event's page towards its
+// trending score. Repeat views from the same client within a short window
+// count once.
+func (cfg *APIConfig) RecordEventView(w http.ResponseWriter, r *http.Request) {
+	eventIDStr := r.PathValue("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid event ID format")
+		return
+	}
+
+	// Only events in the index count, so made-up IDs cannot add scores.
+	var docs []EventDocument
+	err = cfg.withReadBackend(r.Context(), func(backend SearchBackend) error {
+		var err error
+		docs, err = backend.GetDocuments(r.Context(), []uuid.UUID{eventID})
+		return err
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to look up viewed event", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record view")
+		return
+	}
+	if len(docs) == 0 || docs[0].Status != "published" {
+		utils.RespondWithError(w, http.StatusNotFound, "Event not found")
+		return
+	}
+
+	viewKey := trendingViewKey + eventID.String() + ":" + cfg.viewerAddress(r)
+	first, err := cfg.RedisClient.SetNX(r.Context(), viewKey, 1, trendingViewWindow).Result()
+	if err != nil {
+		cfg.Logger.Error("Failed to record event view", "error", err, "event_id", eventID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record view")
+		return
+	}
+
+	status := "duplicate"
+	if first {
+		cfg.recordTrendingSignals(r.Context(), map[uuid.UUID]float64{eventID: trendingViewWeight})
+		status = "recorded"
+	}
+
+	utils.RespondWithJSON(w, http.StatusAccepted, RecordViewResponse{
+		Status:  status,
+		EventID: eventID.String(),
+	})
+}
+
+// viewerAddress identifies the client behind the gateway. Anyone can send
+// client address headers, so they are only believed on requests that come
+// straight from a trusted gateway. The gateway sets X-Real-IP to the
+// address that connected to it, which is also the last entry it adds to
+// X-Forwarded-For; earlier entries are whatever the client sent.
+func (cfg *APIConfig) viewerAddress(r *http.Request) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		peer = host
+	}
+	if !cfg.trustedProxy(peer) {
+		return peer
+	}
+
+	forwarded := strings.TrimSpace(r.Header.Get("X-Real-IP"))
+	if forwarded == "" {
+		if hops := strings.Split(r.Header.Get("X-Forwarded-For"), ","); len(hops) > 0 {
+			forwarded = strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	if addr, err := netip.ParseAddr(forwarded); err == nil {
+		return addr.String()
+	}
+	return peer
+}
+
+func (cfg *APIConfig) trustedProxy(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range cfg.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies reads gateway addresses, each a CIDR range or a
+// single address.
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SEARCH_TRUSTED_PROXIES entry %q: expected an address or CIDR range", value)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
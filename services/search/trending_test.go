@@ -0,0 +1,185 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+)
+
+func testTrendingConfig(t *testing.T) (*APIConfig, *fakeRedis) {
+	t.Helper()
+	client, fake := newFakeRedis(t)
+	backend := testMemoryBackend(t)
+	backend.MarkLoaded()
+	cfg := &APIConfig{
+		Config:         &config.SearchServiceConfig{TrendingHalfLife: 24 * time.Hour},
+		Logger:         logger.New("error"),
+		RedisClient:    client,
+		Backend:        backend,
+		trustedProxies: []netip.Prefix{netip.MustParsePrefix("172.28.0.10/32")},
+	}
+	return cfg, fake
+}
+
+func TestViewerAddress(t *testing.T) {
+	cfg := &APIConfig{trustedProxies: []netip.Prefix{netip.MustParsePrefix("172.28.0.0/24")}}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		forwarded  string
+		want       string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:5123", want: "203.0.113.7"},
+		{name: "direct client spoofing headers", remoteAddr: "203.0.113.7:5123", realIP: "198.51.100.1", forwarded: "198.51.100.2", want: "203.0.113.7"},
+		{name: "gateway with X-Real-IP", remoteAddr: "172.28.0.10:40000", realIP: "198.51.100.1", forwarded: "10.0.0.1, 198.51.100.1", want: "198.51.100.1"},
+		{name: "gateway with only X-Forwarded-For", remoteAddr: "172.28.0.10:40000", forwarded: "10.0.0.1, 198.51.100.1", want: "198.51.100.1"},
+		{name: "gateway with a bad header", remoteAddr: "172.28.0.10:40000", realIP: "not-an-ip", want: "172.28.0.10"},
+		{name: "gateway without headers", remoteAddr: "172.28.0.10:40000", want: "172.28.0.10"},
+		{name: "mapped gateway address", remoteAddr: "[::ffff:172.28.0.10]:40000", realIP: "198.51.100.1", want: "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/search/events/x/view", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := cfg.viewerAddress(r); got != tt.want {
+				t.Errorf("viewerAddress = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	got, err := parseTrustedProxies([]string{"172.28.0.10", "10.1.2.3/16", "::ffff:192.0.2.1", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("parseTrustedProxies error = %v", err)
+	}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("172.28.0.10/32"),
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("fd00::/8"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTrustedProxies = %v, want %v", got, want)
+	}
+
+	if _, err := parseTrustedProxies([]string{"gateway"}); err == nil {
+		t.Error("parseTrustedProxies accepted a host name")
+	}
+}
+
+func TestRecordEventView(t *testing.T) {
+	cfg, fake := testTrendingConfig(t)
+
+	view := func(id string) (int, RecordViewResponse) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/search/events/"+id+"/view", nil)
+		r.SetPathValue("id", id)
+		r.RemoteAddr = "203.0.113.7:5123"
+		w := httptest.NewRecorder()
+		cfg.RecordEventView(w, r)
+
+		var response RecordViewResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "malformed ID", id: "42", wantCode: http.StatusBadRequest},
+		{name: "event not indexed", id: testEventID(99).String(), wantCode: http.StatusNotFound},
+		{name: "draft event", id: testEventID(4).String(), wantCode: http.StatusNotFound},
+		{name: "published event", id: testEventID(1).String(), wantCode: http.StatusAccepted, wantStatus: "recorded"},
+		{name: "repeat view", id: testEventID(1).String(), wantCode: http.StatusAccepted, wantStatus: "duplicate"},
+	}
+
+	for _, tt := range tests {
+		code, response := view(tt.id)
+		if code != tt.wantCode || response.Status != tt.wantStatus {
+			t.Errorf("%s: RecordEventView = %d %q, want %d %q", tt.name, code, response.Status, tt.wantCode, tt.wantStatus)
+		}
+	}
+
+	want := []string{trendingViewKey + testEventID(1).String() + ":203.0.113.7"}
+	if got := fake.keys(trendingViewKey); !reflect.DeepEqual(got, want) {
+		t.Errorf("view keys = %v, want %v", got, want)
+	}
+}
+
+func TestPruneTrendingScores(t *testing.T) {
+	cfg, fake := testTrendingConfig(t)
+	ctx := context.Background()
+
+	scores := map[string]any{
+		testEventID(1).String():  "3.5",
+		testEventID(2).String():  "1.5",
+		testEventID(99).String(): "2.0",
+		"not-a-uuid":             "1.0",
+	}
+	if err := cfg.RedisClient.HSet(ctx, trendingScoresKey, scores).Err(); err != nil {
+		t.Fatalf("HSet error = %v", err)
+	}
+
+	cfg.pruneTrendingScores(ctx)
+
+	want := map[string]string{
+		testEventID(1).String(): "3.5",
+		testEventID(2).String(): "1.5",
+	}
+	if got := fake.hash(trendingScoresKey); !reflect.DeepEqual(got, want) {
+		t.Errorf("scores after prune = %v, want %v", got, want)
+	}
+}
+
+func TestPruneTrendingScoresSkipsUnloadedBackend(t *testing.T) {
+	cfg, fake := testTrendingConfig(t)
+	cfg.Backend = NewMemoryBackend(logger.New("error"))
+	ctx := context.Background()
+
+	if err := cfg.RedisClient.HSet(ctx, trendingScoresKey, testEventID(1).String(), "3.5").Err(); err != nil {
+		t.Fatalf("HSet error = %v", err)
+	}
+
+	cfg.pruneTrendingScores(ctx)
+
+	if got := fake.hash(trendingScoresKey); len(got) != 1 {
+		t.Errorf("scores after prune = %v, want them kept while the index loads", got)
+	}
+}
+
+func TestForgetIndexedEventsDropsTrendingScores(t *testing.T) {
+	cfg, fake := testTrendingConfig(t)
+	ctx := context.Background()
+
+	cfg.RedisClient.HSet(ctx, trendingScoresKey, testEventID(1).String(), "3.5", testEventID(2).String(), "1.5")
+	cfg.RedisClient.SAdd(ctx, trendingDirtyKey, testEventID(1).String(), testEventID(2).String())
+
+	cfg.forgetIndexedEvents(ctx, testEventID(1))
+
+	if got, want := fake.hash(trendingScoresKey), map[string]string{testEventID(2).String(): "1.5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scores = %v, want %v", got, want)
+	}
+	if got, want := fake.members(trendingDirtyKey), []string{testEventID(2).String()}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dirty events = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,250 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/logger"
+	"github.com/google/uuid"
+)
+
+type UserServiceClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	Logger     *logger.Logger
+}
+
+type UserServicePreferences struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Cities     []string  `json:"cities"`
+	Categories []string  `json:"categories"`
+	Tags       []string  `json:"tags"`
+}
+
+func NewUserServiceClient(baseURL, apiKey string, logger *logger.Logger) *UserServiceClient {
+	return &UserServiceClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		APIKey: apiKey,
+		Logger: logger,
+	}
+}
+
+// GetPreferences returns a user's saved preferences; users who never saved
+// any get empty lists.
+func (c *UserServiceClient) GetPreferences(ctx context.Context, userID uuid.UUID) (*UserServicePreferences, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/internal/users/"+userID.String()+"/preferences", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("user service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response UserServicePreferences
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// userServiceError is a response the user service refused a request with.
+type userServiceError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *userServiceError) Error() string {
+	return fmt.Sprintf("user service returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// SaveSearchRequest is a saved search as the user service stores it.
+type SaveSearchRequest struct {
+	Name          string              `json:"name"`
+	Search        SavedSearchCriteria `json:"search"`
+	Frequency     string              `json:"frequency"`
+	AlertsEnabled bool                `json:"alerts_enabled"`
+}
+
+type userServiceSavedSearches struct {
+	SavedSearches []SavedSearch `json:"saved_searches"`
+	HasMore       bool          `json:"has_more"`
+}
+
+type recordSearchAlertRequest struct {
+	Events     []AlertEvent `json:"events"`
+	MatchCount int          `json:"match_count"`
+	AlertedAt  time.Time    `json:"alerted_at"`
+}
+
+// savedSearchRequest sends a saved search request and decodes the answer
+// into out, if given. A 404 comes back as errSavedSearchNotFound and other
+// refusals as *userServiceError.
+func (c *UserServiceClient) savedSearchRequest(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errSavedSearchNotFound
+	}
+	if resp.StatusCode >= 300 {
+		var refusal struct {
+			Error string `json:"error"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &refusal) != nil || refusal.Error == "" {
+			refusal.Error = string(data)
+		}
+		return &userServiceError{StatusCode: resp.StatusCode, Message: refusal.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func userSavedSearchesPath(userID uuid.UUID) string {
+	return "/internal/users/" + userID.String() + "/saved-searches"
+}
+
+// ListSavedSearches returns a user's saved searches, newest first.
+func (c *UserServiceClient) ListSavedSearches(ctx context.Context, userID uuid.UUID) ([]SavedSearch, error) {
+	var response userServiceSavedSearches
+	if err := c.savedSearchRequest(ctx, "GET", userSavedSearchesPath(userID), nil, &response); err != nil {
+		return nil, err
+	}
+	return response.SavedSearches, nil
+}
+
+func (c *UserServiceClient) CreateSavedSearch(ctx context.Context, userID uuid.UUID, search SaveSearchRequest) (*SavedSearch, error) {
+	var response SavedSearch
+	if err := c.savedSearchRequest(ctx, "POST", userSavedSearchesPath(userID), search, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetUserSavedSearch returns one of a user's saved searches; another
+// user's is not found.
+func (c *UserServiceClient) GetUserSavedSearch(ctx context.Context, userID, id uuid.UUID) (*SavedSearch, error) {
+	var response SavedSearch
+	if err := c.savedSearchRequest(ctx, "GET", userSavedSearchesPath(userID)+"/"+id.String(), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *UserServiceClient) UpdateSavedSearch(ctx context.Context, userID, id uuid.UUID, search SaveSearchRequest) (*SavedSearch, error) {
+	var response SavedSearch
+	if err := c.savedSearchRequest(ctx, "PUT", userSavedSearchesPath(userID)+"/"+id.String(), search, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *UserServiceClient) DeleteSavedSearch(ctx context.Context, userID, id uuid.UUID) error {
+	return c.savedSearchRequest(ctx, "DELETE", userSavedSearchesPath(userID)+"/"+id.String(), nil, nil)
+}
+
+// DisableSearchAlerts turns alerts off for all of a user's saved searches
+// and returns the IDs of those that had them on.
+func (c *UserServiceClient) DisableSearchAlerts(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var response struct {
+		SavedSearchIDs []uuid.UUID `json:"saved_search_ids"`
+	}
+	if err := c.savedSearchRequest(ctx, "POST", userSavedSearchesPath(userID)+"/unsubscribe", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.SavedSearchIDs, nil
+}
+
+// ListAlertingSavedSearches returns a page of the saved searches with
+// alerts on, in ID order after the given ID, and whether more follow.
+func (c *UserServiceClient) ListAlertingSavedSearches(ctx context.Context, after uuid.UUID, limit int) ([]SavedSearch, bool, error) {
+	path := fmt.Sprintf("/internal/saved-searches?after=%s&limit=%d", after, limit)
+	var response userServiceSavedSearches
+	if err := c.savedSearchRequest(ctx, "GET", path, nil, &response); err != nil {
+		return nil, false, err
+	}
+	return response.SavedSearches, response.HasMore, nil
+}
+
+// GetSavedSearch returns any user's saved search, with when it last
+// alerted.
+func (c *UserServiceClient) GetSavedSearch(ctx context.Context, id uuid.UUID) (*SavedSearch, error) {
+	var response SavedSearch
+	if err := c.savedSearchRequest(ctx, "GET", "/internal/saved-searches/"+id.String(), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RecordSearchAlert stores an alert for the saved search's user and marks
+// the search alerted at the given time.
+func (c *UserServiceClient) RecordSearchAlert(ctx context.Context, id uuid.UUID, events []AlertEvent, matchCount int, alertedAt time.Time) (*SearchAlert, error) {
+	request := recordSearchAlertRequest{
+		Events:     events,
+		MatchCount: matchCount,
+		AlertedAt:  alertedAt,
+	}
+	var response SearchAlert
+	if err := c.savedSearchRequest(ctx, "POST", "/internal/saved-searches/"+id.String()+"/alerts", request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListSearchAlerts returns a user's most recent alerts, newest first.
+func (c *UserServiceClient) ListSearchAlerts(ctx context.Context, userID uuid.UUID, limit int) ([]SearchAlert, error) {
+	path := fmt.Sprintf("/internal/users/%s/search-alerts?limit=%d", userID, limit)
+	var response struct {
+		Alerts []SearchAlert `json:"alerts"`
+	}
+	if err := c.savedSearchRequest(ctx, "GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Alerts, nil
+}
@@ -80,3 +80,39 @@ func (cfg *APIConfig) HandleInternalGetUser(w http.ResponseWriter, r *http.Reque
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
+
+func (cfg *APIConfig) HandleInternalBatchGetUsers(w http.ResponseWriter, r *http.Request) {
+	var requestBody BatchUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if len(requestBody.UserIDs) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "user_ids is required")
+		return
+	}
+
+	if len(requestBody.UserIDs) > 1000 {
+		utils.RespondWithError(w, http.StatusBadRequest, "At most 1000 user_ids per request")
+		return
+	}
+
+	rows, err := cfg.DB.GetUsersByIDs(r.Context(), cfg.DB_Conn, requestBody.UserIDs)
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch users", "error", err, "count", len(requestBody.UserIDs))
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch users")
+		return
+	}
+
+	response := BatchUsersResponse{Users: make([]UserSummary, len(rows))}
+	for i, row := range rows {
+		response.Users[i] = UserSummary{
+			UserID: row.UserID,
+			Email:  row.Email,
+			Name:   row.Name,
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/fyzanshaik/bookmyevent-ily/internal/config"
@@ -76,3 +77,80 @@ type TokenVerificationResponse struct {
 type VerifyTokenRequest struct {
 	Token string `json:"token"`
 }
+
+type BatchUsersRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+type UserSummary struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+type BatchUsersResponse struct {
+	Users []UserSummary `json:"users"`
+}
+
+type UpdatePreferencesRequest struct {
+	Cities     []string `json:"cities"`
+	Categories []string `json:"categories"`
+	Tags       []string `json:"tags"`
+}
+
+type PreferencesResponse struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Cities     []string   `json:"cities"`
+	Categories []string   `json:"categories"`
+	Tags       []string   `json:"tags"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// SaveSearchRequest carries a saved search from the search service, which
+// validates the criteria; they are stored as given.
+type SaveSearchRequest struct {
+	Name          string          `json:"name"`
+	Search        json.RawMessage `json:"search"`
+	Frequency     string          `json:"frequency"`
+	AlertsEnabled bool            `json:"alerts_enabled"`
+}
+
+type SavedSearchResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	UserID        uuid.UUID       `json:"user_id"`
+	Name          string          `json:"name"`
+	Search        json.RawMessage `json:"search"`
+	Frequency     string          `json:"frequency"`
+	AlertsEnabled bool            `json:"alerts_enabled"`
+	LastAlertedAt *time.Time      `json:"last_alerted_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type SavedSearchesResponse struct {
+	SavedSearches []SavedSearchResponse `json:"saved_searches"`
+	HasMore       bool                  `json:"has_more"`
+}
+
+type UnsubscribeSearchAlertsResponse struct {
+	SavedSearchIDs []uuid.UUID `json:"saved_search_ids"`
+}
+
+type RecordSearchAlertRequest struct {
+	Events     json.RawMessage `json:"events"`
+	MatchCount int             `json:"match_count"`
+	AlertedAt  time.Time       `json:"alerted_at"`
+}
+
+type SearchAlertResponse struct {
+	ID              uuid.UUID       `json:"id"`
+	SavedSearchID   uuid.UUID       `json:"saved_search_id"`
+	SavedSearchName string          `json:"saved_search_name"`
+	Events          json.RawMessage `json:"events"`
+	MatchCount      int             `json:"match_count"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type SearchAlertsResponse struct {
+	Alerts []SearchAlertResponse `json:"alerts"`
+}
@@ -0,0 +1,171 @@
+package user
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/auth"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/users"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	maxPreferredCities     = 10
+	maxPreferredCategories = 20
+	maxPreferredTags       = 30
+	maxPreferenceLength    = 50
+)
+
+// normalizePreferences trims the values, drops blanks and duplicates, and
+// checks the limits. Categories are slugs, so they are lowered; cities and
+// tags are matched regardless of case and keep the user's spelling.
+func normalizePreferences(values []string, field string, limit int, lower bool) ([]string, error) {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if lower {
+			value = strings.ToLower(value)
+		}
+		if value == "" || seen[strings.ToLower(value)] {
+			continue
+		}
+		if len(value) > maxPreferenceLength {
+			return nil, fmt.Errorf("%s must be at most %d characters each", field, maxPreferenceLength)
+		}
+		seen[strings.ToLower(value)] = true
+		normalized = append(normalized, value)
+	}
+
+	if len(normalized) > limit {
+		return nil, fmt.Errorf("at most %d %s are allowed", limit, field)
+	}
+	return normalized, nil
+}
+
+// loadPreferences returns a user's saved preferences, or empty ones if they
+// never saved any.
+func (cfg *APIConfig) loadPreferences(r *http.Request, userID uuid.UUID) (PreferencesResponse, error) {
+	prefs, err := cfg.DB.GetUserPreferences(r.Context(), cfg.DB_Conn, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PreferencesResponse{
+			UserID:     userID,
+			Cities:     []string{},
+			Categories: []string{},
+			Tags:       []string{},
+		}, nil
+	}
+	if err != nil {
+		return PreferencesResponse{}, err
+	}
+	return preferencesResponse(prefs), nil
+}
+
+func preferencesResponse(prefs users.UserPreference) PreferencesResponse {
+	response := PreferencesResponse{
+		UserID:     prefs.UserID,
+		Cities:     prefs.Cities,
+		Categories: prefs.Categories,
+		Tags:       prefs.Tags,
+	}
+	if response.Cities == nil {
+		response.Cities = []string{}
+	}
+	if response.Categories == nil {
+		response.Categories = []string{}
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	if prefs.UpdatedAt.Valid {
+		response.UpdatedAt = &prefs.UpdatedAt.Time
+	}
+	return response
+}
+
+func (cfg *APIConfig) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	response, err := cfg.loadPreferences(r, userID)
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch preferences", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch preferences")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// UpdatePreferences replaces the user's preferences. Omitted lists are
+// saved empty.
+func (cfg *APIConfig) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var requestBody UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	cities, err := normalizePreferences(requestBody.Cities, "cities", maxPreferredCities, false)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	categories, err := normalizePreferences(requestBody.Categories, "categories", maxPreferredCategories, true)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	tags, err := normalizePreferences(requestBody.Tags, "tags", maxPreferredTags, false)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prefs, err := cfg.DB.UpsertUserPreferences(r.Context(), cfg.DB_Conn, users.UpsertUserPreferencesParams{
+		UserID:     userID,
+		Cities:     cities,
+		Categories: categories,
+		Tags:       tags,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to save preferences", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save preferences")
+		return
+	}
+
+	cfg.Logger.Info("User preferences updated", "user_id", userID.String())
+
+	utils.RespondWithJSON(w, http.StatusOK, preferencesResponse(prefs))
+}
+
+func (cfg *APIConfig) HandleInternalGetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("userId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	response, err := cfg.loadPreferences(r, userID)
+	if err != nil {
+		cfg.Logger.Error("Failed to fetch preferences", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch preferences")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,467 @@
+package user
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/bookmyevent-ily/internal/repository/users"
+	"github.com/fyzanshaik/bookmyevent-ily/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Saved searches and the alerts sent for them belong to the user, so they
+// are kept here; the search service validates and matches them, and keeps
+// its own index of the searches with alerts on.
+const (
+	maxSavedSearches        = 20
+	maxSavedSearchName      = 100
+	defaultAlertingListed   = 500
+	maxAlertingListed       = 1000
+	defaultSearchAlertsRead = 20
+	maxSearchAlertsRead     = 100
+)
+
+var savedSearchFrequencies = []string{"instant", "daily", "weekly"}
+
+func savedSearchResponse(search users.SavedSearch) SavedSearchResponse {
+	response := SavedSearchResponse{
+		ID:            search.SavedSearchID,
+		UserID:        search.UserID,
+		Name:          search.Name,
+		Search:        search.Criteria,
+		Frequency:     search.Frequency,
+		AlertsEnabled: search.AlertsEnabled,
+		CreatedAt:     search.CreatedAt,
+		UpdatedAt:     search.UpdatedAt,
+	}
+	if search.LastAlertedAt.Valid {
+		response.LastAlertedAt = &search.LastAlertedAt.Time
+	}
+	return response
+}
+
+func savedSearchResponses(searches []users.SavedSearch) []SavedSearchResponse {
+	responses := make([]SavedSearchResponse, len(searches))
+	for i, search := range searches {
+		responses[i] = savedSearchResponse(search)
+	}
+	return responses
+}
+
+func searchAlertResponse(alert users.SearchAlert) SearchAlertResponse {
+	return SearchAlertResponse{
+		ID:              alert.AlertID,
+		SavedSearchID:   alert.SavedSearchID,
+		SavedSearchName: alert.SavedSearchName,
+		Events:          alert.Events,
+		MatchCount:      int(alert.MatchCount),
+		CreatedAt:       alert.CreatedAt,
+	}
+}
+
+// validateSaveSearchRequest checks what the database would otherwise
+// refuse. The criteria themselves are the search service's to check.
+func validateSaveSearchRequest(req SaveSearchRequest) error {
+	if name := strings.TrimSpace(req.Name); name == "" || len(name) > maxSavedSearchName {
+		return fmt.Errorf("name is required and must be at most %d characters", maxSavedSearchName)
+	}
+	if !isJSON(req.Search, '{') {
+		return errors.New("search must be a JSON object")
+	}
+	for _, frequency := range savedSearchFrequencies {
+		if req.Frequency == frequency {
+			return nil
+		}
+	}
+	return fmt.Errorf("frequency must be one of %s", strings.Join(savedSearchFrequencies, ", "))
+}
+
+// isJSON reports whether data is valid JSON opening with the given
+// bracket, so an object or an array.
+func isJSON(data json.RawMessage, open byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == open && json.Valid(data)
+}
+
+// pathUUID reads a UUID path value, answering the request itself if it is
+// not one.
+func pathUUID(w http.ResponseWriter, r *http.Request, name, label string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid "+label+" ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (cfg *APIConfig) HandleInternalListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+
+	searches, err := cfg.DB.ListUserSavedSearches(r.Context(), cfg.DB_Conn, userID)
+	if err != nil {
+		cfg.Logger.Error("Failed to list saved searches", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list saved searches")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SavedSearchesResponse{SavedSearches: savedSearchResponses(searches)})
+}
+
+// HandleInternalCreateSavedSearch saves a search for a user. The user's row
+// is locked while their searches are counted, so parallel requests cannot
+// both squeeze under the limit.
+func (cfg *APIConfig) HandleInternalCreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+
+	var requestBody SaveSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if err := validateSaveSearchRequest(requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := cfg.DB.LockUserForSavedSearch(r.Context(), tx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		cfg.Logger.Error("Failed to lock user", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+
+	count, err := cfg.DB.CountUserSavedSearches(r.Context(), tx, userID)
+	if err != nil {
+		cfg.Logger.Error("Failed to count saved searches", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+	if count >= maxSavedSearches {
+		utils.RespondWithError(w, http.StatusConflict, fmt.Sprintf("At most %d saved searches are allowed", maxSavedSearches))
+		return
+	}
+
+	search, err := cfg.DB.CreateSavedSearch(r.Context(), tx, users.CreateSavedSearchParams{
+		SavedSearchID: uuid.New(),
+		UserID:        userID,
+		Name:          strings.TrimSpace(requestBody.Name),
+		Criteria:      requestBody.Search,
+		Frequency:     requestBody.Frequency,
+		AlertsEnabled: requestBody.AlertsEnabled,
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to save search", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit saved search", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, savedSearchResponse(search))
+}
+
+func (cfg *APIConfig) HandleInternalGetUserSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+	searchID, ok := pathUUID(w, r, "searchId", "saved search")
+	if !ok {
+		return
+	}
+
+	search, err := cfg.DB.GetUserSavedSearch(r.Context(), cfg.DB_Conn, users.GetUserSavedSearchParams{
+		SavedSearchID: searchID,
+		UserID:        userID,
+	})
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "fetch", searchID)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, savedSearchResponse(search))
+}
+
+// HandleInternalUpdateSavedSearch replaces a saved search; the search
+// service merges partial updates before sending it.
+func (cfg *APIConfig) HandleInternalUpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+	searchID, ok := pathUUID(w, r, "searchId", "saved search")
+	if !ok {
+		return
+	}
+
+	var requestBody SaveSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if err := validateSaveSearchRequest(requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	search, err := cfg.DB.UpdateSavedSearch(r.Context(), cfg.DB_Conn, users.UpdateSavedSearchParams{
+		SavedSearchID: searchID,
+		UserID:        userID,
+		Name:          strings.TrimSpace(requestBody.Name),
+		Criteria:      requestBody.Search,
+		Frequency:     requestBody.Frequency,
+		AlertsEnabled: requestBody.AlertsEnabled,
+	})
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "update", searchID)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, savedSearchResponse(search))
+}
+
+func (cfg *APIConfig) HandleInternalDeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+	searchID, ok := pathUUID(w, r, "searchId", "saved search")
+	if !ok {
+		return
+	}
+
+	deleted, err := cfg.DB.DeleteSavedSearch(r.Context(), cfg.DB_Conn, users.DeleteSavedSearchParams{
+		SavedSearchID: searchID,
+		UserID:        userID,
+	})
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "delete", searchID)
+		return
+	}
+	if deleted == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "Saved search not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleInternalUnsubscribeSearchAlerts turns alerts off for every one of a
+// user's saved searches and returns those that had them on.
+func (cfg *APIConfig) HandleInternalUnsubscribeSearchAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+
+	ids, err := cfg.DB.DisableUserSearchAlerts(r.Context(), cfg.DB_Conn, userID)
+	if err != nil {
+		cfg.Logger.Error("Failed to turn off search alerts", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to turn off search alerts")
+		return
+	}
+
+	cfg.Logger.Info("Search alerts turned off", "user_id", userID.String(), "saved_searches", len(ids))
+	utils.RespondWithJSON(w, http.StatusOK, UnsubscribeSearchAlertsResponse{SavedSearchIDs: ids})
+}
+
+// HandleInternalListSearchAlerts returns a user's most recent alerts,
+// newest first.
+func (cfg *APIConfig) HandleInternalListSearchAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUUID(w, r, "userId", "user")
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > maxSearchAlertsRead {
+		limit = defaultSearchAlertsRead
+	}
+
+	alerts, err := cfg.DB.ListUserSearchAlerts(r.Context(), cfg.DB_Conn, users.ListUserSearchAlertsParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list search alerts", "error", err, "user_id", userID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list search alerts")
+		return
+	}
+
+	responses := make([]SearchAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		responses[i] = searchAlertResponse(alert)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, SearchAlertsResponse{Alerts: responses})
+}
+
+// HandleInternalListAlertingSavedSearches pages through every saved search
+// with alerts on, in ID order after the ?after= ID, for the search service
+// to rebuild its index from.
+func (cfg *APIConfig) HandleInternalListAlertingSavedSearches(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	after := uuid.Nil
+	if value := query.Get("after"); value != "" {
+		var err error
+		if after, err = uuid.Parse(value); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid after ID format")
+			return
+		}
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit <= 0 || limit > maxAlertingListed {
+		limit = defaultAlertingListed
+	}
+
+	// One extra row tells whether there is another page.
+	searches, err := cfg.DB.ListAlertingSavedSearches(r.Context(), cfg.DB_Conn, users.ListAlertingSavedSearchesParams{
+		SavedSearchID: after,
+		Limit:         int32(limit + 1),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to list alerting saved searches", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list saved searches")
+		return
+	}
+
+	hasMore := len(searches) > limit
+	if hasMore {
+		searches = searches[:limit]
+	}
+	utils.RespondWithJSON(w, http.StatusOK, SavedSearchesResponse{
+		SavedSearches: savedSearchResponses(searches),
+		HasMore:       hasMore,
+	})
+}
+
+func (cfg *APIConfig) HandleInternalGetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	searchID, ok := pathUUID(w, r, "searchId", "saved search")
+	if !ok {
+		return
+	}
+
+	search, err := cfg.DB.GetSavedSearch(r.Context(), cfg.DB_Conn, searchID)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "fetch", searchID)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, savedSearchResponse(search))
+}
+
+// HandleInternalRecordSearchAlert stores an alert sent for a saved search
+// and marks when the search alerted, which paces its next alert. Alerts
+// are kept for 30 days.
+func (cfg *APIConfig) HandleInternalRecordSearchAlert(w http.ResponseWriter, r *http.Request) {
+	searchID, ok := pathUUID(w, r, "searchId", "saved search")
+	if !ok {
+		return
+	}
+
+	var requestBody RecordSearchAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if !isJSON(requestBody.Events, '[') {
+		utils.RespondWithError(w, http.StatusBadRequest, "events must be a JSON array")
+		return
+	}
+	if requestBody.MatchCount <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "match_count must be positive")
+		return
+	}
+	if requestBody.AlertedAt.IsZero() {
+		requestBody.AlertedAt = time.Now()
+	}
+
+	tx, err := cfg.DB_Conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		cfg.Logger.Error("Failed to begin transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record search alert")
+		return
+	}
+	defer tx.Rollback()
+
+	search, err := cfg.DB.GetSavedSearch(r.Context(), tx, searchID)
+	if err != nil {
+		cfg.respondSavedSearchError(w, err, "fetch", searchID)
+		return
+	}
+
+	alert, err := cfg.DB.CreateSearchAlert(r.Context(), tx, users.CreateSearchAlertParams{
+		AlertID:         uuid.New(),
+		UserID:          search.UserID,
+		SavedSearchID:   search.SavedSearchID,
+		SavedSearchName: search.Name,
+		Events:          requestBody.Events,
+		MatchCount:      int32(requestBody.MatchCount),
+	})
+	if err != nil {
+		cfg.Logger.Error("Failed to record search alert", "error", err, "saved_search_id", searchID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record search alert")
+		return
+	}
+
+	if err := cfg.DB.MarkSavedSearchAlerted(r.Context(), tx, users.MarkSavedSearchAlertedParams{
+		SavedSearchID: searchID,
+		LastAlertedAt: sql.NullTime{Time: requestBody.AlertedAt.UTC(), Valid: true},
+	}); err != nil {
+		cfg.Logger.Error("Failed to mark saved search alerted", "error", err, "saved_search_id", searchID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record search alert")
+		return
+	}
+
+	if err := cfg.DB.PruneUserSearchAlerts(r.Context(), tx, search.UserID); err != nil {
+		cfg.Logger.Error("Failed to prune search alerts", "error", err, "user_id", search.UserID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record search alert")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		cfg.Logger.Error("Failed to commit search alert", "error", err, "saved_search_id", searchID.String())
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to record search alert")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, searchAlertResponse(alert))
+}
+
+// respondSavedSearchError answers a failed saved search lookup or write.
+func (cfg *APIConfig) respondSavedSearchError(w http.ResponseWriter, err error, action string, searchID uuid.UUID) {
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "Saved search not found")
+		return
+	}
+	cfg.Logger.Error("Failed to "+action+" saved search", "error", err, "saved_search_id", searchID.String())
+	utils.RespondWithError(w, http.StatusInternalServerError, "Failed to "+action+" saved search")
+}
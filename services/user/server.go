@@ -28,10 +28,24 @@ func SetupRoutes(config *APIConfig) *http.ServeMux {
 	mux.HandleFunc("GET /api/v1/users/profile", authMiddleware(config.GetProfile))
 	mux.HandleFunc("PUT /api/v1/users/profile", authMiddleware(config.UpdateProfile))
 	mux.HandleFunc("GET /api/v1/users/bookings", authMiddleware(config.GetUserBookings))
+	mux.HandleFunc("GET /api/v1/users/preferences", authMiddleware(config.GetPreferences))
+	mux.HandleFunc("PUT /api/v1/users/preferences", authMiddleware(config.UpdatePreferences))
 
 	internalAuth := auth.RequireInternalAuth(config.Config.InternalAPIKey)
 	mux.HandleFunc("POST /internal/auth/verify", internalAuth(config.HandleInternalVerify))
 	mux.HandleFunc("GET /internal/users/{userId}", internalAuth(config.HandleInternalGetUser))
+	mux.HandleFunc("POST /internal/users/batch", internalAuth(config.HandleInternalBatchGetUsers))
+	mux.HandleFunc("GET /internal/users/{userId}/preferences", internalAuth(config.HandleInternalGetPreferences))
+	mux.HandleFunc("GET /internal/users/{userId}/saved-searches", internalAuth(config.HandleInternalListSavedSearches))
+	mux.HandleFunc("POST /internal/users/{userId}/saved-searches", internalAuth(config.HandleInternalCreateSavedSearch))
+	mux.HandleFunc("POST /internal/users/{userId}/saved-searches/unsubscribe", internalAuth(config.HandleInternalUnsubscribeSearchAlerts))
+	mux.HandleFunc("GET /internal/users/{userId}/saved-searches/{searchId}", internalAuth(config.HandleInternalGetUserSavedSearch))
+	mux.HandleFunc("PUT /internal/users/{userId}/saved-searches/{searchId}", internalAuth(config.HandleInternalUpdateSavedSearch))
+	mux.HandleFunc("DELETE /internal/users/{userId}/saved-searches/{searchId}", internalAuth(config.HandleInternalDeleteSavedSearch))
+	mux.HandleFunc("GET /internal/users/{userId}/search-alerts", internalAuth(config.HandleInternalListSearchAlerts))
+	mux.HandleFunc("GET /internal/saved-searches", internalAuth(config.HandleInternalListAlertingSavedSearches))
+	mux.HandleFunc("GET /internal/saved-searches/{searchId}", internalAuth(config.HandleInternalGetSavedSearch))
+	mux.HandleFunc("POST /internal/saved-searches/{searchId}/alerts", internalAuth(config.HandleInternalRecordSearchAlert))
 
 	return mux
 }
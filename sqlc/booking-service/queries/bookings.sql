@@ -1,10 +1,10 @@
 
 -- name: CreateBooking :one
 INSERT INTO bookings (
-    user_id, event_id, booking_reference, quantity, total_amount, 
-    status, payment_status, idempotency_key, expires_at
+    user_id, event_id, booking_reference, quantity, total_amount_minor, 
+    status, payment_status, idempotency_key, expires_at, currency
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetBookingByID :one
@@ -66,7 +66,7 @@ DELETE FROM bookings WHERE booking_id = $1;
 SELECT 
     b.*,
     p.payment_id,
-    p.amount as payment_amount,
+    p.amount_minor as payment_amount_minor,
     p.gateway_transaction_id,
     p.payment_method,
     p.ticket_url,
@@ -74,4 +74,66 @@ SELECT
     p.completed_at as payment_completed_at
 FROM bookings b
 LEFT JOIN payments p ON b.booking_id = p.booking_id
-WHERE b.booking_id = $1;
\ No newline at end of file
+WHERE b.booking_id = $1;
+
+-- name: GetEventBookingStats :one
+SELECT
+    COUNT(*) as total_bookings,
+    COUNT(*) FILTER (WHERE status = 'confirmed') as confirmed_bookings,
+    COUNT(*) FILTER (WHERE status = 'cancelled') as cancelled_bookings,
+    COUNT(*) FILTER (WHERE status = 'expired') as expired_bookings,
+    COUNT(*) FILTER (WHERE status = 'pending') as pending_bookings,
+    COALESCE(SUM(quantity) FILTER (WHERE status = 'confirmed'), 0)::bigint as tickets_sold,
+    COALESCE(SUM(quantity) FILTER (WHERE status = 'cancelled'), 0)::bigint as tickets_cancelled
+FROM bookings
+WHERE event_id = $1;
+
+-- name: ListEventAttendees :many
+SELECT
+    b.booking_id,
+    b.user_id,
+    b.booking_reference,
+    b.quantity,
+    b.confirmed_at,
+    b.checked_in_at,
+    COALESCE(string_agg(CONCAT_WS('-', s.seat_section, s.seat_row, s.seat_number), ',' ORDER BY s.seat_section, s.seat_row, s.seat_number) FILTER (WHERE s.booking_seat_id IS NOT NULL), '')::text as seats
+FROM bookings b
+LEFT JOIN booking_seats s ON s.booking_id = b.booking_id
+WHERE b.event_id = @event_id
+    AND b.status = 'confirmed'
+    AND b.booking_id > @after_booking_id
+GROUP BY b.booking_id
+ORDER BY b.booking_id
+LIMIT @page_size;
+
+-- name: CountEventAttendees :one
+SELECT
+    COUNT(*) as total_bookings,
+    COALESCE(SUM(quantity), 0)::bigint as total_tickets,
+    COUNT(checked_in_at) as checked_in_bookings
+FROM bookings
+WHERE event_id = $1 AND status = 'confirmed';
+
+-- name: CheckInBooking :one
+UPDATE bookings
+SET checked_in_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE booking_id = $1
+    AND status = 'confirmed'
+    AND checked_in_at IS NULL
+RETURNING *;
+
+-- name: CountEventBookings :one
+SELECT COUNT(*) FROM bookings
+WHERE event_id = $1;
+
+-- name: ListUserBookedEvents :many
+SELECT
+    event_id,
+    SUM(quantity)::bigint as tickets,
+    MAX(booked_at)::timestamp as last_booked_at
+FROM bookings
+WHERE user_id = $1 AND status = 'confirmed'
+GROUP BY event_id
+ORDER BY last_booked_at DESC
+LIMIT $2;
@@ -1,7 +1,7 @@
 
 -- name: CreatePayment :one
 INSERT INTO payments (
-    booking_id, user_id, event_id, amount, currency, 
+    booking_id, user_id, event_id, amount_minor, currency, 
     payment_method, payment_gateway, gateway_transaction_id, status
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
@@ -47,14 +47,14 @@ LIMIT $2 OFFSET $3;
 SELECT 
     event_id,
     COUNT(*) as total_payments,
-    SUM(amount) as total_revenue,
-    AVG(amount) as average_amount
+    SUM(amount_minor) as total_revenue_minor,
+    AVG(amount_minor) as average_amount_minor
 FROM payments 
 WHERE status = 'completed' 
     AND completed_at >= $1 
     AND completed_at <= $2
 GROUP BY event_id
-ORDER BY total_revenue DESC;
+ORDER BY total_revenue_minor DESC;
 
 -- name: GetUserPaymentHistory :many
 SELECT 
@@ -66,4 +66,60 @@ FROM payments p
 JOIN bookings b ON p.booking_id = b.booking_id
 WHERE p.user_id = $1
 ORDER BY p.completed_at DESC 
-LIMIT $2 OFFSET $3;
\ No newline at end of file
+LIMIT $2 OFFSET $3;
+
+-- name: RecordPaymentRefund :one
+UPDATE payments
+SET refund_amount_minor = $2,
+    refunded_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE booking_id = $1 AND status = 'completed'
+RETURNING *;
+
+-- name: GetEventRevenueSummary :one
+SELECT
+    COUNT(*) as total_payments,
+    COALESCE(MAX(currency), '')::text as currency,
+    COALESCE(SUM(amount_minor), 0)::bigint as gross_revenue_minor,
+    COALESCE(SUM(refund_amount_minor), 0)::bigint as refunded_amount_minor,
+    COALESCE(SUM(amount_minor - refund_amount_minor), 0)::bigint as net_revenue_minor,
+    COALESCE(ROUND(AVG(amount_minor)), 0)::bigint as average_order_value_minor
+FROM payments
+WHERE event_id = $1 AND status = 'completed';
+
+-- name: GetEventSalesTimeSeries :many
+WITH sales AS (
+    SELECT
+        date_trunc($2::text, p.completed_at) as bucket,
+        COUNT(*) as orders,
+        SUM(b.quantity) as tickets_sold,
+        SUM(p.amount_minor) as gross_revenue_minor
+    FROM payments p
+    JOIN bookings b ON p.booking_id = b.booking_id
+    WHERE p.event_id = $1
+        AND p.status = 'completed'
+        AND p.completed_at >= $3
+        AND p.completed_at < $4
+    GROUP BY 1
+),
+refunds AS (
+    -- Refunds count when they happen, not when the sale did.
+    SELECT
+        date_trunc($2::text, p.refunded_at) as bucket,
+        SUM(p.refund_amount_minor) as refunded_amount_minor
+    FROM payments p
+    WHERE p.event_id = $1
+        AND p.status = 'completed'
+        AND p.refunded_at >= $3
+        AND p.refunded_at < $4
+    GROUP BY 1
+)
+SELECT
+    COALESCE(s.bucket, r.bucket)::timestamp as bucket,
+    COALESCE(s.orders, 0)::bigint as orders,
+    COALESCE(s.tickets_sold, 0)::bigint as tickets_sold,
+    COALESCE(s.gross_revenue_minor, 0)::bigint as gross_revenue_minor,
+    COALESCE(r.refunded_amount_minor, 0)::bigint as refunded_amount_minor
+FROM sales s
+FULL OUTER JOIN refunds r ON s.bucket = r.bucket
+ORDER BY bucket;
@@ -91,3 +91,13 @@ WHERE user_id = $1 AND event_id = $2;
 UPDATE waitlist
 SET position = $2, updated_at = CURRENT_TIMESTAMP
 WHERE waitlist_id = $1;
+
+-- name: GetEventWaitlistConversion :one
+SELECT
+    COUNT(*) as total_joined,
+    COUNT(*) FILTER (WHERE status = 'waiting') as waiting,
+    COUNT(*) FILTER (WHERE offered_at IS NOT NULL) as total_offered,
+    COUNT(*) FILTER (WHERE status = 'converted') as converted,
+    COUNT(*) FILTER (WHERE status = 'expired') as expired
+FROM waitlist
+WHERE event_id = $1;
@@ -4,7 +4,7 @@ CREATE TABLE bookings (
     event_id UUID NOT NULL,
     booking_reference VARCHAR(20) UNIQUE NOT NULL,
     quantity INTEGER NOT NULL,
-    total_amount DECIMAL(10, 2) NOT NULL,
+    total_amount_minor BIGINT NOT NULL,
     status VARCHAR(20) NOT NULL,
     payment_status VARCHAR(20) NOT NULL,
     idempotency_key VARCHAR(255) UNIQUE,
@@ -13,7 +13,9 @@ CREATE TABLE bookings (
     confirmed_at TIMESTAMP,
     cancelled_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    checked_in_at TIMESTAMP,
+    currency CHAR(3) NOT NULL
 );
 
 CREATE TABLE payments (
@@ -21,8 +23,8 @@ CREATE TABLE payments (
     booking_id UUID NOT NULL,
     user_id UUID NOT NULL,
     event_id UUID NOT NULL,
-    amount DECIMAL(10, 2) NOT NULL,
-    currency VARCHAR(3) DEFAULT 'USD',
+    amount_minor BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
     payment_method VARCHAR(50),
     payment_gateway VARCHAR(50),
     gateway_transaction_id VARCHAR(255),
@@ -33,7 +35,9 @@ CREATE TABLE payments (
     failed_at TIMESTAMP,
     error_message TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    refund_amount_minor BIGINT NOT NULL DEFAULT 0,
+    refunded_at TIMESTAMP
 );
 
 CREATE TABLE waitlist (
@@ -0,0 +1,78 @@
+-- name: GetAdminTwoFactor :one
+SELECT * FROM admin_two_factor
+WHERE admin_id = $1;
+
+-- name: UpsertPendingAdminTwoFactor :one
+INSERT INTO admin_two_factor (
+    admin_id, secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (admin_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    updated_at = CURRENT_TIMESTAMP
+WHERE admin_two_factor.enabled_at IS NULL
+RETURNING *;
+
+-- name: EnableAdminTwoFactor :execrows
+UPDATE admin_two_factor
+SET enabled_at = CURRENT_TIMESTAMP,
+    last_used_step = @step,
+    updated_at = CURRENT_TIMESTAMP
+WHERE admin_id = @admin_id AND enabled_at IS NULL AND last_used_step < @step;
+
+-- name: UseAdminTwoFactorStep :execrows
+UPDATE admin_two_factor
+SET last_used_step = @step,
+    updated_at = CURRENT_TIMESTAMP
+WHERE admin_id = @admin_id AND enabled_at IS NOT NULL AND last_used_step < @step;
+
+-- name: DeleteAdminTwoFactor :execrows
+DELETE FROM admin_two_factor
+WHERE admin_id = $1;
+
+-- name: CreateAdminRecoveryCode :exec
+INSERT INTO admin_recovery_codes (
+    admin_id, code_hash
+) VALUES (
+    $1, $2
+);
+
+-- name: DeleteAdminRecoveryCodes :exec
+DELETE FROM admin_recovery_codes
+WHERE admin_id = $1;
+
+-- name: UseAdminRecoveryCode :execrows
+UPDATE admin_recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedAdminRecoveryCodes :one
+SELECT COUNT(*) FROM admin_recovery_codes
+WHERE admin_id = $1 AND used_at IS NULL;
+
+-- name: CreateAdminLoginChallenge :one
+INSERT INTO admin_login_challenges (
+    token, admin_id, expires_at
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetAdminLoginChallenge :one
+SELECT * FROM admin_login_challenges
+WHERE token = $1 AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: AttemptAdminLoginChallenge :one
+UPDATE admin_login_challenges
+SET attempts = attempts + 1
+WHERE token = @token AND expires_at > CURRENT_TIMESTAMP AND attempts < @max_attempts
+RETURNING *;
+
+-- name: DeleteAdminLoginChallenge :exec
+DELETE FROM admin_login_challenges
+WHERE token = $1;
+
+-- name: DeleteAdminLoginChallenges :exec
+DELETE FROM admin_login_challenges
+WHERE admin_id = $1 OR expires_at < CURRENT_TIMESTAMP;
@@ -0,0 +1,28 @@
+-- name: CreateCategory :one
+INSERT INTO categories (slug, name, parent_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCategoryBySlug :one
+SELECT * FROM categories
+WHERE slug = $1;
+
+-- name: ListCategories :many
+SELECT * FROM categories
+ORDER BY name, slug;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET name = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE slug = $1
+RETURNING *;
+
+-- name: CountCategoryUsage :one
+SELECT
+    (SELECT COUNT(*) FROM events e WHERE e.category_id = @category_id) AS event_count,
+    (SELECT COUNT(*) FROM categories c WHERE c.parent_id = @category_id) AS subcategory_count;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories
+WHERE category_id = $1;
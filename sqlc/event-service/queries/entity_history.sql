@@ -0,0 +1,32 @@
+-- name: CreateEntityHistory :one
+INSERT INTO entity_history (
+    entity_type, entity_id, version, action, organization_id,
+    changed_by, changes, snapshot, reverted_from_version
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetLatestEntityHistoryVersion :one
+SELECT COALESCE(MAX(version), 0)::int FROM entity_history
+WHERE entity_type = $1 AND entity_id = $2;
+
+-- name: GetEntityHistoryVersion :one
+SELECT * FROM entity_history
+WHERE entity_type = $1 AND entity_id = $2 AND version = $3;
+
+-- name: ListEntityHistory :many
+SELECT * FROM entity_history
+WHERE entity_type = @entity_type
+  AND entity_id = @entity_id
+  AND (@before_version::int = 0 OR version < @before_version::int)
+ORDER BY version DESC
+LIMIT @page_size;
+
+-- name: ListOrganizationAudit :many
+SELECT * FROM entity_history
+WHERE organization_id = @organization_id
+  AND action <> 'baseline'
+  AND (@entity_type::text = '' OR entity_type = @entity_type::text)
+  AND (@changed_by::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR changed_by = @changed_by::uuid)
+  AND (@before::timestamp = '0001-01-01'::timestamp OR created_at < @before::timestamp)
+ORDER BY created_at DESC
+LIMIT @page_size;
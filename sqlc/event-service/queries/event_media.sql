@@ -0,0 +1,38 @@
+-- name: CreateEventMedia :one
+INSERT INTO event_media (
+    media_id, event_id, kind, content_type, width, height,
+    size_bytes, storage_key, variants, position, uploaded_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetEventMedia :one
+SELECT * FROM event_media
+WHERE media_id = $1 AND event_id = $2;
+
+-- name: ListEventMedia :many
+SELECT * FROM event_media
+WHERE event_id = $1
+ORDER BY kind, position, created_at;
+
+-- name: ListEventMediaByEvents :many
+SELECT * FROM event_media
+WHERE event_id = ANY(@event_ids::uuid[])
+ORDER BY event_id, kind, position, created_at;
+
+-- name: CountEventMediaByKind :one
+SELECT COUNT(*) FROM event_media
+WHERE event_id = $1 AND kind = $2;
+
+-- name: NextEventMediaPosition :one
+SELECT COALESCE(MAX(position) + 1, 0)::int FROM event_media
+WHERE event_id = $1 AND kind = $2;
+
+-- name: DeleteEventMedia :one
+DELETE FROM event_media
+WHERE media_id = $1 AND event_id = $2
+RETURNING *;
+
+-- name: DeleteEventMediaByKind :many
+DELETE FROM event_media
+WHERE event_id = $1 AND kind = $2
+RETURNING *;
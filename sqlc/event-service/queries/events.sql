@@ -2,29 +2,38 @@
 -- name: CreateEvent :one
 INSERT INTO events (
     name, description, venue_id, event_type, start_datetime, end_datetime,
-    total_capacity, available_seats, base_price, max_tickets_per_booking,
-    status, created_by
+    total_capacity, available_seats, base_price_minor, max_tickets_per_booking,
+    status, created_by, organization_id, setup_buffer_minutes, teardown_buffer_minutes, currency,
+    category_id, tags
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
 RETURNING *;
 
 -- name: GetEventByID :one
-SELECT e.*, v.name as venue_name, v.address, v.city, v.state, v.country
+SELECT e.*, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+       v.latitude as venue_latitude, v.longitude as venue_longitude
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
-WHERE e.event_id = $1;
+WHERE e.event_id = $1
+  AND e.deleted_at IS NULL;
 
 -- name: ListPublishedEvents :many
-SELECT e.*, v.name as venue_name, v.city, v.state
+SELECT e.*, v.name as venue_name, v.city, v.state, v.timezone as venue_timezone,
+       v.latitude as venue_latitude, v.longitude as venue_longitude
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
 WHERE e.status = 'published'
+  AND e.deleted_at IS NULL
   AND e.start_datetime > CURRENT_TIMESTAMP
-  AND ($3::text = '' OR e.event_type = $3)
+  AND ($3::text = '' OR e.event_type = $3 OR e.category_id IN (
+        SELECT c.category_id FROM categories c
+        JOIN categories p ON p.category_id = c.parent_id
+        WHERE p.slug = $3
+    ))
   AND ($4::text = '' OR v.city ILIKE '%' || $4 || '%')
-  AND ($5::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $5)
-  AND ($6::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $6)
+  AND ($5::date = '0001-01-01'::date OR e.start_datetime >= ($5::date::timestamp AT TIME ZONE v.timezone))
+  AND ($6::date = '0001-01-01'::date OR e.start_datetime < (($6::date + 1)::timestamp AT TIME ZONE v.timezone))
 ORDER BY e.start_datetime ASC
 LIMIT $1 OFFSET $2;
 
@@ -33,18 +42,24 @@ SELECT COUNT(*)
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
 WHERE e.status = 'published'
+  AND e.deleted_at IS NULL
   AND e.start_datetime > CURRENT_TIMESTAMP
-  AND ($1::text = '' OR e.event_type = $1)
+  AND ($1::text = '' OR e.event_type = $1 OR e.category_id IN (
+        SELECT c.category_id FROM categories c
+        JOIN categories p ON p.category_id = c.parent_id
+        WHERE p.slug = $1
+    ))
   AND ($2::text = '' OR v.city ILIKE '%' || $2 || '%')
-  AND ($3::timestamp = '0001-01-01'::timestamp OR e.start_datetime >= $3)
-  AND ($4::timestamp = '0001-01-01'::timestamp OR e.start_datetime <= $4);
+  AND ($3::date = '0001-01-01'::date OR e.start_datetime >= ($3::date::timestamp AT TIME ZONE v.timezone))
+  AND ($4::date = '0001-01-01'::date OR e.start_datetime < (($4::date + 1)::timestamp AT TIME ZONE v.timezone));
 
 -- name: GetEventForBooking :one
 SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
-       status, version, base_price, name
+       status, version, base_price_minor, currency, name
 FROM events
 WHERE event_id = $1
   AND (status = 'published' OR status = 'sold_out')
+  AND deleted_at IS NULL
 FOR UPDATE;
 
 -- name: UpdateEventAvailability :one
@@ -85,31 +100,64 @@ SET name = COALESCE($2, name),
     end_datetime = COALESCE($7, end_datetime),
     total_capacity = COALESCE($8, total_capacity),
     available_seats = COALESCE($9, available_seats),
-    base_price = COALESCE($10, base_price),
+    base_price_minor = COALESCE($10, base_price_minor),
     max_tickets_per_booking = COALESCE($11, max_tickets_per_booking),
     status = COALESCE($12, status),
+    setup_buffer_minutes = COALESCE($14, setup_buffer_minutes),
+    teardown_buffer_minutes = COALESCE($15, teardown_buffer_minutes),
+    currency = COALESCE($16, currency),
+    category_id = COALESCE($17, category_id),
+    tags = COALESCE($18, tags),
     updated_at = CURRENT_TIMESTAMP,
     version = version + 1
 WHERE event_id = $1
   AND version = $13
 RETURNING *;
 
--- name: DeleteEvent :exec
+-- name: DeleteEvent :one
 UPDATE events
 SET status = 'cancelled',
+    deleted_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP,
     version = version + 1
 WHERE event_id = $1
-  AND version = $2;
+  AND version = $2
+  AND deleted_at IS NULL
+RETURNING *;
 
 -- name: ListEventsByAdmin :many
-SELECT e.*, v.name as venue_name, v.city
+SELECT e.*, v.name as venue_name, v.city, v.timezone as venue_timezone
 FROM events e
 JOIN venues v ON e.venue_id = v.venue_id
-WHERE e.created_by = $3
+WHERE e.deleted_at IS NULL
+  AND (e.organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+    )
+   OR (e.organization_id IS NULL AND e.created_by = $3))
 ORDER BY e.created_at DESC
 LIMIT $1 OFFSET $2;
 
+-- name: ListEventsByOrganization :many
+SELECT e.*, v.name as venue_name, v.city, v.timezone as venue_timezone
+FROM events e
+JOIN venues v ON e.venue_id = v.venue_id
+WHERE e.organization_id = $3
+  AND e.deleted_at IS NULL
+ORDER BY e.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountEventsByOrganization :one
+SELECT COUNT(*) FROM events WHERE organization_id = $1 AND deleted_at IS NULL;
+
+-- name: TransferEventOwnership :one
+UPDATE events
+SET created_by = $2,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = $1
+  AND version = $3
+RETURNING *;
+
 -- name: GetEventAnalytics :one
 SELECT
     e.event_id,
@@ -118,12 +166,109 @@ SELECT
     e.available_seats,
     (e.total_capacity - e.available_seats) as tickets_sold,
     ROUND(((e.total_capacity - e.available_seats)::decimal / e.total_capacity::decimal) * 100, 2) as capacity_utilization,
-    e.base_price,
-    ROUND((e.total_capacity - e.available_seats) * e.base_price::decimal, 2) as estimated_revenue
+    e.base_price_minor,
+    e.currency,
+    ((e.total_capacity - e.available_seats) * e.base_price_minor)::bigint as estimated_revenue_minor
 FROM events e
 WHERE e.event_id = $1;
 
 -- name: CheckEventOwnership :one
-SELECT event_id, created_by, status, version
+SELECT e.event_id, e.created_by, e.organization_id, e.status, e.version,
+       COALESCE(m.role, 'owner')::text as role
+FROM events e
+LEFT JOIN organization_members m
+    ON m.organization_id = e.organization_id AND m.admin_id = $2
+WHERE e.event_id = $1
+  AND e.deleted_at IS NULL
+  AND (m.admin_id IS NOT NULL OR (e.organization_id IS NULL AND e.created_by = $2));
+
+-- name: CountDuplicateEvents :one
+SELECT COUNT(*) FROM events
+WHERE LOWER(name) = LOWER(@name::text)
+  AND venue_id = @venue_id
+  AND start_datetime = @start_datetime
+  AND status != 'cancelled';
+
+-- name: ListVenueOccupancy :many
+SELECT event_id, name, status, organization_id, start_datetime, end_datetime,
+       setup_buffer_minutes, teardown_buffer_minutes
+FROM events
+WHERE venue_id = @venue_id
+  AND event_id <> @exclude_event_id
+  AND status <> 'cancelled'
+  AND deleted_at IS NULL
+  AND start_datetime < @occupied_until::timestamptz + interval '1 day'
+  AND end_datetime > @occupied_from::timestamptz - interval '1 day'
+  AND start_datetime - make_interval(mins => setup_buffer_minutes) < @occupied_until::timestamptz
+  AND end_datetime + make_interval(mins => teardown_buffer_minutes) > @occupied_from::timestamptz
+ORDER BY start_datetime;
+
+-- name: LockEvent :one
+SELECT event_id FROM events
+WHERE event_id = $1
+FOR UPDATE;
+
+-- name: GetEventForUpdate :one
+SELECT * FROM events
+WHERE event_id = $1
+FOR UPDATE;
+
+-- name: RevertEvent :one
+UPDATE events
+SET name = @name,
+    description = @description,
+    venue_id = @venue_id,
+    event_type = @event_type,
+    start_datetime = @start_datetime,
+    end_datetime = @end_datetime,
+    available_seats = available_seats + (@total_capacity::int - total_capacity),
+    total_capacity = @total_capacity::int,
+    base_price_minor = @base_price_minor,
+    currency = @currency,
+    max_tickets_per_booking = @max_tickets_per_booking,
+    setup_buffer_minutes = @setup_buffer_minutes,
+    teardown_buffer_minutes = @teardown_buffer_minutes,
+    category_id = @category_id,
+    tags = @tags,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = @event_id
+  AND version = @version
+RETURNING *;
+
+-- name: GetEventRecord :one
+SELECT event_id, available_seats, total_capacity, max_tickets_per_booking,
+       status, version, base_price_minor, currency, name, deleted_at
 FROM events
-WHERE event_id = $1 AND created_by = $2;
+WHERE event_id = $1;
+
+-- name: RestoreEvent :one
+UPDATE events
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP,
+    version = version + 1
+WHERE event_id = $1
+  AND version = $2
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: ListDeletedEvents :many
+SELECT * FROM events
+WHERE deleted_at IS NOT NULL
+  AND (organization_id = @organization_id
+    OR (@organization_id::uuid IS NULL AND organization_id IS NULL AND created_by = @created_by))
+ORDER BY deleted_at DESC
+LIMIT @page_size;
+
+-- name: ListPurgeableEvents :many
+SELECT * FROM events
+WHERE deleted_at < @deleted_before
+  AND (deleted_at, event_id) > (@after_deleted_at::timestamp, @after_event_id::uuid)
+  AND available_seats = total_capacity
+ORDER BY deleted_at, event_id
+LIMIT @batch_size;
+
+-- name: PurgeEvent :execrows
+DELETE FROM events
+WHERE event_id = $1
+  AND deleted_at IS NOT NULL;
@@ -0,0 +1,25 @@
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (from_currency, to_currency, effective_date, rate)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (from_currency, to_currency, effective_date)
+DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListLatestExchangeRates :many
+SELECT DISTINCT ON (from_currency, to_currency) *
+FROM exchange_rates
+ORDER BY from_currency, to_currency, effective_date DESC;
+
+-- name: GetExchangeRate :one
+SELECT r.rate::double precision AS rate
+FROM (
+    SELECT effective_date, rate FROM exchange_rates
+    WHERE from_currency = @from_currency AND to_currency = @to_currency
+      AND effective_date <= @on_date
+    UNION ALL
+    SELECT effective_date, 1 / rate FROM exchange_rates
+    WHERE from_currency = @to_currency AND to_currency = @from_currency
+      AND effective_date <= @on_date
+) r
+ORDER BY r.effective_date DESC
+LIMIT 1;
@@ -0,0 +1,73 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, created_by)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations WHERE organization_id = $1;
+
+-- name: UpdateOrganization :one
+UPDATE organizations
+SET name = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = $1
+RETURNING *;
+
+-- name: ListAdminOrganizations :many
+SELECT o.organization_id, o.name, o.created_by, o.created_at, o.updated_at, m.role
+FROM organizations o
+JOIN organization_members m ON o.organization_id = m.organization_id
+WHERE m.admin_id = $1
+ORDER BY o.name;
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (organization_id, admin_id, role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND admin_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT m.organization_id, m.admin_id, m.role, m.created_at, a.email, a.name
+FROM organization_members m
+JOIN admins a ON m.admin_id = a.admin_id
+WHERE m.organization_id = $1
+ORDER BY m.created_at;
+
+-- name: UpdateOrganizationMemberRole :one
+UPDATE organization_members
+SET role = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = $1 AND admin_id = $2
+RETURNING *;
+
+-- name: RemoveOrganizationMember :exec
+DELETE FROM organization_members
+WHERE organization_id = $1 AND admin_id = $2;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner';
+
+-- name: GetOrganizationAnalytics :one
+SELECT
+    COUNT(*) as total_events,
+    COUNT(*) FILTER (WHERE status = 'published') as published_events,
+    COALESCE(SUM(total_capacity), 0)::bigint as total_capacity,
+    COALESCE(SUM(total_capacity - available_seats), 0)::bigint as tickets_sold
+FROM events
+WHERE organization_id = $1
+  AND status != 'cancelled';
+
+-- name: GetOrganizationRevenueByCurrency :many
+SELECT
+    currency,
+    COALESCE(SUM(total_capacity - available_seats), 0)::bigint as tickets_sold,
+    COALESCE(SUM((total_capacity - available_seats)::bigint * base_price_minor), 0)::bigint as estimated_revenue_minor
+FROM events
+WHERE organization_id = $1
+  AND status != 'cancelled'
+GROUP BY currency
+ORDER BY currency;
@@ -0,0 +1,34 @@
+-- name: ListEventChanges :many
+SELECT c.event_id, c.changed_at, c.purged
+FROM (
+    SELECT event_id, updated_at AS changed_at, false AS purged
+    FROM events
+    WHERE updated_at >= @changed_since::timestamp
+    UNION ALL
+    SELECT entity_id, created_at, true
+    FROM entity_history
+    WHERE entity_type = 'event'
+      AND action = 'purged'
+      AND created_at >= @changed_since::timestamp
+) c
+WHERE (c.changed_at, c.event_id) > (@changed_since::timestamp, @after_event_id::uuid)
+ORDER BY c.changed_at, c.event_id
+LIMIT @page_size;
+
+-- name: ListEventsWithVenues :many
+SELECT e.*, v.name as venue_name, v.address, v.city, v.state, v.country, v.timezone as venue_timezone,
+       v.latitude as venue_latitude, v.longitude as venue_longitude
+FROM events e
+JOIN venues v ON e.venue_id = v.venue_id
+WHERE e.event_id = ANY(@event_ids::uuid[]);
+
+-- name: TouchEvent :exec
+UPDATE events
+SET updated_at = CURRENT_TIMESTAMP
+WHERE event_id = $1;
+
+-- name: TouchVenueEvents :exec
+UPDATE events
+SET updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+  AND deleted_at IS NULL;
@@ -0,0 +1,71 @@
+-- name: EnqueueSearchOutbox :exec
+INSERT INTO search_outbox (
+    event_id, operation
+) VALUES (
+    $1, $2
+);
+
+-- name: EnqueueVenueSearchOutbox :exec
+INSERT INTO search_outbox (event_id, operation)
+SELECT event_id, 'upsert'
+FROM events
+WHERE venue_id = $1
+  AND deleted_at IS NULL;
+
+-- name: TryLockSearchOutboxRelay :one
+SELECT pg_try_advisory_xact_lock(hashtext('search_outbox_relay'))::boolean AS locked;
+
+-- name: ListPendingSearchOutbox :many
+SELECT o.event_id, o.outbox_id, o.operation, pending.attempts, pending.changes
+FROM (
+    SELECT event_id,
+           MIN(outbox_id)::bigint AS first_outbox_id,
+           MAX(outbox_id)::bigint AS last_outbox_id,
+           MAX(attempts)::integer AS attempts,
+           COUNT(*) AS changes
+    FROM search_outbox
+    WHERE delivered_at IS NULL
+    GROUP BY event_id
+    HAVING MAX(next_attempt_at) <= CURRENT_TIMESTAMP
+       AND (MAX(leased_until) IS NULL OR MAX(leased_until) <= CURRENT_TIMESTAMP)
+) pending
+JOIN search_outbox o ON o.outbox_id = pending.last_outbox_id
+ORDER BY pending.first_outbox_id
+LIMIT $1;
+
+-- name: LeaseSearchOutbox :exec
+UPDATE search_outbox
+SET leased_until = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::float8)
+WHERE event_id = @event_id AND outbox_id <= @outbox_id AND delivered_at IS NULL;
+
+-- name: MarkSearchOutboxDelivered :exec
+UPDATE search_outbox
+SET delivered_at = CURRENT_TIMESTAMP
+WHERE event_id = $1 AND outbox_id <= $2 AND delivered_at IS NULL;
+
+-- name: DeferSearchOutbox :exec
+UPDATE search_outbox
+SET attempts = attempts + 1,
+    next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => @backoff_seconds::float8),
+    last_error = @last_error,
+    leased_until = NULL
+WHERE event_id = @event_id AND outbox_id <= @outbox_id AND delivered_at IS NULL;
+
+-- name: DeleteDeliveredSearchOutbox :execrows
+DELETE FROM search_outbox
+WHERE delivered_at < CURRENT_TIMESTAMP - make_interval(secs => @retention_seconds::float8);
+
+-- name: GetSearchOutboxStats :one
+SELECT COUNT(*) AS pending_changes,
+       COUNT(DISTINCT event_id) AS pending_events,
+       COUNT(*) FILTER (WHERE attempts > 0) AS retrying_changes,
+       COALESCE(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - MIN(created_at))), 0)::float8 AS lag_seconds
+FROM search_outbox
+WHERE delivered_at IS NULL;
+
+-- name: GetLatestSearchOutboxError :one
+SELECT event_id, attempts, last_error, next_attempt_at
+FROM search_outbox
+WHERE delivered_at IS NULL AND last_error IS NOT NULL
+ORDER BY outbox_id DESC
+LIMIT 1;
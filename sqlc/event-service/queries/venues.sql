@@ -1,26 +1,45 @@
 
 -- name: CreateVenue :one
 INSERT INTO venues (
-    name, address, city, state, country, postal_code, capacity, layout_config
+    name, address, city, state, country, postal_code, capacity, layout_config, organization_id, timezone, latitude, longitude
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
 RETURNING *;
 
 -- name: GetVenueByID :one
-SELECT * FROM venues WHERE venue_id = $1;
+SELECT * FROM venues WHERE venue_id = $1 AND deleted_at IS NULL;
 
 -- name: ListVenues :many
 SELECT * FROM venues
-WHERE ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
+WHERE deleted_at IS NULL
+  AND ($3::text IS NULL OR city ILIKE '%' || $3 || '%')
   AND ($4::text IS NULL OR state ILIKE '%' || $4 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $5
+    ))
 ORDER BY name
 LIMIT $1 OFFSET $2;
 
 -- name: CountVenues :one
 SELECT COUNT(*) FROM venues
-WHERE ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
-  AND ($2::text IS NULL OR state ILIKE '%' || $2 || '%');
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR city ILIKE '%' || $1 || '%')
+  AND ($2::text IS NULL OR state ILIKE '%' || $2 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $3
+    ));
+
+-- name: GetVenueByIDForUpdate :one
+SELECT * FROM venues WHERE venue_id = $1 AND deleted_at IS NULL FOR UPDATE;
+
+-- name: UpdateVenueLayout :one
+UPDATE venues
+SET layout_config = $2,
+    capacity = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING *;
 
 -- name: UpdateVenue :one
 UPDATE venues
@@ -32,24 +51,109 @@ SET name = COALESCE($2, name),
     postal_code = COALESCE($7, postal_code),
     capacity = COALESCE($8, capacity),
     layout_config = COALESCE($9, layout_config),
+    timezone = COALESCE($10, timezone),
+    latitude = $11,
+    longitude = $12,
     updated_at = CURRENT_TIMESTAMP
 WHERE venue_id = $1
 RETURNING *;
 
--- name: DeleteVenue :exec
-DELETE FROM venues WHERE venue_id = $1;
+-- name: DeleteVenue :one
+UPDATE venues
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+RETURNING *;
 
 -- name: GetVenuesByCity :many
 SELECT venue_id, name, capacity, address FROM venues
 WHERE city = $1
+  AND deleted_at IS NULL
 ORDER BY name;
 
 -- name: SearchVenues :many
 SELECT * FROM venues
-WHERE name ILIKE '%' || $1 || '%'
+WHERE deleted_at IS NULL
+  AND (name ILIKE '%' || $1 || '%'
    OR city ILIKE '%' || $1 || '%'
-   OR address ILIKE '%' || $1 || '%'
+   OR address ILIKE '%' || $1 || '%')
+  AND (organization_id IS NULL OR organization_id IN (
+        SELECT m.organization_id FROM organization_members m WHERE m.admin_id = $2
+    ))
 ORDER BY
     CASE WHEN name ILIKE $1 || '%' THEN 1 ELSE 2 END,
     name
 LIMIT 10;
+
+-- name: GetVenueByNameAndCity :one
+SELECT * FROM venues
+WHERE LOWER(name) = LOWER(@name::text)
+  AND LOWER(city) = LOWER(@city::text)
+  AND (organization_id IS NULL OR organization_id = @organization_id)
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: RevertVenue :one
+UPDATE venues
+SET name = $2,
+    address = $3,
+    city = $4,
+    state = $5,
+    country = $6,
+    postal_code = $7,
+    capacity = $8,
+    layout_config = $9,
+    timezone = $10,
+    latitude = $11,
+    longitude = $12,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING *;
+
+-- name: GetDeletedVenueForUpdate :one
+SELECT * FROM venues WHERE venue_id = $1 AND deleted_at IS NOT NULL FOR UPDATE;
+
+-- name: RestoreVenue :one
+UPDATE venues
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE venue_id = $1
+RETURNING *;
+
+-- name: CountActiveVenueEvents :one
+SELECT COUNT(*) FROM events
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+  AND status <> 'cancelled'
+  AND end_datetime > CURRENT_TIMESTAMP;
+
+-- name: ListDeletedVenues :many
+SELECT * FROM venues
+WHERE deleted_at IS NOT NULL
+  AND organization_id IS NOT DISTINCT FROM @organization_id::uuid
+ORDER BY deleted_at DESC
+LIMIT @page_size;
+
+-- name: ListPurgeableVenues :many
+SELECT * FROM venues v
+WHERE v.deleted_at < @deleted_before
+  AND (v.deleted_at, v.venue_id) > (@after_deleted_at::timestamp, @after_venue_id::uuid)
+  AND NOT EXISTS (SELECT 1 FROM events e WHERE e.venue_id = v.venue_id)
+ORDER BY v.deleted_at, v.venue_id
+LIMIT @batch_size;
+
+-- name: PurgeVenue :execrows
+DELETE FROM venues
+WHERE venue_id = $1
+  AND deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM events WHERE venue_id = $1);
+
+-- name: GetVenueTimezone :one
+SELECT timezone FROM venues WHERE venue_id = $1;
+
+-- name: ListVenueEventIDs :many
+SELECT event_id FROM events
+WHERE venue_id = $1
+  AND deleted_at IS NULL
+ORDER BY start_datetime;
@@ -7,9 +7,27 @@ CREATE TABLE venues (
     country VARCHAR(100) NOT NULL DEFAULT 'USA',
     postal_code VARCHAR(20),
     capacity INTEGER NOT NULL CHECK (capacity > 0),
-    layout_config JSONB DEFAULT '{}',
+    layout_config JSONB DEFAULT '{}' CHECK (jsonb_typeof(layout_config) = 'object'),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    organization_id UUID,
+    deleted_at TIMESTAMP,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    CONSTRAINT check_venue_coordinates
+        CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE TABLE categories (
+    category_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    parent_id UUID REFERENCES categories(category_id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_category_slug CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    CONSTRAINT check_category_parent CHECK (parent_id <> category_id)
 );
 
 CREATE TABLE events (
@@ -18,17 +36,24 @@ CREATE TABLE events (
     description TEXT,
     venue_id UUID NOT NULL,
     event_type VARCHAR(50) NOT NULL,
-    start_datetime TIMESTAMP NOT NULL,
-    end_datetime TIMESTAMP NOT NULL,
+    start_datetime TIMESTAMPTZ NOT NULL,
+    end_datetime TIMESTAMPTZ NOT NULL,
     total_capacity INTEGER NOT NULL CHECK (total_capacity > 0),
     available_seats INTEGER NOT NULL CHECK (available_seats >= 0),
-    base_price DECIMAL(10, 2) NOT NULL CHECK (base_price >= 0),
+    base_price_minor BIGINT NOT NULL CHECK (base_price_minor >= 0),
     max_tickets_per_booking INTEGER DEFAULT 10 CHECK (max_tickets_per_booking > 0),
     status VARCHAR(20) DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'sold_out', 'cancelled')),
     version INTEGER DEFAULT 1 NOT NULL,
     created_by UUID NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    organization_id UUID,
+    setup_buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (setup_buffer_minutes BETWEEN 0 AND 1440),
+    teardown_buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (teardown_buffer_minutes BETWEEN 0 AND 1440),
+    deleted_at TIMESTAMP,
+    currency CHAR(3) NOT NULL DEFAULT 'INR' CHECK (currency ~ '^[A-Z]{3}$'),
+    category_id UUID NOT NULL REFERENCES categories(category_id) ON DELETE RESTRICT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
     CONSTRAINT fk_venue
         FOREIGN KEY(venue_id)
         REFERENCES venues(venue_id),
@@ -65,4 +90,142 @@ CREATE TABLE admin_refresh_tokens (
 );
 
 CREATE INDEX idx_admin_refresh_tokens_admin_id ON admin_refresh_tokens(admin_id);
-CREATE INDEX idx_admin_refresh_tokens_expires ON admin_refresh_tokens(expires_at) WHERE revoked_at IS NULL;
\ No newline at end of file
+CREATE INDEX idx_admin_refresh_tokens_expires ON admin_refresh_tokens(expires_at) WHERE revoked_at IS NULL;
+
+CREATE TABLE admin_two_factor (
+    admin_id UUID PRIMARY KEY REFERENCES admins(admin_id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE admin_recovery_codes (
+    code_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL REFERENCES admins(admin_id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_admin_recovery_code UNIQUE (admin_id, code_hash)
+);
+
+CREATE TABLE admin_login_challenges (
+    token TEXT PRIMARY KEY,
+    admin_id UUID NOT NULL REFERENCES admins(admin_id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_login_challenges_admin_id ON admin_login_challenges(admin_id);
+CREATE INDEX idx_admin_login_challenges_expires ON admin_login_challenges(expires_at);
+
+CREATE TABLE organizations (
+    organization_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_organization_creator
+        FOREIGN KEY(created_by)
+        REFERENCES admins(admin_id)
+);
+
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL,
+    admin_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'manager' CHECK (role IN ('owner', 'manager', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, admin_id),
+    CONSTRAINT fk_member_organization
+        FOREIGN KEY(organization_id)
+        REFERENCES organizations(organization_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_member_admin
+        FOREIGN KEY(admin_id)
+        REFERENCES admins(admin_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_organization_members_admin ON organization_members(admin_id);
+CREATE INDEX idx_events_organization ON events(organization_id, created_at DESC);
+CREATE INDEX idx_venues_organization ON venues(organization_id);
+CREATE INDEX idx_events_venue_schedule ON events(venue_id, start_datetime, end_datetime) WHERE status <> 'cancelled';
+CREATE INDEX idx_venues_deleted_at ON venues(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE event_media (
+    media_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('poster', 'banner', 'gallery')),
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL CHECK (width > 0),
+    height INTEGER NOT NULL CHECK (height > 0),
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_key TEXT NOT NULL,
+    variants JSONB NOT NULL DEFAULT '{}',
+    position INTEGER NOT NULL DEFAULT 0,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_event_media_event
+        FOREIGN KEY(event_id)
+        REFERENCES events(event_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_media_event ON event_media(event_id, kind, position);
+CREATE UNIQUE INDEX uq_event_media_single_kind ON event_media(event_id, kind) WHERE kind IN ('poster', 'banner');
+
+CREATE TABLE entity_history (
+    history_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('event', 'venue')),
+    entity_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('baseline', 'created', 'updated', 'cancelled', 'deleted', 'transferred', 'reverted', 'restored', 'purged')),
+    organization_id UUID,
+    changed_by UUID,
+    changes JSONB NOT NULL DEFAULT '[]',
+    snapshot JSONB NOT NULL,
+    reverted_from_version INTEGER,
+    -- clock_timestamp() so entries written in one transaction (imports)
+    -- keep distinct, ordered times for audit paging
+    created_at TIMESTAMP DEFAULT clock_timestamp()
+);
+
+CREATE UNIQUE INDEX uq_entity_history_version ON entity_history(entity_type, entity_id, version);
+CREATE INDEX idx_entity_history_organization ON entity_history(organization_id, created_at DESC) WHERE organization_id IS NOT NULL;
+
+CREATE TABLE exchange_rates (
+    from_currency CHAR(3) NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+    to_currency CHAR(3) NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+    effective_date DATE NOT NULL,
+    rate DOUBLE PRECISION NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (from_currency, to_currency, effective_date),
+    CONSTRAINT check_exchange_rate_pair CHECK (from_currency <> to_currency)
+);
+
+CREATE INDEX idx_categories_parent ON categories(parent_id);
+CREATE INDEX idx_events_category ON events(category_id);
+CREATE INDEX idx_events_tags ON events USING GIN (tags);
+
+CREATE TABLE search_outbox (
+    outbox_id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('upsert', 'delete')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT clock_timestamp(),
+    leased_until TIMESTAMP
+);
+
+CREATE INDEX idx_search_outbox_pending ON search_outbox(event_id, outbox_id) WHERE delivered_at IS NULL;
+CREATE INDEX idx_search_outbox_delivered ON search_outbox(delivered_at) WHERE delivered_at IS NOT NULL;
+
+CREATE INDEX idx_events_changes ON events(updated_at, event_id);
+CREATE INDEX idx_entity_history_purged_events ON entity_history(created_at) WHERE entity_type = 'event' AND action = 'purged';
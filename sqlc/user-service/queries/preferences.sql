@@ -0,0 +1,20 @@
+-- name: GetUserPreferences :one
+SELECT * FROM user_preferences
+WHERE user_id = $1;
+
+-- name: UpsertUserPreferences :one
+INSERT INTO user_preferences (
+    user_id,
+    cities,
+    categories,
+    tags
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    cities = EXCLUDED.cities,
+    categories = EXCLUDED.categories,
+    tags = EXCLUDED.tags,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,89 @@
+-- name: LockUserForSavedSearch :one
+SELECT user_id FROM users
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: CountUserSavedSearches :one
+SELECT COUNT(*) FROM saved_searches
+WHERE user_id = $1;
+
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (
+    saved_search_id,
+    user_id,
+    name,
+    criteria,
+    frequency,
+    alerts_enabled
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListUserSavedSearches :many
+SELECT * FROM saved_searches
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetUserSavedSearch :one
+SELECT * FROM saved_searches
+WHERE saved_search_id = $1 AND user_id = $2;
+
+-- name: GetSavedSearch :one
+SELECT * FROM saved_searches
+WHERE saved_search_id = $1;
+
+-- name: UpdateSavedSearch :one
+UPDATE saved_searches
+SET
+    name = $3,
+    criteria = $4,
+    frequency = $5,
+    alerts_enabled = $6,
+    updated_at = CURRENT_TIMESTAMP
+WHERE saved_search_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteSavedSearch :execrows
+DELETE FROM saved_searches
+WHERE saved_search_id = $1 AND user_id = $2;
+
+-- name: DisableUserSearchAlerts :many
+UPDATE saved_searches
+SET alerts_enabled = false, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND alerts_enabled
+RETURNING saved_search_id;
+
+-- name: ListAlertingSavedSearches :many
+SELECT * FROM saved_searches
+WHERE alerts_enabled AND saved_search_id > $1
+ORDER BY saved_search_id
+LIMIT $2;
+
+-- name: MarkSavedSearchAlerted :exec
+UPDATE saved_searches
+SET last_alerted_at = $2
+WHERE saved_search_id = $1;
+
+-- name: CreateSearchAlert :one
+INSERT INTO search_alerts (
+    alert_id,
+    user_id,
+    saved_search_id,
+    saved_search_name,
+    events,
+    match_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListUserSearchAlerts :many
+SELECT * FROM search_alerts
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: PruneUserSearchAlerts :exec
+DELETE FROM search_alerts
+WHERE user_id = $1 AND created_at < CURRENT_TIMESTAMP - INTERVAL '30 days';
@@ -40,4 +40,8 @@ SET
 WHERE user_id = $1;
 
 -- name: CheckUserExists :one
-SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND is_active = true) as exists;
\ No newline at end of file
+SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND is_active = true) as exists;
+
+-- name: GetUsersByIDs :many
+SELECT user_id, email, name FROM users
+WHERE user_id = ANY(@user_ids::uuid[]);
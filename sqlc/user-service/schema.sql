@@ -22,7 +22,53 @@ CREATE TABLE refresh_tokens (
         ON DELETE CASCADE
 );
 
+CREATE TABLE user_preferences (
+    user_id UUID PRIMARY KEY,
+    cities TEXT[] NOT NULL DEFAULT '{}',
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_preferences_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE saved_searches (
+    saved_search_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    criteria JSONB NOT NULL,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    alerts_enabled BOOLEAN NOT NULL DEFAULT true,
+    last_alerted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_saved_searches_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE search_alerts (
+    alert_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    saved_search_id UUID NOT NULL,
+    saved_search_name VARCHAR(100) NOT NULL,
+    events JSONB NOT NULL,
+    match_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_search_alerts_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
 CREATE INDEX idx_users_email ON users(email);
 CREATE INDEX idx_users_phone ON users(phone_number);
 CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
-CREATE INDEX idx_refresh_tokens_expires ON refresh_tokens(expires_at) WHERE revoked_at IS NULL;
\ No newline at end of file
+CREATE INDEX idx_refresh_tokens_expires ON refresh_tokens(expires_at) WHERE revoked_at IS NULL;
+CREATE INDEX idx_saved_searches_user ON saved_searches(user_id, created_at DESC);
+CREATE INDEX idx_saved_searches_alerting ON saved_searches(saved_search_id) WHERE alerts_enabled;
+CREATE INDEX idx_search_alerts_user ON search_alerts(user_id, created_at DESC);